@@ -0,0 +1,312 @@
+package aggregation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+)
+
+// materializedPeriods は事前集計する集計期間
+var materializedPeriods = []analyticsApp.AggregationPeriod{
+	analyticsApp.AggregationPeriodDaily,
+	analyticsApp.AggregationPeriodWeekly,
+	analyticsApp.AggregationPeriodMonthly,
+}
+
+// Service はPRメトリクスから日次・週次・月次の集計データを作成して保存する
+// 前回成功した実行以降に収集されたPRが属する期間だけを再集計するため、遅れて収集されたPRも反映される
+// 集計データは対象・期間ごとに上書き保存されるため、同じ期間を何度再集計しても結果は変わらない
+// チームのトレンド分析のスナップショットと、対象のPRから検出したボトルネックの履歴も合わせて保存する
+type Service struct {
+	prRepo      prDomain.MetricsRepository
+	aggRepo     analyticsApp.AggregatedMetricsRepository
+	runs        analyticsApp.AggregationJobRunRepository
+	trends      analyticsApp.TrendSnapshotRepository
+	bottlenecks analyticsApp.BottleneckRepository
+	aggregator  *analyticsApp.MetricsAggregator
+	teams       *teamDomain.Roster
+	now         func() time.Time
+
+	// 定期実行と管理APIからの実行が重ならないようにする
+	mu sync.Mutex
+}
+
+// NewService は新しい集計サービスを作成
+// teams が指定されている場合、チームごとの集計データも作成する
+func NewService(
+	prRepo prDomain.MetricsRepository,
+	aggRepo analyticsApp.AggregatedMetricsRepository,
+	runs analyticsApp.AggregationJobRunRepository,
+	trends analyticsApp.TrendSnapshotRepository,
+	bottlenecks analyticsApp.BottleneckRepository,
+	aggregator *analyticsApp.MetricsAggregator,
+	teams *teamDomain.Roster,
+) *Service {
+	return &Service{
+		prRepo:      prRepo,
+		aggRepo:     aggRepo,
+		runs:        runs,
+		trends:      trends,
+		bottlenecks: bottlenecks,
+		aggregator:  aggregator,
+		teams:       teams,
+		now:         time.Now,
+	}
+}
+
+// Run は前回成功した実行以降に収集されたPRが属する期間を再集計する
+// full が true の場合、または成功した実行がない場合はすべてのPRを対象にする
+// 失敗した場合も実行記録を残し、次回は同じ範囲から再集計する
+func (s *Service) Run(ctx context.Context, full bool) (*analyticsApp.AggregationJobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	startedAt := s.now()
+	run := &analyticsApp.AggregationJobRun{
+		ID:        fmt.Sprintf("aggregation_%d", startedAt.UnixNano()),
+		StartedAt: startedAt,
+	}
+
+	err := s.refresh(ctx, run, full)
+	run.FinishedAt = s.now()
+	run.Status = analyticsApp.AggregationRunSucceeded
+	if err != nil {
+		run.Status = analyticsApp.AggregationRunFailed
+		run.Error = err.Error()
+	}
+
+	if saveErr := s.runs.SaveRun(ctx, run); saveErr != nil && err == nil {
+		err = fmt.Errorf("failed to save aggregation run: %w", saveErr)
+	}
+	return run, err
+}
+
+// RecentRuns は実行記録を新しい順に最大 limit 件返す
+func (s *Service) RecentRuns(ctx context.Context, limit int) ([]*analyticsApp.AggregationJobRun, error) {
+	return s.runs.FindRecentRuns(ctx, limit)
+}
+
+// refresh は対象のPRを決めて、それらが属する期間を集計期間ごとに再集計する
+func (s *Service) refresh(ctx context.Context, run *analyticsApp.AggregationJobRun, full bool) error {
+	var since time.Time
+	if !full {
+		latest, err := s.runs.FindLatestSucceeded(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find latest aggregation run: %w", err)
+		}
+		if latest != nil {
+			since = latest.StartedAt
+			run.CollectedSince = &since
+		}
+	}
+
+	changed, err := s.prRepo.FindCollectedSince(ctx, since)
+	if err != nil {
+		return fmt.Errorf("failed to find collected pr metrics: %w", err)
+	}
+	run.ChangedPRs = len(changed)
+
+	for _, period := range materializedPeriods {
+		starts := affectedPeriodStarts(changed, period)
+		if len(starts) == 0 {
+			continue
+		}
+
+		// 対象期間の全PRを一度に取得し、期間ごとに振り分ける
+		rangeEnd := analyticsApp.PeriodEnd(starts[len(starts)-1], period)
+		metrics, err := s.prRepo.FindByDateRange(ctx, starts[0], rangeEnd.Add(-time.Nanosecond), nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to find pr metrics for %s aggregation: %w", period, err)
+		}
+		buckets := make(map[time.Time][]*prDomain.PRMetrics)
+		for _, metric := range metrics {
+			start := analyticsApp.PeriodStart(metric.CreatedAt.UTC(), period)
+			buckets[start] = append(buckets[start], metric)
+		}
+
+		for _, start := range starts {
+			records, err := s.refreshPeriod(ctx, period, start, buckets[start], run.StartedAt)
+			run.Records += records
+			if err != nil {
+				return fmt.Errorf("failed to aggregate %s period starting %s: %w", period, start.Format("2006-01-02"), err)
+			}
+			run.Periods++
+		}
+	}
+
+	if err := s.syncBottlenecks(ctx, changed, run.StartedAt); err != nil {
+		return fmt.Errorf("failed to update bottlenecks: %w", err)
+	}
+
+	return nil
+}
+
+// refreshPeriod は1つの期間の集計データを作成して保存し、保存件数を返す
+// 集計範囲は含まれるPRの作成日時ではなく期間の境界とする
+func (s *Service) refreshPeriod(ctx context.Context, period analyticsApp.AggregationPeriod, start time.Time, metrics []*prDomain.PRMetrics, generatedAt time.Time) (int, error) {
+	dateRange := analyticsApp.DateRange{Start: start, End: analyticsApp.PeriodEnd(start, period)}
+	saved := 0
+
+	teamMetrics, err := s.aggregator.AggregateTeamMetrics(ctx, metrics, period)
+	if err != nil {
+		return saved, err
+	}
+	teamMetrics.DateRange, teamMetrics.GeneratedAt = dateRange, generatedAt
+	if err := s.saveTeamMetrics(ctx, teamMetrics); err != nil {
+		return saved, err
+	}
+	saved++
+
+	if s.teams != nil {
+		for _, team := range s.teams.List() {
+			namedMetrics, err := s.aggregator.AggregateMetricsForTeam(ctx, team, metrics, period)
+			if err != nil {
+				return saved, err
+			}
+			if namedMetrics.TotalPRs == 0 {
+				continue
+			}
+			namedMetrics.DateRange, namedMetrics.GeneratedAt = dateRange, generatedAt
+			if err := s.saveTeamMetrics(ctx, namedMetrics); err != nil {
+				return saved, err
+			}
+			saved++
+		}
+	}
+
+	developerMetrics, err := s.aggregator.AggregateDeveloperMetrics(ctx, metrics, period)
+	if err != nil {
+		return saved, err
+	}
+	for _, developer := range developerMetrics {
+		developer.DateRange, developer.GeneratedAt = dateRange, generatedAt
+		if err := s.aggRepo.SaveDeveloperMetrics(ctx, developer); err != nil {
+			return saved, err
+		}
+		saved++
+	}
+
+	repositoryMetrics, err := s.aggregator.AggregateRepositoryMetrics(ctx, metrics, period)
+	if err != nil {
+		return saved, err
+	}
+	for _, repository := range repositoryMetrics {
+		repository.DateRange, repository.GeneratedAt = dateRange, generatedAt
+		if err := s.aggRepo.SaveRepositoryMetrics(ctx, repository); err != nil {
+			return saved, err
+		}
+		saved++
+	}
+
+	labelMetrics, err := s.aggregator.AggregateLabelMetrics(ctx, metrics, period)
+	if err != nil {
+		return saved, err
+	}
+	for _, label := range labelMetrics {
+		label.DateRange, label.GeneratedAt = dateRange, generatedAt
+		if err := s.aggRepo.SaveLabelMetrics(ctx, label); err != nil {
+			return saved, err
+		}
+		saved++
+	}
+
+	return saved, nil
+}
+
+// saveTeamMetrics はチームの集計データとトレンド分析のスナップショットを保存する
+func (s *Service) saveTeamMetrics(ctx context.Context, metrics *analyticsApp.TeamMetrics) error {
+	if err := s.aggRepo.SaveTeamMetrics(ctx, metrics); err != nil {
+		return err
+	}
+	return s.trends.SaveTrendSnapshots(ctx, analyticsApp.NewTrendSnapshots(metrics))
+}
+
+// syncBottlenecks は対象のPRからボトルネックを検出し、履歴を更新する
+// 初めて検出したものは detectedAt を最初の検出日時とし、検出されなくなったものは解消済みにする
+// 解消済みのものが再び検出された場合は最初の検出日時を残したまま再開する。無視に設定されたものは状態を変えない
+func (s *Service) syncBottlenecks(ctx context.Context, metrics []*prDomain.PRMetrics, detectedAt time.Time) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	prs := make(map[string]*prDomain.PRMetrics, len(metrics))
+	prIDs := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		prs[metric.PRID] = metric
+		prIDs = append(prIDs, metric.PRID)
+	}
+
+	existing, err := s.bottlenecks.FindBottlenecksByPRIDs(ctx, prIDs)
+	if err != nil {
+		return err
+	}
+	existingByID := make(map[string]*analyticsApp.BottleneckRecord, len(existing))
+	for _, record := range existing {
+		existingByID[record.ID] = record
+	}
+
+	var records []*analyticsApp.BottleneckRecord
+	detected := make(map[string]bool)
+	for _, bottleneck := range s.aggregator.IdentifyBottlenecks(metrics) {
+		id := analyticsApp.BottleneckRecordID(bottleneck.Type, bottleneck.PRID)
+		detected[id] = true
+
+		record, ok := existingByID[id]
+		if !ok {
+			record = &analyticsApp.BottleneckRecord{
+				ID:          id,
+				Type:        bottleneck.Type,
+				PRID:        bottleneck.PRID,
+				Status:      analyticsApp.BottleneckStatusActive,
+				FirstSeenAt: detectedAt,
+			}
+		} else if record.Status == analyticsApp.BottleneckStatusResolved {
+			record.Status = analyticsApp.BottleneckStatusActive
+			record.ResolvedAt = nil
+		}
+		record.Severity = bottleneck.Severity
+		record.Description = bottleneck.Description
+		record.Value = bottleneck.Value
+		record.Threshold = bottleneck.Threshold
+		record.Author = prs[bottleneck.PRID].Author
+		record.Repository = prs[bottleneck.PRID].Repository
+		record.UpdatedAt = detectedAt
+		records = append(records, record)
+	}
+
+	for _, record := range existing {
+		if detected[record.ID] || record.Status != analyticsApp.BottleneckStatusActive {
+			continue
+		}
+		resolvedAt := detectedAt
+		record.Status = analyticsApp.BottleneckStatusResolved
+		record.ResolvedAt = &resolvedAt
+		record.UpdatedAt = detectedAt
+		records = append(records, record)
+	}
+
+	return s.bottlenecks.SaveBottlenecks(ctx, records)
+}
+
+// affectedPeriodStarts はPRが属する期間の開始時刻（UTC）を古い順に重複なく返す
+func affectedPeriodStarts(metrics []*prDomain.PRMetrics, period analyticsApp.AggregationPeriod) []time.Time {
+	seen := make(map[time.Time]bool)
+	var starts []time.Time
+	for _, metric := range metrics {
+		start := analyticsApp.PeriodStart(metric.CreatedAt.UTC(), period)
+		if !seen[start] {
+			seen[start] = true
+			starts = append(starts, start)
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+	return starts
+}
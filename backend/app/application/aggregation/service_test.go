@@ -0,0 +1,253 @@
+package aggregation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/infrastructure/memory"
+)
+
+// failingAggregatedRepository は開発者メトリクスの保存に失敗するテスト用実装
+type failingAggregatedRepository struct {
+	analyticsApp.AggregatedMetricsRepository
+}
+
+func (r *failingAggregatedRepository) SaveDeveloperMetrics(ctx context.Context, metrics *analyticsApp.DeveloperMetrics) error {
+	return errors.New("storage unavailable")
+}
+
+type serviceFixture struct {
+	service     *Service
+	prRepo      prDomain.MetricsRepository
+	aggRepo     analyticsApp.AggregatedMetricsRepository
+	runs        analyticsApp.AggregationJobRunRepository
+	trends      analyticsApp.TrendSnapshotRepository
+	bottlenecks analyticsApp.BottleneckRepository
+}
+
+func newServiceFixture(t *testing.T) *serviceFixture {
+	roster, err := teamDomain.NewRoster([]teamDomain.Team{
+		{Name: "platform", Members: []teamDomain.Membership{{Login: "alice"}}},
+	})
+	require.NoError(t, err)
+
+	prRepo := memory.NewPRMetricsRepository()
+	aggRepo := memory.NewAggregatedMetricsRepository()
+	runs := memory.NewAggregationJobRunRepository()
+	trends := memory.NewTrendSnapshotRepository()
+	bottlenecks := memory.NewBottleneckRepository()
+	service := NewService(prRepo, aggRepo, runs, trends, bottlenecks, analyticsApp.NewMetricsAggregator(), roster)
+
+	return &serviceFixture{
+		service: service, prRepo: prRepo, aggRepo: aggRepo, runs: runs,
+		trends: trends, bottlenecks: bottlenecks,
+	}
+}
+
+func (f *serviceFixture) savePR(t *testing.T, prID, author string, createdAt time.Time) {
+	require.NoError(t, f.prRepo.Save(context.Background(), &prDomain.PRMetrics{
+		PRID: prID, Author: author, Repository: "org/api", CreatedAt: createdAt,
+	}))
+}
+
+func TestService_Run(t *testing.T) {
+	ctx := context.Background()
+	// 2024-01-10 は水曜日で、週次の期間は 2024-01-08 から始まる
+	january10 := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	january11 := time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)
+	february5 := time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC)
+
+	t.Run("PRが属する期間ごとに期間の境界で集計データを保存する", func(t *testing.T) {
+		f := newServiceFixture(t)
+		f.savePR(t, "pr-1", "alice", january10)
+		f.savePR(t, "pr-2", "bob", january11)
+
+		run, err := f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, analyticsApp.AggregationRunSucceeded, run.Status)
+		assert.Nil(t, run.CollectedSince)
+		assert.Equal(t, 2, run.ChangedPRs)
+		assert.Equal(t, 4, run.Periods, "日次2件・週次1件・月次1件")
+
+		weekly, err := f.aggRepo.FindTeamMetrics(ctx, analyticsApp.AggregationPeriodWeekly,
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, weekly, 1)
+		assert.Equal(t, 2, weekly[0].TotalPRs)
+		assert.True(t, weekly[0].DateRange.Start.Equal(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, weekly[0].DateRange.End.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+
+		platform, err := f.aggRepo.FindTeamMetricsByName(ctx, "platform", analyticsApp.AggregationPeriodMonthly,
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, platform, 1)
+		assert.Equal(t, 1, platform[0].TotalPRs)
+
+		bob, err := f.aggRepo.FindDeveloperMetrics(ctx, "bob", analyticsApp.AggregationPeriodDaily,
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, bob, 1)
+		assert.True(t, bob[0].DateRange.Start.Equal(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("前回以降に収集されたPRが属する期間だけを再集計する", func(t *testing.T) {
+		f := newServiceFixture(t)
+		f.savePR(t, "pr-1", "alice", january10)
+		f.savePR(t, "pr-2", "bob", february5)
+
+		first, err := f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		// 1月のPRが遅れて収集された場合、1月の期間だけを作り直す
+		f.savePR(t, "pr-3", "carol", january11)
+		second, err := f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		require.NotNil(t, second.CollectedSince)
+		assert.True(t, second.CollectedSince.Equal(first.StartedAt))
+		assert.Equal(t, 1, second.ChangedPRs)
+		assert.Equal(t, 3, second.Periods)
+
+		monthly, err := f.aggRepo.FindTeamMetrics(ctx, analyticsApp.AggregationPeriodMonthly,
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, monthly, 2, "同じ期間は上書きされる")
+		assert.Equal(t, 1, monthly[0].TotalPRs, "2月は再集計しない")
+		assert.Equal(t, 2, monthly[1].TotalPRs)
+		assert.True(t, monthly[1].GeneratedAt.Equal(second.StartedAt))
+	})
+
+	t.Run("全件指定の場合はすべてのPRを再集計する", func(t *testing.T) {
+		f := newServiceFixture(t)
+		f.savePR(t, "pr-1", "alice", january10)
+		_, err := f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		run, err := f.service.Run(ctx, true)
+		require.NoError(t, err)
+		assert.Nil(t, run.CollectedSince)
+		assert.Equal(t, 1, run.ChangedPRs)
+		assert.Equal(t, 3, run.Periods)
+	})
+
+	t.Run("失敗した場合は記録を残し、次回も同じ範囲を対象にする", func(t *testing.T) {
+		f := newServiceFixture(t)
+		f.savePR(t, "pr-1", "alice", january10)
+		f.service.aggRepo = &failingAggregatedRepository{AggregatedMetricsRepository: f.aggRepo}
+
+		run, err := f.service.Run(ctx, false)
+		require.Error(t, err)
+		assert.Equal(t, analyticsApp.AggregationRunFailed, run.Status)
+		assert.Contains(t, run.Error, "storage unavailable")
+
+		f.service.aggRepo = f.aggRepo
+		retry, err := f.service.Run(ctx, false)
+		require.NoError(t, err)
+		assert.Nil(t, retry.CollectedSince)
+		assert.Equal(t, 1, retry.ChangedPRs)
+
+		runs, err := f.service.RecentRuns(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Equal(t, analyticsApp.AggregationRunSucceeded, runs[0].Status)
+		assert.Equal(t, analyticsApp.AggregationRunFailed, runs[1].Status)
+	})
+
+	t.Run("チームのトレンド分析を期間ごとのスナップショットとして保存する", func(t *testing.T) {
+		f := newServiceFixture(t)
+		for i, createdAt := range []time.Time{january10, january11} {
+			cycleTime := time.Duration(i+1) * 10 * time.Hour
+			require.NoError(t, f.prRepo.Save(ctx, &prDomain.PRMetrics{
+				PRID: fmt.Sprintf("pr-%d", i+1), Author: "alice", Repository: "org/api", CreatedAt: createdAt,
+				TimeMetrics: prDomain.PRTimeMetrics{TotalCycleTime: &cycleTime},
+			}))
+		}
+
+		run, err := f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		for _, team := range []string{"", "platform"} {
+			snapshots, err := f.trends.FindTrendSnapshots(ctx, team, analyticsApp.AggregationPeriodWeekly,
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			require.NoError(t, err)
+			require.Len(t, snapshots, 3, "サイクルタイム・レビュー時間・品質")
+
+			var cycleTime *analyticsApp.TrendSnapshot
+			for _, snapshot := range snapshots {
+				if snapshot.MetricType == analyticsApp.TrendMetricCycleTime {
+					cycleTime = snapshot
+				}
+			}
+			require.NotNil(t, cycleTime)
+			assert.Equal(t, team, cycleTime.Team)
+			assert.True(t, cycleTime.DateRange.Start.Equal(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)))
+			assert.True(t, cycleTime.GeneratedAt.Equal(run.StartedAt))
+			assert.Equal(t, []analyticsApp.TrendPoint{{Date: "2024-01-10", Value: 10}, {Date: "2024-01-11", Value: 20}}, cycleTime.Series)
+			assert.InDelta(t, 100.0, cycleTime.ChangePercent, 1e-9)
+			assert.Equal(t, "increasing", cycleTime.Analysis.Trend)
+		}
+	})
+
+	t.Run("ボトルネックの最初の検出日時と解消日時を記録する", func(t *testing.T) {
+		f := newServiceFixture(t)
+		clock := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		f.service.now = func() time.Time { return clock }
+
+		pr := &prDomain.PRMetrics{
+			PRID: "pr-1", Author: "alice", Repository: "org/api", CreatedAt: january10,
+			SizeMetrics:  prDomain.PRSizeMetrics{LinesChanged: 450},
+			SizeCategory: prDomain.PRSizeLarge,
+		}
+		require.NoError(t, f.prRepo.Save(ctx, pr))
+		firstSeen := clock
+		_, err := f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		records, err := f.bottlenecks.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, analyticsApp.BottleneckRecordID("large_pr", "pr-1"), records[0].ID)
+		assert.Equal(t, analyticsApp.BottleneckStatusActive, records[0].Status)
+		assert.True(t, records[0].FirstSeenAt.Equal(firstSeen))
+		assert.Equal(t, "org/api", records[0].Repository)
+		assert.Equal(t, 300.0, records[0].Threshold)
+
+		// 再計算などでボトルネックでなくなった場合は解消済みにする
+		clock = clock.Add(24 * time.Hour)
+		pr.SizeMetrics.LinesChanged, pr.SizeCategory = 40, prDomain.PRSizeSmall
+		require.NoError(t, f.prRepo.Update(ctx, pr))
+		_, err = f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		records, err = f.bottlenecks.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, analyticsApp.BottleneckStatusResolved, records[0].Status)
+		require.NotNil(t, records[0].ResolvedAt)
+		assert.True(t, records[0].ResolvedAt.Equal(clock))
+
+		// 再び検出された場合は最初の検出日時を残したまま再開する
+		clock = clock.Add(24 * time.Hour)
+		pr.SizeMetrics.LinesChanged, pr.SizeCategory = 450, prDomain.PRSizeLarge
+		require.NoError(t, f.prRepo.Update(ctx, pr))
+		_, err = f.service.Run(ctx, false)
+		require.NoError(t, err)
+
+		records, err = f.bottlenecks.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, analyticsApp.BottleneckStatusActive, records[0].Status)
+		assert.Nil(t, records[0].ResolvedAt)
+		assert.True(t, records[0].FirstSeenAt.Equal(firstSeen))
+	})
+}
@@ -0,0 +1,38 @@
+package analytics
+
+import (
+	"context"
+	"time"
+)
+
+// 集計ジョブの実行結果
+const (
+	AggregationRunSucceeded = "succeeded"
+	AggregationRunFailed    = "failed"
+)
+
+// AggregationJobRun は集計ジョブ1回分の実行記録
+type AggregationJobRun struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// CollectedSince はこの日時以降に収集されたPRを再集計の対象とした（nil の場合は全件）
+	CollectedSince *time.Time `json:"collectedSince,omitempty"`
+	ChangedPRs     int        `json:"changedPrs"` // 対象となったPR数
+	Periods        int        `json:"periods"`    // 再集計した期間数（集計期間ごとに数える）
+	Records        int        `json:"records"`    // 保存した集計データ数
+	Error          string     `json:"error,omitempty"`
+}
+
+// AggregationJobRunRepository は集計ジョブの実行記録の永続化の抽象化
+type AggregationJobRunRepository interface {
+	// SaveRun は実行記録を保存
+	SaveRun(ctx context.Context, run *AggregationJobRun) error
+
+	// FindLatestSucceeded は最後に成功した実行記録を取得（存在しない場合は nil）
+	FindLatestSucceeded(ctx context.Context) (*AggregationJobRun, error)
+
+	// FindRecentRuns は実行記録を開始日時の新しい順に最大 limit 件取得
+	FindRecentRuns(ctx context.Context, limit int) ([]*AggregationJobRun, error)
+}
@@ -0,0 +1,148 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/shared/utils"
+)
+
+// ChangeFailureMetrics はリバート・ホットフィックスのPRと、それによって取り消されたPRの集計
+type ChangeFailureMetrics struct {
+	Period       AggregationPeriod              `json:"period"`
+	MergedPRs    int                            `json:"mergedPRs"`
+	RevertedPRs  int                            `json:"revertedPRs"`  // 期間中にマージされ、その後リバートされたPR
+	RevertRate   float64                        `json:"revertRate"`   // RevertedPRs / MergedPRs
+	RevertPRs    int                            `json:"revertPRs"`    // 期間中に作成されたリバートPR
+	HotfixPRs    int                            `json:"hotfixPRs"`    // 期間中に作成されたホットフィックスPR
+	UnlinkedPRs  int                            `json:"unlinkedPRs"`  // 元PRが見つからなかったリバート・ホットフィックスPR
+	TimeToRevert utils.DurationStatistics       `json:"timeToRevert"` // 元PRのマージからリバートのマージまで
+	DateRange    DateRange                      `json:"dateRange"`
+	GeneratedAt  time.Time                      `json:"generatedAt"`
+	ByAuthor     map[string]*ChangeFailureStats `json:"byAuthor"`
+	ByRepository map[string]*ChangeFailureStats `json:"byRepository"`
+	Links        []prDomain.ChangeFailureLink   `json:"-"`
+}
+
+// ChangeFailureStats は作者・リポジトリ別のリバート率と取り消しまでの時間
+// 作者は取り消された元PRの作者で、HotfixPRs のみホットフィックスPRの作者で数える
+type ChangeFailureStats struct {
+	Key          string                   `json:"key"`
+	MergedPRs    int                      `json:"mergedPRs"`
+	RevertedPRs  int                      `json:"revertedPRs"`
+	RevertRate   float64                  `json:"revertRate"`
+	HotfixPRs    int                      `json:"hotfixPRs"`
+	TimeToRevert utils.DurationStatistics `json:"timeToRevert"`
+}
+
+// AggregateChangeFailureMetrics はリバート・ホットフィックスのPRを元PRと対応付けて集計
+// metrics は集計期間に作成されたPR、history は元PRの探索にのみ使う期間より前のPR
+// リバート率は期間中にマージされたPRのうち、期間中に作成されたリバートPRで取り消されたものの割合
+func (aggregator *MetricsAggregator) AggregateChangeFailureMetrics(ctx context.Context, metrics, history []*prDomain.PRMetrics, period AggregationPeriod) (*ChangeFailureMetrics, error) {
+	result := &ChangeFailureMetrics{
+		Period:       period,
+		GeneratedAt:  time.Now(),
+		ByAuthor:     make(map[string]*ChangeFailureStats),
+		ByRepository: make(map[string]*ChangeFailureStats),
+	}
+	if len(metrics) == 0 {
+		return result, nil
+	}
+	result.DateRange = aggregator.calculateDateRange(metrics)
+
+	// リバートは作成者によらず検出し、元PRの側でbotの設定を適用する
+	candidates := make([]*prDomain.PRMetrics, 0, len(metrics)+len(history))
+	candidates = append(candidates, metrics...)
+	candidates = append(candidates, history...)
+	result.Links = prDomain.LinkChangeFailures(metrics, candidates)
+
+	revertedBy := make(map[string]prDomain.ChangeFailureLink)
+	for _, link := range result.Links {
+		if link.Change.ChangeFailure.IsHotfix() {
+			result.HotfixPRs++
+			aggregator.changeFailureStats(result.ByAuthor, aggregator.resolveAuthor(link.Change.Author)).HotfixPRs++
+			aggregator.changeFailureStats(result.ByRepository, link.Change.Repository).HotfixPRs++
+		} else {
+			result.RevertPRs++
+		}
+		if link.Original == nil {
+			result.UnlinkedPRs++
+			continue
+		}
+		if !link.Change.ChangeFailure.IsRevert() {
+			continue
+		}
+		// 同じPRが複数回リバートされた場合は最初のリバートを使う
+		if existing, exists := revertedBy[link.Original.PRID]; !exists || link.Change.CreatedAt.Before(existing.Change.CreatedAt) {
+			revertedBy[link.Original.PRID] = link
+		}
+	}
+
+	authorDurations := make(map[string][]time.Duration)
+	repositoryDurations := make(map[string][]time.Duration)
+	var durations []time.Duration
+	for _, metric := range aggregator.filterBots(metrics) {
+		if metric.MergedAt == nil {
+			continue
+		}
+		author := aggregator.resolveAuthor(metric.Author)
+		authorStats := aggregator.changeFailureStats(result.ByAuthor, author)
+		repositoryStats := aggregator.changeFailureStats(result.ByRepository, metric.Repository)
+		result.MergedPRs++
+		authorStats.MergedPRs++
+		repositoryStats.MergedPRs++
+
+		link, reverted := revertedBy[metric.PRID]
+		if !reverted {
+			continue
+		}
+		result.RevertedPRs++
+		authorStats.RevertedPRs++
+		repositoryStats.RevertedPRs++
+		if timeToRevert := link.TimeToRevert(); timeToRevert != nil {
+			durations = append(durations, *timeToRevert)
+			authorDurations[author] = append(authorDurations[author], *timeToRevert)
+			repositoryDurations[metric.Repository] = append(repositoryDurations[metric.Repository], *timeToRevert)
+		}
+	}
+
+	result.RevertRate = revertRate(result.RevertedPRs, result.MergedPRs)
+	result.TimeToRevert = aggregator.statsCalc.CalculateDurationStatistics(durations)
+	for author, stats := range result.ByAuthor {
+		stats.RevertRate = revertRate(stats.RevertedPRs, stats.MergedPRs)
+		stats.TimeToRevert = aggregator.statsCalc.CalculateDurationStatistics(authorDurations[author])
+	}
+	for repository, stats := range result.ByRepository {
+		stats.RevertRate = revertRate(stats.RevertedPRs, stats.MergedPRs)
+		stats.TimeToRevert = aggregator.statsCalc.CalculateDurationStatistics(repositoryDurations[repository])
+	}
+
+	return result, nil
+}
+
+// changeFailureStats は作者・リポジトリ別の統計を取得（未作成の場合は作成）
+func (aggregator *MetricsAggregator) changeFailureStats(stats map[string]*ChangeFailureStats, key string) *ChangeFailureStats {
+	if existing, exists := stats[key]; exists {
+		return existing
+	}
+	created := &ChangeFailureStats{Key: key}
+	stats[key] = created
+	return created
+}
+
+// resolveAuthor は同一人物の複数アカウントを正規IDに揃える
+func (aggregator *MetricsAggregator) resolveAuthor(author string) string {
+	if aggregator.config.IdentityResolver == nil {
+		return author
+	}
+	return aggregator.config.IdentityResolver.Resolve(author)
+}
+
+// revertRate はマージ数に対するリバート数の割合（マージがない場合は 0）
+func revertRate(reverted, merged int) float64 {
+	if merged == 0 {
+		return 0
+	}
+	return float64(reverted) / float64(merged)
+}
@@ -0,0 +1,188 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/shared/utils"
+)
+
+// CodeOwnershipMetrics はCODEOWNERSの所有者によるレビューの集計
+type CodeOwnershipMetrics struct {
+	Period            AggregationPeriod          `json:"period"`
+	TotalPRs          int                        `json:"totalPRs"`
+	CoveredPRs        int                        `json:"coveredPRs"`       // CODEOWNERSのあるリポジトリのPR
+	OwnedPRs          int                        `json:"ownedPRs"`         // 所有者のいるファイルを変更したPR
+	OwnerReviewedPRs  int                        `json:"ownerReviewedPRs"` // 所有者のいずれかがレビューしたPR
+	OwnerReviewRate   float64                    `json:"ownerReviewRate"`  // OwnerReviewedPRs / OwnedPRs
+	TimeToOwnerReview utils.DurationStatistics   `json:"timeToOwnerReview"`
+	UnownedPRs        int                        `json:"unownedPRs"`   // 所有者のいないファイルを変更したPR
+	UnownedFiles      int                        `json:"unownedFiles"` // 所有者のいない変更ファイル（PRごとに数える）
+	DateRange         DateRange                  `json:"dateRange"`
+	GeneratedAt       time.Time                  `json:"generatedAt"`
+	ByOwner           map[string]*OwnershipStats `json:"byOwner"`
+
+	// CODEOWNERSがない、または取得できなかったリポジトリ
+	RepositoriesWithoutCodeOwners []string `json:"repositoriesWithoutCodeOwners"`
+
+	// 所有者のいないファイルを変更したPRとそのファイル
+	Unowned []UnownedChange `json:"-"`
+}
+
+// OwnershipStats は所有者（ユーザーまたはチーム）ごとのレビュー負荷
+type OwnershipStats struct {
+	Owner        string                   `json:"owner"`
+	Team         string                   `json:"team,omitempty"` // "@org/team" に対応するロスターのチーム
+	PRs          int                      `json:"prs"`            // 所有するファイルを変更したPR
+	Files        int                      `json:"files"`
+	ReviewedPRs  int                      `json:"reviewedPRs"` // 所有者（チームの場合はその時点のメンバー）がレビューしたPR
+	ReviewRate   float64                  `json:"reviewRate"`
+	TimeToReview utils.DurationStatistics `json:"timeToReview"`
+}
+
+// UnownedChange は所有者のいないファイルを変更したPR
+type UnownedChange struct {
+	PR    *prDomain.PRMetrics
+	Files []string
+}
+
+// AggregateCodeOwnership はCODEOWNERSで変更ファイルの所有者を判定し、所有者がレビューしたかを集計
+// codeOwners はリポジトリごとのCODEOWNERS（ないリポジトリは含めないか nil）、events はPR IDごとのレビューイベント
+// "@org/team" の所有者は teams のうち GitHubTeam が一致するチームのメンバーのレビューで判定する
+// 所有者までのレビュー時間はPRの作成から所有者の最初のレビューまで
+func (aggregator *MetricsAggregator) AggregateCodeOwnership(ctx context.Context, metrics []*prDomain.PRMetrics, events map[string][]prDomain.ReviewEvent, codeOwners map[string]*prDomain.CodeOwners, teams []teamDomain.Team, period AggregationPeriod) (*CodeOwnershipMetrics, error) {
+	result := &CodeOwnershipMetrics{
+		Period:                        period,
+		GeneratedAt:                   time.Now(),
+		ByOwner:                       make(map[string]*OwnershipStats),
+		RepositoriesWithoutCodeOwners: []string{},
+	}
+	metrics = aggregator.filterBots(metrics)
+	if len(metrics) == 0 {
+		return result, nil
+	}
+	result.TotalPRs = len(metrics)
+	result.DateRange = aggregator.calculateDateRange(metrics)
+
+	githubTeams := make(map[string]teamDomain.Team)
+	for _, team := range teams {
+		if team.GitHubTeam != "" {
+			githubTeams["@"+strings.ToLower(team.GitHubTeam)] = team
+		}
+	}
+
+	reviews := make(map[string][]prDomain.ReviewActivity)
+	for _, activity := range prDomain.CollectReviewActivities(metrics, events, aggregator.config.IdentityResolver) {
+		if activity.Reviewed {
+			reviews[activity.PR.PRID] = append(reviews[activity.PR.PRID], activity)
+		}
+	}
+
+	withoutCodeOwners := make(map[string]bool)
+	var durations []time.Duration
+	ownerDurations := make(map[string][]time.Duration)
+	for _, metric := range metrics {
+		owners := codeOwners[metric.Repository]
+		if owners == nil {
+			withoutCodeOwners[metric.Repository] = true
+			continue
+		}
+		result.CoveredPRs++
+
+		ownership := owners.Analyze(metric)
+		if len(ownership.UnownedFiles) > 0 {
+			result.UnownedPRs++
+			result.UnownedFiles += len(ownership.UnownedFiles)
+			result.Unowned = append(result.Unowned, UnownedChange{PR: metric, Files: ownership.UnownedFiles})
+		}
+		if len(ownership.Owners) == 0 {
+			continue
+		}
+		result.OwnedPRs++
+
+		var firstOwnerReview *time.Time
+		for _, owner := range ownership.Owners {
+			stats := aggregator.ownershipStats(result.ByOwner, owner, githubTeams)
+			stats.PRs++
+			stats.Files += len(ownership.OwnedFiles[owner])
+
+			reviewedAt := aggregator.firstOwnerReview(owner, reviews[metric.PRID], githubTeams)
+			if reviewedAt == nil {
+				continue
+			}
+			stats.ReviewedPRs++
+			ownerDurations[owner] = append(ownerDurations[owner], reviewedAt.Sub(metric.CreatedAt))
+			if firstOwnerReview == nil || reviewedAt.Before(*firstOwnerReview) {
+				firstOwnerReview = reviewedAt
+			}
+		}
+		if firstOwnerReview != nil {
+			result.OwnerReviewedPRs++
+			durations = append(durations, firstOwnerReview.Sub(metric.CreatedAt))
+		}
+	}
+
+	result.OwnerReviewRate = ownerReviewRate(result.OwnerReviewedPRs, result.OwnedPRs)
+	result.TimeToOwnerReview = aggregator.statsCalc.CalculateDurationStatistics(durations)
+	for owner, stats := range result.ByOwner {
+		stats.ReviewRate = ownerReviewRate(stats.ReviewedPRs, stats.PRs)
+		stats.TimeToReview = aggregator.statsCalc.CalculateDurationStatistics(ownerDurations[owner])
+	}
+	for repository := range withoutCodeOwners {
+		result.RepositoriesWithoutCodeOwners = append(result.RepositoriesWithoutCodeOwners, repository)
+	}
+	sort.Strings(result.RepositoriesWithoutCodeOwners)
+
+	return result, nil
+}
+
+// firstOwnerReview は所有者（チームの場合はレビュー時点のメンバー）による最初のレビューの日時を返す
+// メールアドレスの所有者とロスターに対応するチームのないチームの所有者は判定できないため nil を返す
+func (aggregator *MetricsAggregator) firstOwnerReview(owner string, reviews []prDomain.ReviewActivity, githubTeams map[string]teamDomain.Team) *time.Time {
+	if !strings.HasPrefix(owner, "@") {
+		return nil
+	}
+	team, isTeam := githubTeams[strings.ToLower(owner)]
+	if !isTeam && strings.Contains(owner, "/") {
+		return nil
+	}
+	user := aggregator.resolveAuthor(strings.TrimPrefix(owner, "@"))
+
+	var first *time.Time
+	for _, review := range reviews {
+		matches := strings.EqualFold(review.Reviewer, user)
+		if isTeam {
+			matches = team.IsMemberAtResolved(review.Reviewer, review.ReviewedAt, aggregator.config.IdentityResolver)
+		}
+		if matches && (first == nil || review.ReviewedAt.Before(*first)) {
+			reviewedAt := review.ReviewedAt
+			first = &reviewedAt
+		}
+	}
+	return first
+}
+
+// ownershipStats は所有者の統計を取得（未作成の場合は作成）
+func (aggregator *MetricsAggregator) ownershipStats(stats map[string]*OwnershipStats, owner string, githubTeams map[string]teamDomain.Team) *OwnershipStats {
+	if existing, exists := stats[owner]; exists {
+		return existing
+	}
+	created := &OwnershipStats{Owner: owner}
+	if team, exists := githubTeams[strings.ToLower(owner)]; exists {
+		created.Team = team.Name
+	}
+	stats[owner] = created
+	return created
+}
+
+// ownerReviewRate は所有者のいるPRに対する所有者がレビューしたPRの割合（PRがない場合は 0）
+func ownerReviewRate(reviewed, owned int) float64 {
+	if owned == 0 {
+		return 0
+	}
+	return float64(reviewed) / float64(owned)
+}
@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// KnowledgeDistributionMetrics はディレクトリごとの作成・レビューの担い手の分散
+type KnowledgeDistributionMetrics struct {
+	WindowStart time.Time                     `json:"windowStart"`
+	WindowEnd   time.Time                     `json:"windowEnd"`
+	SiloShare   float64                       `json:"siloShare"` // 知識の集中とみなす1人の貢献の割合
+	TotalPRs    int                           `json:"totalPRs"`  // 集計範囲にマージされたPR
+	GeneratedAt time.Time                     `json:"generatedAt"`
+	Directories []prDomain.DirectoryKnowledge `json:"directories"` // バスファクターの小さい順
+	Silos       []prDomain.DirectoryKnowledge `json:"silos"`       // 1人の貢献の割合が閾値を超えるディレクトリ
+	Bottlenecks []Bottleneck                  `json:"bottlenecks"` // 知識の集中をボトルネックとして表したもの
+}
+
+// AggregateKnowledgeDistribution は windowStart から windowEnd までにマージされたPRの変更ファイルとレビュアーから、
+// ディレクトリ（depth 階層まで）ごとの作者・レビュアー数、バスファクター、知識の集中を集計
+// events はPR IDごとのレビューイベント（イベントのないPRはレビュアー一覧で代用）
+func (aggregator *MetricsAggregator) AggregateKnowledgeDistribution(ctx context.Context, metrics []*prDomain.PRMetrics, events map[string][]prDomain.ReviewEvent, depth int, windowStart, windowEnd time.Time) (*KnowledgeDistributionMetrics, error) {
+	analyzer := prDomain.NewKnowledgeAnalyzer(aggregator.config.KnowledgeSiloShare, depth, aggregator.config.IdentityResolver)
+	result := &KnowledgeDistributionMetrics{
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		SiloShare:   analyzer.SiloShare(),
+		GeneratedAt: time.Now(),
+		Directories: []prDomain.DirectoryKnowledge{},
+		Silos:       []prDomain.DirectoryKnowledge{},
+		Bottlenecks: []Bottleneck{},
+	}
+
+	var merged []*prDomain.PRMetrics
+	for _, metric := range aggregator.filterBots(metrics) {
+		if metric.MergedAt != nil && !metric.MergedAt.Before(windowStart) && !metric.MergedAt.After(windowEnd) {
+			merged = append(merged, metric)
+		}
+	}
+	if len(merged) == 0 {
+		return result, nil
+	}
+	result.TotalPRs = len(merged)
+
+	result.Directories = analyzer.Analyze(merged, events)
+	for _, directory := range result.Directories {
+		if !directory.IsSilo {
+			continue
+		}
+		result.Silos = append(result.Silos, directory)
+		result.Bottlenecks = append(result.Bottlenecks, knowledgeSiloBottleneck(directory, result.SiloShare))
+	}
+
+	return result, nil
+}
+
+// knowledgeSiloBottleneck は知識の集中したディレクトリをボトルネックとして表す
+// 作成・レビューとも1人しか関わっていない場合は重大度を high とする
+func knowledgeSiloBottleneck(directory prDomain.DirectoryKnowledge, siloShare float64) Bottleneck {
+	top := directory.TopContributor()
+	severity := "medium"
+	if len(directory.Contributors) == 1 {
+		severity = "high"
+	}
+	return Bottleneck{
+		Type:     string(prDomain.BottleneckTypeKnowledgeSilo),
+		Severity: severity,
+		Description: fmt.Sprintf("%s の %s は %s が貢献の%.0f%%を占めている（%dPR、作者%d人・レビュアー%d人）",
+			directory.Repository, directory.Directory, top.Login, top.Share*100, directory.PRs, directory.DistinctAuthors, directory.DistinctReviewers),
+		Value:              top.Share,
+		Threshold:          siloShare,
+		AffectedDevelopers: []string{top.Login},
+	}
+}
@@ -3,6 +3,8 @@ package analytics
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	prDomain "github-stats-metrics/domain/pull_request"
@@ -12,20 +14,61 @@ import (
 // MetricsAggregator はメトリクスを集計するサービス
 type MetricsAggregator struct {
 	statsCalc *utils.StatisticsCalculator
+	config    AggregatorConfig
+}
+
+// AggregatorConfig は集計の設定
+type AggregatorConfig struct {
+	// IncludeBots が false の場合、botのPRをチーム・開発者集計から除外する
+	IncludeBots bool
+
+	// IdentityResolver が設定されている場合、同一人物の複数アカウントを1人として集計する
+	IdentityResolver prDomain.IdentityResolver
+
+	// Definitions が設定されている場合、リポジトリごとの計算定義のボトルネックの閾値を使う
+	Definitions prDomain.DefinitionSource
+
+	// ReworkWindow はマージ後の手戻りとして数える期間（0 の場合は既定の21日）
+	ReworkWindow time.Duration
+
+	// ReviewLoadAlertShare を超える割合のレビューを1人が担当している場合に警告する（0 の場合は既定の0.4）
+	ReviewLoadAlertShare float64
+
+	// KnowledgeSiloShare を超える割合の貢献を1人が占めるディレクトリを知識の集中とする（0 の場合は既定の0.8）
+	KnowledgeSiloShare float64
+
+	// WIPStaleAfter を過ぎても未マージのPRは放置されたとみなしWIPに数えない（0 の場合は既定の30日）
+	WIPStaleAfter time.Duration
+}
+
+// DefaultAggregatorConfig はデフォルトの集計設定を返す
+func DefaultAggregatorConfig() AggregatorConfig {
+	return AggregatorConfig{
+		IncludeBots: false,
+	}
 }
 
 // NewMetricsAggregator は新しいメトリクス集計器を作成
 func NewMetricsAggregator() *MetricsAggregator {
+	return NewMetricsAggregatorWithConfig(DefaultAggregatorConfig())
+}
+
+// NewMetricsAggregatorWithConfig は設定を指定してメトリクス集計器を作成
+func NewMetricsAggregatorWithConfig(config AggregatorConfig) *MetricsAggregator {
 	return &MetricsAggregator{
 		statsCalc: utils.NewStatisticsCalculator(),
+		config:    config,
 	}
 }
 
 // AggregateTeamMetrics はチーム全体のメトリクスを集計
 func (aggregator *MetricsAggregator) AggregateTeamMetrics(ctx context.Context, metrics []*prDomain.PRMetrics, period AggregationPeriod) (*TeamMetrics, error) {
+	metrics = aggregator.filterBots(metrics)
 	if len(metrics) == 0 {
 		return &TeamMetrics{Period: period}, nil
 	}
+	// レビュアー数が別アカウントで水増しされないよう正規IDに揃える
+	metrics = prDomain.ResolveIdentities(metrics, aggregator.config.IdentityResolver)
 
 	teamMetrics := &TeamMetrics{
 		Period:      period,
@@ -60,7 +103,7 @@ func (aggregator *MetricsAggregator) AggregateTeamMetrics(ctx context.Context, m
 
 // AggregateDeveloperMetrics は開発者別のメトリクスを集計
 func (aggregator *MetricsAggregator) AggregateDeveloperMetrics(ctx context.Context, metrics []*prDomain.PRMetrics, period AggregationPeriod) (map[string]*DeveloperMetrics, error) {
-	developerData := aggregator.groupByDeveloper(metrics)
+	developerData := aggregator.groupByDeveloper(aggregator.filterBots(metrics))
 	result := make(map[string]*DeveloperMetrics)
 
 	for developer, devMetrics := range developerData {
@@ -106,6 +149,108 @@ func (aggregator *MetricsAggregator) AggregateRepositoryMetrics(ctx context.Cont
 	return result, nil
 }
 
+// AggregateLabelMetrics はラベル別のメトリクスを集計
+// 複数ラベルを持つPRは各ラベルに重複して計上される。ラベル名は小文字に揃える
+func (aggregator *MetricsAggregator) AggregateLabelMetrics(ctx context.Context, metrics []*prDomain.PRMetrics, period AggregationPeriod) (map[string]*LabelMetrics, error) {
+	labelData := aggregator.groupByLabel(metrics)
+	result := make(map[string]*LabelMetrics)
+
+	for label, labelMetrics := range labelData {
+		result[label] = &LabelMetrics{
+			Label:           label,
+			Period:          period,
+			TotalPRs:        len(labelMetrics),
+			DateRange:       aggregator.calculateDateRange(labelMetrics),
+			GeneratedAt:     time.Now(),
+			CycleTimeStats:  aggregator.aggregateCycleTimeStats(labelMetrics),
+			ReviewStats:     aggregator.aggregateReviewStats(labelMetrics),
+			SizeStats:       aggregator.aggregateSizeStats(labelMetrics),
+			QualityStats:    aggregator.aggregateQualityStats(labelMetrics),
+			ComplexityStats: aggregator.aggregateComplexityStats(labelMetrics),
+			Contributors:    aggregator.getUniqueContributors(labelMetrics),
+		}
+	}
+
+	return result, nil
+}
+
+// AggregateAutomationMetrics はbotが作成したPRのみを集計
+func (aggregator *MetricsAggregator) AggregateAutomationMetrics(ctx context.Context, metrics []*prDomain.PRMetrics, period AggregationPeriod) (*AutomationMetrics, error) {
+	automationMetrics := &AutomationMetrics{
+		Period:      period,
+		GeneratedAt: time.Now(),
+		ByBot:       make(map[string]*BotAutomationStats),
+	}
+
+	botData := make(map[string][]*prDomain.PRMetrics)
+	var botPRs []*prDomain.PRMetrics
+	for _, metric := range metrics {
+		if !metric.IsBot {
+			continue
+		}
+		botPRs = append(botPRs, metric)
+		botData[metric.Author] = append(botData[metric.Author], metric)
+	}
+
+	if len(botPRs) == 0 {
+		return automationMetrics, nil
+	}
+
+	automationMetrics.TotalPRs = len(botPRs)
+	automationMetrics.DateRange = aggregator.calculateDateRange(botPRs)
+	automationMetrics.ShareOfAllPRs = float64(len(botPRs)) / float64(len(metrics))
+
+	var mergeLatencies []time.Duration
+	for bot, prs := range botData {
+		latencies := aggregator.collectMergeLatencies(prs)
+		mergeLatencies = append(mergeLatencies, latencies...)
+		automationMetrics.ByBot[bot] = &BotAutomationStats{
+			Bot:          bot,
+			TotalPRs:     len(prs),
+			MergedPRs:    len(latencies),
+			MergeLatency: aggregator.statsCalc.CalculateDurationStatistics(latencies),
+		}
+	}
+
+	automationMetrics.MergedPRs = len(mergeLatencies)
+	automationMetrics.MergeLatency = aggregator.statsCalc.CalculateDurationStatistics(mergeLatencies)
+
+	return automationMetrics, nil
+}
+
+// ExcludeBots は設定（IncludeBots）に応じてbotのPRを除外
+// 集計器を通さずにPRメトリクスから値を求める場合も、集計と同じ条件でbotを扱うために使う
+func (aggregator *MetricsAggregator) ExcludeBots(metrics []*prDomain.PRMetrics) []*prDomain.PRMetrics {
+	return aggregator.filterBots(metrics)
+}
+
+// filterBots は設定に応じてbotのPRを除外
+func (aggregator *MetricsAggregator) filterBots(metrics []*prDomain.PRMetrics) []*prDomain.PRMetrics {
+	if aggregator.config.IncludeBots {
+		return metrics
+	}
+
+	filtered := make([]*prDomain.PRMetrics, 0, len(metrics))
+	for _, metric := range metrics {
+		if !metric.IsBot {
+			filtered = append(filtered, metric)
+		}
+	}
+	return filtered
+}
+
+// collectMergeLatencies は作成からマージまでの時間を収集（未マージは除外）
+func (aggregator *MetricsAggregator) collectMergeLatencies(metrics []*prDomain.PRMetrics) []time.Duration {
+	var latencies []time.Duration
+	for _, metric := range metrics {
+		if metric.MergedAt == nil {
+			continue
+		}
+		latencies = append(latencies, metric.MergedAt.Sub(metric.CreatedAt))
+	}
+	return latencies
+}
+
 // aggregateCycleTimeStats はサイクルタイム統計を集計
 func (aggregator *MetricsAggregator) aggregateCycleTimeStats(metrics []*prDomain.PRMetrics) CycleTimeStatsAgg {
 	var totalCycleTimes []time.Duration
@@ -230,14 +375,21 @@ func (aggregator *MetricsAggregator) aggregateComplexityStats(metrics []*prDomai
 
 // calculateTrends はトレンド分析を実行
 func (aggregator *MetricsAggregator) calculateTrends(metrics []*prDomain.PRMetrics) TrendAnalysisResult {
-	// 時系列データの準備
+	// 時系列データの準備（日付順に並べる）
 	dailyData := aggregator.groupByDay(metrics)
+	days := make([]string, 0, len(dailyData))
+	for day := range dailyData {
+		days = append(days, day)
+	}
+	sort.Strings(days)
 	
 	var cycleTimes []float64
 	var reviewTimes []float64
 	var qualityScores []float64
+	var series []TrendDataPoint
 
-	for _, dayMetrics := range dailyData {
+	for _, day := range days {
+		dayMetrics := dailyData[day]
 		if len(dayMetrics) == 0 {
 			continue
 		}
@@ -250,54 +402,87 @@ func (aggregator *MetricsAggregator) calculateTrends(metrics []*prDomain.PRMetri
 		cycleTimes = append(cycleTimes, avgCycleTime)
 		reviewTimes = append(reviewTimes, avgReviewTime)
 		qualityScores = append(qualityScores, avgQualityScore)
+		series = append(series, TrendDataPoint{
+			Date:       day,
+			CycleTime:  avgCycleTime,
+			ReviewTime: avgReviewTime,
+			Quality:    avgQualityScore,
+		})
 	}
 
 	return TrendAnalysisResult{
 		CycleTimeTrend:    aggregator.statsCalc.AnalyzeTrend(cycleTimes),
 		ReviewTimeTrend:   aggregator.statsCalc.AnalyzeTrend(reviewTimes),
 		QualityTrend:      aggregator.statsCalc.AnalyzeTrend(qualityScores),
+		Series:            series,
+	}
+}
+
+// IdentifyBottlenecks はbotを除いたPRからボトルネックを特定
+func (aggregator *MetricsAggregator) IdentifyBottlenecks(metrics []*prDomain.PRMetrics) []Bottleneck {
+	return aggregator.identifyBottlenecks(aggregator.filterBots(metrics))
+}
+
+// bottleneckThresholds はリポジトリのボトルネックの閾値を返す
+func (aggregator *MetricsAggregator) bottleneckThresholds(repository string) prDomain.BottleneckThresholds {
+	if aggregator.config.Definitions == nil {
+		return prDomain.DefaultBottleneckThresholds()
 	}
+	return aggregator.config.Definitions.DefinitionFor(repository).Bottleneck
 }
 
-// identifyBottlenecks はボトルネックを特定
+// identifyBottlenecks はボトルネックを特定（閾値はPRのリポジトリの設定を使う）
 func (aggregator *MetricsAggregator) identifyBottlenecks(metrics []*prDomain.PRMetrics) []Bottleneck {
 	var bottlenecks []Bottleneck
+	thresholds := make(map[string]prDomain.BottleneckThresholds)
+	thresholdsFor := func(repository string) prDomain.BottleneckThresholds {
+		if _, exists := thresholds[repository]; !exists {
+			thresholds[repository] = aggregator.bottleneckThresholds(repository)
+		}
+		return thresholds[repository]
+	}
 
 	// 長いサイクルタイムのPR
 	for _, metric := range metrics {
-		if metric.TimeMetrics.TotalCycleTime != nil && *metric.TimeMetrics.TotalCycleTime > 7*24*time.Hour {
+		longCycleTimeThreshold := thresholdsFor(metric.Repository).LongCycleTime
+		if metric.TimeMetrics.TotalCycleTime != nil && *metric.TimeMetrics.TotalCycleTime > longCycleTimeThreshold {
 			bottlenecks = append(bottlenecks, Bottleneck{
 				Type:        "long_cycle_time",
 				PRID:        metric.PRID,
 				Severity:    "high",
 				Description: fmt.Sprintf("サイクルタイムが%s", formatDuration(*metric.TimeMetrics.TotalCycleTime)),
 				Value:       metric.TimeMetrics.TotalCycleTime.Hours(),
+				Threshold:   longCycleTimeThreshold.Hours(),
 			})
 		}
 	}
 
 	// 多数のレビューラウンド
 	for _, metric := range metrics {
-		if metric.QualityMetrics.ReviewRoundCount > 5 {
+		reviewRoundThreshold := thresholdsFor(metric.Repository).ReviewRounds
+		if metric.QualityMetrics.ReviewRoundCount > reviewRoundThreshold {
 			bottlenecks = append(bottlenecks, Bottleneck{
 				Type:        "multiple_review_rounds",
 				PRID:        metric.PRID,
 				Severity:    "medium",
 				Description: fmt.Sprintf("%d回のレビューラウンド", metric.QualityMetrics.ReviewRoundCount),
 				Value:       float64(metric.QualityMetrics.ReviewRoundCount),
+				Threshold:   float64(reviewRoundThreshold),
 			})
 		}
 	}
 
 	// 大きなPR
 	for _, metric := range metrics {
-		if metric.IsLargePR() {
+		largePRLinesThreshold := thresholdsFor(metric.Repository).LargePRLines
+		if metric.SizeMetrics.LinesChanged > largePRLinesThreshold {
 			bottlenecks = append(bottlenecks, Bottleneck{
 				Type:        "large_pr",
 				PRID:        metric.PRID,
 				Severity:    "medium",
 				Description: fmt.Sprintf("大きなPR（%d行の変更）", metric.SizeMetrics.LinesChanged),
 				Value:       float64(metric.SizeMetrics.LinesChanged),
+				Threshold:   float64(largePRLinesThreshold),
 			})
 		}
 	}
@@ -309,7 +494,7 @@ func (aggregator *MetricsAggregator) identifyBottlenecks(metrics []*prDomain.PRM
 
 func (aggregator *MetricsAggregator) groupByDeveloper(metrics []*prDomain.PRMetrics) map[string][]*prDomain.PRMetrics {
 	result := make(map[string][]*prDomain.PRMetrics)
-	for _, metric := range metrics {
+	for _, metric := range prDomain.ResolveIdentities(metrics, aggregator.config.IdentityResolver) {
 		result[metric.Author] = append(result[metric.Author], metric)
 	}
 	return result
@@ -323,6 +508,28 @@ func (aggregator *MetricsAggregator) groupByRepository(metrics []*prDomain.PRMet
 	return result
 }
 
+func (aggregator *MetricsAggregator) groupByLabel(metrics []*prDomain.PRMetrics) map[string][]*prDomain.PRMetrics {
+	result := make(map[string][]*prDomain.PRMetrics)
+	for _, metric := range metrics {
+		if len(metric.Labels) == 0 {
+			result[UnlabeledKey] = append(result[UnlabeledKey], metric)
+			continue
+		}
+		// PRMetrics.HasLabel と同じく大文字・小文字を区別せず、小文字に揃えて集計する
+		// 同一PR内の重複ラベルは1回のみ計上
+		seen := make(map[string]bool)
+		for _, label := range metric.Labels {
+			key := strings.ToLower(label)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result[key] = append(result[key], metric)
+		}
+	}
+	return result
+}
+
 func (aggregator *MetricsAggregator) groupByDay(metrics []*prDomain.PRMetrics) map[string][]*prDomain.PRMetrics {
 	result := make(map[string][]*prDomain.PRMetrics)
 	for _, metric := range metrics {
@@ -358,7 +565,7 @@ func (aggregator *MetricsAggregator) calculateDateRange(metrics []*prDomain.PRMe
 func (aggregator *MetricsAggregator) getUniqueContributors(metrics []*prDomain.PRMetrics) []string {
 	contributors := make(map[string]bool)
 	for _, metric := range metrics {
-		contributors[metric.Author] = true
+		contributors[aggregator.resolveLogin(metric.Author)] = true
 	}
 
 	result := make([]string, 0, len(contributors))
@@ -368,6 +575,14 @@ func (aggregator *MetricsAggregator) getUniqueContributors(metrics []*prDomain.P
 	return result
 }
 
+// resolveLogin はログイン名を開発者の正規IDに変換（未設定時はそのまま）
+func (aggregator *MetricsAggregator) resolveLogin(login string) string {
+	if aggregator.config.IdentityResolver == nil {
+		return login
+	}
+	return aggregator.config.IdentityResolver.Resolve(login)
+}
+
 func (aggregator *MetricsAggregator) calculateProductivity(metrics []*prDomain.PRMetrics, period AggregationPeriod) ProductivityMetrics {
 	if len(metrics) == 0 {
 		return ProductivityMetrics{}
@@ -505,6 +720,7 @@ type DateRange struct {
 
 // TeamMetrics はチーム全体のメトリクス
 type TeamMetrics struct {
+	Team            string               `json:"team,omitempty"` // 空の場合は集計対象全員
 	Period          AggregationPeriod    `json:"period"`
 	TotalPRs        int                  `json:"totalPRs"`
 	DateRange       DateRange            `json:"dateRange"`
@@ -548,6 +764,44 @@ type RepositoryMetrics struct {
 	Contributors    []string             `json:"contributors"`
 }
 
+// UnlabeledKey はラベルが付与されていないPRの集計キー
+const UnlabeledKey = "(unlabeled)"
+
+// LabelMetrics はラベル別のメトリクス
+type LabelMetrics struct {
+	Label           string               `json:"label"`
+	Period          AggregationPeriod    `json:"period"`
+	TotalPRs        int                  `json:"totalPRs"`
+	DateRange       DateRange            `json:"dateRange"`
+	GeneratedAt     time.Time            `json:"generatedAt"`
+	CycleTimeStats  CycleTimeStatsAgg    `json:"cycleTimeStats"`
+	ReviewStats     ReviewStatsAgg       `json:"reviewStats"`
+	SizeStats       SizeStatsAgg         `json:"sizeStats"`
+	QualityStats    QualityStatsAgg      `json:"qualityStats"`
+	ComplexityStats ComplexityStatsAgg   `json:"complexityStats"`
+	Contributors    []string             `json:"contributors"`
+}
+
+// AutomationMetrics はbotが作成したPRのメトリクス
+type AutomationMetrics struct {
+	Period        AggregationPeriod              `json:"period"`
+	TotalPRs      int                            `json:"totalPRs"`
+	MergedPRs     int                            `json:"mergedPRs"`
+	ShareOfAllPRs float64                        `json:"shareOfAllPRs"` // 全PRに占める割合
+	DateRange     DateRange                      `json:"dateRange"`
+	GeneratedAt   time.Time                      `json:"generatedAt"`
+	MergeLatency  utils.DurationStatistics       `json:"mergeLatency"` // 作成からマージまで
+	ByBot         map[string]*BotAutomationStats `json:"byBot"`
+}
+
+// BotAutomationStats はbotアカウント別の統計
+type BotAutomationStats struct {
+	Bot          string                   `json:"bot"`
+	TotalPRs     int                      `json:"totalPRs"`
+	MergedPRs    int                      `json:"mergedPRs"`
+	MergeLatency utils.DurationStatistics `json:"mergeLatency"`
+}
+
 // 各種統計構造体
 type CycleTimeStatsAgg struct {
 	TotalCycleTime    utils.DurationStatistics `json:"totalCycleTime"`
@@ -594,6 +848,15 @@ type TrendAnalysisResult struct {
 	CycleTimeTrend  utils.TrendAnalysis `json:"cycleTimeTrend"`
 	ReviewTimeTrend utils.TrendAnalysis `json:"reviewTimeTrend"`
 	QualityTrend    utils.TrendAnalysis `json:"qualityTrend"`
+	Series          []TrendDataPoint    `json:"series,omitempty"` // トレンド分析に使った日次平均
+}
+
+// TrendDataPoint はトレンド分析の日次データ（時間は時間単位）
+type TrendDataPoint struct {
+	Date       string  `json:"date"` // YYYY-MM-DD
+	CycleTime  float64 `json:"cycleTime"`
+	ReviewTime float64 `json:"reviewTime"`
+	Quality    float64 `json:"quality"`
 }
 
 type Bottleneck struct {
@@ -602,4 +865,8 @@ type Bottleneck struct {
 	Severity    string  `json:"severity"`
 	Description string  `json:"description"`
 	Value       float64 `json:"value"`
+	Threshold   float64 `json:"threshold"` // 判定に使った閾値（Value と同じ単位）
+
+	// PRに紐付かないボトルネック（知識の集中等）の対象の開発者
+	AffectedDevelopers []string `json:"affectedDevelopers,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,33 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+func TestMetricsAggregator_AggregateLabelMetrics_IgnoresCase(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	metrics := []*prDomain.PRMetrics{
+		{PRID: "pr-1", Author: "alice", CreatedAt: createdAt, Labels: []string{"Bug", "bug"}},
+		{PRID: "pr-2", Author: "bob", CreatedAt: createdAt, Labels: []string{"bug", "Feature"}},
+		{PRID: "pr-3", Author: "carol", CreatedAt: createdAt},
+	}
+
+	result, err := NewMetricsAggregator().AggregateLabelMetrics(context.Background(), metrics, AggregationPeriodMonthly)
+	require.NoError(t, err)
+
+	assert.Len(t, result, 3)
+	require.Contains(t, result, "bug")
+	assert.Equal(t, "bug", result["bug"].Label)
+	assert.Equal(t, 2, result["bug"].TotalPRs, "大文字・小文字違いのラベルは同じラベルとして1回だけ計上する")
+	require.Contains(t, result, "feature")
+	assert.Equal(t, 1, result["feature"].TotalPRs)
+	require.Contains(t, result, UnlabeledKey)
+	assert.Equal(t, 1, result[UnlabeledKey].TotalPRs)
+}
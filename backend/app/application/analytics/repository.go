@@ -0,0 +1,105 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github-stats-metrics/domain/analytics"
+)
+
+// AggregatedMetricsRepository は集計済みメトリクスの永続化の抽象化
+// 集計データは集計レベル・期間・対象・期間範囲の組で一意になり、再保存は上書きになる
+type AggregatedMetricsRepository interface {
+	// SaveTeamMetrics はチームメトリクスを保存（チーム名が空の場合は集計対象全員）
+	SaveTeamMetrics(ctx context.Context, metrics *TeamMetrics) error
+
+	// SaveDeveloperMetrics は開発者メトリクスを保存
+	SaveDeveloperMetrics(ctx context.Context, metrics *DeveloperMetrics) error
+
+	// SaveRepositoryMetrics はリポジトリメトリクスを保存
+	SaveRepositoryMetrics(ctx context.Context, metrics *RepositoryMetrics) error
+
+	// SaveLabelMetrics はラベルメトリクスを保存
+	SaveLabelMetrics(ctx context.Context, metrics *LabelMetrics) error
+
+	// FindTeamMetrics は集計対象全員のチームメトリクスを取得
+	FindTeamMetrics(ctx context.Context, period AggregationPeriod, startDate, endDate time.Time) ([]*TeamMetrics, error)
+
+	// FindTeamMetricsByName は指定チームのメトリクスを取得（空の場合は集計対象全員）
+	FindTeamMetricsByName(ctx context.Context, team string, period AggregationPeriod, startDate, endDate time.Time) ([]*TeamMetrics, error)
+
+	// FindDeveloperMetrics は開発者メトリクスを期間開始の新しい順に取得
+	FindDeveloperMetrics(ctx context.Context, developer string, period AggregationPeriod, startDate, endDate time.Time) ([]*DeveloperMetrics, error)
+
+	// FindRepositoryMetrics はリポジトリメトリクスを期間開始の新しい順に取得
+	FindRepositoryMetrics(ctx context.Context, repository string, period AggregationPeriod, startDate, endDate time.Time) ([]*RepositoryMetrics, error)
+
+	// FindAllDeveloperMetrics は全開発者のメトリクスを開発者ごとに取得
+	FindAllDeveloperMetrics(ctx context.Context, period AggregationPeriod, startDate, endDate time.Time) (map[string]*DeveloperMetrics, error)
+
+	// FindAllRepositoryMetrics は全リポジトリのメトリクスをリポジトリごとに取得
+	FindAllRepositoryMetrics(ctx context.Context, period AggregationPeriod, startDate, endDate time.Time) (map[string]*RepositoryMetrics, error)
+
+	// FindAllLabelMetrics は全ラベルのメトリクスをラベルと集計期間の組ごとに取得
+	FindAllLabelMetrics(ctx context.Context, period AggregationPeriod, startDate, endDate time.Time) (map[LabelMetricsKey]*LabelMetrics, error)
+
+	// DeleteOldAggregatedData は保持期間を過ぎた集計データを削除し、削除件数を返す
+	DeleteOldAggregatedData(ctx context.Context, retentionPolicy analytics.DataRetentionPolicy) (int64, error)
+
+	// FindGeneratedBefore は指定期間のうち生成日時が cutoff より前の集計データを全集計レベル分取得
+	FindGeneratedBefore(ctx context.Context, period AggregationPeriod, cutoff time.Time) ([]*AggregatedRecord, error)
+
+	// DeleteGeneratedBefore は指定期間のうち生成日時が cutoff より前の集計データを削除し、削除件数を返す
+	DeleteGeneratedBefore(ctx context.Context, period AggregationPeriod, cutoff time.Time) (int64, error)
+
+	// GetAggregatedStatistics は集計データの統計情報を取得
+	GetAggregatedStatistics(ctx context.Context) (*AggregatedStatistics, error)
+}
+
+// 集計レベル
+const (
+	AggregationLevelTeam       = "team"
+	AggregationLevelDeveloper  = "developer"
+	AggregationLevelRepository = "repository"
+	AggregationLevelLabel      = "label"
+)
+
+// LabelMetricsKey はラベル別メトリクスをラベルと集計期間の開始時刻（UTC）の組で識別する
+// 日付範囲に複数の集計期間が含まれる場合も、期間ごとのデータが上書きされないようにする
+type LabelMetricsKey struct {
+	Label       string
+	PeriodStart time.Time
+}
+
+// NewLabelMetricsKey はラベル別メトリクスのキーを作成
+func NewLabelMetricsKey(metrics *LabelMetrics) LabelMetricsKey {
+	return LabelMetricsKey{Label: metrics.Label, PeriodStart: metrics.DateRange.Start.UTC()}
+}
+
+// AggregatedRecord は集計レベルを問わない集計データ1件
+// Level に応じていずれか1つのメトリクスのみが設定される
+type AggregatedRecord struct {
+	Level      string             `json:"level"`
+	Team       *TeamMetrics       `json:"team,omitempty"`
+	Developer  *DeveloperMetrics  `json:"developer,omitempty"`
+	Repository *RepositoryMetrics `json:"repository,omitempty"`
+	Label      *LabelMetrics      `json:"label,omitempty"`
+}
+
+// AggregatedStatistics は集計データの統計情報
+type AggregatedStatistics struct {
+	LevelStats map[string]*AggregationLevelStats `json:"levelStats"`
+}
+
+// AggregationLevelStats は集計レベル別の統計情報
+type AggregationLevelStats struct {
+	PeriodStats map[string]*PeriodStats `json:"periodStats"`
+}
+
+// PeriodStats は期間別の統計情報
+type PeriodStats struct {
+	RecordCount   int64     `json:"recordCount"`
+	UniqueTargets int64     `json:"uniqueTargets"`
+	OldestPeriod  time.Time `json:"oldestPeriod"`
+	NewestPeriod  time.Time `json:"newestPeriod"`
+}
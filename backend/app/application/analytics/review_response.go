@@ -0,0 +1,150 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/shared/utils"
+)
+
+// ReviewResponseMetrics はレビュー依頼ごとの、依頼されたレビュアーがレビューするまでの時間の集計
+type ReviewResponseMetrics struct {
+	TotalRequests int                      `json:"totalRequests"`
+	Reviewed      int                      `json:"reviewed"`
+	Removed       int                      `json:"removed"`      // レビューの前に取り消された依頼
+	Unanswered    int                      `json:"unanswered"`   // レビューも取り消しもされていない依頼
+	ResponseRate  float64                  `json:"responseRate"` // Reviewed / (TotalRequests - Removed)
+	ResponseTime  utils.DurationStatistics `json:"responseTime"` // 依頼からそのレビュアーのレビューまで
+	DateRange     DateRange                `json:"dateRange"`
+	GeneratedAt   time.Time                `json:"generatedAt"`
+	Reviewers     []*ReviewResponseStats   `json:"reviewers"` // 依頼数の多い順
+	Teams         []*ReviewResponseStats   `json:"teams"`
+
+	// 未対応の依頼（待ち時間の長い順）
+	UnansweredRequests []UnansweredReviewRequest `json:"-"`
+}
+
+// ReviewResponseStats はレビュアーまたはチームごとのレビュー依頼への応答
+type ReviewResponseStats struct {
+	Name          string                   `json:"name"` // レビュアーのログイン名またはチーム名
+	TotalRequests int                      `json:"totalRequests"`
+	Reviewed      int                      `json:"reviewed"`
+	Removed       int                      `json:"removed"`
+	Unanswered    int                      `json:"unanswered"`
+	ResponseRate  float64                  `json:"responseRate"`
+	ResponseTime  utils.DurationStatistics `json:"responseTime"`
+}
+
+// UnansweredReviewRequest はレビューも取り消しもされていない依頼
+type UnansweredReviewRequest struct {
+	Request     prDomain.ReviewRequest
+	WaitingTime time.Duration // 依頼から現在（マージ済みのPRはマージ）まで
+}
+
+// AggregateReviewResponse は依頼されたレビュアーが依頼からレビューするまでの時間をレビュアーごと・チームごとに集計
+// metrics は集計期間に作成されたPR、events はPR IDごとのレビューイベント（依頼イベントのないPRは対象外）
+// チームには依頼時点で所属していたメンバーへの依頼を数える。待ち時間は now を基準にする
+func (aggregator *MetricsAggregator) AggregateReviewResponse(ctx context.Context, metrics []*prDomain.PRMetrics, events map[string][]prDomain.ReviewEvent, teams []teamDomain.Team, now time.Time) (*ReviewResponseMetrics, error) {
+	result := &ReviewResponseMetrics{
+		GeneratedAt:        time.Now(),
+		Reviewers:          []*ReviewResponseStats{},
+		Teams:              []*ReviewResponseStats{},
+		UnansweredRequests: []UnansweredReviewRequest{},
+	}
+	metrics = aggregator.filterBots(metrics)
+	if len(metrics) == 0 {
+		return result, nil
+	}
+	result.DateRange = aggregator.calculateDateRange(metrics)
+
+	requests := prDomain.CollectReviewRequests(metrics, events, aggregator.config.IdentityResolver)
+
+	overall := &reviewResponseAccumulator{}
+	byReviewer := make(map[string]*reviewResponseAccumulator)
+	byTeam := make(map[string]*reviewResponseAccumulator)
+	for _, team := range teams {
+		byTeam[team.Name] = &reviewResponseAccumulator{}
+	}
+	for _, request := range requests {
+		overall.add(request)
+		if byReviewer[request.Reviewer] == nil {
+			byReviewer[request.Reviewer] = &reviewResponseAccumulator{}
+		}
+		byReviewer[request.Reviewer].add(request)
+		for _, team := range teams {
+			if team.IsMemberAtResolved(request.Reviewer, request.RequestedAt, aggregator.config.IdentityResolver) {
+				byTeam[team.Name].add(request)
+			}
+		}
+
+		if request.Outcome == prDomain.ReviewRequestOutcomeUnanswered {
+			result.UnansweredRequests = append(result.UnansweredRequests, UnansweredReviewRequest{
+				Request:     request,
+				WaitingTime: request.WaitingTime(now),
+			})
+		}
+	}
+
+	summary := overall.summarize("", aggregator.statsCalc)
+	result.TotalRequests = summary.TotalRequests
+	result.Reviewed = summary.Reviewed
+	result.Removed = summary.Removed
+	result.Unanswered = summary.Unanswered
+	result.ResponseRate = summary.ResponseRate
+	result.ResponseTime = summary.ResponseTime
+
+	for reviewer, accumulator := range byReviewer {
+		result.Reviewers = append(result.Reviewers, accumulator.summarize(reviewer, aggregator.statsCalc))
+	}
+	sort.Slice(result.Reviewers, func(i, j int) bool {
+		if result.Reviewers[i].TotalRequests != result.Reviewers[j].TotalRequests {
+			return result.Reviewers[i].TotalRequests > result.Reviewers[j].TotalRequests
+		}
+		return result.Reviewers[i].Name < result.Reviewers[j].Name
+	})
+	for _, team := range teams {
+		result.Teams = append(result.Teams, byTeam[team.Name].summarize(team.Name, aggregator.statsCalc))
+	}
+
+	sort.SliceStable(result.UnansweredRequests, func(i, j int) bool {
+		return result.UnansweredRequests[i].WaitingTime > result.UnansweredRequests[j].WaitingTime
+	})
+
+	return result, nil
+}
+
+// reviewResponseAccumulator はレビュー依頼の結果と応答時間を集める
+type reviewResponseAccumulator struct {
+	stats         ReviewResponseStats
+	responseTimes []time.Duration
+}
+
+// add は依頼1件を加える
+func (a *reviewResponseAccumulator) add(request prDomain.ReviewRequest) {
+	a.stats.TotalRequests++
+	switch request.Outcome {
+	case prDomain.ReviewRequestOutcomeReviewed:
+		a.stats.Reviewed++
+		if responseTime, ok := request.ResponseTime(); ok {
+			a.responseTimes = append(a.responseTimes, responseTime)
+		}
+	case prDomain.ReviewRequestOutcomeRemoved:
+		a.stats.Removed++
+	default:
+		a.stats.Unanswered++
+	}
+}
+
+// summarize は応答率と応答時間の統計を計算する（取り消された依頼は応答率の分母に含めない）
+func (a *reviewResponseAccumulator) summarize(name string, statsCalc *utils.StatisticsCalculator) *ReviewResponseStats {
+	stats := a.stats
+	stats.Name = name
+	if answerable := stats.TotalRequests - stats.Removed; answerable > 0 {
+		stats.ResponseRate = float64(stats.Reviewed) / float64(answerable)
+	}
+	stats.ResponseTime = statsCalc.CalculateDurationStatistics(a.responseTimes)
+	return &stats
+}
@@ -0,0 +1,243 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+)
+
+// ReviewLoadAlertMinReviews はレビューの集中を警告する対象の最小レビュー数（少数のレビューでの誤検知を避ける）
+const ReviewLoadAlertMinReviews = 5
+
+// ReviewWorkloadMetrics はレビュアーごとのレビュー負荷と偏りの集計
+type ReviewWorkloadMetrics struct {
+	Period             AggregationPeriod       `json:"period"`
+	AlertShare         float64                 `json:"alertShare"` // 警告するレビュー数の割合
+	TotalRequests      int                     `json:"totalRequests"`
+	TotalReviews       int                     `json:"totalReviews"`
+	TotalLinesReviewed int                     `json:"totalLinesReviewed"`
+	GiniCoefficient    float64                 `json:"giniCoefficient"` // レビュー数の偏り（0 は均等）
+	BalanceIndex       float64                 `json:"balanceIndex"`    // 1 - GiniCoefficient
+	DateRange          DateRange               `json:"dateRange"`
+	GeneratedAt        time.Time               `json:"generatedAt"`
+	Reviewers          []*ReviewerLoad         `json:"reviewers"` // レビュー数の多い順
+	Periods            []*ReviewWorkloadPeriod `json:"periods"`   // 古い順
+	Teams              []*TeamReviewBalance    `json:"teams"`
+	Alerts             []ReviewLoadAlert       `json:"alerts"`
+}
+
+// ReviewerLoad はレビュアー1人のレビュー負荷
+// 依頼は依頼された日時、レビューは最初のレビューの日時の期間で数える
+type ReviewerLoad struct {
+	Reviewer         string  `json:"reviewer"`
+	RequestsReceived int     `json:"requestsReceived"`
+	ReviewsGiven     int     `json:"reviewsGiven"` // レビューしたPR数
+	LinesReviewed    int     `json:"linesReviewed"`
+	ReviewShare      float64 `json:"reviewShare"` // 集計範囲のレビュー数に占める割合
+}
+
+// ReviewWorkloadPeriod は集計期間ごとのレビュー負荷
+type ReviewWorkloadPeriod struct {
+	Start           time.Time       `json:"start"`
+	End             time.Time       `json:"end"`
+	TotalReviews    int             `json:"totalReviews"`
+	GiniCoefficient float64         `json:"giniCoefficient"`
+	BalanceIndex    float64         `json:"balanceIndex"`
+	Reviewers       []*ReviewerLoad `json:"reviewers"`
+}
+
+// TeamReviewBalance はチームメンバーのレビュー負荷の偏り
+// レビュー時点で所属していたメンバーのレビューを数え、レビューのないメンバーも 0 件として含める
+type TeamReviewBalance struct {
+	Team            string          `json:"team"`
+	TotalReviews    int             `json:"totalReviews"`
+	GiniCoefficient float64         `json:"giniCoefficient"`
+	BalanceIndex    float64         `json:"balanceIndex"`
+	Reviewers       []*ReviewerLoad `json:"reviewers"`
+}
+
+// ReviewLoadAlert は1人のレビュアーにレビューが集中していることの警告
+type ReviewLoadAlert struct {
+	Reviewer     string  `json:"reviewer"`
+	Team         string  `json:"team,omitempty"` // 空の場合は全体での集中
+	ReviewsGiven int     `json:"reviewsGiven"`
+	TotalReviews int     `json:"totalReviews"`
+	Share        float64 `json:"share"`
+	Threshold    float64 `json:"threshold"`
+}
+
+// AggregateReviewWorkload はレビュアーごとの依頼数・レビュー数・レビュー行数を期間ごとに集計し、偏りを評価
+// metrics は集計期間に作成されたPR、events はPR IDごとのレビューイベント（イベントのないPRはレビュアー一覧で代用）
+// teams に指定したチームごとにメンバー間の偏りを評価する
+func (aggregator *MetricsAggregator) AggregateReviewWorkload(ctx context.Context, metrics []*prDomain.PRMetrics, events map[string][]prDomain.ReviewEvent, teams []teamDomain.Team, period AggregationPeriod) (*ReviewWorkloadMetrics, error) {
+	result := &ReviewWorkloadMetrics{
+		Period:      period,
+		AlertShare:  aggregator.reviewLoadAlertShare(),
+		GeneratedAt: time.Now(),
+		Reviewers:   []*ReviewerLoad{},
+		Periods:     []*ReviewWorkloadPeriod{},
+		Teams:       []*TeamReviewBalance{},
+		Alerts:      []ReviewLoadAlert{},
+	}
+	metrics = aggregator.filterBots(metrics)
+	if len(metrics) == 0 {
+		return result, nil
+	}
+	result.DateRange = aggregator.calculateDateRange(metrics)
+
+	activities := prDomain.CollectReviewActivities(metrics, events, aggregator.config.IdentityResolver)
+
+	overall := make(map[string]*ReviewerLoad)
+	buckets := make(map[time.Time]map[string]*ReviewerLoad)
+	bucket := func(at time.Time) map[string]*ReviewerLoad {
+		start := PeriodStart(at, period)
+		if buckets[start] == nil {
+			buckets[start] = make(map[string]*ReviewerLoad)
+		}
+		return buckets[start]
+	}
+	for _, activity := range activities {
+		if activity.Requested {
+			reviewerLoad(overall, activity.Reviewer).RequestsReceived++
+			reviewerLoad(bucket(activity.RequestedAt), activity.Reviewer).RequestsReceived++
+		}
+		if activity.Reviewed {
+			reviewerLoad(overall, activity.Reviewer).addReview(activity.LinesReviewed())
+			reviewerLoad(bucket(activity.ReviewedAt), activity.Reviewer).addReview(activity.LinesReviewed())
+		}
+	}
+
+	result.Reviewers, result.TotalReviews, result.GiniCoefficient = aggregator.summarizeReviewerLoads(overall)
+	result.BalanceIndex = 1 - result.GiniCoefficient
+	for _, load := range result.Reviewers {
+		result.TotalRequests += load.RequestsReceived
+		result.TotalLinesReviewed += load.LinesReviewed
+	}
+	result.Alerts = append(result.Alerts, aggregator.reviewLoadAlerts("", result.Reviewers, result.TotalReviews)...)
+
+	starts := make([]time.Time, 0, len(buckets))
+	for start := range buckets {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+	for _, start := range starts {
+		loads, total, gini := aggregator.summarizeReviewerLoads(buckets[start])
+		result.Periods = append(result.Periods, &ReviewWorkloadPeriod{
+			Start:           start,
+			End:             PeriodEnd(start, period),
+			TotalReviews:    total,
+			GiniCoefficient: gini,
+			BalanceIndex:    1 - gini,
+			Reviewers:       loads,
+		})
+	}
+
+	for _, team := range teams {
+		balance := aggregator.teamReviewBalance(team, activities, result.DateRange.End)
+		result.Teams = append(result.Teams, balance)
+		result.Alerts = append(result.Alerts, aggregator.reviewLoadAlerts(team.Name, balance.Reviewers, balance.TotalReviews)...)
+	}
+
+	return result, nil
+}
+
+// teamReviewBalance はレビュー時点でチームに所属していたメンバーのレビュー負荷を集計
+func (aggregator *MetricsAggregator) teamReviewBalance(team teamDomain.Team, activities []prDomain.ReviewActivity, rangeEnd time.Time) *TeamReviewBalance {
+	loads := make(map[string]*ReviewerLoad)
+	// 集計範囲の終わりに所属しているメンバーはレビューがなくても偏りの評価に含める
+	for _, member := range team.MembersAt(rangeEnd) {
+		reviewerLoad(loads, aggregator.resolveAuthor(member))
+	}
+	for _, activity := range activities {
+		if activity.Requested && team.IsMemberAtResolved(activity.Reviewer, activity.RequestedAt, aggregator.config.IdentityResolver) {
+			reviewerLoad(loads, activity.Reviewer).RequestsReceived++
+		}
+		if activity.Reviewed && team.IsMemberAtResolved(activity.Reviewer, activity.ReviewedAt, aggregator.config.IdentityResolver) {
+			reviewerLoad(loads, activity.Reviewer).addReview(activity.LinesReviewed())
+		}
+	}
+
+	reviewers, total, gini := aggregator.summarizeReviewerLoads(loads)
+	return &TeamReviewBalance{
+		Team:            team.Name,
+		TotalReviews:    total,
+		GiniCoefficient: gini,
+		BalanceIndex:    1 - gini,
+		Reviewers:       reviewers,
+	}
+}
+
+// summarizeReviewerLoads はレビュー数の割合を設定してレビュー数の多い順に並べ、合計とジニ係数を返す
+func (aggregator *MetricsAggregator) summarizeReviewerLoads(loads map[string]*ReviewerLoad) ([]*ReviewerLoad, int, float64) {
+	reviewers := make([]*ReviewerLoad, 0, len(loads))
+	reviewCounts := make([]float64, 0, len(loads))
+	total := 0
+	for _, load := range loads {
+		reviewers = append(reviewers, load)
+		reviewCounts = append(reviewCounts, float64(load.ReviewsGiven))
+		total += load.ReviewsGiven
+	}
+	for _, load := range reviewers {
+		if total > 0 {
+			load.ReviewShare = float64(load.ReviewsGiven) / float64(total)
+		}
+	}
+	sort.Slice(reviewers, func(i, j int) bool {
+		if reviewers[i].ReviewsGiven != reviewers[j].ReviewsGiven {
+			return reviewers[i].ReviewsGiven > reviewers[j].ReviewsGiven
+		}
+		return reviewers[i].Reviewer < reviewers[j].Reviewer
+	})
+	return reviewers, total, aggregator.statsCalc.CalculateGiniCoefficient(reviewCounts)
+}
+
+// reviewLoadAlerts はレビュー数の割合が閾値を超えるレビュアーを警告する
+func (aggregator *MetricsAggregator) reviewLoadAlerts(team string, reviewers []*ReviewerLoad, total int) []ReviewLoadAlert {
+	if total < ReviewLoadAlertMinReviews {
+		return nil
+	}
+	threshold := aggregator.reviewLoadAlertShare()
+	var alerts []ReviewLoadAlert
+	for _, load := range reviewers {
+		if load.ReviewShare > threshold {
+			alerts = append(alerts, ReviewLoadAlert{
+				Reviewer:     load.Reviewer,
+				Team:         team,
+				ReviewsGiven: load.ReviewsGiven,
+				TotalReviews: total,
+				Share:        load.ReviewShare,
+				Threshold:    threshold,
+			})
+		}
+	}
+	return alerts
+}
+
+// reviewLoadAlertShare はレビューの集中を警告する割合を返す
+func (aggregator *MetricsAggregator) reviewLoadAlertShare() float64 {
+	if aggregator.config.ReviewLoadAlertShare <= 0 {
+		return prDomain.DefaultReviewLoadAlertShare
+	}
+	return aggregator.config.ReviewLoadAlertShare
+}
+
+// addReview はレビューしたPR1件分を加える
+func (load *ReviewerLoad) addReview(linesReviewed int) {
+	load.ReviewsGiven++
+	load.LinesReviewed += linesReviewed
+}
+
+// reviewerLoad はレビュアーの負荷を取得（未作成の場合は作成）
+func reviewerLoad(loads map[string]*ReviewerLoad, reviewer string) *ReviewerLoad {
+	if existing, exists := loads[reviewer]; exists {
+		return existing
+	}
+	created := &ReviewerLoad{Reviewer: reviewer}
+	loads[reviewer] = created
+	return created
+}
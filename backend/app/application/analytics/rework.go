@@ -0,0 +1,139 @@
+package analytics
+
+import (
+	"context"
+	"path"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// RootDirectoryKey はリポジトリ直下のファイルの集計キー
+const RootDirectoryKey = "(root)"
+
+// ReworkMetrics はマージ後の手戻り（同じファイルへの後続の変更）の集計
+type ReworkMetrics struct {
+	Period       AggregationPeriod       `json:"period"`
+	Window       time.Duration           `json:"window"` // マージ後に手戻りとして数える期間
+	MergedPRs    int                     `json:"mergedPRs"`
+	ReworkedPRs  int                     `json:"reworkedPRs"` // 手戻りのあったPR
+	LinesAdded   int                     `json:"linesAdded"`
+	ReworkLines  int                     `json:"reworkLines"`
+	ChurnRate    float64                 `json:"churnRate"` // ReworkLines / LinesAdded
+	DateRange    DateRange               `json:"dateRange"`
+	GeneratedAt  time.Time               `json:"generatedAt"`
+	ByDeveloper  map[string]*ReworkStats `json:"byDeveloper"`
+	ByRepository map[string]*ReworkStats `json:"byRepository"`
+	ByDirectory  map[string]*ReworkStats `json:"byDirectory"` // キーは "リポジトリ:ディレクトリ"
+	PRs          []prDomain.PRRework     `json:"-"`
+}
+
+// ReworkStats は開発者・リポジトリ・ディレクトリ別の手戻り
+// 開発者は手戻りの対象になった元のPRの作者で集計する
+type ReworkStats struct {
+	Key         string  `json:"key"`
+	Repository  string  `json:"repository,omitempty"` // ディレクトリ別の場合のみ
+	Directory   string  `json:"directory,omitempty"`  // ディレクトリ別の場合のみ
+	MergedPRs   int     `json:"mergedPRs"`
+	ReworkedPRs int     `json:"reworkedPRs"`
+	LinesAdded  int     `json:"linesAdded"`
+	ReworkLines int     `json:"reworkLines"`
+	ChurnRate   float64 `json:"churnRate"`
+}
+
+// ReworkWindow はマージ後の手戻りとして数える期間を返す
+func (aggregator *MetricsAggregator) ReworkWindow() time.Duration {
+	return prDomain.NewReworkAnalyzer(aggregator.config.ReworkWindow).Window()
+}
+
+// AggregateReworkMetrics はマージ済みのPRごとに後続のPRによる手戻りを推定して集計
+// metrics は集計期間に作成されたPR、followUps は手戻りの探索にのみ使うPR（期間後に作成されたPRを含める）
+// 期間の終わり近くにマージされたPRは手戻りの期間が経過していないため、少なめに推定される
+func (aggregator *MetricsAggregator) AggregateReworkMetrics(ctx context.Context, metrics, followUps []*prDomain.PRMetrics, period AggregationPeriod) (*ReworkMetrics, error) {
+	analyzer := prDomain.NewReworkAnalyzer(aggregator.config.ReworkWindow)
+	result := &ReworkMetrics{
+		Period:       period,
+		Window:       analyzer.Window(),
+		GeneratedAt:  time.Now(),
+		ByDeveloper:  make(map[string]*ReworkStats),
+		ByRepository: make(map[string]*ReworkStats),
+		ByDirectory:  make(map[string]*ReworkStats),
+	}
+	if len(metrics) == 0 {
+		return result, nil
+	}
+	result.DateRange = aggregator.calculateDateRange(metrics)
+
+	// 手戻りは作成者によらず数え、元のPRの側でbotの設定を適用する
+	candidates := make([]*prDomain.PRMetrics, 0, len(metrics)+len(followUps))
+	candidates = append(candidates, metrics...)
+	candidates = append(candidates, followUps...)
+	result.PRs = analyzer.Analyze(aggregator.filterBots(metrics), candidates)
+
+	for _, rework := range result.PRs {
+		result.MergedPRs++
+		result.LinesAdded += rework.LinesAdded
+		result.ReworkLines += rework.ReworkLines
+		if rework.ReworkLines > 0 {
+			result.ReworkedPRs++
+		}
+		reworkStats(result.ByDeveloper, aggregator.resolveAuthor(rework.PR.Author), "", "").add(rework.LinesAdded, rework.ReworkLines)
+		reworkStats(result.ByRepository, rework.PR.Repository, "", "").add(rework.LinesAdded, rework.ReworkLines)
+
+		// ディレクトリ別はPR内のファイルをディレクトリごとにまとめてから数える
+		type directoryLines struct{ added, rework int }
+		directories := make(map[string]*directoryLines)
+		for _, file := range rework.Files {
+			directory := path.Dir(file.FileName)
+			if directory == "." {
+				directory = RootDirectoryKey
+			}
+			if directories[directory] == nil {
+				directories[directory] = &directoryLines{}
+			}
+			directories[directory].added += file.LinesAdded
+			directories[directory].rework += file.ReworkLines
+		}
+		for directory, lines := range directories {
+			reworkStats(result.ByDirectory, rework.PR.Repository+":"+directory, rework.PR.Repository, directory).
+				add(lines.added, lines.rework)
+		}
+	}
+
+	result.ChurnRate = churnRate(result.ReworkLines, result.LinesAdded)
+	for _, group := range []map[string]*ReworkStats{result.ByDeveloper, result.ByRepository, result.ByDirectory} {
+		for _, stats := range group {
+			stats.ChurnRate = churnRate(stats.ReworkLines, stats.LinesAdded)
+		}
+	}
+
+	return result, nil
+}
+
+// add はPR1件分の追加行数と手戻りを加える
+func (stats *ReworkStats) add(linesAdded, reworkLines int) {
+	stats.MergedPRs++
+	stats.LinesAdded += linesAdded
+	stats.ReworkLines += reworkLines
+	if reworkLines > 0 {
+		stats.ReworkedPRs++
+	}
+}
+
+// reworkStats は集計キーの統計を取得（未作成の場合は作成）
+func reworkStats(stats map[string]*ReworkStats, key, repository, directory string) *ReworkStats {
+	if existing, exists := stats[key]; exists {
+		return existing
+	}
+	created := &ReworkStats{Key: key, Repository: repository, Directory: directory}
+	stats[key] = created
+	return created
+}
+
+// churnRate は追加行数に対する手戻りの行数の割合（追加がない場合は 0）
+func churnRate(reworkLines, linesAdded int) float64 {
+	if linesAdded == 0 {
+		return 0
+	}
+	return float64(reworkLines) / float64(linesAdded)
+}
@@ -0,0 +1,153 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github-stats-metrics/shared/utils"
+)
+
+// トレンド分析の対象メトリクス
+const (
+	TrendMetricCycleTime  = "cycle_time"
+	TrendMetricReviewTime = "review_time"
+	TrendMetricQuality    = "quality"
+)
+
+// TrendSnapshot は集計ジョブが保存した1期間・1メトリクス分のトレンド分析
+type TrendSnapshot struct {
+	MetricType    string              `json:"metricType"`
+	Team          string              `json:"team,omitempty"` // 空の場合は集計対象全員
+	Period        AggregationPeriod   `json:"period"`
+	DateRange     DateRange           `json:"dateRange"`
+	Analysis      utils.TrendAnalysis `json:"analysis"`
+	DataPoints    int                 `json:"dataPoints"`
+	StartValue    float64             `json:"startValue"`
+	EndValue      float64             `json:"endValue"`
+	ChangePercent float64             `json:"changePercent"` // 開始値が0の場合は0
+	Series        []TrendPoint        `json:"series"`
+	GeneratedAt   time.Time           `json:"generatedAt"`
+}
+
+// TrendPoint はトレンド分析の日次の値
+type TrendPoint struct {
+	Date  string  `json:"date"` // YYYY-MM-DD
+	Value float64 `json:"value"`
+}
+
+// NewTrendSnapshots はチームメトリクスのトレンド分析をメトリクスごとのスナップショットに変換
+func NewTrendSnapshots(metrics *TeamMetrics) []*TrendSnapshot {
+	trends := []struct {
+		metricType string
+		analysis   utils.TrendAnalysis
+		value      func(point TrendDataPoint) float64
+	}{
+		{TrendMetricCycleTime, metrics.TrendAnalysis.CycleTimeTrend, func(point TrendDataPoint) float64 { return point.CycleTime }},
+		{TrendMetricReviewTime, metrics.TrendAnalysis.ReviewTimeTrend, func(point TrendDataPoint) float64 { return point.ReviewTime }},
+		{TrendMetricQuality, metrics.TrendAnalysis.QualityTrend, func(point TrendDataPoint) float64 { return point.Quality }},
+	}
+
+	snapshots := make([]*TrendSnapshot, 0, len(trends))
+	for _, trend := range trends {
+		snapshot := &TrendSnapshot{
+			MetricType:  trend.metricType,
+			Team:        metrics.Team,
+			Period:      metrics.Period,
+			DateRange:   metrics.DateRange,
+			Analysis:    trend.analysis,
+			DataPoints:  len(metrics.TrendAnalysis.Series),
+			Series:      make([]TrendPoint, 0, len(metrics.TrendAnalysis.Series)),
+			GeneratedAt: metrics.GeneratedAt,
+		}
+		for _, point := range metrics.TrendAnalysis.Series {
+			snapshot.Series = append(snapshot.Series, TrendPoint{Date: point.Date, Value: trend.value(point)})
+		}
+		if len(snapshot.Series) > 0 {
+			snapshot.StartValue = snapshot.Series[0].Value
+			snapshot.EndValue = snapshot.Series[len(snapshot.Series)-1].Value
+			if snapshot.StartValue != 0 {
+				snapshot.ChangePercent = (snapshot.EndValue - snapshot.StartValue) / snapshot.StartValue * 100
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// ToTrendAnalysisResult は同じ期間のスナップショットをトレンド分析の結果にまとめる
+func ToTrendAnalysisResult(snapshots []*TrendSnapshot) TrendAnalysisResult {
+	var result TrendAnalysisResult
+	for _, snapshot := range snapshots {
+		switch snapshot.MetricType {
+		case TrendMetricCycleTime:
+			result.CycleTimeTrend = snapshot.Analysis
+		case TrendMetricReviewTime:
+			result.ReviewTimeTrend = snapshot.Analysis
+		case TrendMetricQuality:
+			result.QualityTrend = snapshot.Analysis
+		}
+	}
+	return result
+}
+
+// TrendSnapshotRepository はトレンド分析のスナップショットの永続化の抽象化
+type TrendSnapshotRepository interface {
+	// SaveTrendSnapshots はスナップショットを保存（同じメトリクス・チーム・期間のものは上書き）
+	SaveTrendSnapshots(ctx context.Context, snapshots []*TrendSnapshot) error
+
+	// FindTrendSnapshots は期間が startDate から endDate に収まるスナップショットを期間の新しい順に取得
+	FindTrendSnapshots(ctx context.Context, team string, period AggregationPeriod, startDate, endDate time.Time) ([]*TrendSnapshot, error)
+}
+
+// ボトルネックの状態
+const (
+	BottleneckStatusActive   = "active"
+	BottleneckStatusResolved = "resolved"
+	BottleneckStatusIgnored  = "ignored"
+)
+
+// BottleneckRecord は検出されたボトルネックの履歴
+// 同じPR・種類のボトルネックは1件として扱い、最初に検出した日時と解消した日時を持つ
+type BottleneckRecord struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	PRID        string     `json:"prId"`
+	Severity    string     `json:"severity"`
+	Description string     `json:"description"`
+	Value       float64    `json:"value"`
+	Threshold   float64    `json:"threshold"`
+	Author      string     `json:"author"`
+	Repository  string     `json:"repository"`
+	Status      string     `json:"status"`
+	FirstSeenAt time.Time  `json:"firstSeenAt"`
+	ResolvedAt  *time.Time `json:"resolvedAt,omitempty"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// BottleneckRecordID は種類とPR IDからボトルネックのIDを返す
+func BottleneckRecordID(bottleneckType, prID string) string {
+	return bottleneckType + ":" + prID
+}
+
+// BottleneckFilter はボトルネック履歴の絞り込み条件（ゼロ値の条件は使わない）
+type BottleneckFilter struct {
+	Status     string
+	Type       string
+	Repository string
+	Authors    []string  // いずれかの作者のPRのみ（大文字・小文字を区別しない）
+	SeenSince  time.Time // 最初の検出日時の下限
+	SeenUntil  time.Time // 最初の検出日時の上限
+	Limit      int
+}
+
+// BottleneckRepository はボトルネック履歴の永続化の抽象化
+type BottleneckRepository interface {
+	// SaveBottlenecks はボトルネックを保存（同じIDのものは上書き）
+	SaveBottlenecks(ctx context.Context, records []*BottleneckRecord) error
+
+	// FindBottlenecksByPRIDs は指定したPRのボトルネックを状態を問わず取得
+	FindBottlenecksByPRIDs(ctx context.Context, prIDs []string) ([]*BottleneckRecord, error)
+
+	// FindBottlenecks は条件に合うボトルネックを最初の検出日時の新しい順に取得
+	FindBottlenecks(ctx context.Context, filter BottleneckFilter) ([]*BottleneckRecord, error)
+}
@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+)
+
+// AggregateMetricsForTeam は指定チームのメトリクスを集計
+// PR作成時点の所属で判定するため、後からの組織変更で過去の集計は変わらない
+func (aggregator *MetricsAggregator) AggregateMetricsForTeam(ctx context.Context, team teamDomain.Team, metrics []*prDomain.PRMetrics, period AggregationPeriod) (*TeamMetrics, error) {
+	teamMetrics, err := aggregator.AggregateTeamMetrics(ctx, team.FilterMetrics(metrics, aggregator.config.IdentityResolver), period)
+	if err != nil {
+		return nil, err
+	}
+	teamMetrics.Team = team.Name
+	return teamMetrics, nil
+}
+
+// AggregateTeamMetricsOverTime は指定チームのメトリクスを期間ごとに集計（古い順）
+func (aggregator *MetricsAggregator) AggregateTeamMetricsOverTime(ctx context.Context, team teamDomain.Team, metrics []*prDomain.PRMetrics, period AggregationPeriod) ([]*TeamMetrics, error) {
+	buckets := make(map[time.Time][]*prDomain.PRMetrics)
+	for _, metric := range team.FilterMetrics(metrics, aggregator.config.IdentityResolver) {
+		start := PeriodStart(metric.CreatedAt, period)
+		buckets[start] = append(buckets[start], metric)
+	}
+
+	starts := make([]time.Time, 0, len(buckets))
+	for start := range buckets {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+
+	result := make([]*TeamMetrics, 0, len(starts))
+	for _, start := range starts {
+		teamMetrics, err := aggregator.AggregateTeamMetrics(ctx, buckets[start], period)
+		if err != nil {
+			return nil, err
+		}
+		teamMetrics.Team = team.Name
+		// 期間の境界を集計範囲とする
+		teamMetrics.DateRange = DateRange{Start: start, End: PeriodEnd(start, period)}
+		result = append(result, teamMetrics)
+	}
+
+	return result, nil
+}
+
+// PeriodStart は時刻が属する集計期間の開始時刻を返す（週次は月曜始まり）
+func PeriodStart(t time.Time, period AggregationPeriod) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case AggregationPeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case AggregationPeriodMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// PeriodEnd は集計期間の終了時刻（次の期間の開始時刻）を返す
+func PeriodEnd(start time.Time, period AggregationPeriod) time.Time {
+	switch period {
+	case AggregationPeriodWeekly:
+		return start.AddDate(0, 0, 7)
+	case AggregationPeriodMonthly:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,186 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+)
+
+// WIPLimitMinMergedPRs は推奨WIP上限を算出する最小のマージ数（少数のPRでの誤検知を避ける）
+const WIPLimitMinMergedPRs = 3
+
+// WIPMetrics は開発者ごと・チームごとの同時にオープンなPR数（WIP）の集計
+type WIPMetrics struct {
+	Period      AggregationPeriod `json:"period"`
+	StaleAfter  time.Duration     `json:"staleAfter"` // これを過ぎた未マージのPRはWIPに数えない
+	DateRange   DateRange         `json:"dateRange"`  // End 時点のWIPを現在のWIPとする
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Developers  []*WIPStats       `json:"developers"` // 現在のWIPの多い順
+	Teams       []*WIPStats       `json:"teams"`
+	Bottlenecks []Bottleneck      `json:"bottlenecks"` // 推奨WIP上限を超えている開発者・チーム
+}
+
+// WIPStats は開発者またはチームのWIPと推奨WIP上限
+type WIPStats struct {
+	Name             string        `json:"name"` // 開発者のログイン名またはチーム名
+	CurrentWIP       int           `json:"currentWIP"`
+	AverageWIP       float64       `json:"averageWIP"` // 集計範囲の時間加重平均
+	PeakWIP          int           `json:"peakWIP"`
+	MergedPRs        int           `json:"mergedPRs"`        // 集計範囲にマージされたPR
+	Throughput       float64       `json:"throughput"`       // 1日あたりのマージ数
+	MedianLeadTime   time.Duration `json:"medianLeadTime"`   // 作成からマージまでの中央値
+	RecommendedLimit int           `json:"recommendedLimit"` // マージが少ない場合は 0（判定しない）
+	Exceeded         bool          `json:"exceeded"`         // 現在のWIPが推奨WIP上限を超えている
+	Periods          []WIPPeriod   `json:"periods"`          // 古い順
+}
+
+// WIPPeriod は集計期間ごとのWIP
+type WIPPeriod struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	AverageWIP float64   `json:"averageWIP"`
+	PeakWIP    int       `json:"peakWIP"`
+}
+
+// AggregateWIP は start から end までの開発者ごと・チームごとのWIPを集計し、リトルの法則から推奨WIP上限を算出
+// metrics は集計範囲にオープンだった可能性のあるPR（集計範囲より前に作成されたPRを含む）
+// 推奨WIP上限は集計範囲のスループット（1日あたりのマージ数）× リードタイムの中央値とし、end 時点のWIPがこれを超える場合にボトルネックとする
+// チームには作成時点で所属していたメンバーのPRを数える
+func (aggregator *MetricsAggregator) AggregateWIP(ctx context.Context, metrics []*prDomain.PRMetrics, teams []teamDomain.Team, period AggregationPeriod, start, end time.Time) (*WIPMetrics, error) {
+	result := &WIPMetrics{
+		Period:      period,
+		StaleAfter:  aggregator.wipStaleAfter(),
+		DateRange:   DateRange{Start: start, End: end},
+		GeneratedAt: time.Now(),
+		Developers:  []*WIPStats{},
+		Teams:       []*WIPStats{},
+		Bottlenecks: []Bottleneck{},
+	}
+	if !end.After(start) {
+		return result, nil
+	}
+
+	byDeveloper := make(map[string][]*prDomain.PRMetrics)
+	byTeam := make(map[string][]*prDomain.PRMetrics)
+	for _, metric := range aggregator.filterBots(metrics) {
+		if metric.CreatedAt.After(end) {
+			continue
+		}
+		author := aggregator.resolveAuthor(metric.Author)
+		byDeveloper[author] = append(byDeveloper[author], metric)
+		for _, team := range teams {
+			if team.IsMemberAtResolved(metric.Author, metric.CreatedAt, aggregator.config.IdentityResolver) {
+				byTeam[team.Name] = append(byTeam[team.Name], metric)
+			}
+		}
+	}
+
+	for developer, prs := range byDeveloper {
+		stats := aggregator.wipStats(developer, prs, period, start, end)
+		if stats.AverageWIP == 0 && stats.MergedPRs == 0 {
+			// 集計範囲にオープンだったPRのない開発者は含めない
+			continue
+		}
+		result.Developers = append(result.Developers, stats)
+	}
+	sort.Slice(result.Developers, func(i, j int) bool {
+		if result.Developers[i].CurrentWIP != result.Developers[j].CurrentWIP {
+			return result.Developers[i].CurrentWIP > result.Developers[j].CurrentWIP
+		}
+		return result.Developers[i].Name < result.Developers[j].Name
+	})
+	for _, developer := range result.Developers {
+		if developer.Exceeded {
+			result.Bottlenecks = append(result.Bottlenecks, wipExceededBottleneck(developer, "開発者", []string{developer.Name}))
+		}
+	}
+
+	for _, team := range teams {
+		stats := aggregator.wipStats(team.Name, byTeam[team.Name], period, start, end)
+		result.Teams = append(result.Teams, stats)
+		if stats.Exceeded {
+			result.Bottlenecks = append(result.Bottlenecks, wipExceededBottleneck(stats, "チーム", aggregator.openAuthors(byTeam[team.Name], end)))
+		}
+	}
+
+	return result, nil
+}
+
+// wipStats はPRのオープン期間からWIPとスループット・リードタイム・推奨WIP上限を計算
+func (aggregator *MetricsAggregator) wipStats(name string, prs []*prDomain.PRMetrics, period AggregationPeriod, start, end time.Time) *WIPStats {
+	intervals := prDomain.OpenIntervals(prs, end, aggregator.wipStaleAfter())
+	stats := &WIPStats{Name: name, CurrentWIP: prDomain.CountOpenAt(intervals, end), Periods: []WIPPeriod{}}
+	stats.AverageWIP, stats.PeakWIP = prDomain.MeasureWIP(intervals, start, end)
+
+	for bucketStart := PeriodStart(start, period); bucketStart.Before(end); bucketStart = PeriodEnd(bucketStart, period) {
+		bucketEnd := PeriodEnd(bucketStart, period)
+		from, to := bucketStart, bucketEnd
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		average, peak := prDomain.MeasureWIP(intervals, from, to)
+		stats.Periods = append(stats.Periods, WIPPeriod{Start: bucketStart, End: bucketEnd, AverageWIP: average, PeakWIP: peak})
+	}
+
+	var leadTimes []time.Duration
+	for _, pr := range prs {
+		if pr.MergedAt != nil && !pr.MergedAt.Before(start) && !pr.MergedAt.After(end) {
+			leadTimes = append(leadTimes, pr.MergedAt.Sub(pr.CreatedAt))
+		}
+	}
+	stats.MergedPRs = len(leadTimes)
+	stats.Throughput = float64(stats.MergedPRs) / (end.Sub(start).Hours() / 24)
+	stats.MedianLeadTime = aggregator.statsCalc.CalculateDurationStatistics(leadTimes).Median
+	if stats.MergedPRs >= WIPLimitMinMergedPRs {
+		stats.RecommendedLimit = prDomain.RecommendWIPLimit(stats.Throughput, stats.MedianLeadTime)
+		stats.Exceeded = stats.CurrentWIP > stats.RecommendedLimit
+	}
+	return stats
+}
+
+// openAuthors は日時 at にオープンなPRの作者を名前順に返す
+func (aggregator *MetricsAggregator) openAuthors(prs []*prDomain.PRMetrics, at time.Time) []string {
+	seen := make(map[string]bool)
+	var authors []string
+	for _, interval := range prDomain.OpenIntervals(prs, at, aggregator.wipStaleAfter()) {
+		author := aggregator.resolveAuthor(interval.PR.Author)
+		if interval.Open && !seen[author] {
+			seen[author] = true
+			authors = append(authors, author)
+		}
+	}
+	sort.Strings(authors)
+	return authors
+}
+
+// wipStaleAfter は未マージのPRをWIPに数える期間を返す
+func (aggregator *MetricsAggregator) wipStaleAfter() time.Duration {
+	if aggregator.config.WIPStaleAfter <= 0 {
+		return prDomain.DefaultWIPStaleAfter
+	}
+	return aggregator.config.WIPStaleAfter
+}
+
+// wipExceededBottleneck は推奨WIP上限の超過をボトルネックとして表す
+// 上限の2倍以上のPRがオープンな場合は重大度を high とする
+func wipExceededBottleneck(stats *WIPStats, kind string, developers []string) Bottleneck {
+	severity := "medium"
+	if stats.CurrentWIP >= 2*stats.RecommendedLimit {
+		severity = "high"
+	}
+	return Bottleneck{
+		Type:               string(prDomain.BottleneckTypeWIPExceeded),
+		Severity:           severity,
+		Description:        fmt.Sprintf("%s %s のオープンなPRが%d件（推奨WIP上限 %d件）", kind, stats.Name, stats.CurrentWIP, stats.RecommendedLimit),
+		Value:              float64(stats.CurrentWIP),
+		Threshold:          float64(stats.RecommendedLimit),
+		AffectedDevelopers: developers,
+	}
+}
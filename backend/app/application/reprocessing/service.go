@@ -0,0 +1,113 @@
+package reprocessing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// Request は再計算の対象範囲
+type Request struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	Repositories []string // 空の場合はすべてのリポジトリ
+	// Force が true の場合は定義バージョンが現在と同じPRも再計算する
+	Force bool
+	// DryRun が true の場合は件数の集計のみ行い、保存しない
+	DryRun bool
+}
+
+// Report は再計算の結果
+type Report struct {
+	DefinitionVersion string    `json:"definitionVersion"` // 全体の定義バージョン（リポジトリごとの設定がある場合はそれぞれの定義で再計算する）
+	DryRun            bool      `json:"dryRun"`
+	ExecutedAt        time.Time `json:"executedAt"`
+	StartDate         time.Time `json:"startDate"`
+	EndDate           time.Time `json:"endDate"`
+	Scanned           int       `json:"scanned"`     // 範囲内のPR数
+	Reprocessed       int       `json:"reprocessed"` // 再計算した（DryRun の場合は対象となる）PR数
+	UpToDate          int       `json:"upToDate"`    // 現在の定義で計算済みのPR数
+	// MissingEvents はレビュー時間が記録されているがレビューイベントが保存されていないため再計算できないPR数
+	MissingEvents int `json:"missingEvents"`
+}
+
+// Service は保存済みのPRメトリクスを現在の計算定義で再計算する
+// 再計算したPRは収集日時が更新されるため、次回の事前集計で該当期間の集計データも作り直される
+type Service struct {
+	prRepo      prDomain.MetricsRepository
+	definitions prDomain.DefinitionSource
+	analysis    *prDomain.PRAnalysisService
+	now         func() time.Time
+}
+
+// NewService はリポジトリごとの計算定義の取得元を指定して新しい再計算サービスを作成
+func NewService(prRepo prDomain.MetricsRepository, definitions prDomain.DefinitionSource) *Service {
+	return &Service{
+		prRepo:      prRepo,
+		definitions: definitions,
+		analysis:    prDomain.NewPRAnalysisServiceWithSource(definitions),
+		now:         time.Now,
+	}
+}
+
+// Definition は現在の全体の計算定義を返す
+func (s *Service) Definition() prDomain.MetricDefinition {
+	return s.definitions.DefinitionFor("")
+}
+
+// DefinitionVersion は現在の計算定義のバージョンを返す
+func (s *Service) DefinitionVersion() string {
+	return s.analysis.DefinitionVersion()
+}
+
+// Run は範囲内のPRのうち、現在と異なる定義で計算されたものを再計算する
+func (s *Service) Run(ctx context.Context, req Request) (*Report, error) {
+	report := &Report{
+		DefinitionVersion: s.analysis.DefinitionVersion(),
+		DryRun:            req.DryRun,
+		ExecutedAt:        s.now(),
+		StartDate:         req.StartDate,
+		EndDate:           req.EndDate,
+	}
+
+	metricsList, err := s.prRepo.FindByDateRange(ctx, req.StartDate, req.EndDate, nil, req.Repositories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pr metrics: %w", err)
+	}
+	report.Scanned = len(metricsList)
+
+	for _, metrics := range metricsList {
+		if !req.Force && metrics.DefinitionVersion == s.analysis.DefinitionVersionFor(metrics.Repository) {
+			report.UpToDate++
+			continue
+		}
+
+		events, err := s.prRepo.FindReviewEvents(ctx, metrics.PRID)
+		if err != nil {
+			return report, fmt.Errorf("failed to find review events for %s: %w", metrics.PRID, err)
+		}
+		if len(events) == 0 && hasReviewTimes(metrics) {
+			report.MissingEvents++
+			continue
+		}
+
+		if req.DryRun {
+			report.Reprocessed++
+			continue
+		}
+		if err := s.prRepo.Update(ctx, s.analysis.Recalculate(metrics, events)); err != nil {
+			return report, fmt.Errorf("failed to update pr metrics %s: %w", metrics.PRID, err)
+		}
+		report.Reprocessed++
+	}
+
+	return report, nil
+}
+
+// hasReviewTimes はレビューに関する時間が記録されているかを判定する
+// イベントなしで再計算するとこれらが失われるため、再計算の対象から外す
+func hasReviewTimes(metrics *prDomain.PRMetrics) bool {
+	return metrics.TimeMetrics.TimeToFirstReview != nil || metrics.TimeMetrics.TimeToApproval != nil
+}
@@ -0,0 +1,169 @@
+package reprocessing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/infrastructure/memory"
+)
+
+type serviceFixture struct {
+	service    *Service
+	prRepo     prDomain.MetricsRepository
+	definition prDomain.MetricDefinition
+	settings   *prDomain.AnalysisSettingsRegistry
+}
+
+// newServiceFixture は営業時間（UTC 9-18時、週末除外）で計算するサービスを作成
+// 保存済みのPRは定義なし（24時間計算）の値を持つ
+func newServiceFixture(t *testing.T) *serviceFixture {
+	ctx := context.Background()
+	prRepo := memory.NewPRMetricsRepository()
+
+	// 2024-01-12 は金曜日
+	createdAt := time.Date(2024, 1, 12, 17, 0, 0, 0, time.UTC)
+	approvedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	mergedAt := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	cycleTime := mergedAt.Sub(createdAt)
+	approval := approvedAt.Sub(createdAt)
+
+	withEvents := &prDomain.PRMetrics{
+		PRID: "pr-1", Author: "alice", Repository: "org/api", CreatedAt: createdAt, MergedAt: &mergedAt,
+		TimeMetrics: prDomain.PRTimeMetrics{TotalCycleTime: &cycleTime, TimeToApproval: &approval},
+		ReviewEvents: []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeApproved, CreatedAt: approvedAt, Actor: "bob"},
+		},
+	}
+	withoutEvents := &prDomain.PRMetrics{
+		PRID: "pr-2", Author: "alice", Repository: "org/web", CreatedAt: createdAt, MergedAt: &mergedAt,
+		TimeMetrics: prDomain.PRTimeMetrics{TotalCycleTime: &cycleTime, TimeToApproval: &approval},
+	}
+	unreviewed := &prDomain.PRMetrics{
+		PRID: "pr-3", Author: "bob", Repository: "org/api", CreatedAt: createdAt, MergedAt: &mergedAt,
+		TimeMetrics: prDomain.PRTimeMetrics{TotalCycleTime: &cycleTime},
+	}
+	for _, metrics := range []*prDomain.PRMetrics{withEvents, withoutEvents, unreviewed} {
+		require.NoError(t, prRepo.Save(ctx, metrics))
+	}
+
+	definition := prDomain.DefaultMetricDefinition()
+	definition.CycleTime.UseBusinessHours = true
+	definition.CycleTime.ExcludeWeekends = true
+	definition.CycleTime.Timezone = time.UTC
+
+	settings, err := prDomain.NewAnalysisSettingsRegistry(definition, prDomain.AnalysisSettings{})
+	require.NoError(t, err)
+
+	service := NewService(prRepo, settings)
+	return &serviceFixture{service: service, prRepo: prRepo, definition: definition, settings: settings}
+}
+
+func (f *serviceFixture) request() Request {
+	return Request{
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
+	}
+}
+
+func TestService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("現在と異なる定義のPRを保存済みのイベントから再計算する", func(t *testing.T) {
+		f := newServiceFixture(t)
+
+		report, err := f.service.Run(ctx, f.request())
+		require.NoError(t, err)
+
+		assert.Equal(t, f.definition.Version(), report.DefinitionVersion)
+		assert.Equal(t, 3, report.Scanned)
+		assert.Equal(t, 2, report.Reprocessed)
+		assert.Equal(t, 1, report.MissingEvents, "レビュー時間があるのにイベントがないPRは再計算しない")
+
+		reprocessed, err := f.prRepo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, f.definition.Version(), reprocessed.DefinitionVersion)
+		require.NotNil(t, reprocessed.TimeMetrics.TotalCycleTime)
+		assert.Equal(t, 3*time.Hour, *reprocessed.TimeMetrics.TotalCycleTime, "金曜17-18時と月曜9-11時")
+		require.NotNil(t, reprocessed.TimeMetrics.TimeToApproval)
+		assert.Equal(t, 2*time.Hour, *reprocessed.TimeMetrics.TimeToApproval)
+
+		skipped, err := f.prRepo.FindByPRID(ctx, "pr-2")
+		require.NoError(t, err)
+		assert.Empty(t, skipped.DefinitionVersion)
+
+		// 再計算後も保存済みのレビューイベントは残る
+		events, err := f.prRepo.FindReviewEvents(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("現在の定義で計算済みのPRは再計算しない", func(t *testing.T) {
+		f := newServiceFixture(t)
+		_, err := f.service.Run(ctx, f.request())
+		require.NoError(t, err)
+
+		report, err := f.service.Run(ctx, f.request())
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.UpToDate)
+		assert.Zero(t, report.Reprocessed)
+
+		req := f.request()
+		req.Force = true
+		report, err = f.service.Run(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Reprocessed)
+	})
+
+	t.Run("DryRun では件数のみ返し保存しない", func(t *testing.T) {
+		f := newServiceFixture(t)
+		req := f.request()
+		req.DryRun = true
+
+		report, err := f.service.Run(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 2, report.Reprocessed)
+
+		stored, err := f.prRepo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Empty(t, stored.DefinitionVersion)
+	})
+
+	t.Run("リポジトリで対象を絞り込む", func(t *testing.T) {
+		f := newServiceFixture(t)
+		req := f.request()
+		req.Repositories = []string{"org/web"}
+
+		report, err := f.service.Run(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Scanned)
+		assert.Equal(t, 1, report.MissingEvents)
+		assert.Zero(t, report.Reprocessed)
+	})
+
+	t.Run("リポジトリごとの設定を変更したリポジトリのPRだけ再計算する", func(t *testing.T) {
+		f := newServiceFixture(t)
+		_, err := f.service.Run(ctx, f.request())
+		require.NoError(t, err)
+
+		businessEnd := 10
+		require.NoError(t, f.settings.SetRepository("org/api", prDomain.DefinitionOverride{BusinessEnd: &businessEnd}))
+
+		report, err := f.service.Run(ctx, f.request())
+		require.NoError(t, err)
+		assert.Equal(t, f.definition.Version(), report.DefinitionVersion, "全体の定義バージョンは変わらない")
+		assert.Equal(t, 2, report.Reprocessed)
+
+		reprocessed, err := f.prRepo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, f.settings.DefinitionFor("org/api").Version(), reprocessed.DefinitionVersion)
+		assert.NotEqual(t, f.definition.Version(), reprocessed.DefinitionVersion)
+		require.NotNil(t, reprocessed.TimeMetrics.TotalCycleTime)
+		assert.Equal(t, time.Hour, *reprocessed.TimeMetrics.TotalCycleTime, "月曜9-10時のみ")
+	})
+}
@@ -0,0 +1,55 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+var (
+	// ErrArchiveNotFound は指定したアーカイブが存在しないことを表す
+	ErrArchiveNotFound = errors.New("archive not found")
+
+	// ErrInvalidArchiveName はアーカイブ名として使えない名前であることを表す
+	ErrInvalidArchiveName = errors.New("invalid archive name")
+
+	// ErrArchiveNotConfigured はアーカイブの保存先が設定されていないことを表す
+	ErrArchiveNotConfigured = errors.New("archive is not configured")
+)
+
+// アーカイブレコードの種別
+const (
+	RecordKindPRMetrics         = "pr_metrics"
+	RecordKindAggregatedMetrics = "aggregated_metrics"
+)
+
+// ArchiveRecord はアーカイブ内の1行
+// Kind に応じて PRMetrics か Aggregated のいずれかが設定される
+type ArchiveRecord struct {
+	Kind       string                         `json:"kind"`
+	PRMetrics  *prDomain.PRMetrics            `json:"prMetrics,omitempty"`
+	Aggregated *analyticsApp.AggregatedRecord `json:"aggregated,omitempty"`
+}
+
+// ArchiveInfo は保存済みアーカイブの情報
+type ArchiveInfo struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Archive は削除前のデータを書き出すアーカイブ保存先の抽象化
+type Archive interface {
+	// Write はレコードを新しいアーカイブとして書き出し、保存したアーカイブ名を返す
+	// 同名のアーカイブが既にある場合は上書きせずエラーを返す
+	Write(ctx context.Context, name string, records []ArchiveRecord) (string, error)
+
+	// Read はアーカイブのレコードを読み込む（存在しない場合は ErrArchiveNotFound）
+	Read(ctx context.Context, name string) ([]ArchiveRecord, error)
+
+	// List は保存済みアーカイブを名前順に返す
+	List(ctx context.Context) ([]ArchiveInfo, error)
+}
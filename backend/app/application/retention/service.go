@@ -0,0 +1,294 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/domain/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// archiveTimeFormat はアーカイブ名に含める実行日時の形式
+const archiveTimeFormat = "20060102T150405Z"
+
+// Target は保持ポリシーの適用対象1件の結果
+type Target struct {
+	Name          string    `json:"name"`             // pr_metrics または aggregated_metrics
+	Period        string    `json:"period,omitempty"` // 集計データの集計期間
+	RetentionDays int       `json:"retentionDays"`
+	Cutoff        time.Time `json:"cutoff"`            // この日時より前のデータが対象
+	Count         int64     `json:"count"`             // 削除対象（実行時は削除済み）の件数
+	Archive       string    `json:"archive,omitempty"` // 書き出したアーカイブ名
+}
+
+// Report は保持ポリシーの適用結果
+type Report struct {
+	DryRun     bool      `json:"dryRun"`
+	ExecutedAt time.Time `json:"executedAt"`
+	Targets    []Target  `json:"targets"`
+}
+
+// RestoreResult はアーカイブからの復元結果
+type RestoreResult struct {
+	Archive    string `json:"archive"`
+	PRMetrics  int    `json:"prMetrics"`
+	Aggregated int    `json:"aggregated"`
+}
+
+// Service はデータ保持ポリシーに従って古いデータをアーカイブ・削除する
+type Service struct {
+	prRepo  prDomain.MetricsRepository
+	aggRepo analyticsApp.AggregatedMetricsRepository
+	archive Archive
+	policy  analytics.DataRetentionPolicy
+	now     func() time.Time
+
+	// 定期実行と管理APIからの実行が重ならないようにする
+	mu sync.Mutex
+}
+
+// NewService は新しいデータ保持サービスを作成
+// archive が nil の場合はアーカイブせずに削除する
+func NewService(prRepo prDomain.MetricsRepository, aggRepo analyticsApp.AggregatedMetricsRepository, archive Archive, policy analytics.DataRetentionPolicy) *Service {
+	return &Service{
+		prRepo:  prRepo,
+		aggRepo: aggRepo,
+		archive: archive,
+		policy:  policy,
+		now:     time.Now,
+	}
+}
+
+// Policy は適用する保持ポリシーを返す
+func (s *Service) Policy() analytics.DataRetentionPolicy {
+	return s.policy
+}
+
+// Preview は削除せずに削除対象の件数を返す
+func (s *Service) Preview(ctx context.Context) (*Report, error) {
+	report := &Report{DryRun: true, ExecutedAt: s.now()}
+	for _, target := range s.targets(report.ExecutedAt) {
+		var err error
+		if target.Name == RecordKindPRMetrics {
+			target.Count, err = s.countPRMetrics(ctx, target.Cutoff)
+		} else {
+			target.Count, err = s.countAggregated(ctx, analyticsApp.AggregationPeriod(target.Period), target.Cutoff)
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Targets = append(report.Targets, target)
+	}
+	return report, nil
+}
+
+// Run は保持期間を過ぎたデータを削除する
+// アーカイブが有効な場合は削除前に書き出し、書き出しに失敗した対象は削除しない
+func (s *Service) Run(ctx context.Context) (*Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := &Report{ExecutedAt: s.now()}
+	for _, target := range s.targets(report.ExecutedAt) {
+		var err error
+		if target.Name == RecordKindPRMetrics {
+			err = s.purgePRMetrics(ctx, &target, report.ExecutedAt)
+		} else {
+			err = s.purgeAggregated(ctx, &target, report.ExecutedAt)
+		}
+		if err != nil {
+			return report, err
+		}
+		report.Targets = append(report.Targets, target)
+	}
+	return report, nil
+}
+
+// ListArchives は保存済みアーカイブを返す
+func (s *Service) ListArchives(ctx context.Context) ([]ArchiveInfo, error) {
+	if s.archive == nil {
+		return nil, ErrArchiveNotConfigured
+	}
+	return s.archive.List(ctx)
+}
+
+// Restore はアーカイブのデータを書き戻す
+// PRメトリクスは既存のPRを上書きせず、収集日時は復元時点になる
+// 集計データは生成日時を保持するため、保持期間を過ぎていれば次回の実行で再びアーカイブされる
+func (s *Service) Restore(ctx context.Context, name string) (*RestoreResult, error) {
+	if s.archive == nil {
+		return nil, ErrArchiveNotConfigured
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.archive.Read(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestoreResult{Archive: name}
+	var prMetrics []*prDomain.PRMetrics
+	for _, record := range records {
+		switch {
+		case record.Kind == RecordKindPRMetrics && record.PRMetrics != nil:
+			prMetrics = append(prMetrics, record.PRMetrics)
+		case record.Kind == RecordKindAggregatedMetrics && record.Aggregated != nil:
+			if err := s.restoreAggregated(ctx, record.Aggregated); err != nil {
+				return result, err
+			}
+			result.Aggregated++
+		default:
+			return result, fmt.Errorf("unknown archive record kind: %s", record.Kind)
+		}
+	}
+
+	if len(prMetrics) > 0 {
+		if err := s.prRepo.SaveBatch(ctx, prMetrics); err != nil {
+			return result, fmt.Errorf("failed to restore pr metrics: %w", err)
+		}
+		result.PRMetrics = len(prMetrics)
+	}
+	return result, nil
+}
+
+// targets は保持期間が設定された対象を返す
+func (s *Service) targets(now time.Time) []Target {
+	candidates := []Target{
+		{Name: RecordKindPRMetrics, RetentionDays: s.policy.DetailedDataRetentionDays},
+		{Name: RecordKindAggregatedMetrics, Period: string(analyticsApp.AggregationPeriodDaily), RetentionDays: s.policy.DailyAggregationRetentionDays},
+		{Name: RecordKindAggregatedMetrics, Period: string(analyticsApp.AggregationPeriodWeekly), RetentionDays: s.policy.WeeklyAggregationRetentionDays},
+		{Name: RecordKindAggregatedMetrics, Period: string(analyticsApp.AggregationPeriodMonthly), RetentionDays: s.policy.MonthlyAggregationRetentionDays},
+	}
+
+	var targets []Target
+	for _, target := range candidates {
+		if target.RetentionDays <= 0 {
+			continue
+		}
+		target.Cutoff = now.AddDate(0, 0, -target.RetentionDays)
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+func (s *Service) countPRMetrics(ctx context.Context, cutoff time.Time) (int64, error) {
+	metrics, err := s.prRepo.FindCollectedBefore(ctx, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired pr metrics: %w", err)
+	}
+	return int64(len(metrics)), nil
+}
+
+func (s *Service) countAggregated(ctx context.Context, period analyticsApp.AggregationPeriod, cutoff time.Time) (int64, error) {
+	records, err := s.aggRepo.FindGeneratedBefore(ctx, period, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired %s aggregated metrics: %w", period, err)
+	}
+	return int64(len(records)), nil
+}
+
+func (s *Service) purgePRMetrics(ctx context.Context, target *Target, now time.Time) error {
+	if s.archiving() {
+		metrics, err := s.prRepo.FindCollectedBefore(ctx, target.Cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to find expired pr metrics: %w", err)
+		}
+		// レビューイベントもPRメトリクスと一緒に削除されるため、復元できるようアーカイブに含める
+		prIDs := make([]string, 0, len(metrics))
+		for _, m := range metrics {
+			prIDs = append(prIDs, m.PRID)
+		}
+		events, err := s.prRepo.FindReviewEventsByPRIDs(ctx, prIDs)
+		if err != nil {
+			return fmt.Errorf("failed to find review events of expired pr metrics: %w", err)
+		}
+		records := make([]ArchiveRecord, 0, len(metrics))
+		for _, m := range metrics {
+			m.ReviewEvents = events[m.PRID]
+			records = append(records, ArchiveRecord{Kind: RecordKindPRMetrics, PRMetrics: m})
+		}
+		if target.Archive, err = s.writeArchive(ctx, target, now, records); err != nil {
+			return err
+		}
+	}
+
+	deleted, err := s.prRepo.DeleteCollectedBefore(ctx, target.Cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired pr metrics: %w", err)
+	}
+	target.Count = deleted
+	return nil
+}
+
+func (s *Service) purgeAggregated(ctx context.Context, target *Target, now time.Time) error {
+	period := analyticsApp.AggregationPeriod(target.Period)
+	if s.archiving() {
+		aggregated, err := s.aggRepo.FindGeneratedBefore(ctx, period, target.Cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to find expired %s aggregated metrics: %w", period, err)
+		}
+		records := make([]ArchiveRecord, 0, len(aggregated))
+		for _, record := range aggregated {
+			records = append(records, ArchiveRecord{Kind: RecordKindAggregatedMetrics, Aggregated: record})
+		}
+		if target.Archive, err = s.writeArchive(ctx, target, now, records); err != nil {
+			return err
+		}
+	}
+
+	deleted, err := s.aggRepo.DeleteGeneratedBefore(ctx, period, target.Cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired %s aggregated metrics: %w", period, err)
+	}
+	target.Count = deleted
+	return nil
+}
+
+// writeArchive は削除対象をアーカイブへ書き出す（対象がない場合は書き出さない）
+func (s *Service) writeArchive(ctx context.Context, target *Target, now time.Time, records []ArchiveRecord) (string, error) {
+	if len(records) == 0 {
+		return "", nil
+	}
+
+	name := target.Name
+	if target.Period != "" {
+		name += "-" + target.Period
+	}
+	name += "-" + now.UTC().Format(archiveTimeFormat)
+
+	stored, err := s.archive.Write(ctx, name, records)
+	if err != nil {
+		return "", fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+	return stored, nil
+}
+
+func (s *Service) restoreAggregated(ctx context.Context, record *analyticsApp.AggregatedRecord) error {
+	var err error
+	switch {
+	case record.Team != nil:
+		err = s.aggRepo.SaveTeamMetrics(ctx, record.Team)
+	case record.Developer != nil:
+		err = s.aggRepo.SaveDeveloperMetrics(ctx, record.Developer)
+	case record.Repository != nil:
+		err = s.aggRepo.SaveRepositoryMetrics(ctx, record.Repository)
+	case record.Label != nil:
+		err = s.aggRepo.SaveLabelMetrics(ctx, record.Label)
+	default:
+		return fmt.Errorf("aggregated archive record has no metrics: level=%s", record.Level)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore %s metrics: %w", record.Level, err)
+	}
+	return nil
+}
+
+// archiving は削除前にアーカイブするかを返す
+func (s *Service) archiving() bool {
+	return s.policy.EnableArchiving && s.archive != nil
+}
@@ -0,0 +1,256 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/domain/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/infrastructure/memory"
+)
+
+// fakeArchive はメモリ内にアーカイブを保持するテスト用実装
+type fakeArchive struct {
+	archives map[string][]ArchiveRecord
+	writeErr error
+}
+
+func newFakeArchive() *fakeArchive {
+	return &fakeArchive{archives: make(map[string][]ArchiveRecord)}
+}
+
+func (a *fakeArchive) Write(ctx context.Context, name string, records []ArchiveRecord) (string, error) {
+	if a.writeErr != nil {
+		return "", a.writeErr
+	}
+	stored := name + ".jsonl"
+	a.archives[stored] = records
+	return stored, nil
+}
+
+func (a *fakeArchive) Read(ctx context.Context, name string) ([]ArchiveRecord, error) {
+	records, ok := a.archives[name]
+	if !ok {
+		return nil, ErrArchiveNotFound
+	}
+	return records, nil
+}
+
+func (a *fakeArchive) List(ctx context.Context) ([]ArchiveInfo, error) {
+	var infos []ArchiveInfo
+	for name := range a.archives {
+		infos = append(infos, ArchiveInfo{Name: name})
+	}
+	return infos, nil
+}
+
+type serviceFixture struct {
+	service *Service
+	prRepo  prDomain.MetricsRepository
+	aggRepo analyticsApp.AggregatedMetricsRepository
+	archive *fakeArchive
+	now     time.Time
+}
+
+// newServiceFixture は詳細データ90日・月次集計365日を保持するサービスを作成
+// 実行時刻を100日後にずらし、保存したばかりのPRメトリクスを期限切れとして扱う
+func newServiceFixture(t *testing.T, archiving bool) *serviceFixture {
+	prRepo := memory.NewPRMetricsRepository()
+	aggRepo := memory.NewAggregatedMetricsRepository()
+	archive := newFakeArchive()
+	policy := analytics.DataRetentionPolicy{
+		DetailedDataRetentionDays:       90,
+		MonthlyAggregationRetentionDays: 365,
+		EnableArchiving:                 archiving,
+	}
+
+	now := time.Now().AddDate(0, 0, 100)
+	service := NewService(prRepo, aggRepo, archive, policy)
+	service.now = func() time.Time { return now }
+
+	return &serviceFixture{service: service, prRepo: prRepo, aggRepo: aggRepo, archive: archive, now: now}
+}
+
+func (f *serviceFixture) seed(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, f.prRepo.Save(ctx, &prDomain.PRMetrics{
+		PRID: "pr-1", Author: "alice", Repository: "org/api", CreatedAt: created,
+		ReviewEvents: []prDomain.ReviewEvent{{
+			Type: prDomain.ReviewEventTypeApproved, CreatedAt: created.Add(time.Hour), Actor: "bob", Reviewer: "bob",
+		}},
+	}))
+	require.NoError(t, f.prRepo.Save(ctx, &prDomain.PRMetrics{PRID: "pr-2", Author: "bob", Repository: "org/api", CreatedAt: created}))
+
+	january := analyticsApp.DateRange{
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
+	}
+	february := analyticsApp.DateRange{
+		Start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC),
+	}
+	require.NoError(t, f.aggRepo.SaveTeamMetrics(ctx, &analyticsApp.TeamMetrics{
+		Period: analyticsApp.AggregationPeriodMonthly, TotalPRs: 12, DateRange: january, GeneratedAt: f.now.AddDate(-2, 0, 0),
+	}))
+	require.NoError(t, f.aggRepo.SaveDeveloperMetrics(ctx, &analyticsApp.DeveloperMetrics{
+		Developer: "alice", Period: analyticsApp.AggregationPeriodMonthly, TotalPRs: 5, DateRange: february, GeneratedAt: f.now.AddDate(0, 0, -10),
+	}))
+}
+
+func TestService_Preview(t *testing.T) {
+	f := newServiceFixture(t, true)
+	f.seed(t)
+
+	report, err := f.service.Preview(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Targets, 2, "保持期間が0の対象は含めない")
+	assert.Equal(t, RecordKindPRMetrics, report.Targets[0].Name)
+	assert.Equal(t, int64(2), report.Targets[0].Count)
+	assert.True(t, report.Targets[0].Cutoff.Equal(f.now.AddDate(0, 0, -90)))
+	assert.Equal(t, RecordKindAggregatedMetrics, report.Targets[1].Name)
+	assert.Equal(t, "monthly", report.Targets[1].Period)
+	assert.Equal(t, int64(1), report.Targets[1].Count)
+
+	// プレビューでは削除もアーカイブもしない
+	stored, err := f.prRepo.FindByPRID(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.NotNil(t, stored)
+	assert.Empty(t, f.archive.archives)
+}
+
+func TestService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("削除前にアーカイブへ書き出す", func(t *testing.T) {
+		f := newServiceFixture(t, true)
+		f.seed(t)
+
+		report, err := f.service.Run(ctx)
+		require.NoError(t, err)
+
+		assert.False(t, report.DryRun)
+		require.Len(t, report.Targets, 2)
+		assert.Equal(t, int64(2), report.Targets[0].Count)
+		assert.Equal(t, "pr_metrics-"+f.now.UTC().Format(archiveTimeFormat)+".jsonl", report.Targets[0].Archive)
+		assert.Equal(t, int64(1), report.Targets[1].Count)
+		assert.Equal(t, "aggregated_metrics-monthly-"+f.now.UTC().Format(archiveTimeFormat)+".jsonl", report.Targets[1].Archive)
+
+		archived := f.archive.archives[report.Targets[0].Archive]
+		require.Len(t, archived, 2)
+		for _, record := range archived {
+			if record.PRMetrics.PRID == "pr-1" {
+				require.Len(t, record.PRMetrics.ReviewEvents, 1, "レビューイベントもアーカイブする")
+				assert.Equal(t, "bob", record.PRMetrics.ReviewEvents[0].Reviewer)
+			} else {
+				assert.Empty(t, record.PRMetrics.ReviewEvents)
+			}
+		}
+		aggregated := f.archive.archives[report.Targets[1].Archive]
+		require.Len(t, aggregated, 1)
+		assert.Equal(t, analyticsApp.AggregationLevelTeam, aggregated[0].Aggregated.Level)
+
+		stored, err := f.prRepo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		remaining, err := f.aggRepo.FindDeveloperMetrics(ctx, "alice", analyticsApp.AggregationPeriodMonthly, time.Time{}, f.now)
+		require.NoError(t, err)
+		assert.Len(t, remaining, 1, "保持期間内の集計データは残す")
+	})
+
+	t.Run("対象がない場合はアーカイブを作らない", func(t *testing.T) {
+		f := newServiceFixture(t, true)
+
+		report, err := f.service.Run(ctx)
+		require.NoError(t, err)
+		for _, target := range report.Targets {
+			assert.Zero(t, target.Count)
+			assert.Empty(t, target.Archive)
+		}
+		assert.Empty(t, f.archive.archives)
+	})
+
+	t.Run("アーカイブに失敗した場合は削除しない", func(t *testing.T) {
+		f := newServiceFixture(t, true)
+		f.seed(t)
+		f.archive.writeErr = errors.New("disk full")
+
+		_, err := f.service.Run(ctx)
+		require.Error(t, err)
+
+		stored, err := f.prRepo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+	})
+
+	t.Run("アーカイブ無効の場合はそのまま削除する", func(t *testing.T) {
+		f := newServiceFixture(t, false)
+		f.seed(t)
+
+		report, err := f.service.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), report.Targets[0].Count)
+		assert.Empty(t, report.Targets[0].Archive)
+		assert.Empty(t, f.archive.archives)
+	})
+}
+
+func TestService_Restore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("アーカイブしたデータを書き戻す", func(t *testing.T) {
+		f := newServiceFixture(t, true)
+		f.seed(t)
+		report, err := f.service.Run(ctx)
+		require.NoError(t, err)
+
+		result, err := f.service.Restore(ctx, report.Targets[0].Archive)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.PRMetrics)
+
+		stored, err := f.prRepo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, "alice", stored.Author)
+
+		events, err := f.prRepo.FindReviewEvents(ctx, "pr-1")
+		require.NoError(t, err)
+		require.Len(t, events, 1, "レビューイベントも書き戻す")
+		assert.Equal(t, prDomain.ReviewEventTypeApproved, events[0].Type)
+
+		result, err = f.service.Restore(ctx, report.Targets[1].Archive)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Aggregated)
+
+		team, err := f.aggRepo.FindTeamMetrics(ctx, analyticsApp.AggregationPeriodMonthly, time.Time{}, f.now)
+		require.NoError(t, err)
+		require.Len(t, team, 1)
+		assert.Equal(t, 12, team[0].TotalPRs)
+	})
+
+	t.Run("存在しないアーカイブ", func(t *testing.T) {
+		f := newServiceFixture(t, true)
+
+		_, err := f.service.Restore(ctx, "missing.jsonl")
+		assert.ErrorIs(t, err, ErrArchiveNotFound)
+	})
+
+	t.Run("アーカイブ未設定", func(t *testing.T) {
+		service := NewService(memory.NewPRMetricsRepository(), memory.NewAggregatedMetricsRepository(), nil, analytics.GetDefaultRetentionPolicy())
+
+		_, err := service.Restore(ctx, "pr_metrics.jsonl")
+		assert.ErrorIs(t, err, ErrArchiveNotConfigured)
+
+		_, err = service.ListArchives(ctx)
+		assert.ErrorIs(t, err, ErrArchiveNotConfigured)
+	})
+}
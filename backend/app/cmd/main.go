@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"log"
 	"os"
 
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/infrastructure/database/migration"
 	"github-stats-metrics/server"
 	"github-stats-metrics/shared/config"
 	"github-stats-metrics/shared/logging"
@@ -20,6 +23,11 @@ func main() {
 		log.Printf("Warning: Could not load .env file: %v", loadErr)
 	}
 
+	// マイグレーションのサブコマンド（サーバーは起動しない）
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
 	// 設定を読み込み
 	cfg, err := config.NewConfig()
 	if err != nil {
@@ -54,11 +62,76 @@ func main() {
 		"listen_address": cfg.GetListenAddress(),
 	})
 
+	// データベースに接続（接続できない場合もメトリクス系APIを 503 にして起動を続ける）
+	db, dialect := connectDatabase(ctx, cfg, logger, healthChecker)
+	defer db.Close()
+
 	// server パッケージから StartWebServer を呼び出す
-	// TODO: 実際のデータベース接続を設定する
-	err = server.StartWebServer(cfg, logger, metricsCollector, healthChecker, nil)
+	err = server.StartWebServer(cfg, logger, metricsCollector, healthChecker, db, dialect)
 	if err != nil {
 		logger.Fatal(ctx, "Failed to start web server", err)
 		os.Exit(1)
 	}
 }
+
+// connectDatabase は接続プールを作成し、疎通確認とマイグレーションを行う
+// DATABASE_URL 未設定の場合は常に database.ErrNotConfigured を返す接続を返す
+func connectDatabase(ctx context.Context, cfg *config.Config, logger *logging.StructuredLogger, healthChecker *monitoring.HealthChecker) (*sql.DB, database.Dialect) {
+	if cfg.Database.URL == "" {
+		logger.Warn(ctx, "DATABASE_URL is not set; metrics endpoints will return 503")
+		return database.Disconnected(database.ErrNotConfigured), database.Postgres()
+	}
+	if cfg.Database.UsesMemoryStorage() {
+		logger.Warn(ctx, "Using in-memory metrics storage; data will be lost on restart")
+		return database.Disconnected(database.ErrNotConfigured), database.Postgres()
+	}
+
+	db, dialect, err := database.Connect(ctx, cfg.Database.URL, database.PoolConfig{
+		MaxOpenConns:    cfg.Database.MaxConnections,
+		MaxIdleConns:    cfg.Database.MaxIdleConnections,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnectTimeout:  cfg.Database.ConnectTimeout,
+		QueryTimeout:    cfg.Database.QueryTimeout,
+	})
+	if db == nil {
+		// 接続文字列が不正な場合は設定ミスなので起動しない
+		logger.Fatal(ctx, "Failed to open database", err)
+		os.Exit(1)
+	}
+	healthChecker.AddCheck(monitoring.NewDatabaseHealthCheck(db, dialect.Name(), cfg.Database.ConnectTimeout))
+
+	if err != nil {
+		logger.Error(ctx, "Database is unavailable; metrics endpoints will return 503 until it recovers", err, map[string]interface{}{
+			"dialect": dialect.Name(),
+		})
+		return db, dialect
+	}
+
+	logger.Info(ctx, "Database connected", map[string]interface{}{
+		"dialect":         dialect.Name(),
+		"max_connections": cfg.Database.MaxConnections,
+	})
+
+	// スキーマのマイグレーション（DATABASE_AUTO_MIGRATE=true の場合のみ自動適用）
+	migrator := migration.NewMigrator(db, dialect)
+	if cfg.Database.AutoMigrate {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			logger.Fatal(ctx, "Failed to migrate database", err)
+			os.Exit(1)
+		}
+		logger.Info(ctx, "Database migrated", map[string]interface{}{
+			"applied": len(applied),
+		})
+	} else if pending, err := migrator.Pending(ctx); err != nil {
+		logger.Warn(ctx, "Failed to check database migrations", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else if pending > 0 {
+		logger.Warn(ctx, "Database has pending migrations; run 'migrate up' or set DATABASE_AUTO_MIGRATE=true", map[string]interface{}{
+			"pending": pending,
+		})
+	}
+
+	return db, dialect
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/infrastructure/database/migration"
+)
+
+const migrateUsage = `usage: app migrate <command>
+
+commands:
+  up          未適用のマイグレーションをすべて適用
+  down [n]    適用済みのマイグレーションを新しい順に n 件取り消す（デフォルト1件）
+  status      マイグレーションの適用状況を表示
+  partition   pr_metrics を作成日時の月次パーティションへ作り替える（PostgreSQL のみ、テーブル全体を作り直す）
+  unpartition pr_metrics をパーティションなしのテーブルに戻す（マイグレーション 5 より前に戻す場合は先に実行）
+
+接続先は環境変数 DATABASE_URL で指定する`
+
+// runMigrate は migrate サブコマンドを実行し、終了コードを返す
+// GitHub関連の設定は不要なため、設定全体ではなく DATABASE_URL のみを読み込む
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	databaseURL := strings.TrimSpace(os.Getenv("DATABASE_URL"))
+	if databaseURL == "" {
+		fmt.Fprintln(os.Stderr, "DATABASE_URL is not set")
+		return 1
+	}
+
+	db, dialect, err := database.Open(databaseURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	migrator := migration.NewMigrator(db, dialect)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied  %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				fmt.Fprintf(os.Stderr, "invalid number of steps: %s\n", args[1])
+				return 2
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+			return 1
+		}
+		if len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get migration status: %v\n", err)
+			return 1
+		}
+		fmt.Printf("dialect: %s\n", dialect.Name())
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%4d  %-40s %s\n", status.Version, status.Name, state)
+		}
+
+	case "partition":
+		changed, err := migrator.Partition(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Partitioning failed: %v\n", err)
+			return 1
+		}
+		if changed {
+			fmt.Println("pr_metrics partitioned by created_at")
+		} else {
+			fmt.Println("pr_metrics is already partitioned")
+		}
+
+	case "unpartition":
+		changed, err := migrator.Unpartition(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unpartitioning failed: %v\n", err)
+			return 1
+		}
+		if changed {
+			fmt.Println("pr_metrics unpartitioned")
+		} else {
+			fmt.Println("pr_metrics is not partitioned")
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
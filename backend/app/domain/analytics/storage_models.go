@@ -46,6 +46,21 @@ type PRMetricsStorage struct {
 	YearMonth  string `json:"yearMonth" db:"year_month"`   // YYYY-MM形式
 	WeekOfYear string `json:"weekOfYear" db:"week_of_year"` // YYYY-WW形式
 	DayOfYear  string `json:"dayOfYear" db:"day_of_year"`   // YYYY-DDD形式
+	
+	// ラベル情報（JSON配列）
+	LabelsJSON string `json:"labelsJson" db:"labels_json"`
+	
+	// botが作成したPRかどうか
+	IsBot bool `json:"isBot" db:"is_bot"`
+	
+	// 計算に使った定義バージョン（空の場合は定義によらない収集時の値）
+	DefinitionVersion string `json:"definitionVersion" db:"definition_version"`
+	
+	// ブランチ・マージコミットとリバート・ホットフィックスの判定結果（JSON）
+	BaseBranch        string `json:"baseBranch" db:"base_branch"`
+	HeadBranch        string `json:"headBranch" db:"head_branch"`
+	MergeCommitSHA    string `json:"mergeCommitSha" db:"merge_commit_sha"`
+	ChangeFailureJSON string `json:"changeFailureJson" db:"change_failure_json"`
 }
 
 // PRMetricsStorageSchema はデータベーススキーマ定義
@@ -84,13 +99,20 @@ func GetPRMetricsSchema() PRMetricsStorageSchema {
 				Unique:  true,
 				Type:    IndexTypeBTree,
 			},
-			// PR識別用
+			// PR識別用（パーティション化したテーブルには作れないため、PR IDの一意性は pr_metrics_ids でも保証する）
 			{
 				Name:    "idx_pr_metrics_pr_id",
 				Columns: []string{"pr_id"},
 				Unique:  true,
 				Type:    IndexTypeBTree,
 			},
+			// パーティションキーを含む一意キー（パーティション化したテーブルの保存時の衝突判定に使う）
+			{
+				Name:    "uk_pr_metrics_pr_id_created_at",
+				Columns: []string{"pr_id", "created_at"},
+				Unique:  true,
+				Type:    IndexTypeBTree,
+			},
 			// 日時検索用
 			{
 				Name:    "idx_pr_metrics_created_at",
@@ -212,6 +234,7 @@ type ReviewEventStorage struct {
 	CreatedAt   time.Time                   `json:"createdAt" db:"created_at"`      // イベント発生日時
 	Actor       string                      `json:"actor" db:"actor"`               // 実行者
 	Reviewer    *string                     `json:"reviewer" db:"reviewer"`         // レビュアー（該当する場合）
+	IsBot       bool                        `json:"isBot" db:"is_bot"`              // Actor がbotかどうか
 	CollectedAt time.Time                   `json:"collectedAt" db:"collected_at"`  // データ収集日時
 }
 
@@ -326,6 +349,18 @@ func GetRecommendedPartitionStrategy() PartitionStrategy {
 	}
 }
 
+// PartitionInfo はパーティション1つの状態
+type PartitionInfo struct {
+	Name          string     `json:"name"`
+	Bound         string     `json:"bound"`                // パーティション範囲の定義（切り離し済みの場合は空）
+	RangeStart    *time.Time `json:"rangeStart,omitempty"` // 月次パーティションの範囲（既定パーティションは nil）
+	RangeEnd      *time.Time `json:"rangeEnd,omitempty"`
+	IsDefault     bool       `json:"isDefault"`
+	Attached      bool       `json:"attached"` // 保持期間により切り離した場合は false
+	EstimatedRows int64      `json:"estimatedRows"`
+	SizeBytes     int64      `json:"sizeBytes"`
+}
+
 // StorageConfiguration はストレージ設定
 type StorageConfiguration struct {
 	// データベース設定
@@ -371,7 +406,7 @@ func GetDefaultStorageConfiguration() StorageConfiguration {
 type AggregatedMetricsStorage struct {
 	// 基本識別情報
 	ID               string    `json:"id" db:"id"`                             // ユニークID
-	AggregationLevel string    `json:"aggregationLevel" db:"aggregation_level"` // "team", "developer", "repository"
+	AggregationLevel string    `json:"aggregationLevel" db:"aggregation_level"` // "team", "developer", "repository", "label"
 	AggregationPeriod string   `json:"aggregationPeriod" db:"aggregation_period"` // "daily", "weekly", "monthly"
 	
 	// 集計対象の識別
@@ -532,10 +567,11 @@ type TrendDataStorage struct {
 	// 基本識別情報
 	ID               string    `json:"id" db:"id"`                             // ユニークID
 	MetricType       string    `json:"metricType" db:"metric_type"`            // "cycle_time", "review_time", "quality"
-	AggregationLevel string    `json:"aggregationLevel" db:"aggregation_level"` // "team", "developer", "repository"
+	AggregationLevel string    `json:"aggregationLevel" db:"aggregation_level"` // "team", "developer", "repository", "label"
 	TargetID         string    `json:"targetId" db:"target_id"`                // 対象ID
 	
 	// 期間情報
+	AggregationPeriod string   `json:"aggregationPeriod" db:"aggregation_period"` // "daily", "weekly", "monthly"
 	PeriodStart time.Time `json:"periodStart" db:"period_start"` // 分析期間開始
 	PeriodEnd   time.Time `json:"periodEnd" db:"period_end"`     // 分析期間終了
 	
@@ -714,4 +750,62 @@ func GetBottleneckDataSchema() PRMetricsStorageSchema {
 			},
 		},
 	}
-}
\ No newline at end of file
+}
+// AggregationJobRunStorage は集計ジョブの実行記録の永続化モデル
+type AggregationJobRunStorage struct {
+	ID             string     `json:"id" db:"id"`                           // ユニークID
+	Status         string     `json:"status" db:"status"`                   // "succeeded", "failed"
+	StartedAt      time.Time  `json:"startedAt" db:"started_at"`            // 開始日時
+	FinishedAt     time.Time  `json:"finishedAt" db:"finished_at"`          // 終了日時
+	CollectedSince *time.Time `json:"collectedSince" db:"collected_since"`  // 対象とした収集日時の下限（全件の場合は NULL）
+	ChangedPRs     int        `json:"changedPrs" db:"changed_prs"`          // 前回以降に収集されたPR数
+	Periods        int        `json:"periods" db:"periods"`                 // 再集計した期間数
+	Records        int        `json:"records" db:"records"`                 // 保存した集計データ数
+	ErrorMessage   *string    `json:"errorMessage" db:"error_message"`      // 失敗時のエラー
+}
+
+// GetAggregationJobRunSchema は集計ジョブの実行記録のスキーマ定義を返す
+func GetAggregationJobRunSchema() PRMetricsStorageSchema {
+	return PRMetricsStorageSchema{
+		TableName: "aggregation_job_runs",
+		Indexes: []IndexDefinition{
+			// 主キー
+			{
+				Name:    "pk_aggregation_job_runs",
+				Columns: []string{"id"},
+				Unique:  true,
+				Type:    IndexTypeBTree,
+			},
+			// 最新の成功した実行の検索用
+			{
+				Name:    "idx_aggregation_job_runs_status_started",
+				Columns: []string{"status", "started_at"},
+				Unique:  false,
+				Type:    IndexTypeBTree,
+			},
+		},
+	}
+}
+
+// PRMetricsIDStorage はPR IDごとに最初に保存した作成日時の永続化モデル
+// pr_metrics はパーティション化するとPR IDだけの一意制約を持てないため、PR IDの一意性をこの表で保証する
+type PRMetricsIDStorage struct {
+	PRID      string    `json:"prId" db:"pr_id"`           // GitHub PR ID
+	CreatedAt time.Time `json:"createdAt" db:"created_at"` // pr_metrics の行の作成日時（以後の保存でも変えない）
+}
+
+// GetPRMetricsIDSchema はPR IDの一意性を保証する表のスキーマ定義を返す
+func GetPRMetricsIDSchema() PRMetricsStorageSchema {
+	return PRMetricsStorageSchema{
+		TableName: "pr_metrics_ids",
+		Indexes: []IndexDefinition{
+			// 主キー
+			{
+				Name:    "pk_pr_metrics_ids",
+				Columns: []string{"pr_id"},
+				Unique:  true,
+				Type:    IndexTypeBTree,
+			},
+		},
+	}
+}
@@ -1,7 +1,47 @@
 package developer
 
+import (
+	"strings"
+)
+
+// Developer は1人の開発者（複数アカウントを束ねた同一人物）
+// Id は集計時の正規識別子として使用する
 type Developer struct {
-	Id         string
-	ScreenName string
-	ImageURL   string
+	Id         string   `json:"id"`
+	ScreenName string   `json:"screenName"`
+	ImageURL   string   `json:"imageUrl,omitempty"`
+	Logins     []string `json:"logins,omitempty"` // GitHub/GitLab等のログイン名
+	Emails     []string `json:"emails,omitempty"` // コミット等で使用されるメールアドレス
+}
+
+// Aliases はこの開発者を指す全ての別名（Id・ログイン名・メールアドレス）を返す
+func (d Developer) Aliases() []string {
+	aliases := make([]string, 0, 1+len(d.Logins)+len(d.Emails))
+	aliases = append(aliases, d.Id)
+	aliases = append(aliases, d.Logins...)
+	aliases = append(aliases, d.Emails...)
+	return aliases
+}
+
+// Validate は開発者定義の妥当性を検証
+func (d Developer) Validate() error {
+	if strings.TrimSpace(d.Id) == "" {
+		return &IdentityError{Type: "VALIDATION_ERROR", Message: "developer id is required"}
+	}
+	return nil
+}
+
+// IdentityError はID管理で発生するエラー
+type IdentityError struct {
+	Type    string
+	Message string
+}
+
+func (e *IdentityError) Error() string {
+	return e.Type + ": " + e.Message
+}
+
+// IsConflict は別名の重複エラーかどうかを判定
+func (e *IdentityError) IsConflict() bool {
+	return e.Type == "ALIAS_CONFLICT"
 }
@@ -0,0 +1,179 @@
+package developer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// IdentityRegistry は複数のログイン名・メールアドレスを1人の開発者に対応付ける
+// 実行時に API から更新されるため、並行アクセスに対して安全
+type IdentityRegistry struct {
+	mu         sync.RWMutex
+	developers map[string]Developer // Id -> Developer
+	aliases    map[string]string    // 正規化した別名 -> Id
+}
+
+// NewIdentityRegistry は開発者定義から新しいレジストリを作成
+func NewIdentityRegistry(developers []Developer) (*IdentityRegistry, error) {
+	registry := &IdentityRegistry{
+		developers: make(map[string]Developer),
+		aliases:    make(map[string]string),
+	}
+	for _, developer := range developers {
+		if err := registry.Register(developer); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// Register は開発者を登録（同じIdが存在する場合は置き換え）
+func (r *IdentityRegistry) Register(developer Developer) error {
+	if err := developer.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 他の開発者と別名が衝突しないか確認
+	for _, alias := range developer.Aliases() {
+		key := normalizeAlias(alias)
+		if key == "" {
+			continue
+		}
+		if owner, exists := r.aliases[key]; exists && owner != developer.Id {
+			return &IdentityError{
+				Type:    "ALIAS_CONFLICT",
+				Message: fmt.Sprintf("alias %q is already assigned to %q", alias, owner),
+			}
+		}
+	}
+
+	r.removeLocked(developer.Id)
+	r.developers[developer.Id] = developer
+	for _, alias := range developer.Aliases() {
+		if key := normalizeAlias(alias); key != "" {
+			r.aliases[key] = developer.Id
+		}
+	}
+	return nil
+}
+
+// Remove は開発者の登録を削除
+func (r *IdentityRegistry) Remove(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.removeLocked(id)
+}
+
+// Get はIdから開発者を取得
+func (r *IdentityRegistry) Get(id string) (Developer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	developer, exists := r.developers[id]
+	return developer, exists
+}
+
+// List は登録済みの開発者をId順で返す
+func (r *IdentityRegistry) List() []Developer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	developers := make([]Developer, 0, len(r.developers))
+	for _, developer := range r.developers {
+		developers = append(developers, developer)
+	}
+	sort.Slice(developers, func(i, j int) bool {
+		return developers[i].Id < developers[j].Id
+	})
+	return developers
+}
+
+// Lookup はログイン名・メールアドレスから開発者を取得
+func (r *IdentityRegistry) Lookup(alias string) (Developer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.aliases[normalizeAlias(alias)]
+	if !exists {
+		return Developer{}, false
+	}
+	return r.developers[id], true
+}
+
+// Resolve はログイン名・メールアドレスを正規のIdに変換
+// 未登録の場合は入力をそのまま返す
+func (r *IdentityRegistry) Resolve(alias string) string {
+	if r == nil {
+		return alias
+	}
+	if developer, exists := r.Lookup(alias); exists {
+		return developer.Id
+	}
+	return alias
+}
+
+// ResolveAll は複数の別名を正規のIdに変換し、重複を除いて返す（順序は維持）
+func (r *IdentityRegistry) ResolveAll(aliases []string) []string {
+	if len(aliases) == 0 {
+		return aliases
+	}
+
+	resolved := make([]string, 0, len(aliases))
+	seen := make(map[string]bool)
+	for _, alias := range aliases {
+		id := r.Resolve(alias)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		resolved = append(resolved, id)
+	}
+	return resolved
+}
+
+// ExpandLogins は別名が指す開発者の全ログイン名を返す（フィルタ条件の展開用）
+// 未登録の場合は入力のみを返す
+func (r *IdentityRegistry) ExpandLogins(alias string) []string {
+	if r == nil {
+		return []string{alias}
+	}
+	developer, exists := r.Lookup(alias)
+	if !exists {
+		return []string{alias}
+	}
+
+	logins := make([]string, 0, 1+len(developer.Logins))
+	logins = append(logins, developer.Id)
+	for _, login := range developer.Logins {
+		if login != developer.Id {
+			logins = append(logins, login)
+		}
+	}
+	return logins
+}
+
+func (r *IdentityRegistry) removeLocked(id string) bool {
+	developer, exists := r.developers[id]
+	if !exists {
+		return false
+	}
+	for _, alias := range developer.Aliases() {
+		key := normalizeAlias(alias)
+		if r.aliases[key] == id {
+			delete(r.aliases, key)
+		}
+	}
+	delete(r.developers, id)
+	return true
+}
+
+// normalizeAlias は別名を比較用に正規化（GitHubのログイン名・メールは大文字小文字を区別しない）
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
@@ -0,0 +1,128 @@
+package developer
+
+import (
+	"testing"
+)
+
+func newTestRegistry(t *testing.T) *IdentityRegistry {
+	t.Helper()
+	registry, err := NewIdentityRegistry([]Developer{
+		{Id: "alice", ScreenName: "Alice", Logins: []string{"alice-work", "alice-old"}, Emails: []string{"alice@example.com"}},
+		{Id: "bob", ScreenName: "Bob", Logins: []string{"bob-gh", "bob-gl"}},
+	})
+	if err != nil {
+		t.Fatalf("NewIdentityRegistry() error = %v", err)
+	}
+	return registry
+}
+
+func TestIdentityRegistry_Resolve(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	tests := []struct {
+		name     string
+		alias    string
+		expected string
+	}{
+		{"ログイン名から解決", "alice-work", "alice"},
+		{"旧アカウントから解決", "alice-old", "alice"},
+		{"メールアドレスから解決", "alice@example.com", "alice"},
+		{"大文字小文字を区別しない", "BOB-GL", "bob"},
+		{"Id自体も解決できる", "bob", "bob"},
+		{"未登録はそのまま返す", "carol", "carol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := registry.Resolve(tt.alias); result != tt.expected {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.alias, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIdentityRegistry_ResolveAll(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	result := registry.ResolveAll([]string{"alice-work", "bob-gh", "alice-old", "carol"})
+	expected := []string{"alice", "bob", "carol"}
+
+	if len(result) != len(expected) {
+		t.Fatalf("ResolveAll() = %v, want %v", result, expected)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("ResolveAll()[%d] = %q, want %q", i, result[i], expected[i])
+		}
+	}
+}
+
+func TestIdentityRegistry_Register(t *testing.T) {
+	t.Run("別の開発者のエイリアスと衝突する場合はエラー", func(t *testing.T) {
+		registry := newTestRegistry(t)
+
+		err := registry.Register(Developer{Id: "mallory", Logins: []string{"alice-work"}})
+		identityErr, ok := err.(*IdentityError)
+		if !ok || !identityErr.IsConflict() {
+			t.Fatalf("Register() error = %v, want alias conflict", err)
+		}
+		if registry.Resolve("alice-work") != "alice" {
+			t.Error("衝突時に既存のエイリアスが変更されている")
+		}
+	})
+
+	t.Run("同じIdの再登録で古いエイリアスが外れる", func(t *testing.T) {
+		registry := newTestRegistry(t)
+
+		if err := registry.Register(Developer{Id: "alice", Logins: []string{"alice-new"}}); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		if registry.Resolve("alice-new") != "alice" {
+			t.Error("新しいエイリアスが解決されない")
+		}
+		if registry.Resolve("alice-old") != "alice-old" {
+			t.Error("古いエイリアスが残っている")
+		}
+	})
+
+	t.Run("Idが空の場合はエラー", func(t *testing.T) {
+		registry := newTestRegistry(t)
+
+		if err := registry.Register(Developer{Logins: []string{"someone"}}); err == nil {
+			t.Error("Register() error = nil, want validation error")
+		}
+	})
+}
+
+func TestIdentityRegistry_Remove(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	if !registry.Remove("bob") {
+		t.Fatal("Remove() = false, want true")
+	}
+	if registry.Resolve("bob-gh") != "bob-gh" {
+		t.Error("削除後もエイリアスが解決される")
+	}
+	if registry.Remove("bob") {
+		t.Error("2回目の Remove() = true, want false")
+	}
+}
+
+func TestIdentityRegistry_ExpandLogins(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	logins := registry.ExpandLogins("alice-old")
+	expected := map[string]bool{"alice": true, "alice-work": true, "alice-old": true}
+	if len(logins) != len(expected) {
+		t.Fatalf("ExpandLogins() = %v, want %v", logins, expected)
+	}
+	for _, login := range logins {
+		if !expected[login] {
+			t.Errorf("unexpected login %q", login)
+		}
+	}
+
+	if result := registry.ExpandLogins("carol"); len(result) != 1 || result[0] != "carol" {
+		t.Errorf("ExpandLogins(未登録) = %v, want [carol]", result)
+	}
+}
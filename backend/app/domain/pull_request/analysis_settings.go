@@ -0,0 +1,303 @@
+package pull_request
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SizeThresholds はサイズカテゴリごとの変更行数の上限（XL は Large を超えるもの）
+type SizeThresholds struct {
+	XSmall int `json:"xsmall"`
+	Small  int `json:"small"`
+	Medium int `json:"medium"`
+	Large  int `json:"large"`
+}
+
+// DefaultSizeThresholds はデフォルトのサイズカテゴリの閾値を返す
+func DefaultSizeThresholds() SizeThresholds {
+	return SizeThresholds{XSmall: 50, Small: 100, Medium: 300, Large: 600}
+}
+
+// Category は変更行数からサイズカテゴリを返す
+func (t SizeThresholds) Category(linesChanged int) PRSizeCategory {
+	switch {
+	case linesChanged <= t.XSmall:
+		return PRSizeXSmall
+	case linesChanged <= t.Small:
+		return PRSizeSmall
+	case linesChanged <= t.Medium:
+		return PRSizeMedium
+	case linesChanged <= t.Large:
+		return PRSizeLarge
+	default:
+		return PRSizeXLarge
+	}
+}
+
+// Validate は閾値が正の値で小さいカテゴリから順に大きくなっているか検証
+func (t SizeThresholds) Validate() error {
+	if t.XSmall <= 0 || t.XSmall >= t.Small || t.Small >= t.Medium || t.Medium >= t.Large {
+		return NewValidationError("size thresholds must be positive and increasing (xsmall < small < medium < large)", t)
+	}
+	return nil
+}
+
+// BottleneckThresholds はボトルネックと判定する閾値
+// PRごとのメトリクスには影響しないため定義バージョンには含めない
+type BottleneckThresholds struct {
+	LongCycleTime time.Duration // これを超えるサイクルタイム
+	ReviewRounds  int           // これを超えるレビューラウンド数
+	LargePRLines  int           // これを超える変更行数
+}
+
+// DefaultBottleneckThresholds はデフォルトのボトルネックの閾値を返す
+func DefaultBottleneckThresholds() BottleneckThresholds {
+	return BottleneckThresholds{
+		LongCycleTime: 7 * 24 * time.Hour,
+		ReviewRounds:  5,
+		LargePRLines:  300, // サイズカテゴリ L 以上
+	}
+}
+
+// DefinitionOverride は計算定義の一部を上書きする設定（nil・空の項目は上書きしない）
+type DefinitionOverride struct {
+	// サイクルタイム
+	UseBusinessHours  *bool `json:"useBusinessHours,omitempty"`
+	BusinessStart     *int  `json:"businessStart,omitempty"`
+	BusinessEnd       *int  `json:"businessEnd,omitempty"`
+	ExcludeWeekends   *bool `json:"excludeWeekends,omitempty"`
+	ExcludeHolidays   *bool `json:"excludeHolidays,omitempty"`
+	LegacyReviewStart *bool `json:"legacyReviewStart,omitempty"` // レビュー待ちの起点をPR作成時刻とする旧定義
+
+	// サイズ・複雑度
+	SizeThresholds  *SizeThresholds    `json:"sizeThresholds,omitempty"`
+	FileTypeWeights map[string]float64 `json:"fileTypeWeights,omitempty"` // 拡張子ごとに上書き
+
+	// レビュー
+	ReviewRoundIntervalMinutes  *int `json:"reviewRoundIntervalMinutes,omitempty"`
+	HighQualityCommentThreshold *int `json:"highQualityCommentThreshold,omitempty"`
+	LowQualityCommentThreshold  *int `json:"lowQualityCommentThreshold,omitempty"`
+
+	// ボトルネック
+	LongCycleTimeHours *float64 `json:"longCycleTimeHours,omitempty"`
+	ReviewRoundLimit   *int     `json:"reviewRoundLimit,omitempty"`
+	LargePRLines       *int     `json:"largePRLines,omitempty"`
+}
+
+// Apply は definition に上書きを適用した定義を返す（definition は変更しない）
+func (o DefinitionOverride) Apply(definition MetricDefinition) MetricDefinition {
+	definition = definition.withDefaults()
+	cycleTime := &definition.CycleTime
+	if o.UseBusinessHours != nil {
+		cycleTime.UseBusinessHours = *o.UseBusinessHours
+	}
+	if o.BusinessStart != nil {
+		cycleTime.BusinessStart = *o.BusinessStart
+	}
+	if o.BusinessEnd != nil {
+		cycleTime.BusinessEnd = *o.BusinessEnd
+	}
+	if o.ExcludeWeekends != nil {
+		cycleTime.ExcludeWeekends = *o.ExcludeWeekends
+	}
+	if o.ExcludeHolidays != nil {
+		cycleTime.ExcludeHolidays = *o.ExcludeHolidays
+	}
+	if o.LegacyReviewStart != nil {
+		cycleTime.UseLegacyReviewStart = *o.LegacyReviewStart
+	}
+
+	if o.SizeThresholds != nil {
+		definition.Size = *o.SizeThresholds
+	}
+	if len(o.FileTypeWeights) > 0 {
+		weights := make(map[string]float64, len(definition.Complexity.FileTypeWeights)+len(o.FileTypeWeights))
+		for ext, weight := range definition.Complexity.FileTypeWeights {
+			weights[ext] = weight
+		}
+		for ext, weight := range o.FileTypeWeights {
+			weights[strings.ToLower(ext)] = weight
+		}
+		definition.Complexity.FileTypeWeights = weights
+	}
+
+	if o.ReviewRoundIntervalMinutes != nil {
+		definition.Review.MinTimeBetweenRounds = time.Duration(*o.ReviewRoundIntervalMinutes) * time.Minute
+	}
+	if o.HighQualityCommentThreshold != nil {
+		definition.Review.HighQualityCommentThreshold = *o.HighQualityCommentThreshold
+	}
+	if o.LowQualityCommentThreshold != nil {
+		definition.Review.LowQualityCommentThreshold = *o.LowQualityCommentThreshold
+	}
+
+	if o.LongCycleTimeHours != nil {
+		definition.Bottleneck.LongCycleTime = time.Duration(*o.LongCycleTimeHours * float64(time.Hour))
+	}
+	if o.ReviewRoundLimit != nil {
+		definition.Bottleneck.ReviewRounds = *o.ReviewRoundLimit
+	}
+	if o.LargePRLines != nil {
+		definition.Bottleneck.LargePRLines = *o.LargePRLines
+	}
+	return definition
+}
+
+// validateDefinition は上書きを適用した後の定義の妥当性を検証
+func validateDefinition(definition MetricDefinition) error {
+	cycleTime := definition.CycleTime
+	if cycleTime.BusinessStart < 0 || cycleTime.BusinessEnd > 24 || cycleTime.BusinessStart >= cycleTime.BusinessEnd {
+		return NewValidationError("businessStart must be before businessEnd within 0-24", nil)
+	}
+	if err := definition.Size.Validate(); err != nil {
+		return err
+	}
+	for ext, weight := range definition.Complexity.FileTypeWeights {
+		if weight <= 0 {
+			return NewValidationError("file type weight must be positive: "+ext, weight)
+		}
+	}
+	if definition.Review.MinTimeBetweenRounds < 0 {
+		return NewValidationError("reviewRoundIntervalMinutes must not be negative", nil)
+	}
+	if definition.Review.HighQualityCommentThreshold < 0 || definition.Review.HighQualityCommentThreshold > definition.Review.LowQualityCommentThreshold {
+		return NewValidationError("highQualityCommentThreshold must be between 0 and lowQualityCommentThreshold", nil)
+	}
+	if definition.Bottleneck.LongCycleTime <= 0 || definition.Bottleneck.ReviewRounds <= 0 || definition.Bottleneck.LargePRLines <= 0 {
+		return NewValidationError("bottleneck thresholds must be positive", nil)
+	}
+	return nil
+}
+
+// AnalysisSettings は管理APIで変更できるPR分析の設定
+// 全体の上書き（Defaults）にリポジトリごとの上書きを重ねる
+type AnalysisSettings struct {
+	Defaults     DefinitionOverride            `json:"defaults"`
+	Repositories map[string]DefinitionOverride `json:"repositories"`
+}
+
+// DefinitionSource はリポジトリに適用する計算定義を返す
+type DefinitionSource interface {
+	DefinitionFor(repository string) MetricDefinition
+}
+
+// AnalysisSettingsRegistry は環境変数の計算定義に管理APIで変更した設定を重ねて保持する
+// 実行時に API から更新されるため、並行アクセスに対して安全
+type AnalysisSettingsRegistry struct {
+	mu       sync.RWMutex
+	base     MetricDefinition
+	settings AnalysisSettings
+}
+
+// NewAnalysisSettingsRegistry は計算定義と保存済みの設定から新しいレジストリを作成
+func NewAnalysisSettingsRegistry(base MetricDefinition, settings AnalysisSettings) (*AnalysisSettingsRegistry, error) {
+	registry := &AnalysisSettingsRegistry{
+		base:     base.withDefaults(),
+		settings: AnalysisSettings{Repositories: make(map[string]DefinitionOverride)},
+	}
+	if err := registry.UpdateDefaults(settings.Defaults); err != nil {
+		return nil, err
+	}
+	for repository, override := range settings.Repositories {
+		if err := registry.SetRepository(repository, override); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// DefinitionFor はリポジトリに適用する計算定義を返す（空の場合は全体の定義）
+func (r *AnalysisSettingsRegistry) DefinitionFor(repository string) MetricDefinition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	definition := r.settings.Defaults.Apply(r.base)
+	if override, exists := r.settings.Repositories[repository]; exists && repository != "" {
+		definition = override.Apply(definition)
+	}
+	return definition
+}
+
+// Settings は現在の設定の複製を返す
+func (r *AnalysisSettingsRegistry) Settings() AnalysisSettings {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings := AnalysisSettings{
+		Defaults:     r.settings.Defaults,
+		Repositories: make(map[string]DefinitionOverride, len(r.settings.Repositories)),
+	}
+	for repository, override := range r.settings.Repositories {
+		settings.Repositories[repository] = override
+	}
+	return settings
+}
+
+// Repositories は上書き設定のあるリポジトリを名前順で返す
+func (r *AnalysisSettingsRegistry) Repositories() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	repositories := make([]string, 0, len(r.settings.Repositories))
+	for repository := range r.settings.Repositories {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+	return repositories
+}
+
+// UpdateDefaults は全体の上書きを置き換える
+// 既存のリポジトリごとの上書きと組み合わせた定義も検証する
+func (r *AnalysisSettingsRegistry) UpdateDefaults(override DefinitionOverride) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	defaults := override.Apply(r.base)
+	if err := validateDefinition(defaults); err != nil {
+		return err
+	}
+	for _, repositoryOverride := range r.settings.Repositories {
+		if err := validateDefinition(repositoryOverride.Apply(defaults)); err != nil {
+			return err
+		}
+	}
+	r.settings.Defaults = override
+	return nil
+}
+
+// SetRepository はリポジトリの上書きを登録（既にある場合は置き換え）
+func (r *AnalysisSettingsRegistry) SetRepository(repository string, override DefinitionOverride) error {
+	if strings.TrimSpace(repository) == "" {
+		return NewValidationError("repository is required", nil)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateDefinition(override.Apply(r.settings.Defaults.Apply(r.base))); err != nil {
+		return err
+	}
+	r.settings.Repositories[repository] = override
+	return nil
+}
+
+// RemoveRepository はリポジトリの上書きを削除
+func (r *AnalysisSettingsRegistry) RemoveRepository(repository string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.settings.Repositories[repository]; !exists {
+		return false
+	}
+	delete(r.settings.Repositories, repository)
+	return true
+}
+
+// staticDefinition はすべてのリポジトリに同じ定義を使う DefinitionSource
+type staticDefinition MetricDefinition
+
+func (d staticDefinition) DefinitionFor(repository string) MetricDefinition {
+	return MetricDefinition(d)
+}
@@ -0,0 +1,186 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSizeThresholds_Category(t *testing.T) {
+	thresholds := SizeThresholds{XSmall: 10, Small: 20, Medium: 30, Large: 40}
+
+	tests := []struct {
+		lines int
+		want  PRSizeCategory
+	}{
+		{lines: 10, want: PRSizeXSmall},
+		{lines: 11, want: PRSizeSmall},
+		{lines: 30, want: PRSizeMedium},
+		{lines: 40, want: PRSizeLarge},
+		{lines: 41, want: PRSizeXLarge},
+	}
+
+	for _, tt := range tests {
+		if got := thresholds.Category(tt.lines); got != tt.want {
+			t.Errorf("Category(%d) = %v, want %v", tt.lines, got, tt.want)
+		}
+	}
+
+	if got := DefaultSizeThresholds().Category(150); got != PRSizeMedium {
+		t.Errorf("DefaultSizeThresholds().Category(150) = %v, want %v", got, PRSizeMedium)
+	}
+}
+
+func TestAnalysisSettingsRegistry(t *testing.T) {
+	boolPtr := func(value bool) *bool { return &value }
+
+	newRegistry := func(t *testing.T, settings AnalysisSettings) *AnalysisSettingsRegistry {
+		registry, err := NewAnalysisSettingsRegistry(DefaultMetricDefinition(), settings)
+		if err != nil {
+			t.Fatalf("NewAnalysisSettingsRegistry() error = %v", err)
+		}
+		return registry
+	}
+
+	t.Run("上書きがない場合は元の定義と同じバージョンになる", func(t *testing.T) {
+		registry := newRegistry(t, AnalysisSettings{})
+		if got, want := registry.DefinitionFor("org/api").Version(), DefaultMetricDefinition().Version(); got != want {
+			t.Errorf("DefinitionFor().Version() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("全体の上書きにリポジトリの上書きを重ねる", func(t *testing.T) {
+		registry := newRegistry(t, AnalysisSettings{
+			Defaults: DefinitionOverride{UseBusinessHours: boolPtr(true), BusinessEnd: intPtr(17)},
+			Repositories: map[string]DefinitionOverride{
+				"org/api": {
+					BusinessEnd:        intPtr(20),
+					FileTypeWeights:    map[string]float64{".GO": 1.5},
+					ReviewRoundLimit:   intPtr(8),
+					LongCycleTimeHours: func() *float64 { v := 36.0; return &v }(),
+				},
+			},
+		})
+
+		api := registry.DefinitionFor("org/api")
+		if !api.CycleTime.UseBusinessHours || api.CycleTime.BusinessEnd != 20 {
+			t.Errorf("org/api cycle time = %+v, want business hours until 20", api.CycleTime)
+		}
+		if got := api.Complexity.FileTypeWeights[".go"]; got != 1.5 {
+			t.Errorf("org/api .go weight = %v, want 1.5", got)
+		}
+		if got := api.Complexity.FileTypeWeights[".md"]; got != DefaultComplexityConfig().FileTypeWeights[".md"] {
+			t.Errorf("org/api .md weight = %v, want default", got)
+		}
+		if api.Bottleneck.ReviewRounds != 8 || api.Bottleneck.LongCycleTime != 36*time.Hour {
+			t.Errorf("org/api bottleneck = %+v, want 36h / 8 rounds", api.Bottleneck)
+		}
+
+		web := registry.DefinitionFor("org/web")
+		if !web.CycleTime.UseBusinessHours || web.CycleTime.BusinessEnd != 17 {
+			t.Errorf("org/web cycle time = %+v, want business hours until 17", web.CycleTime)
+		}
+		if web.Version() == api.Version() {
+			t.Error("リポジトリの上書きがある場合はバージョンが変わるべき")
+		}
+		if _, changed := DefaultComplexityConfig().FileTypeWeights[".GO"]; changed {
+			t.Error("上書きの適用で元の定義が変更されてはいけない")
+		}
+	})
+
+	t.Run("リポジトリごとにレビュー待ちの起点を旧定義に戻せる", func(t *testing.T) {
+		registry := newRegistry(t, AnalysisSettings{
+			Repositories: map[string]DefinitionOverride{"org/api": {LegacyReviewStart: boolPtr(true)}},
+		})
+
+		if !registry.DefinitionFor("org/api").CycleTime.UseLegacyReviewStart {
+			t.Error("org/api は旧定義を使うべき")
+		}
+		if registry.DefinitionFor("org/web").CycleTime.UseLegacyReviewStart {
+			t.Error("org/web は新しい定義を使うべき")
+		}
+	})
+
+	t.Run("不正な設定は登録しない", func(t *testing.T) {
+		registry := newRegistry(t, AnalysisSettings{})
+
+		invalid := []DefinitionOverride{
+			{BusinessStart: intPtr(18), BusinessEnd: intPtr(9)},
+			{SizeThresholds: &SizeThresholds{XSmall: 100, Small: 50, Medium: 300, Large: 600}},
+			{FileTypeWeights: map[string]float64{".go": 0}},
+			{HighQualityCommentThreshold: intPtr(20)},
+			{ReviewRoundLimit: intPtr(0)},
+		}
+		for _, override := range invalid {
+			if err := registry.SetRepository("org/api", override); err == nil {
+				t.Errorf("SetRepository(%+v) error = nil, want error", override)
+			}
+		}
+		if err := registry.SetRepository(" ", DefinitionOverride{}); err == nil {
+			t.Error("SetRepository() with empty repository error = nil, want error")
+		}
+		if len(registry.Repositories()) != 0 {
+			t.Errorf("Repositories() = %v, want empty", registry.Repositories())
+		}
+	})
+
+	t.Run("リポジトリの設定と矛盾する全体の上書きは登録しない", func(t *testing.T) {
+		registry := newRegistry(t, AnalysisSettings{
+			Repositories: map[string]DefinitionOverride{"org/api": {BusinessStart: intPtr(12)}},
+		})
+
+		if err := registry.UpdateDefaults(DefinitionOverride{BusinessEnd: intPtr(11)}); err == nil {
+			t.Error("UpdateDefaults() error = nil, want error")
+		}
+		if registry.DefinitionFor("").CycleTime.BusinessEnd != DefaultCycleTimeConfig().BusinessEnd {
+			t.Error("失敗した更新は反映されてはいけない")
+		}
+	})
+
+	t.Run("リポジトリの設定を削除すると全体の設定に戻る", func(t *testing.T) {
+		registry := newRegistry(t, AnalysisSettings{
+			Repositories: map[string]DefinitionOverride{"org/api": {ExcludeWeekends: boolPtr(false)}},
+		})
+
+		if !registry.RemoveRepository("org/api") {
+			t.Fatal("RemoveRepository() = false, want true")
+		}
+		if registry.RemoveRepository("org/api") {
+			t.Error("RemoveRepository() for removed repository = true, want false")
+		}
+		if got, want := registry.DefinitionFor("org/api").Version(), registry.DefinitionFor("").Version(); got != want {
+			t.Errorf("DefinitionFor(org/api).Version() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestPRAnalysisService_PerRepositoryDefinition(t *testing.T) {
+	registry, err := NewAnalysisSettingsRegistry(DefaultMetricDefinition(), AnalysisSettings{
+		Repositories: map[string]DefinitionOverride{
+			"org/api": {SizeThresholds: &SizeThresholds{XSmall: 5, Small: 10, Medium: 20, Large: 40}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAnalysisSettingsRegistry() error = %v", err)
+	}
+	service := NewPRAnalysisServiceWithSource(registry)
+
+	if service.DefinitionVersionFor("org/api") == service.DefinitionVersion() {
+		t.Error("上書きのあるリポジトリは全体と異なるバージョンになるべき")
+	}
+
+	metrics := &PRMetrics{PRID: "pr-1", Repository: "org/api", SizeMetrics: PRSizeMetrics{LinesChanged: 30}}
+	recalculated := service.Recalculate(metrics, nil)
+	if recalculated.SizeCategory != PRSizeLarge {
+		t.Errorf("SizeCategory = %v, want %v", recalculated.SizeCategory, PRSizeLarge)
+	}
+	if recalculated.DefinitionVersion != service.DefinitionVersionFor("org/api") {
+		t.Errorf("DefinitionVersion = %q, want %q", recalculated.DefinitionVersion, service.DefinitionVersionFor("org/api"))
+	}
+
+	// 上書きのないリポジトリはデフォルトの閾値を使う
+	metrics.Repository = "org/web"
+	recalculated = service.Recalculate(metrics, nil)
+	if recalculated.SizeCategory != PRSizeXSmall {
+		t.Errorf("SizeCategory = %v, want %v", recalculated.SizeCategory, PRSizeXSmall)
+	}
+}
@@ -0,0 +1,77 @@
+package pull_request
+
+import (
+	"strings"
+)
+
+// BotAuthorType はGraphQLのActor型のうちbotを表す __typename
+const BotAuthorType = "Bot"
+
+// botLoginSuffix はGitHub Appアカウントのログイン名に付くサフィックス
+const botLoginSuffix = "[bot]"
+
+// defaultBotAccounts は既定でbotとして扱うアカウント
+// GraphQLでは "dependabot" のように [bot] サフィックスなしで返る場合がある
+var defaultBotAccounts = []string{
+	"dependabot",
+	"dependabot-preview",
+	"renovate",
+	"renovate-bot",
+	"github-actions",
+	"greenkeeper",
+	"snyk-bot",
+}
+
+// BotDetector はbotアカウントを判定する
+type BotDetector struct {
+	accounts map[string]bool
+}
+
+// NewBotDetector は既定のbotアカウントに additional を加えた判定器を作成
+func NewBotDetector(additional []string) *BotDetector {
+	detector := &BotDetector{
+		accounts: make(map[string]bool),
+	}
+	for _, account := range defaultBotAccounts {
+		detector.accounts[normalizeBotLogin(account)] = true
+	}
+	for _, account := range additional {
+		if normalized := normalizeBotLogin(account); normalized != "" {
+			detector.accounts[normalized] = true
+		}
+	}
+	return detector
+}
+
+// IsBot はログイン名とGraphQLの作者型からbotかどうかを判定
+func (d *BotDetector) IsBot(login string, authorType string) bool {
+	if authorType == BotAuthorType {
+		return true
+	}
+	lower := strings.ToLower(strings.TrimSpace(login))
+	if lower == "" {
+		return false
+	}
+	if strings.HasSuffix(lower, botLoginSuffix) {
+		return true
+	}
+	return d.accounts[normalizeBotLogin(lower)]
+}
+
+// MarkPullRequest はPR作者のbotフラグを設定
+func (d *BotDetector) MarkPullRequest(pr *PullRequest, authorType string) {
+	pr.Author.IsBot = pr.Author.IsBot || d.IsBot(pr.Author.Login, authorType)
+}
+
+// MarkReviewEvents はレビューイベントのActorのbotフラグを設定
+func (d *BotDetector) MarkReviewEvents(events []ReviewEvent) {
+	for i := range events {
+		events[i].IsBot = events[i].IsBot || d.IsBot(events[i].Actor, "")
+	}
+}
+
+// normalizeBotLogin はログイン名を比較用に正規化（小文字化・[bot]除去）
+func normalizeBotLogin(login string) string {
+	normalized := strings.ToLower(strings.TrimSpace(login))
+	return strings.TrimSuffix(normalized, botLoginSuffix)
+}
@@ -0,0 +1,101 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBotDetector_IsBot(t *testing.T) {
+	detector := NewBotDetector([]string{"internal-release-bot", " Deploy-Helper "})
+
+	tests := []struct {
+		name       string
+		login      string
+		authorType string
+		expected   bool
+	}{
+		{"GraphQLのBot型", "some-app", "Bot", true},
+		{"[bot]サフィックス", "my-app[bot]", "User", true},
+		{"[bot]サフィックス（大文字）", "My-App[BOT]", "", true},
+		{"既定リスト dependabot", "dependabot", "", true},
+		{"既定リスト renovate（サフィックス付き）", "renovate[bot]", "", true},
+		{"追加リスト", "internal-release-bot", "User", true},
+		{"追加リスト（空白・大文字を正規化）", "deploy-helper", "", true},
+		{"通常ユーザー", "octocat", "User", false},
+		{"botを含むが別ユーザー", "robotics-dev", "User", false},
+		{"空のログイン", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := detector.IsBot(tt.login, tt.authorType)
+			if result != tt.expected {
+				t.Errorf("IsBot(%q, %q) = %v, want %v", tt.login, tt.authorType, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBotDetector_MarkReviewEvents(t *testing.T) {
+	detector := NewBotDetector(nil)
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	events := []ReviewEvent{
+		{Type: ReviewEventTypeCommented, CreatedAt: baseTime, Actor: "github-actions[bot]"},
+		{Type: ReviewEventTypeApproved, CreatedAt: baseTime.Add(time.Hour), Actor: "reviewer1"},
+		{Type: ReviewEventTypeCommented, CreatedAt: baseTime.Add(2 * time.Hour), Actor: "app", IsBot: true},
+	}
+
+	detector.MarkReviewEvents(events)
+
+	expected := []bool{true, false, true}
+	for i, event := range events {
+		if event.IsBot != expected[i] {
+			t.Errorf("events[%d].IsBot = %v, want %v", i, event.IsBot, expected[i])
+		}
+	}
+}
+
+func TestReviewTimeAnalyzer_CalculateQualityMetrics_ExcludesBotReviewers(t *testing.T) {
+	analyzer := NewReviewTimeAnalyzer()
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	events := []ReviewEvent{
+		{Type: ReviewEventTypeCommented, CreatedAt: baseTime, Actor: "codecov[bot]", IsBot: true},
+		{Type: ReviewEventTypeApproved, CreatedAt: baseTime.Add(time.Hour), Actor: "reviewer1"},
+		{Type: ReviewEventTypeCommented, CreatedAt: baseTime.Add(2 * time.Hour), Actor: "codecov[bot]", IsBot: true},
+	}
+
+	result := analyzer.CalculateQualityMetrics(PullRequest{Additions: 10}, events)
+
+	if result.ReviewerCount != 1 {
+		t.Errorf("ReviewerCount = %d, want 1", result.ReviewerCount)
+	}
+	if len(result.BotReviewersInvolved) != 1 || result.BotReviewersInvolved[0] != "codecov[bot]" {
+		t.Errorf("BotReviewersInvolved = %v, want [codecov[bot]]", result.BotReviewersInvolved)
+	}
+}
+
+func TestPullRequestService_CalculateMetrics_ExcludesBots(t *testing.T) {
+	service := NewPullRequestService()
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	humanReviewed := baseTime.Add(2 * time.Hour)
+	botReviewed := baseTime.Add(100 * time.Hour)
+
+	pullRequests := []PullRequest{
+		{ID: "1", CreatedAt: baseTime, FirstReviewed: &humanReviewed, Author: Author{Login: "octocat"}},
+		{ID: "2", CreatedAt: baseTime, FirstReviewed: &botReviewed, Author: Author{Login: "dependabot[bot]", IsBot: true}},
+	}
+
+	result := service.CalculateMetrics(pullRequests)
+
+	if result.AverageTimeToFirstReview != (2 * time.Hour).Seconds() {
+		t.Errorf("AverageTimeToFirstReview = %v, want %v", result.AverageTimeToFirstReview, (2 * time.Hour).Seconds())
+	}
+	if result.TotalPullRequests != 2 {
+		t.Errorf("TotalPullRequests = %d, want 2", result.TotalPullRequests)
+	}
+	if result.AutomationPullRequests != 1 {
+		t.Errorf("AutomationPullRequests = %d, want 1", result.AutomationPullRequests)
+	}
+}
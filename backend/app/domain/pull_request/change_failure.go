@@ -0,0 +1,227 @@
+package pull_request
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeType は以前の変更の失敗を示すPRの種類
+type ChangeType string
+
+const (
+	ChangeTypeRevert ChangeType = "revert" // 以前のPRを取り消すPR
+	ChangeTypeHotfix ChangeType = "hotfix" // リリースブランチへの緊急修正PR
+)
+
+// ChangeFailureSignals はリバート・ホットフィックスの判定結果と元PRを探すための手がかり
+type ChangeFailureSignals struct {
+	Type              ChangeType `json:"type,omitempty"`
+	Signals           []string   `json:"signals,omitempty"`           // 判定の根拠（title / branch / commit_message / release_branch / label）
+	RevertedTitle     string     `json:"revertedTitle,omitempty"`     // タイトル Revert "..." で引用された元PRのタイトル
+	RevertedPRNumber  int        `json:"revertedPrNumber,omitempty"`  // ブランチ名 revert-<番号>-... の元PR番号
+	ReferencedCommits []string   `json:"referencedCommits,omitempty"` // コミットメッセージで参照された元のコミット
+}
+
+// IsRevert はリバートPRかどうか
+func (s ChangeFailureSignals) IsRevert() bool {
+	return s.Type == ChangeTypeRevert
+}
+
+// IsHotfix はホットフィックスPRかどうか
+func (s ChangeFailureSignals) IsHotfix() bool {
+	return s.Type == ChangeTypeHotfix
+}
+
+// defaultReleaseBranchPatterns は既定でリリースブランチとして扱うブランチ名のパターン
+var defaultReleaseBranchPatterns = []string{"release/*", "release-*", "releases/*"}
+
+var (
+	// GitHub の Revert ボタンや git revert が付けるタイトル
+	revertTitlePattern = regexp.MustCompile(`^Revert "(.+)"$`)
+	// GitHub の Revert ボタンが作るブランチ名（revert-<PR番号>-<元のブランチ名>）
+	revertBranchPattern = regexp.MustCompile(`^revert-(\d+)-`)
+	// git revert のコミットメッセージ
+	revertCommitPattern = regexp.MustCompile(`This reverts commit ([0-9a-f]{7,40})`)
+	// git cherry-pick -x のコミットメッセージ
+	cherryPickCommitPattern = regexp.MustCompile(`cherry picked from commit ([0-9a-f]{7,40})`)
+)
+
+// ChangeFailureDetector はリバート・ホットフィックスのPRを判定する
+type ChangeFailureDetector struct {
+	releaseBranches []string
+}
+
+// NewChangeFailureDetector はリリースブランチのパターン（path.Match 形式）を指定して判定器を作成
+// patterns が空の場合は既定のパターンを使う
+func NewChangeFailureDetector(releaseBranchPatterns []string) *ChangeFailureDetector {
+	patterns := make([]string, 0, len(releaseBranchPatterns))
+	for _, pattern := range releaseBranchPatterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if len(patterns) == 0 {
+		patterns = defaultReleaseBranchPatterns
+	}
+	return &ChangeFailureDetector{releaseBranches: patterns}
+}
+
+// IsReleaseBranch はブランチ名がリリースブランチのパターンに一致するか
+func (d *ChangeFailureDetector) IsReleaseBranch(branch string) bool {
+	for _, pattern := range d.releaseBranches {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Detect はタイトル・ブランチ名・ラベル・コミットメッセージからリバート・ホットフィックスを判定
+// リバートの判定を優先し、ホットフィックスはリリースブランチ向けで hotfix の目印があるものとする
+func (d *ChangeFailureDetector) Detect(pr PullRequest) ChangeFailureSignals {
+	var signals ChangeFailureSignals
+
+	if match := revertTitlePattern.FindStringSubmatch(strings.TrimSpace(pr.Title)); match != nil {
+		signals.RevertedTitle = match[1]
+		signals.Signals = append(signals.Signals, "title")
+	}
+	if match := revertBranchPattern.FindStringSubmatch(pr.HeadRefName); match != nil {
+		signals.RevertedPRNumber, _ = strconv.Atoi(match[1])
+		signals.Signals = append(signals.Signals, "branch")
+	}
+	revertedCommits := findCommitReferences(pr.CommitMessages, revertCommitPattern)
+	if len(revertedCommits) > 0 {
+		signals.ReferencedCommits = revertedCommits
+		signals.Signals = append(signals.Signals, "commit_message")
+	}
+	if len(signals.Signals) > 0 {
+		signals.Type = ChangeTypeRevert
+		return signals
+	}
+
+	if !d.IsReleaseBranch(pr.BaseRefName) {
+		return ChangeFailureSignals{}
+	}
+	hotfixSignals := []string{"release_branch"}
+	if containsHotfix(pr.HeadRefName) {
+		hotfixSignals = append(hotfixSignals, "branch")
+	}
+	if containsHotfix(pr.Title) {
+		hotfixSignals = append(hotfixSignals, "title")
+	}
+	for _, label := range pr.Labels {
+		if containsHotfix(label) {
+			hotfixSignals = append(hotfixSignals, "label")
+			break
+		}
+	}
+	if len(hotfixSignals) == 1 {
+		return ChangeFailureSignals{}
+	}
+	return ChangeFailureSignals{
+		Type:              ChangeTypeHotfix,
+		Signals:           hotfixSignals,
+		ReferencedCommits: findCommitReferences(pr.CommitMessages, cherryPickCommitPattern),
+	}
+}
+
+// containsHotfix は hotfix / hot-fix の目印を含むか
+func containsHotfix(value string) bool {
+	lower := strings.ToLower(value)
+	return strings.Contains(lower, "hotfix") || strings.Contains(lower, "hot-fix")
+}
+
+// findCommitReferences はコミットメッセージから参照されたコミットを重複なく抽出
+func findCommitReferences(messages []string, pattern *regexp.Regexp) []string {
+	var commits []string
+	seen := make(map[string]bool)
+	for _, message := range messages {
+		for _, match := range pattern.FindAllStringSubmatch(message, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				commits = append(commits, match[1])
+			}
+		}
+	}
+	return commits
+}
+
+// FindOriginalPR はリバート・ホットフィックスのPRが参照する元PRを候補から探す（見つからない場合は nil）
+// 候補は同じリポジトリで change の作成より前にマージされたPRに限り、
+// PR番号・コミット・タイトルの順に照合する（タイトルが一致するPRが複数ある場合は最後にマージされたもの）
+func FindOriginalPR(change *PRMetrics, candidates []*PRMetrics) *PRMetrics {
+	signals := change.ChangeFailure
+	if signals.Type == "" {
+		return nil
+	}
+
+	var byCommit, byTitle *PRMetrics
+	for _, candidate := range candidates {
+		if candidate.PRID == change.PRID || candidate.Repository != change.Repository ||
+			candidate.MergedAt == nil || !candidate.MergedAt.Before(change.CreatedAt) {
+			continue
+		}
+		if signals.RevertedPRNumber != 0 && candidate.PRNumber == signals.RevertedPRNumber {
+			return candidate
+		}
+		if byCommit == nil && matchesCommit(candidate.MergeCommitSHA, signals.ReferencedCommits) {
+			byCommit = candidate
+		}
+		if signals.RevertedTitle != "" && candidate.Title == signals.RevertedTitle &&
+			(byTitle == nil || candidate.MergedAt.After(*byTitle.MergedAt)) {
+			byTitle = candidate
+		}
+	}
+	if byCommit != nil {
+		return byCommit
+	}
+	return byTitle
+}
+
+// matchesCommit はマージコミットが参照されたコミット（短縮形を含む）のいずれかと一致するか
+func matchesCommit(mergeCommit string, references []string) bool {
+	if mergeCommit == "" {
+		return false
+	}
+	for _, reference := range references {
+		if strings.HasPrefix(mergeCommit, reference) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeFailureLink はリバート・ホットフィックスのPRと元PRの対応
+type ChangeFailureLink struct {
+	Change   *PRMetrics
+	Original *PRMetrics // 元PRが見つからない場合は nil
+}
+
+// TimeToRevert は元PRのマージからリバート・ホットフィックスのマージまでの時間
+// 元PRが見つからない場合や、リバート・ホットフィックスが未マージの場合は nil
+func (l ChangeFailureLink) TimeToRevert() *time.Duration {
+	if l.Original == nil || l.Original.MergedAt == nil || l.Change.MergedAt == nil {
+		return nil
+	}
+	duration := l.Change.MergedAt.Sub(*l.Original.MergedAt)
+	return &duration
+}
+
+// LinkChangeFailures は changes に含まれるリバート・ホットフィックスのPRを candidates の元PRと対応付ける
+// 判定のないPRは結果に含めない
+func LinkChangeFailures(changes, candidates []*PRMetrics) []ChangeFailureLink {
+	var links []ChangeFailureLink
+	for _, change := range changes {
+		if change.ChangeFailure.Type == "" {
+			continue
+		}
+		links = append(links, ChangeFailureLink{
+			Change:   change,
+			Original: FindOriginalPR(change, candidates),
+		})
+	}
+	return links
+}
@@ -0,0 +1,178 @@
+package pull_request
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChangeFailureDetector_Detect(t *testing.T) {
+	detector := NewChangeFailureDetector(nil)
+
+	tests := []struct {
+		name     string
+		pr       PullRequest
+		expected ChangeFailureSignals
+	}{
+		{
+			name: "GitHubのRevertボタンで作られたPR",
+			pr:   PullRequest{Title: `Revert "Add login form"`, HeadRefName: "revert-42-feature/login", BaseRefName: "main"},
+			expected: ChangeFailureSignals{
+				Type:             ChangeTypeRevert,
+				Signals:          []string{"title", "branch"},
+				RevertedTitle:    "Add login form",
+				RevertedPRNumber: 42,
+			},
+		},
+		{
+			name: "git revert のコミットメッセージ",
+			pr: PullRequest{
+				Title:          "Roll back the cache change",
+				HeadRefName:    "rollback-cache",
+				CommitMessages: []string{"Revert \"Cache users\"\n\nThis reverts commit 0a1b2c3d4e5f60718293a4b5c6d7e8f901234567."},
+			},
+			expected: ChangeFailureSignals{
+				Type:              ChangeTypeRevert,
+				Signals:           []string{"commit_message"},
+				ReferencedCommits: []string{"0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"},
+			},
+		},
+		{
+			name: "リリースブランチ向けのホットフィックス",
+			pr: PullRequest{
+				Title:          "Fix crash on startup",
+				HeadRefName:    "hotfix/startup-crash",
+				BaseRefName:    "release/1.2",
+				Labels:         []string{"HotFix"},
+				CommitMessages: []string{"Fix crash\n\n(cherry picked from commit abc1234)"},
+			},
+			expected: ChangeFailureSignals{
+				Type:              ChangeTypeHotfix,
+				Signals:           []string{"release_branch", "branch", "label"},
+				ReferencedCommits: []string{"abc1234"},
+			},
+		},
+		{
+			name:     "目印のないリリースブランチ向けPRは対象外",
+			pr:       PullRequest{Title: "Bump version", HeadRefName: "bump-1.2.1", BaseRefName: "release/1.2"},
+			expected: ChangeFailureSignals{},
+		},
+		{
+			name:     "main向けのhotfixブランチは対象外",
+			pr:       PullRequest{Title: "Quick fix", HeadRefName: "hotfix/typo", BaseRefName: "main"},
+			expected: ChangeFailureSignals{},
+		},
+		{
+			name:     "タイトルの途中の Revert は対象外",
+			pr:       PullRequest{Title: `Partially Revert "Add login form"`, HeadRefName: "feature/login-fix"},
+			expected: ChangeFailureSignals{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := detector.Detect(tt.pr)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Detect() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChangeFailureDetector_ReleaseBranchPatterns(t *testing.T) {
+	detector := NewChangeFailureDetector([]string{" stable-* ", ""})
+
+	if !detector.IsReleaseBranch("stable-2024") {
+		t.Error("stable-2024 should match the configured pattern")
+	}
+	if detector.IsReleaseBranch("release/1.0") {
+		t.Error("default patterns should not apply when patterns are configured")
+	}
+}
+
+func TestFindOriginalPR(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	merged := func(id string, number int, title string, mergedAt time.Time) *PRMetrics {
+		return &PRMetrics{
+			PRID:           id,
+			PRNumber:       number,
+			Title:          title,
+			Repository:     "org/api",
+			CreatedAt:      mergedAt.Add(-time.Hour),
+			MergedAt:       &mergedAt,
+			MergeCommitSHA: id + "0000000000",
+		}
+	}
+
+	older := merged("pr-a", 10, "Add login form", baseTime)
+	newer := merged("pr-b", 11, "Add login form", baseTime.Add(24*time.Hour))
+	other := merged("pr-c", 12, "Cache users", baseTime.Add(48*time.Hour))
+	otherRepository := merged("pr-d", 10, "Add login form", baseTime)
+	otherRepository.Repository = "org/web"
+	candidates := []*PRMetrics{older, newer, other, otherRepository}
+
+	change := func(signals ChangeFailureSignals) *PRMetrics {
+		return &PRMetrics{PRID: "revert", Repository: "org/api", CreatedAt: baseTime.Add(72 * time.Hour), ChangeFailure: signals}
+	}
+
+	tests := []struct {
+		name     string
+		change   *PRMetrics
+		expected *PRMetrics
+	}{
+		{"PR番号で特定", change(ChangeFailureSignals{Type: ChangeTypeRevert, RevertedPRNumber: 10, RevertedTitle: "Cache users"}), older},
+		{"コミットで特定", change(ChangeFailureSignals{Type: ChangeTypeRevert, ReferencedCommits: []string{"pr-c00"}, RevertedTitle: "Add login form"}), other},
+		{"同じタイトルは最後にマージされたPR", change(ChangeFailureSignals{Type: ChangeTypeRevert, RevertedTitle: "Add login form"}), newer},
+		{"判定のないPR", change(ChangeFailureSignals{RevertedTitle: "Add login form"}), nil},
+		{"見つからない", change(ChangeFailureSignals{Type: ChangeTypeRevert, RevertedTitle: "Unknown"}), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FindOriginalPR(tt.change, candidates)
+			if result != tt.expected {
+				t.Errorf("FindOriginalPR() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("リバートの作成後にマージされたPRは候補にしない", func(t *testing.T) {
+		revert := change(ChangeFailureSignals{Type: ChangeTypeRevert, RevertedTitle: "Cache users"})
+		revert.CreatedAt = baseTime.Add(36 * time.Hour)
+		if result := FindOriginalPR(revert, candidates); result != nil {
+			t.Errorf("FindOriginalPR() = %v, want nil", result)
+		}
+	})
+}
+
+func TestChangeFailureLink_TimeToRevert(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	originalMerged := baseTime
+	revertMerged := baseTime.Add(5 * time.Hour)
+
+	original := &PRMetrics{PRID: "pr-1", Title: "Add login form", Repository: "org/api", CreatedAt: baseTime.Add(-time.Hour), MergedAt: &originalMerged}
+	revert := &PRMetrics{
+		PRID:          "pr-2",
+		Repository:    "org/api",
+		CreatedAt:     baseTime.Add(4 * time.Hour),
+		MergedAt:      &revertMerged,
+		ChangeFailure: ChangeFailureSignals{Type: ChangeTypeRevert, RevertedTitle: "Add login form"},
+	}
+	unmergedRevert := &PRMetrics{
+		PRID:          "pr-3",
+		Repository:    "org/api",
+		CreatedAt:     baseTime.Add(6 * time.Hour),
+		ChangeFailure: ChangeFailureSignals{Type: ChangeTypeRevert, RevertedTitle: "Add login form"},
+	}
+
+	links := LinkChangeFailures([]*PRMetrics{original, revert, unmergedRevert}, []*PRMetrics{original, revert, unmergedRevert})
+	if len(links) != 2 {
+		t.Fatalf("len(links) = %d, want 2", len(links))
+	}
+	if links[0].Original != original || links[0].TimeToRevert() == nil || *links[0].TimeToRevert() != 5*time.Hour {
+		t.Errorf("merged revert: original = %v, timeToRevert = %v", links[0].Original, links[0].TimeToRevert())
+	}
+	if links[1].Original != original || links[1].TimeToRevert() != nil {
+		t.Errorf("unmerged revert: original = %v, timeToRevert = %v", links[1].Original, links[1].TimeToRevert())
+	}
+}
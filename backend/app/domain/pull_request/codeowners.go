@@ -0,0 +1,261 @@
+package pull_request
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CodeOwnersLocations はGitHubがCODEOWNERSを探す場所（先に見つかったものを使う）
+var CodeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwnersRule はCODEOWNERSの1行（パターンと所有者）
+// 所有者のない行は、それより前の行の所有者を打ち消す（所有者なしとする）
+type CodeOwnersRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"` // "@user"、"@org/team" またはメールアドレス
+	Line    int      `json:"line"`
+}
+
+// CodeOwners はファイルパスの所有者を判定するCODEOWNERSのルール一覧
+type CodeOwners struct {
+	rules    []CodeOwnersRule
+	patterns []*regexp.Regexp
+}
+
+// NewCodeOwners はルールからCODEOWNERSを作成
+func NewCodeOwners(rules []CodeOwnersRule) (*CodeOwners, error) {
+	codeOwners := &CodeOwners{}
+	for _, rule := range rules {
+		pattern, err := compileCodeOwnersPattern(rule.Pattern)
+		if err != nil {
+			return nil, NewValidationError(fmt.Sprintf("line %d: %v", rule.Line, err), rule.Pattern)
+		}
+		codeOwners.rules = append(codeOwners.rules, rule)
+		codeOwners.patterns = append(codeOwners.patterns, pattern)
+	}
+	return codeOwners, nil
+}
+
+// ParseCodeOwners はGitHubのCODEOWNERS形式を読み込む
+// "#" 以降はコメントとし、"\#" で始まるパターンは "#" から始まるファイル名として扱う
+func ParseCodeOwners(r io.Reader) (*CodeOwners, error) {
+	var rules []CodeOwnersRule
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := stripCodeOwnersComment(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, CodeOwnersRule{
+			Pattern: strings.ReplaceAll(fields[0], `\#`, "#"),
+			Owners:  fields[1:],
+			Line:    lineNumber,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewCodeOwners(rules)
+}
+
+// Rules はルール一覧を返す
+func (c *CodeOwners) Rules() []CodeOwnersRule {
+	return c.rules
+}
+
+// Owners はファイルパスの所有者を返す（後の行ほど優先し、所有者がいない場合は nil）
+func (c *CodeOwners) Owners(filePath string) []string {
+	filePath = strings.TrimPrefix(filePath, "/")
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if !c.patterns[i].MatchString(filePath) {
+			continue
+		}
+		if len(c.rules[i].Owners) == 0 {
+			return nil
+		}
+		return c.rules[i].Owners
+	}
+	return nil
+}
+
+// PROwnership はPRの変更ファイルの所有者
+type PROwnership struct {
+	Owners       []string            // 変更ファイルの所有者（重複なし、名前順）
+	OwnedFiles   map[string][]string // 所有者ごとの変更ファイル
+	UnownedFiles []string            // 所有者のいない変更ファイル
+}
+
+// Analyze はPRの変更ファイルごとに所有者を判定する
+func (c *CodeOwners) Analyze(pr *PRMetrics) PROwnership {
+	ownership := PROwnership{OwnedFiles: make(map[string][]string)}
+	for _, file := range pr.SizeMetrics.FileChanges {
+		owners := c.Owners(file.FileName)
+		if len(owners) == 0 {
+			ownership.UnownedFiles = append(ownership.UnownedFiles, file.FileName)
+			continue
+		}
+		for _, owner := range owners {
+			if _, exists := ownership.OwnedFiles[owner]; !exists {
+				ownership.Owners = append(ownership.Owners, owner)
+			}
+			ownership.OwnedFiles[owner] = append(ownership.OwnedFiles[owner], file.FileName)
+		}
+	}
+	sort.Strings(ownership.Owners)
+	return ownership
+}
+
+// stripCodeOwnersComment は行のコメント（エスケープされていない "#" 以降）を除く
+func stripCodeOwnersComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// compileCodeOwnersPattern はgitignore形式のパターンを正規表現に変換する
+// "/" で始まるか途中に "/" を含むパターンはリポジトリ直下から、それ以外は任意の階層で一致する
+// ディレクトリに一致したパターンは配下の全ファイルに一致するが、最後の要素がワイルドカードの場合（"docs/*"）は直下のファイルのみ
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("unsupported pattern syntax: %s", pattern)
+	}
+
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+	if trimmed == "" || trimmed == "**" || trimmed == "*" && !anchored {
+		// "*" と "/" はすべてのファイルに一致する
+		return regexp.MustCompile(`^.*$`), nil
+	}
+
+	var expression strings.Builder
+	expression.WriteString("^")
+	if !anchored {
+		expression.WriteString("(?:.*/)?")
+	}
+
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "**" {
+			if last {
+				expression.WriteString(".*")
+			} else {
+				expression.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+		for _, r := range segment {
+			switch r {
+			case '*':
+				expression.WriteString("[^/]*")
+			case '?':
+				expression.WriteString("[^/]")
+			default:
+				expression.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		if !last {
+			expression.WriteString("/")
+		}
+	}
+
+	lastSegment := segments[len(segments)-1]
+	switch {
+	case lastSegment == "**":
+		expression.WriteString("$")
+	case directoryOnly:
+		expression.WriteString("/.*$")
+	case strings.ContainsAny(lastSegment, "*?"):
+		expression.WriteString("$")
+	default:
+		expression.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expression.String())
+}
+
+// CodeOwnersSource はリポジトリのCODEOWNERSを提供する（GitHubのリポジトリ等）
+// CODEOWNERSがないリポジトリでは nil を返す
+type CodeOwnersSource interface {
+	FetchCodeOwners(ctx context.Context, repository string) (*CodeOwners, error)
+}
+
+// CodeOwnersRegistry はリポジトリごとのCODEOWNERS
+// ファイルで指定したリポジトリはそれを使い、それ以外は取得元から取得して一定時間キャッシュする
+// 並行アクセスに対して安全
+type CodeOwnersRegistry struct {
+	mu      sync.Mutex
+	files   map[string]*CodeOwners
+	source  CodeOwnersSource
+	ttl     time.Duration
+	fetched map[string]fetchedCodeOwners
+	now     func() time.Time
+}
+
+type fetchedCodeOwners struct {
+	owners    *CodeOwners
+	fetchedAt time.Time
+}
+
+// NewCodeOwnersRegistry はリポジトリごとのCODEOWNERSのレジストリを作成
+// source が nil の場合はファイルで指定したリポジトリのみを扱う
+func NewCodeOwnersRegistry(files map[string]*CodeOwners, source CodeOwnersSource, ttl time.Duration) *CodeOwnersRegistry {
+	copied := make(map[string]*CodeOwners, len(files))
+	for repository, owners := range files {
+		copied[repository] = owners
+	}
+	return &CodeOwnersRegistry{
+		files:   copied,
+		source:  source,
+		ttl:     ttl,
+		fetched: make(map[string]fetchedCodeOwners),
+		now:     time.Now,
+	}
+}
+
+// Lookup はリポジトリのCODEOWNERSを返す（CODEOWNERSがない場合は nil）
+func (r *CodeOwnersRegistry) Lookup(ctx context.Context, repository string) (*CodeOwners, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	if owners, exists := r.files[repository]; exists {
+		r.mu.Unlock()
+		return owners, nil
+	}
+	if cached, exists := r.fetched[repository]; exists && r.now().Sub(cached.fetchedAt) < r.ttl {
+		r.mu.Unlock()
+		return cached.owners, nil
+	}
+	r.mu.Unlock()
+
+	if r.source == nil {
+		return nil, nil
+	}
+	owners, err := r.source.FetchCodeOwners(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.fetched[repository] = fetchedCodeOwners{owners: owners, fetchedAt: r.now()}
+	r.mu.Unlock()
+	return owners, nil
+}
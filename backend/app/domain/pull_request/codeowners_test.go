@@ -0,0 +1,139 @@
+package pull_request
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	content := `# 全体の既定の所有者
+*                   @org/core
+
+# フロントエンド
+*.ts                @org/frontend
+/docs/              @alice docs@example.com
+apps/               @bob
+/build/logs/        @carol
+docs/*.md           @dave
+**/migrations       @org/dba
+/vendor/**          @erin
+/api/generated/     
+\#notes.txt         @frank   # インラインのコメント
+`
+	codeOwners, err := ParseCodeOwners(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ParseCodeOwners() error = %v", err)
+	}
+	if len(codeOwners.Rules()) != 10 {
+		t.Fatalf("len(Rules()) = %d, want 10", len(codeOwners.Rules()))
+	}
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"main.go", []string{"@org/core"}},
+		{"web/src/index.ts", []string{"@org/frontend"}},
+		{"docs/guide/setup.txt", []string{"@alice", "docs@example.com"}},
+		{"docs/README.md", []string{"@dave"}},
+		{"docs/guide/README.md", []string{"@alice", "docs@example.com"}}, // docs/*.md は直下のみ
+		{"src/apps/main.go", []string{"@bob"}},
+		{"build/logs/today.log", []string{"@carol"}},
+		{"src/build/logs/today.log", []string{"@org/core"}},
+		{"db/migrations/001.sql", []string{"@org/dba"}},
+		{"vendor/lib/a.go", []string{"@erin"}},
+		{"api/generated/client.go", nil},
+		{"#notes.txt", []string{"@frank"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if owners := codeOwners.Owners(tt.path); !reflect.DeepEqual(owners, tt.expected) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.path, owners, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCodeOwners_UnsupportedPattern(t *testing.T) {
+	if _, err := ParseCodeOwners(strings.NewReader("!keep.txt @alice\n")); err == nil {
+		t.Error("negation patterns should be rejected")
+	}
+}
+
+func TestCodeOwners_Analyze(t *testing.T) {
+	codeOwners, err := NewCodeOwners([]CodeOwnersRule{
+		{Pattern: "/api/", Owners: []string{"@org/backend", "@alice"}, Line: 1},
+		{Pattern: "*.md", Owners: []string{"@alice"}, Line: 2},
+	})
+	if err != nil {
+		t.Fatalf("NewCodeOwners() error = %v", err)
+	}
+
+	pr := &PRMetrics{SizeMetrics: PRSizeMetrics{FileChanges: []FileChangeMetrics{
+		{FileName: "api/handler.go"},
+		{FileName: "README.md"},
+		{FileName: "scripts/build.sh"},
+	}}}
+	ownership := codeOwners.Analyze(pr)
+
+	if !reflect.DeepEqual(ownership.Owners, []string{"@alice", "@org/backend"}) {
+		t.Errorf("Owners = %v", ownership.Owners)
+	}
+	if !reflect.DeepEqual(ownership.OwnedFiles["@alice"], []string{"api/handler.go", "README.md"}) {
+		t.Errorf("OwnedFiles[@alice] = %v", ownership.OwnedFiles["@alice"])
+	}
+	if !reflect.DeepEqual(ownership.UnownedFiles, []string{"scripts/build.sh"}) {
+		t.Errorf("UnownedFiles = %v", ownership.UnownedFiles)
+	}
+}
+
+// countingCodeOwnersSource は取得回数を数えるテスト用の取得元
+type countingCodeOwnersSource struct {
+	owners *CodeOwners
+	calls  int
+}
+
+func (s *countingCodeOwnersSource) FetchCodeOwners(ctx context.Context, repository string) (*CodeOwners, error) {
+	s.calls++
+	if repository == "org/none" {
+		return nil, nil
+	}
+	return s.owners, nil
+}
+
+func TestCodeOwnersRegistry_Lookup(t *testing.T) {
+	local, _ := NewCodeOwners([]CodeOwnersRule{{Pattern: "*", Owners: []string{"@local"}, Line: 1}})
+	remote, _ := NewCodeOwners([]CodeOwnersRule{{Pattern: "*", Owners: []string{"@remote"}, Line: 1}})
+	source := &countingCodeOwnersSource{owners: remote}
+
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	registry := NewCodeOwnersRegistry(map[string]*CodeOwners{"org/api": local}, source, time.Hour)
+	registry.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if owners, _ := registry.Lookup(ctx, "org/api"); owners != local || source.calls != 0 {
+		t.Errorf("local file should be used without fetching (calls = %d)", source.calls)
+	}
+	registry.Lookup(ctx, "org/web")
+	registry.Lookup(ctx, "org/none")
+	if owners, _ := registry.Lookup(ctx, "org/web"); owners != remote || source.calls != 2 {
+		t.Errorf("fetched CODEOWNERS should be cached (calls = %d)", source.calls)
+	}
+	if owners, _ := registry.Lookup(ctx, "org/none"); owners != nil || source.calls != 2 {
+		t.Errorf("missing CODEOWNERS should be cached as nil (calls = %d)", source.calls)
+	}
+
+	now = now.Add(2 * time.Hour)
+	registry.Lookup(ctx, "org/web")
+	if source.calls != 3 {
+		t.Errorf("expired cache should be refetched (calls = %d)", source.calls)
+	}
+
+	var missing *CodeOwnersRegistry
+	if owners, err := missing.Lookup(ctx, "org/api"); owners != nil || err != nil {
+		t.Errorf("nil registry Lookup() = %v, %v", owners, err)
+	}
+}
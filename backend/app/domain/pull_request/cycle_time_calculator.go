@@ -2,12 +2,16 @@ package pull_request
 
 import (
 	"sort"
+	"sync"
 	"time"
 )
 
 // CycleTimeCalculator はサイクルタイムを計算するサービス
 type CycleTimeCalculator struct {
 	config CycleTimeConfig
+	
+	// 勤務時間の上書き設定で指定されたタイムゾーンのキャッシュ
+	locations sync.Map
 }
 
 // CycleTimeConfig はサイクルタイム計算の設定
@@ -21,8 +25,21 @@ type CycleTimeConfig struct {
 	ExcludeWeekends  bool
 	ExcludeHolidays  bool
 	
+	// 休日カレンダー（ExcludeHolidays の場合に使う）
+	// HolidayCalendar は既定のカレンダー名で、空の場合は組み込みの日本の祝日を使う
+	HolidayCalendar  string
+	HolidayCalendars map[string]*HolidayCalendar // 名前で参照できるカレンダー（組み込みの日本の祝日は常に参照できる）
+	
+	// チーム・開発者ごとの勤務時間（nil の場合は全員に上の設定を使う）
+	// 実行時に変更されるため定義バージョンには含めない
+	WorkingHours WorkingHoursResolver
+	
 	// タイムゾーン
 	Timezone *time.Location
+	
+	// レビュー待ちの起点をPR作成時刻とする旧定義を使うか
+	// false の場合は最後に ready_for_review になった時刻を起点とする
+	UseLegacyReviewStart bool
 }
 
 // NewCycleTimeCalculator は新しいサイクルタイム計算機を作成
@@ -32,6 +49,38 @@ func NewCycleTimeCalculator() *CycleTimeCalculator {
 	}
 }
 
+// NewCycleTimeCalculatorWithConfig は設定を指定してサイクルタイム計算機を作成
+func NewCycleTimeCalculatorWithConfig(config CycleTimeConfig) *CycleTimeCalculator {
+	if config.Timezone == nil {
+		config.Timezone = getDefaultCycleTimeConfig().Timezone
+	}
+	return &CycleTimeCalculator{
+		config: config,
+	}
+}
+
+// DefaultCycleTimeConfig はデフォルトの設定を返す
+func DefaultCycleTimeConfig() CycleTimeConfig {
+	return getDefaultCycleTimeConfig()
+}
+
+// LookupHolidayCalendar は名前から休日カレンダーを返す（空の場合は既定のカレンダー）
+func (c CycleTimeConfig) LookupHolidayCalendar(name string) (*HolidayCalendar, bool) {
+	if name == "" {
+		name = c.HolidayCalendar
+	}
+	if name == "" {
+		name = JapaneseHolidayCalendarName
+	}
+	if calendar, exists := c.HolidayCalendars[name]; exists {
+		return calendar, true
+	}
+	if name == JapaneseHolidayCalendarName {
+		return JapaneseHolidayCalendar(), true
+	}
+	return nil, false
+}
+
 // getDefaultCycleTimeConfig はデフォルトの設定を返す
 func getDefaultCycleTimeConfig() CycleTimeConfig {
 	loc, _ := time.LoadLocation("Asia/Tokyo")
@@ -42,6 +91,7 @@ func getDefaultCycleTimeConfig() CycleTimeConfig {
 		ExcludeWeekends:  false,
 		ExcludeHolidays:  false,
 		Timezone:        loc,
+		UseLegacyReviewStart: false,
 	}
 }
 
@@ -63,33 +113,74 @@ func (calc *CycleTimeCalculator) CalculateTimeMetrics(pr PullRequest, reviewEven
 		return sortedEvents[i].CreatedAt.Before(sortedEvents[j].CreatedAt)
 	})
 	
+	// ドラフト期間を計算
+	timeMetrics.DraftIntervals = ExtractDraftIntervals(pr, sortedEvents)
+	timeMetrics.TimeInDraft = calc.calculateTimeInDraft(pr, timeMetrics.DraftIntervals)
+	timeMetrics.ReadyForReviewAt = LastReadyForReviewAt(sortedEvents)
+	
+	// レビュー待ち系メトリクスの起点
+	reviewStart := calc.reviewStartTime(pr, sortedEvents)
+	
 	// 各段階の時間を計算
-	timeMetrics.TimeToFirstReview = calc.calculateTimeToFirstReview(pr, sortedEvents)
-	timeMetrics.TimeToApproval = calc.calculateTimeToApproval(pr, sortedEvents)
+	timeMetrics.TimeToFirstReview = calc.calculateTimeToFirstReview(pr, reviewStart, sortedEvents)
+	timeMetrics.TimeToApproval = calc.calculateTimeToApproval(pr, reviewStart, sortedEvents)
 	timeMetrics.TimeToMerge = calc.calculateTimeToMerge(pr, sortedEvents)
 	timeMetrics.TotalCycleTime = calc.calculateTotalCycleTime(pr)
-	timeMetrics.ReviewWaitTime = calc.calculateReviewWaitTime(pr, sortedEvents)
+	timeMetrics.ReviewWaitTime = calc.calculateReviewWaitTime(reviewStart, sortedEvents)
 	timeMetrics.ReviewActiveTime = calc.calculateReviewActiveTime(sortedEvents)
 	timeMetrics.FirstCommitToMerge = calc.calculateFirstCommitToMerge(pr)
 	
 	return timeMetrics
 }
 
+// reviewStartTime はレビュー待ち時間の起点を返す
+// ドラフトを経由したPRは最後に ready_for_review になった時刻から計測する
+func (calc *CycleTimeCalculator) reviewStartTime(pr PullRequest, events []ReviewEvent) time.Time {
+	if calc.config.UseLegacyReviewStart {
+		return pr.CreatedAt
+	}
+	if readyAt := LastReadyForReviewAt(events); readyAt != nil && readyAt.After(pr.CreatedAt) {
+		return *readyAt
+	}
+	return pr.CreatedAt
+}
+
+// calculateTimeInDraft はドラフト状態の合計時間を計算
+func (calc *CycleTimeCalculator) calculateTimeInDraft(pr PullRequest, intervals []DraftInterval) *time.Duration {
+	if len(intervals) == 0 {
+		return nil
+	}
+	
+	total := time.Duration(0)
+	for _, interval := range intervals {
+		// 未終了かつ未マージのドラフトは計上しない
+		if interval.End == nil {
+			continue
+		}
+		total += calc.calculateDuration(pr.Author.Login, interval.Start, *interval.End)
+	}
+	
+	return &total
+}
+
 // calculateTimeToFirstReview は初回レビューまでの時間を計算
-func (calc *CycleTimeCalculator) calculateTimeToFirstReview(pr PullRequest, events []ReviewEvent) *time.Duration {
-	// 最初のレビューイベントを探す
+func (calc *CycleTimeCalculator) calculateTimeToFirstReview(pr PullRequest, reviewStart time.Time, events []ReviewEvent) *time.Duration {
+	// 起点以降の最初のレビューイベントを探す
 	for _, event := range events {
+		if event.CreatedAt.Before(reviewStart) {
+			continue
+		}
 		if event.Type == ReviewEventTypeCommented || 
 		   event.Type == ReviewEventTypeApproved || 
 		   event.Type == ReviewEventTypeChangesRequested {
-			duration := calc.calculateDuration(pr.CreatedAt, event.CreatedAt)
+			duration := calc.calculateDuration(reviewerOf(event), reviewStart, event.CreatedAt)
 			return &duration
 		}
 	}
 	
-	// イベントがない場合は既存のFirstReviewedを使用
-	if pr.FirstReviewed != nil {
-		duration := calc.calculateDuration(pr.CreatedAt, *pr.FirstReviewed)
+	// イベントがない場合は既存のFirstReviewedを使用（レビュアーが不明なため既定の勤務時間で計算）
+	if pr.FirstReviewed != nil && !pr.FirstReviewed.Before(reviewStart) {
+		duration := calc.calculateDuration("", reviewStart, *pr.FirstReviewed)
 		return &duration
 	}
 	
@@ -97,18 +188,21 @@ func (calc *CycleTimeCalculator) calculateTimeToFirstReview(pr PullRequest, even
 }
 
 // calculateTimeToApproval は承認までの時間を計算
-func (calc *CycleTimeCalculator) calculateTimeToApproval(pr PullRequest, events []ReviewEvent) *time.Duration {
-	// 最初の承認イベントを探す
+func (calc *CycleTimeCalculator) calculateTimeToApproval(pr PullRequest, reviewStart time.Time, events []ReviewEvent) *time.Duration {
+	// 起点以降の最初の承認イベントを探す
 	for _, event := range events {
+		if event.CreatedAt.Before(reviewStart) {
+			continue
+		}
 		if event.Type == ReviewEventTypeApproved {
-			duration := calc.calculateDuration(pr.CreatedAt, event.CreatedAt)
+			duration := calc.calculateDuration(reviewerOf(event), reviewStart, event.CreatedAt)
 			return &duration
 		}
 	}
 	
-	// イベントがない場合は既存のLastApprovedを使用
-	if pr.LastApproved != nil {
-		duration := calc.calculateDuration(pr.CreatedAt, *pr.LastApproved)
+	// イベントがない場合は既存のLastApprovedを使用（承認者が不明なため既定の勤務時間で計算）
+	if pr.LastApproved != nil && !pr.LastApproved.Before(reviewStart) {
+		duration := calc.calculateDuration("", reviewStart, *pr.LastApproved)
 		return &duration
 	}
 	
@@ -131,13 +225,13 @@ func (calc *CycleTimeCalculator) calculateTimeToMerge(pr PullRequest, events []R
 	}
 	
 	if lastApproval != nil {
-		duration := calc.calculateDuration(*lastApproval, *pr.MergedAt)
+		duration := calc.calculateDuration(pr.Author.Login, *lastApproval, *pr.MergedAt)
 		return &duration
 	}
 	
 	// イベントがない場合は既存のLastApprovedを使用
 	if pr.LastApproved != nil {
-		duration := calc.calculateDuration(*pr.LastApproved, *pr.MergedAt)
+		duration := calc.calculateDuration(pr.Author.Login, *pr.LastApproved, *pr.MergedAt)
 		return &duration
 	}
 	
@@ -150,27 +244,32 @@ func (calc *CycleTimeCalculator) calculateTotalCycleTime(pr PullRequest) *time.D
 		return nil
 	}
 	
-	duration := calc.calculateDuration(pr.CreatedAt, *pr.MergedAt)
+	duration := calc.calculateDuration(pr.Author.Login, pr.CreatedAt, *pr.MergedAt)
 	return &duration
 }
 
 // calculateReviewWaitTime はレビュー待ち時間を計算
-func (calc *CycleTimeCalculator) calculateReviewWaitTime(pr PullRequest, events []ReviewEvent) *time.Duration {
+func (calc *CycleTimeCalculator) calculateReviewWaitTime(reviewStart time.Time, events []ReviewEvent) *time.Duration {
 	if len(events) == 0 {
 		return nil
 	}
 	
 	totalWaitTime := time.Duration(0)
-	lastEventTime := pr.CreatedAt
+	lastEventTime := reviewStart
 	
 	for _, event := range events {
+		// 起点より前（ドラフト中）のイベントは対象外
+		if event.CreatedAt.Before(reviewStart) {
+			continue
+		}
+
 		// レビュー要求からレビュー実施までの時間
 		if event.Type == ReviewEventTypeRequested {
 			lastEventTime = event.CreatedAt
 		} else if event.Type == ReviewEventTypeCommented || 
 				  event.Type == ReviewEventTypeApproved || 
 				  event.Type == ReviewEventTypeChangesRequested {
-			waitTime := calc.calculateDuration(lastEventTime, event.CreatedAt)
+			waitTime := calc.calculateDuration(reviewerOf(event), lastEventTime, event.CreatedAt)
 			totalWaitTime += waitTime
 			lastEventTime = event.CreatedAt
 		}
@@ -222,68 +321,96 @@ func (calc *CycleTimeCalculator) calculateFirstCommitToMerge(pr PullRequest) *ti
 	
 	// PR作成時刻を最初のコミット時刻として近似
 	// 実際の実装では、GitHubのコミット情報から最初のコミット時刻を取得する
-	duration := calc.calculateDuration(pr.CreatedAt, *pr.MergedAt)
+	duration := calc.calculateDuration(pr.Author.Login, pr.CreatedAt, *pr.MergedAt)
 	return &duration
 }
 
 // calculateDuration は営業時間を考慮して時間を計算
-func (calc *CycleTimeCalculator) calculateDuration(start, end time.Time) time.Duration {
+// 営業時間は login（レビュー待ちはレビュアー、それ以外は作者）の勤務時間・休日で数える
+func (calc *CycleTimeCalculator) calculateDuration(login string, start, end time.Time) time.Duration {
 	if !calc.config.UseBusinessHours {
 		return end.Sub(start)
 	}
 	
-	// 営業時間のみを計算（簡易版）
-	return calc.calculateBusinessHours(start, end)
+	return calc.scheduleFor(login, start).businessDuration(start, end)
+}
+
+// reviewerOf はレビューイベントを行ったレビュアーを返す
+func reviewerOf(event ReviewEvent) string {
+	if event.Actor != "" {
+		return event.Actor
+	}
+	return event.Reviewer
 }
 
-// calculateBusinessHours は営業時間のみを計算
+// calculateBusinessHours は既定の勤務時間で営業時間のみを計算
 func (calc *CycleTimeCalculator) calculateBusinessHours(start, end time.Time) time.Duration {
-	// タイムゾーンを設定
-	start = start.In(calc.config.Timezone)
-	end = end.In(calc.config.Timezone)
-	
-	if start.After(end) {
-		return 0
-	}
-	
-	totalDuration := time.Duration(0)
-	current := start
-	
-	for current.Before(end) {
-		// 当日の営業時間を計算
-		dayStart := time.Date(current.Year(), current.Month(), current.Day(), 
-			calc.config.BusinessStart, 0, 0, 0, calc.config.Timezone)
-		dayEnd := time.Date(current.Year(), current.Month(), current.Day(), 
-			calc.config.BusinessEnd, 0, 0, 0, calc.config.Timezone)
-		
-		// 週末をスキップ
-		if calc.config.ExcludeWeekends && (current.Weekday() == time.Saturday || current.Weekday() == time.Sunday) {
-			current = current.AddDate(0, 0, 1)
-			current = time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, calc.config.Timezone)
-			continue
-		}
-		
-		// その日の作業時間を計算
-		workStart := current
-		if workStart.Before(dayStart) {
-			workStart = dayStart
-		}
-		
-		workEnd := end
-		if workEnd.After(dayEnd) {
-			workEnd = dayEnd
+	return calc.scheduleFor("", start).businessDuration(start, end)
+}
+
+// scheduleFor は at の時点で login に適用する勤務時間と休日を返す
+// login が空の場合や上書き設定がない場合は全体の設定を使う
+func (calc *CycleTimeCalculator) scheduleFor(login string, at time.Time) workSchedule {
+	schedule := workSchedule{
+		location:        calc.config.Timezone,
+		businessStart:   calc.config.BusinessStart,
+		businessEnd:     calc.config.BusinessEnd,
+		excludeWeekends: calc.config.ExcludeWeekends,
+	}
+	if schedule.location == nil {
+		schedule.location = getDefaultCycleTimeConfig().Timezone
+	}
+	calendarName := ""
+	
+	if calc.config.WorkingHours != nil && login != "" {
+		if hours, ok := calc.config.WorkingHours.WorkingHoursFor(login, at); ok {
+			if location, ok := calc.loadLocation(hours.Timezone); ok {
+				schedule.location = location
+			}
+			start, end := schedule.businessStart, schedule.businessEnd
+			if hours.BusinessStart != nil {
+				start = *hours.BusinessStart
+			}
+			if hours.BusinessEnd != nil {
+				end = *hours.BusinessEnd
+			}
+			// 片方だけの上書きで開始と終了が逆転する場合は全体の設定を使う
+			if start < end {
+				schedule.businessStart, schedule.businessEnd = start, end
+			}
+			if hours.ExcludeWeekends != nil {
+				schedule.excludeWeekends = *hours.ExcludeWeekends
+			}
+			calendarName = hours.HolidayCalendar
 		}
-		
-		if workStart.Before(workEnd) && workStart.Day() == workEnd.Day() {
-			totalDuration += workEnd.Sub(workStart)
+	}
+	
+	if calc.config.ExcludeHolidays {
+		// 未登録のカレンダー名は既定のカレンダーとして扱う
+		calendar, ok := calc.config.LookupHolidayCalendar(calendarName)
+		if !ok {
+			calendar, _ = calc.config.LookupHolidayCalendar("")
 		}
-		
-		// 次の日に進む
-		current = current.AddDate(0, 0, 1)
-		current = time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, calc.config.Timezone)
+		schedule.holidays = calendar
 	}
 	
-	return totalDuration
+	return schedule
+}
+
+// loadLocation はタイムゾーン名から所在地を読み込み、結果をキャッシュする
+func (calc *CycleTimeCalculator) loadLocation(name string) (*time.Location, bool) {
+	if name == "" {
+		return nil, false
+	}
+	if cached, ok := calc.locations.Load(name); ok {
+		return cached.(*time.Location), true
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false
+	}
+	calc.locations.Store(name, location)
+	return location, true
 }
 
 // CalculateCycleTimeStatistics は複数PRのサイクルタイム統計を計算
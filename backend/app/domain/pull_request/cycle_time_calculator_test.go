@@ -82,6 +82,95 @@ func TestCycleTimeCalculator_CalculateTimeMetrics(t *testing.T) {
 	}
 }
 
+func TestCycleTimeCalculator_CalculateTimeMetrics_DraftPR(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	
+	// ドラフトで作成 → 2日後にレビュー可能 → ドラフトに戻す → 再度レビュー可能
+	pr := PullRequest{
+		CreatedAt: baseTime,
+		MergedAt:  timePtr2(baseTime.Add(80 * time.Hour)),
+	}
+	
+	reviewEvents := []ReviewEvent{
+		{Type: ReviewEventTypeReadyForReview, CreatedAt: baseTime.Add(48 * time.Hour)},
+		{Type: ReviewEventTypeCommented, CreatedAt: baseTime.Add(50 * time.Hour), Reviewer: "reviewer1"},
+		{Type: ReviewEventTypeConvertedToDraft, CreatedAt: baseTime.Add(52 * time.Hour)},
+		{Type: ReviewEventTypeReadyForReview, CreatedAt: baseTime.Add(60 * time.Hour)},
+		{Type: ReviewEventTypeApproved, CreatedAt: baseTime.Add(63 * time.Hour), Reviewer: "reviewer1"},
+		{Type: ReviewEventTypeMerged, CreatedAt: baseTime.Add(80 * time.Hour)},
+	}
+	
+	t.Run("最後のready_for_reviewから計測", func(t *testing.T) {
+		calc := NewCycleTimeCalculator()
+		result := calc.CalculateTimeMetrics(pr, reviewEvents)
+		
+		if len(result.DraftIntervals) != 2 {
+			t.Fatalf("DraftIntervals = %d, want 2", len(result.DraftIntervals))
+		}
+		if result.TimeInDraft == nil || *result.TimeInDraft != 56*time.Hour {
+			t.Errorf("TimeInDraft = %v, want %v", result.TimeInDraft, 56*time.Hour)
+		}
+		if result.ReadyForReviewAt == nil || !result.ReadyForReviewAt.Equal(baseTime.Add(60*time.Hour)) {
+			t.Errorf("ReadyForReviewAt = %v, want %v", result.ReadyForReviewAt, baseTime.Add(60*time.Hour))
+		}
+		if result.TimeToFirstReview == nil || *result.TimeToFirstReview != 3*time.Hour {
+			t.Errorf("TimeToFirstReview = %v, want %v", result.TimeToFirstReview, 3*time.Hour)
+		}
+		if result.TimeToApproval == nil || *result.TimeToApproval != 3*time.Hour {
+			t.Errorf("TimeToApproval = %v, want %v", result.TimeToApproval, 3*time.Hour)
+		}
+		if result.TotalCycleTime == nil || *result.TotalCycleTime != 80*time.Hour {
+			t.Errorf("TotalCycleTime = %v, want %v", result.TotalCycleTime, 80*time.Hour)
+		}
+	})
+	
+	t.Run("旧定義ではPR作成時刻から計測", func(t *testing.T) {
+		config := DefaultCycleTimeConfig()
+		config.UseLegacyReviewStart = true
+		calc := NewCycleTimeCalculatorWithConfig(config)
+		result := calc.CalculateTimeMetrics(pr, reviewEvents)
+		
+		if result.TimeToFirstReview == nil || *result.TimeToFirstReview != 50*time.Hour {
+			t.Errorf("TimeToFirstReview = %v, want %v", result.TimeToFirstReview, 50*time.Hour)
+		}
+		if result.TimeToApproval == nil || *result.TimeToApproval != 63*time.Hour {
+			t.Errorf("TimeToApproval = %v, want %v", result.TimeToApproval, 63*time.Hour)
+		}
+		// ドラフト時間は定義に関わらず計算される
+		if result.TimeInDraft == nil || *result.TimeInDraft != 56*time.Hour {
+			t.Errorf("TimeInDraft = %v, want %v", result.TimeInDraft, 56*time.Hour)
+		}
+	})
+}
+
+func TestExtractDraftIntervals(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	pr := PullRequest{CreatedAt: baseTime}
+	
+	tests := []struct {
+		name          string
+		events        []ReviewEvent
+		expectedCount int
+		lastOpen      bool
+	}{
+		{"ドラフトなし", []ReviewEvent{{Type: ReviewEventTypeApproved, CreatedAt: baseTime.Add(time.Hour)}}, 0, false},
+		{"ドラフトで作成", []ReviewEvent{{Type: ReviewEventTypeReadyForReview, CreatedAt: baseTime.Add(time.Hour)}}, 1, false},
+		{"途中でドラフトに戻したまま", []ReviewEvent{{Type: ReviewEventTypeConvertedToDraft, CreatedAt: baseTime.Add(time.Hour)}}, 1, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intervals := ExtractDraftIntervals(pr, tt.events)
+			if len(intervals) != tt.expectedCount {
+				t.Fatalf("intervals = %d, want %d", len(intervals), tt.expectedCount)
+			}
+			if tt.expectedCount > 0 && (intervals[len(intervals)-1].End == nil) != tt.lastOpen {
+				t.Errorf("last interval open = %v, want %v", intervals[len(intervals)-1].End == nil, tt.lastOpen)
+			}
+		})
+	}
+}
+
 func TestCycleTimeCalculator_calculateTimeToFirstReview(t *testing.T) {
 	calc := NewCycleTimeCalculator()
 	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
@@ -132,7 +221,7 @@ func TestCycleTimeCalculator_calculateTimeToFirstReview(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calc.calculateTimeToFirstReview(pr, tt.events)
+			result := calc.calculateTimeToFirstReview(pr, pr.CreatedAt, tt.events)
 			
 			if tt.expected == nil {
 				if result != nil {
@@ -189,7 +278,7 @@ func TestCycleTimeCalculator_calculateTimeToApproval(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calc.calculateTimeToApproval(pr, tt.events)
+			result := calc.calculateTimeToApproval(pr, pr.CreatedAt, tt.events)
 			
 			if tt.expected == nil {
 				if result != nil {
@@ -502,4 +591,84 @@ func TestDefaultCycleTimeConfig(t *testing.T) {
 	if config.Timezone == nil {
 		t.Error("Expected Timezone to be set")
 	}
-}
\ No newline at end of file
+}
+type fixedWorkingHours map[string]WorkingHours
+
+func (r fixedWorkingHours) WorkingHoursFor(login string, at time.Time) (WorkingHours, bool) {
+	hours, exists := r[login]
+	return hours, exists
+}
+
+func TestCycleTimeCalculator_HolidaysAndWorkingHours(t *testing.T) {
+	jst, _ := time.LoadLocation("Asia/Tokyo")
+	
+	// ゴールデンウィーク前の金曜 17:00 に作成し、連休明けの水曜 10:00 に承認
+	pr := PullRequest{
+		Author:    Author{Login: "alice"},
+		CreatedAt: time.Date(2025, 5, 2, 17, 0, 0, 0, jst),
+	}
+	events := []ReviewEvent{
+		{Type: ReviewEventTypeApproved, CreatedAt: time.Date(2025, 5, 7, 10, 0, 0, 0, jst), Actor: "bob"},
+	}
+	
+	newConfig := func() CycleTimeConfig {
+		config := DefaultCycleTimeConfig()
+		config.UseBusinessHours = true
+		config.ExcludeWeekends = true
+		config.Timezone = jst
+		return config
+	}
+	
+	t.Run("祝日を除外しない場合は連休中の平日も数える", func(t *testing.T) {
+		calc := NewCycleTimeCalculatorWithConfig(newConfig())
+		result := calc.CalculateTimeMetrics(pr, events)
+		
+		// 金曜 1時間 + 月・火 各9時間 + 水曜 1時間
+		if result.TimeToApproval == nil || *result.TimeToApproval != 20*time.Hour {
+			t.Errorf("TimeToApproval = %v, want %v", result.TimeToApproval, 20*time.Hour)
+		}
+	})
+	
+	t.Run("日本の祝日を除外する", func(t *testing.T) {
+		config := newConfig()
+		config.ExcludeHolidays = true
+		calc := NewCycleTimeCalculatorWithConfig(config)
+		result := calc.CalculateTimeMetrics(pr, events)
+		
+		if result.TimeToApproval == nil || *result.TimeToApproval != 2*time.Hour {
+			t.Errorf("TimeToApproval = %v, want %v", result.TimeToApproval, 2*time.Hour)
+		}
+	})
+	
+	t.Run("レビュアーの勤務時間と休日カレンダーで数える", func(t *testing.T) {
+		utc := time.UTC
+		start, end := 8, 16
+		config := newConfig()
+		config.ExcludeHolidays = true
+		config.HolidayCalendars = map[string]*HolidayCalendar{
+			"uk": NewHolidayCalendar("uk", []Holiday{{Date: time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC), Name: "Early May bank holiday"}}),
+		}
+		config.WorkingHours = fixedWorkingHours{
+			"bob": {Timezone: utc.String(), BusinessStart: &start, BusinessEnd: &end, HolidayCalendar: "uk"},
+		}
+		calc := NewCycleTimeCalculatorWithConfig(config)
+		result := calc.CalculateTimeMetrics(pr, events)
+		
+		// 作成は UTC 金曜 8:00、承認は UTC 水曜 1:00。金曜 8時間 + 火曜 8時間（月曜は英国の祝日）
+		if result.TimeToApproval == nil || *result.TimeToApproval != 16*time.Hour {
+			t.Errorf("TimeToApproval = %v, want %v", result.TimeToApproval, 16*time.Hour)
+		}
+	})
+	
+	t.Run("上書き設定のない開発者は全体の設定を使う", func(t *testing.T) {
+		config := newConfig()
+		config.ExcludeHolidays = true
+		config.WorkingHours = fixedWorkingHours{}
+		calc := NewCycleTimeCalculatorWithConfig(config)
+		result := calc.CalculateTimeMetrics(pr, events)
+		
+		if result.TimeToApproval == nil || *result.TimeToApproval != 2*time.Hour {
+			t.Errorf("TimeToApproval = %v, want %v", result.TimeToApproval, 2*time.Hour)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package pull_request
+
+import (
+	"sort"
+	"time"
+)
+
+// DraftInterval はPRがドラフト状態だった期間
+type DraftInterval struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"` // nil の場合はドラフトのまま
+}
+
+// Duration はドラフト期間の長さを返す（未終了の場合は until までの長さ）
+func (d DraftInterval) Duration(until time.Time) time.Duration {
+	end := until
+	if d.End != nil {
+		end = *d.End
+	}
+	if end.Before(d.Start) {
+		return 0
+	}
+	return end.Sub(d.Start)
+}
+
+// ExtractDraftIntervals はレビューイベントからドラフト期間を抽出
+// 最初のドラフト関連イベントが ready_for_review の場合、PRはドラフトとして作成されたとみなす
+func ExtractDraftIntervals(pr PullRequest, reviewEvents []ReviewEvent) []DraftInterval {
+	events := make([]ReviewEvent, 0, len(reviewEvents))
+	for _, event := range reviewEvents {
+		if event.Type == ReviewEventTypeReadyForReview || event.Type == ReviewEventTypeConvertedToDraft {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	var intervals []DraftInterval
+	var current *DraftInterval
+
+	for i, event := range events {
+		switch event.Type {
+		case ReviewEventTypeConvertedToDraft:
+			if current == nil {
+				current = &DraftInterval{Start: event.CreatedAt}
+			}
+		case ReviewEventTypeReadyForReview:
+			if current == nil && i == 0 {
+				// ドラフトとして作成されたPR
+				current = &DraftInterval{Start: pr.CreatedAt}
+			}
+			if current != nil {
+				end := event.CreatedAt
+				current.End = &end
+				intervals = append(intervals, *current)
+				current = nil
+			}
+		}
+	}
+
+	// ドラフトのまま終わっている期間
+	if current != nil {
+		if pr.MergedAt != nil {
+			end := *pr.MergedAt
+			current.End = &end
+		}
+		intervals = append(intervals, *current)
+	}
+
+	return intervals
+}
+
+// LastReadyForReviewAt は最後にレビュー可能状態になった時刻を返す
+// ドラフトを経由していない場合は nil
+func LastReadyForReviewAt(reviewEvents []ReviewEvent) *time.Time {
+	var last *time.Time
+	for i := range reviewEvents {
+		if reviewEvents[i].Type != ReviewEventTypeReadyForReview {
+			continue
+		}
+		if last == nil || reviewEvents[i].CreatedAt.After(*last) {
+			t := reviewEvents[i].CreatedAt
+			last = &t
+		}
+	}
+	return last
+}
@@ -0,0 +1,89 @@
+package pull_request
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+)
+
+// Holiday は休日の1日分
+type Holiday struct {
+	Date time.Time // 年月日のみを使う
+	Name string
+}
+
+// HolidayCalendar は祝日・会社休日等の休日の一覧
+// 日付は年月日で判定するため、判定する時刻は勤務地のタイムゾーンに合わせて渡す
+type HolidayCalendar struct {
+	name        string
+	holidays    map[string]string // YYYY-MM-DD → 休日名
+	fingerprint string
+}
+
+// NewHolidayCalendar は休日の一覧から新しい休日カレンダーを作成
+// 同じ日付が複数ある場合は先に指定したものを使う
+func NewHolidayCalendar(name string, holidays []Holiday) *HolidayCalendar {
+	calendar := &HolidayCalendar{
+		name:     name,
+		holidays: make(map[string]string, len(holidays)),
+	}
+	for _, holiday := range holidays {
+		key := holidayKey(holiday.Date)
+		if _, exists := calendar.holidays[key]; !exists {
+			calendar.holidays[key] = holiday.Name
+		}
+	}
+
+	// 定義バージョンに含めるため、内容から一意な値を作っておく
+	hash := sha256.New()
+	hash.Write([]byte(name))
+	for _, holiday := range calendar.Holidays() {
+		hash.Write([]byte("\n" + holidayKey(holiday.Date) + " " + holiday.Name))
+	}
+	calendar.fingerprint = hex.EncodeToString(hash.Sum(nil))[:12]
+	return calendar
+}
+
+// Name はカレンダー名を返す
+func (c *HolidayCalendar) Name() string {
+	return c.name
+}
+
+// IsHoliday は date の年月日が休日かどうか
+func (c *HolidayCalendar) IsHoliday(date time.Time) bool {
+	_, ok := c.HolidayName(date)
+	return ok
+}
+
+// HolidayName は date の年月日が休日の場合にその名前を返す
+func (c *HolidayCalendar) HolidayName(date time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	name, ok := c.holidays[holidayKey(date)]
+	return name, ok
+}
+
+// Holidays は休日を日付順で返す
+func (c *HolidayCalendar) Holidays() []Holiday {
+	holidays := make([]Holiday, 0, len(c.holidays))
+	for key, name := range c.holidays {
+		date, _ := time.Parse("2006-01-02", key)
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays
+}
+
+// Fingerprint はカレンダー名と休日の一覧から作った値を返す（内容が同じであれば常に同じ値）
+func (c *HolidayCalendar) Fingerprint() string {
+	return c.fingerprint
+}
+
+// holidayKey は時刻の所在地での年月日を返す
+func holidayKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
@@ -0,0 +1,28 @@
+package pull_request
+
+// IdentityResolver はログイン名を開発者の正規IDに変換する
+// 同一人物の複数アカウントを集計時に束ねるために使用する
+type IdentityResolver interface {
+	Resolve(login string) string
+	ResolveAll(logins []string) []string
+}
+
+// ResolveIdentities は作者・レビュアー・承認者を正規IDに置き換えたコピーを返す
+// 元のメトリクスは変更しない。resolver が nil の場合は入力をそのまま返す
+func ResolveIdentities(metrics []*PRMetrics, resolver IdentityResolver) []*PRMetrics {
+	if resolver == nil {
+		return metrics
+	}
+
+	resolved := make([]*PRMetrics, 0, len(metrics))
+	for _, metric := range metrics {
+		copied := *metric
+		copied.Author = resolver.Resolve(metric.Author)
+		copied.QualityMetrics.ReviewersInvolved = resolver.ResolveAll(metric.QualityMetrics.ReviewersInvolved)
+		copied.QualityMetrics.ApproversInvolved = resolver.ResolveAll(metric.QualityMetrics.ApproversInvolved)
+		copied.QualityMetrics.ReviewerCount = len(copied.QualityMetrics.ReviewersInvolved)
+		copied.QualityMetrics.ApprovalsReceived = len(copied.QualityMetrics.ApproversInvolved)
+		resolved = append(resolved, &copied)
+	}
+	return resolved
+}
@@ -0,0 +1,72 @@
+package pull_request
+
+import (
+	"testing"
+)
+
+// mapIdentityResolver はテスト用の別名解決
+type mapIdentityResolver map[string]string
+
+func (m mapIdentityResolver) Resolve(login string) string {
+	if id, ok := m[login]; ok {
+		return id
+	}
+	return login
+}
+
+func (m mapIdentityResolver) ResolveAll(logins []string) []string {
+	result := make([]string, 0, len(logins))
+	seen := make(map[string]bool)
+	for _, login := range logins {
+		id := m.Resolve(login)
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func TestResolveIdentities(t *testing.T) {
+	resolver := mapIdentityResolver{"alice-work": "alice", "alice-old": "alice"}
+	original := &PRMetrics{
+		PRID:   "pr-1",
+		Author: "alice-old",
+		QualityMetrics: PRQualityMetrics{
+			ReviewerCount:     3,
+			ReviewersInvolved: []string{"alice-work", "bob", "alice-old"},
+			ApprovalsReceived: 2,
+			ApproversInvolved: []string{"alice-work", "alice-old"},
+		},
+	}
+
+	t.Run("作者・レビュアー・承認者が正規IDに揃う", func(t *testing.T) {
+		result := ResolveIdentities([]*PRMetrics{original}, resolver)
+
+		if result[0].Author != "alice" {
+			t.Errorf("Author = %q, want alice", result[0].Author)
+		}
+		if result[0].QualityMetrics.ReviewerCount != 2 {
+			t.Errorf("ReviewerCount = %d, want 2", result[0].QualityMetrics.ReviewerCount)
+		}
+		if result[0].QualityMetrics.ApprovalsReceived != 1 {
+			t.Errorf("ApprovalsReceived = %d, want 1", result[0].QualityMetrics.ApprovalsReceived)
+		}
+	})
+
+	t.Run("元のメトリクスは変更されない", func(t *testing.T) {
+		ResolveIdentities([]*PRMetrics{original}, resolver)
+
+		if original.Author != "alice-old" || len(original.QualityMetrics.ReviewersInvolved) != 3 {
+			t.Errorf("original was modified: %+v", original)
+		}
+	})
+
+	t.Run("resolverがnilの場合はそのまま返す", func(t *testing.T) {
+		result := ResolveIdentities([]*PRMetrics{original}, nil)
+
+		if result[0] != original {
+			t.Error("nil resolver should return input as is")
+		}
+	})
+}
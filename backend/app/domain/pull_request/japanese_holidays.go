@@ -0,0 +1,147 @@
+package pull_request
+
+import (
+	"sync"
+	"time"
+)
+
+// JapaneseHolidayCalendarName は組み込みの日本の祝日カレンダーの名前
+const JapaneseHolidayCalendarName = "japan"
+
+// 組み込みカレンダーで祝日を計算する年の範囲（春分・秋分の日の近似式が使える範囲）
+const (
+	japaneseHolidaysFirstYear = 2007
+	japaneseHolidaysLastYear  = 2099
+)
+
+var (
+	japaneseHolidayCalendar     *HolidayCalendar
+	japaneseHolidayCalendarOnce sync.Once
+)
+
+// JapaneseHolidayCalendar は日本の国民の祝日・振替休日・国民の休日のカレンダーを返す
+// 2007年から2099年までを対象とし、春分・秋分の日は近似式で求める
+func JapaneseHolidayCalendar() *HolidayCalendar {
+	japaneseHolidayCalendarOnce.Do(func() {
+		var holidays []Holiday
+		for year := japaneseHolidaysFirstYear; year <= japaneseHolidaysLastYear; year++ {
+			holidays = append(holidays, JapaneseHolidays(year)...)
+		}
+		japaneseHolidayCalendar = NewHolidayCalendar(JapaneseHolidayCalendarName, holidays)
+	})
+	return japaneseHolidayCalendar
+}
+
+// JapaneseHolidays は指定した年の日本の祝日を日付順で返す（対象外の年は nil）
+// 2007年施行の祝日法（振替休日は祝日でない次の日）に基づく
+func JapaneseHolidays(year int) []Holiday {
+	if year < japaneseHolidaysFirstYear || year > japaneseHolidaysLastYear {
+		return nil
+	}
+
+	names := make(map[time.Time]string)
+	add := func(month time.Month, day int, name string) {
+		names[time.Date(year, month, day, 0, 0, 0, 0, time.UTC)] = name
+	}
+
+	add(time.January, 1, "元日")
+	add(time.January, nthMonday(year, time.January, 2), "成人の日")
+	add(time.February, 11, "建国記念の日")
+	switch {
+	case year <= 2018:
+		add(time.December, 23, "天皇誕生日")
+	case year >= 2020:
+		add(time.February, 23, "天皇誕生日")
+	}
+	add(time.March, equinoxDay(year, 20.8431), "春分の日")
+	add(time.April, 29, "昭和の日")
+	add(time.May, 3, "憲法記念日")
+	add(time.May, 4, "みどりの日")
+	add(time.May, 5, "こどもの日")
+	add(time.September, nthMonday(year, time.September, 3), "敬老の日")
+	add(time.September, equinoxDay(year, 23.2488), "秋分の日")
+	add(time.November, 3, "文化の日")
+	add(time.November, 23, "勤労感謝の日")
+
+	// 東京オリンピック・パラリンピックに伴う移動
+	switch year {
+	case 2020:
+		add(time.July, 23, "海の日")
+		add(time.July, 24, "スポーツの日")
+		add(time.August, 10, "山の日")
+	case 2021:
+		add(time.July, 22, "海の日")
+		add(time.July, 23, "スポーツの日")
+		add(time.August, 8, "山の日")
+	default:
+		add(time.July, nthMonday(year, time.July, 3), "海の日")
+		if year >= 2016 {
+			add(time.August, 11, "山の日")
+		}
+		sportsDay := "体育の日"
+		if year >= 2020 {
+			sportsDay = "スポーツの日"
+		}
+		add(time.October, nthMonday(year, time.October, 2), sportsDay)
+	}
+
+	// 天皇の即位に伴う祝日
+	if year == 2019 {
+		add(time.May, 1, "休日（即位の日）")
+		add(time.October, 22, "休日（即位礼正殿の儀の行われる日）")
+	}
+
+	// 国民の休日: 前日と翌日が祝日の日（日曜日を除く）
+	var citizensHolidays []time.Time
+	for date := time.Date(year, time.January, 2, 0, 0, 0, 0, time.UTC); date.Year() == year; date = date.AddDate(0, 0, 1) {
+		if _, isHoliday := names[date]; isHoliday || date.Weekday() == time.Sunday {
+			continue
+		}
+		_, before := names[date.AddDate(0, 0, -1)]
+		_, after := names[date.AddDate(0, 0, 1)]
+		if before && after {
+			citizensHolidays = append(citizensHolidays, date)
+		}
+	}
+	for _, date := range citizensHolidays {
+		names[date] = "国民の休日"
+	}
+
+	// 振替休日: 日曜日の祝日の後で最初の祝日でない日
+	var substitutes []time.Time
+	for date := range names {
+		if date.Weekday() != time.Sunday {
+			continue
+		}
+		substitute := date.AddDate(0, 0, 1)
+		for {
+			if _, isHoliday := names[substitute]; !isHoliday {
+				break
+			}
+			substitute = substitute.AddDate(0, 0, 1)
+		}
+		substitutes = append(substitutes, substitute)
+	}
+	for _, date := range substitutes {
+		names[date] = "振替休日"
+	}
+
+	holidays := make([]Holiday, 0, len(names))
+	for date, name := range names {
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+	return NewHolidayCalendar(JapaneseHolidayCalendarName, holidays).Holidays()
+}
+
+// nthMonday は指定した月の第n月曜日の日を返す
+func nthMonday(year int, month time.Month, n int) int {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+	return 1 + offset + (n-1)*7
+}
+
+// equinoxDay は春分・秋分の日を近似式で求める（1980年から2099年まで有効）
+func equinoxDay(year int, base float64) int {
+	elapsed := year - 1980
+	return int(base+0.242194*float64(elapsed)) - elapsed/4
+}
@@ -0,0 +1,47 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJapaneseHolidayCalendar(t *testing.T) {
+	calendar := JapaneseHolidayCalendar()
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		holiday string
+	}{
+		{name: "固定の祝日", date: time.Date(2025, 5, 3, 0, 0, 0, 0, time.UTC), holiday: "憲法記念日"},
+		{name: "ハッピーマンデー", date: time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), holiday: "成人の日"},
+		{name: "春分の日", date: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), holiday: "春分の日"},
+		{name: "秋分の日", date: time.Date(2025, 9, 23, 0, 0, 0, 0, time.UTC), holiday: "秋分の日"},
+		{name: "日曜日の祝日の振替休日", date: time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC), holiday: "振替休日"},
+		{name: "祝日に挟まれた国民の休日", date: time.Date(2026, 9, 22, 0, 0, 0, 0, time.UTC), holiday: "国民の休日"},
+		{name: "即位に伴う国民の休日", date: time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC), holiday: "国民の休日"},
+		{name: "オリンピックに伴う移動", date: time.Date(2021, 8, 9, 0, 0, 0, 0, time.UTC), holiday: "振替休日"},
+		{name: "天皇誕生日の変更後", date: time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC), holiday: "天皇誕生日"},
+		{name: "平日", date: time.Date(2025, 5, 7, 0, 0, 0, 0, time.UTC)},
+		{name: "移動前の海の日", date: time.Date(2021, 7, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "対象外の年", date: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := calendar.HolidayName(tt.date)
+			if ok != (tt.holiday != "") || name != tt.holiday {
+				t.Errorf("HolidayName(%s) = %q, %v, want %q", tt.date.Format("2006-01-02"), name, ok, tt.holiday)
+			}
+		})
+	}
+
+	t.Run("判定は渡した時刻の所在地の日付で行う", func(t *testing.T) {
+		jst := time.FixedZone("JST", 9*60*60)
+		// UTC では 5月5日 20時だが、日本時間では 5月6日（振替休日）
+		at := time.Date(2025, 5, 5, 20, 0, 0, 0, time.UTC)
+		if !calendar.IsHoliday(at.In(jst)) {
+			t.Errorf("IsHoliday(%v) = false, want true", at.In(jst))
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package pull_request
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultKnowledgeSiloShare は1人に知識が集中しているとみなす既定の貢献の割合
+	DefaultKnowledgeSiloShare = 0.8
+
+	// DefaultKnowledgeDirectoryDepth はディレクトリを集計する既定の階層の深さ
+	DefaultKnowledgeDirectoryDepth = 2
+
+	// reviewKnowledgeWeight はレビューしたPRの変更行数を貢献として数える重み（作成は 1）
+	reviewKnowledgeWeight = 0.5
+
+	// minKnowledgeSiloPRs は知識の集中と判定する最小のPR数（少数のPRでの誤検知を避ける）
+	minKnowledgeSiloPRs = 3
+)
+
+// KnowledgeContributor はディレクトリへの1人の貢献
+type KnowledgeContributor struct {
+	Login         string  `json:"login"`
+	AuthoredPRs   int     `json:"authoredPRs"`
+	ReviewedPRs   int     `json:"reviewedPRs"`
+	LinesChanged  int     `json:"linesChanged"`  // 作成したPRでのディレクトリ内の変更行数
+	LinesReviewed int     `json:"linesReviewed"` // レビューしたPRでのディレクトリ内の変更行数
+	Contribution  float64 `json:"contribution"`  // LinesChanged + LinesReviewed × レビューの重み
+	Share         float64 `json:"share"`         // ディレクトリ全体の貢献に占める割合
+}
+
+// DirectoryKnowledge はディレクトリ（モジュール）ごとの知識の分散
+type DirectoryKnowledge struct {
+	Repository        string                 `json:"repository"`
+	Directory         string                 `json:"directory"`
+	PRs               int                    `json:"prs"`
+	DistinctAuthors   int                    `json:"distinctAuthors"`
+	DistinctReviewers int                    `json:"distinctReviewers"`
+	BusFactor         int                    `json:"busFactor"`    // 貢献の過半を占める最少の人数
+	Contributors      []KnowledgeContributor `json:"contributors"` // 貢献の大きい順
+	IsSilo            bool                   `json:"isSilo"`       // 1人の貢献の割合が閾値を超える
+}
+
+// TopContributor は最も貢献の大きい人を返す（貢献がない場合は nil）
+func (d DirectoryKnowledge) TopContributor() *KnowledgeContributor {
+	if len(d.Contributors) == 0 {
+		return nil
+	}
+	return &d.Contributors[0]
+}
+
+// KnowledgeAnalyzer はディレクトリごとの作成・レビューの貢献から知識の分散を推定する
+type KnowledgeAnalyzer struct {
+	siloShare float64
+	depth     int
+	resolver  IdentityResolver
+}
+
+// NewKnowledgeAnalyzer は新しい分析器を作成
+// siloShare が 0 以下の場合は既定の割合、depth が 0 以下の場合はファイルのディレクトリをそのまま使う
+// resolver が指定されている場合、同一人物の複数アカウントを1人として数える
+func NewKnowledgeAnalyzer(siloShare float64, depth int, resolver IdentityResolver) *KnowledgeAnalyzer {
+	if siloShare <= 0 {
+		siloShare = DefaultKnowledgeSiloShare
+	}
+	return &KnowledgeAnalyzer{siloShare: siloShare, depth: depth, resolver: resolver}
+}
+
+// SiloShare は知識の集中とみなす割合を返す
+func (a *KnowledgeAnalyzer) SiloShare() float64 {
+	return a.siloShare
+}
+
+// DirectoryOf はファイルの集計対象のディレクトリを返す（リポジトリ直下は "."）
+func (a *KnowledgeAnalyzer) DirectoryOf(fileName string) string {
+	directory := path.Dir(fileName)
+	if a.depth <= 0 || directory == "." {
+		return directory
+	}
+	segments := strings.Split(directory, "/")
+	if len(segments) > a.depth {
+		segments = segments[:a.depth]
+	}
+	return strings.Join(segments, "/")
+}
+
+// Analyze はPRの作者とレビュアーのディレクトリごとの貢献を集計する
+// events はPR IDごとのレビューイベント（イベントのないPRは ReviewersInvolved をレビュアーとする）
+// 結果はバスファクターの小さい順、同じ場合はPR数の多い順に並ぶ
+func (a *KnowledgeAnalyzer) Analyze(metrics []*PRMetrics, events map[string][]ReviewEvent) []DirectoryKnowledge {
+	type directoryKey struct{ repository, directory string }
+	type directoryData struct {
+		prs          int
+		contributors map[string]*KnowledgeContributor
+	}
+	directories := make(map[directoryKey]*directoryData)
+	contributor := func(data *directoryData, login string) *KnowledgeContributor {
+		if existing, exists := data.contributors[login]; exists {
+			return existing
+		}
+		created := &KnowledgeContributor{Login: login}
+		data.contributors[login] = created
+		return created
+	}
+
+	reviewers := make(map[string][]string)
+	for _, activity := range CollectReviewActivities(metrics, events, a.resolver) {
+		if activity.Reviewed {
+			reviewers[activity.PR.PRID] = append(reviewers[activity.PR.PRID], activity.Reviewer)
+		}
+	}
+
+	for _, pr := range metrics {
+		if pr.IsBot {
+			continue
+		}
+		author := pr.Author
+		if a.resolver != nil {
+			author = a.resolver.Resolve(author)
+		}
+
+		lines := make(map[string]int)
+		var order []string
+		for _, file := range pr.SizeMetrics.FileChanges {
+			directory := a.DirectoryOf(file.FileName)
+			if _, exists := lines[directory]; !exists {
+				order = append(order, directory)
+			}
+			lines[directory] += file.LinesAdded + file.LinesDeleted
+		}
+
+		for _, directory := range order {
+			key := directoryKey{pr.Repository, directory}
+			data := directories[key]
+			if data == nil {
+				data = &directoryData{contributors: make(map[string]*KnowledgeContributor)}
+				directories[key] = data
+			}
+			data.prs++
+
+			authorContribution := contributor(data, author)
+			authorContribution.AuthoredPRs++
+			authorContribution.LinesChanged += lines[directory]
+			for _, reviewer := range reviewers[pr.PRID] {
+				reviewerContribution := contributor(data, reviewer)
+				reviewerContribution.ReviewedPRs++
+				reviewerContribution.LinesReviewed += lines[directory]
+			}
+		}
+	}
+
+	results := make([]DirectoryKnowledge, 0, len(directories))
+	for key, data := range directories {
+		knowledge := DirectoryKnowledge{Repository: key.repository, Directory: key.directory, PRs: data.prs}
+		total := 0.0
+		for _, c := range data.contributors {
+			// 変更行数のないPR（リネームのみ等）も1行として数える
+			c.Contribution = float64(maxInt(c.LinesChanged, c.AuthoredPRs)) + reviewKnowledgeWeight*float64(maxInt(c.LinesReviewed, c.ReviewedPRs))
+			total += c.Contribution
+			if c.AuthoredPRs > 0 {
+				knowledge.DistinctAuthors++
+			}
+			if c.ReviewedPRs > 0 {
+				knowledge.DistinctReviewers++
+			}
+			knowledge.Contributors = append(knowledge.Contributors, *c)
+		}
+		sort.Slice(knowledge.Contributors, func(i, j int) bool {
+			if knowledge.Contributors[i].Contribution != knowledge.Contributors[j].Contribution {
+				return knowledge.Contributors[i].Contribution > knowledge.Contributors[j].Contribution
+			}
+			return knowledge.Contributors[i].Login < knowledge.Contributors[j].Login
+		})
+
+		cumulative := 0.0
+		for i := range knowledge.Contributors {
+			knowledge.Contributors[i].Share = knowledge.Contributors[i].Contribution / total
+			if cumulative <= 0.5 {
+				knowledge.BusFactor++
+			}
+			cumulative += knowledge.Contributors[i].Share
+		}
+		knowledge.IsSilo = knowledge.PRs >= minKnowledgeSiloPRs && knowledge.Contributors[0].Share > a.siloShare
+		results = append(results, knowledge)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].BusFactor != results[j].BusFactor {
+			return results[i].BusFactor < results[j].BusFactor
+		}
+		if results[i].PRs != results[j].PRs {
+			return results[i].PRs > results[j].PRs
+		}
+		if results[i].Repository != results[j].Repository {
+			return results[i].Repository < results[j].Repository
+		}
+		return results[i].Directory < results[j].Directory
+	})
+	return results
+}
+
+// maxInt は大きい方の値を返す
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
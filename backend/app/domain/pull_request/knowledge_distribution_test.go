@@ -0,0 +1,91 @@
+package pull_request
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestKnowledgeAnalyzer_DirectoryOf(t *testing.T) {
+	analyzer := NewKnowledgeAnalyzer(0, 2, nil)
+	tests := map[string]string{
+		"main.go":                  ".",
+		"api/handler.go":           "api",
+		"api/handlers/user.go":     "api/handlers",
+		"api/handlers/v1/order.go": "api/handlers",
+	}
+	for fileName, expected := range tests {
+		if got := analyzer.DirectoryOf(fileName); got != expected {
+			t.Errorf("DirectoryOf(%q) = %q, want %q", fileName, got, expected)
+		}
+	}
+
+	if got := NewKnowledgeAnalyzer(0, 0, nil).DirectoryOf("api/handlers/v1/order.go"); got != "api/handlers/v1" {
+		t.Errorf("DirectoryOf without depth = %q, want api/handlers/v1", got)
+	}
+}
+
+func TestKnowledgeAnalyzer_Analyze(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	pr := func(id, author string, files ...FileChangeMetrics) *PRMetrics {
+		metric := &PRMetrics{PRID: id, Author: author, Repository: "org/app", CreatedAt: createdAt}
+		metric.SizeMetrics.FileChanges = files
+		return metric
+	}
+	file := func(name string, lines int) FileChangeMetrics {
+		return FileChangeMetrics{FileName: name, LinesAdded: lines}
+	}
+
+	metrics := []*PRMetrics{
+		pr("pr-1", "alice", file("api/handlers/user.go", 10)),
+		pr("pr-2", "alice", file("api/handlers/order.go", 10)),
+		pr("pr-3", "alice-work", file("api/handlers/v1/item.go", 10)),
+		pr("pr-4", "carol", file("web/app.ts", 10), file("README.md", 2)),
+		pr("pr-5", "dave", file("web/index.ts", 10)),
+		pr("pr-6", "renovate", file("api/handlers/go.mod", 100)),
+	}
+	metrics[5].IsBot = true
+	events := map[string][]ReviewEvent{
+		"pr-1": {
+			{Type: ReviewEventTypeApproved, CreatedAt: createdAt.Add(time.Hour), Actor: "bob", Reviewer: "bob"},
+		},
+	}
+	resolver := mapIdentityResolver{"alice-work": "alice"}
+
+	results := NewKnowledgeAnalyzer(0, 2, resolver).Analyze(metrics, events)
+	byDirectory := make(map[string]DirectoryKnowledge)
+	for _, result := range results {
+		byDirectory[result.Directory] = result
+	}
+	if len(byDirectory) != 3 {
+		t.Fatalf("directories = %v, want api/handlers, web and .", results)
+	}
+
+	// alice: 30 行、bob: レビュー 10 行 × 0.5 → alice が 30/35 を占める
+	api := byDirectory["api/handlers"]
+	if api.PRs != 3 || api.DistinctAuthors != 1 || api.DistinctReviewers != 1 {
+		t.Errorf("api/handlers = %+v, want 3 PRs, 1 author and 1 reviewer", api)
+	}
+	if top := api.TopContributor(); top == nil || top.Login != "alice" || math.Abs(top.Share-30.0/35.0) > 1e-9 {
+		t.Errorf("api/handlers top contributor = %+v, want alice with share 30/35", top)
+	}
+	if api.BusFactor != 1 || !api.IsSilo {
+		t.Errorf("api/handlers bus factor = %d, silo = %v, want 1 and true", api.BusFactor, api.IsSilo)
+	}
+
+	web := byDirectory["web"]
+	if web.PRs != 2 || web.DistinctAuthors != 2 || web.BusFactor != 2 || web.IsSilo {
+		t.Errorf("web = %+v, want 2 PRs, 2 authors, bus factor 2 and no silo", web)
+	}
+
+	// 1人だけが変更していてもPRが少ない場合は知識の集中としない
+	root := byDirectory["."]
+	if root.BusFactor != 1 || root.IsSilo {
+		t.Errorf(". = %+v, want bus factor 1 and no silo", root)
+	}
+
+	// バスファクターの小さい順、同じ場合はPR数の多い順
+	if results[0].Directory != "api/handlers" || results[2].Directory != "web" {
+		t.Errorf("order = %s, %s, %s, want api/handlers first and web last", results[0].Directory, results[1].Directory, results[2].Directory)
+	}
+}
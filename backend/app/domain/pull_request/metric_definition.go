@@ -0,0 +1,108 @@
+package pull_request
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// MetricDefinition はPRメトリクスの計算方法を決める設定
+// 設定を変更すると定義バージョンが変わり、保存済みのメトリクスを再計算の対象として判別できる
+type MetricDefinition struct {
+	CycleTime  CycleTimeConfig
+	Complexity ComplexityConfig
+	Size       SizeThresholds
+	Review     ReviewTimeConfig
+	Bottleneck BottleneckThresholds
+}
+
+// DefaultMetricDefinition はデフォルトの計算設定を返す
+func DefaultMetricDefinition() MetricDefinition {
+	return MetricDefinition{
+		CycleTime:  DefaultCycleTimeConfig(),
+		Complexity: DefaultComplexityConfig(),
+		Size:       DefaultSizeThresholds(),
+		Review:     DefaultReviewTimeConfig(),
+		Bottleneck: DefaultBottleneckThresholds(),
+	}
+}
+
+// withDefaults は未設定（ゼロ値）の閾値をデフォルトで埋めた定義を返す
+func (d MetricDefinition) withDefaults() MetricDefinition {
+	if d.Size == (SizeThresholds{}) {
+		d.Size = DefaultSizeThresholds()
+	}
+	if d.Review == (ReviewTimeConfig{}) {
+		d.Review = DefaultReviewTimeConfig()
+	}
+	if d.Bottleneck == (BottleneckThresholds{}) {
+		d.Bottleneck = DefaultBottleneckThresholds()
+	}
+	return d
+}
+
+// Version は計算設定のハッシュから定義バージョンを返す
+// 同じ設定であれば常に同じ値になる
+func (d MetricDefinition) Version() string {
+	d = d.withDefaults()
+	
+	timezone := ""
+	if d.CycleTime.Timezone != nil {
+		timezone = d.CycleTime.Timezone.String()
+	} else if defaultTimezone := DefaultCycleTimeConfig().Timezone; defaultTimezone != nil {
+		timezone = defaultTimezone.String()
+	}
+
+	// 休日を除外する場合は参照できるカレンダーの内容も含める
+	var holidayCalendars map[string]string
+	if d.CycleTime.ExcludeHolidays {
+		holidayCalendars = map[string]string{}
+		if calendar, ok := d.CycleTime.LookupHolidayCalendar(""); ok {
+			holidayCalendars[""] = calendar.Fingerprint()
+		}
+		for name, calendar := range d.CycleTime.HolidayCalendars {
+			holidayCalendars[name] = calendar.Fingerprint()
+		}
+	}
+
+	// サイズ・レビューの閾値はデフォルトから変更した場合のみ含める
+	var size *SizeThresholds
+	if d.Size != DefaultSizeThresholds() {
+		size = &d.Size
+	}
+	var review *ReviewTimeConfig
+	if d.Review != DefaultReviewTimeConfig() {
+		review = &d.Review
+	}
+
+	// time.Location は JSON にできないため名前に置き換える（map のキーは JSON 化で整列される）
+	// 後から追加した項目は未設定の場合に省略し、既存の定義バージョンを変えない
+	payload, _ := json.Marshal(struct {
+		UseBusinessHours     bool
+		BusinessStart        int
+		BusinessEnd          int
+		ExcludeWeekends      bool
+		ExcludeHolidays      bool
+		HolidayCalendars     map[string]string `json:",omitempty"`
+		Timezone             string
+		UseLegacyReviewStart bool
+		Complexity           ComplexityConfig
+		Size                 *SizeThresholds   `json:",omitempty"`
+		Review               *ReviewTimeConfig `json:",omitempty"`
+	}{
+		UseBusinessHours:     d.CycleTime.UseBusinessHours,
+		BusinessStart:        d.CycleTime.BusinessStart,
+		BusinessEnd:          d.CycleTime.BusinessEnd,
+		ExcludeWeekends:      d.CycleTime.ExcludeWeekends,
+		ExcludeHolidays:      d.CycleTime.ExcludeHolidays,
+		HolidayCalendars:     holidayCalendars,
+		Timezone:             timezone,
+		UseLegacyReviewStart: d.CycleTime.UseLegacyReviewStart,
+		Complexity:           d.Complexity,
+		Size:                 size,
+		Review:               review,
+	})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,104 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetricDefinition_Version(t *testing.T) {
+	base := DefaultMetricDefinition()
+
+	businessHours := DefaultMetricDefinition()
+	businessHours.CycleTime.UseBusinessHours = true
+
+	newFileWeight := DefaultMetricDefinition()
+	newFileWeight.Complexity.NewFileWeight = 2.0
+
+	goWeight := DefaultMetricDefinition()
+	goWeight.Complexity.FileTypeWeights = map[string]float64{}
+	for ext, weight := range base.Complexity.FileTypeWeights {
+		goWeight.Complexity.FileTypeWeights[ext] = weight
+	}
+	goWeight.Complexity.FileTypeWeights[".go"] = 1.5
+
+	utc := DefaultMetricDefinition()
+	utc.CycleTime.Timezone = time.UTC
+
+	nilTimezone := DefaultMetricDefinition()
+	nilTimezone.CycleTime.Timezone = nil
+
+	sizeThresholds := DefaultMetricDefinition()
+	sizeThresholds.Size.Medium = 400
+
+	reviewInterval := DefaultMetricDefinition()
+	reviewInterval.Review.MinTimeBetweenRounds = 4 * time.Hour
+
+	bottleneck := DefaultMetricDefinition()
+	bottleneck.Bottleneck.ReviewRounds = 10
+
+	zeroValues := DefaultMetricDefinition()
+	zeroValues.Size = SizeThresholds{}
+	zeroValues.Review = ReviewTimeConfig{}
+
+	tests := []struct {
+		name       string
+		definition MetricDefinition
+		same       bool
+	}{
+		{name: "同じ設定は同じバージョンになる", definition: DefaultMetricDefinition(), same: true},
+		{name: "タイムゾーン未指定はデフォルトと同じ扱い", definition: nilTimezone, same: true},
+		{name: "営業時間の設定を変えるとバージョンが変わる", definition: businessHours, same: false},
+		{name: "複雑度の重みを変えるとバージョンが変わる", definition: newFileWeight, same: false},
+		{name: "ファイル種別の重みを変えるとバージョンが変わる", definition: goWeight, same: false},
+		{name: "タイムゾーンを変えるとバージョンが変わる", definition: utc, same: false},
+		{name: "サイズの閾値を変えるとバージョンが変わる", definition: sizeThresholds, same: false},
+		{name: "レビューラウンドの間隔を変えるとバージョンが変わる", definition: reviewInterval, same: false},
+		{name: "ボトルネックの閾値はバージョンに含めない", definition: bottleneck, same: true},
+		{name: "未設定のサイズ・レビュー設定はデフォルトと同じ扱い", definition: zeroValues, same: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.definition.Version()
+			if len(got) != 12 {
+				t.Errorf("Version() = %q, want 12 characters", got)
+			}
+			if (got == base.Version()) != tt.same {
+				t.Errorf("Version() = %q, base = %q, want same = %v", got, base.Version(), tt.same)
+			}
+		})
+	}
+}
+
+func TestMetricDefinition_Version_Holidays(t *testing.T) {
+	excludeHolidays := DefaultMetricDefinition()
+	excludeHolidays.CycleTime.ExcludeHolidays = true
+
+	withCalendar := DefaultMetricDefinition()
+	withCalendar.CycleTime.ExcludeHolidays = true
+	withCalendar.CycleTime.HolidayCalendars = map[string]*HolidayCalendar{
+		"company": NewHolidayCalendar("company", []Holiday{{Date: time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), Name: "年末休暇"}}),
+	}
+
+	changedCalendar := DefaultMetricDefinition()
+	changedCalendar.CycleTime.ExcludeHolidays = true
+	changedCalendar.CycleTime.HolidayCalendars = map[string]*HolidayCalendar{
+		"company": NewHolidayCalendar("company", []Holiday{{Date: time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC), Name: "年末休暇"}}),
+	}
+
+	unusedCalendar := DefaultMetricDefinition()
+	unusedCalendar.CycleTime.HolidayCalendars = withCalendar.CycleTime.HolidayCalendars
+
+	if excludeHolidays.Version() == DefaultMetricDefinition().Version() {
+		t.Error("祝日を除外するとバージョンが変わるべき")
+	}
+	if withCalendar.Version() == excludeHolidays.Version() {
+		t.Error("休日カレンダーを追加するとバージョンが変わるべき")
+	}
+	if withCalendar.Version() == changedCalendar.Version() {
+		t.Error("休日カレンダーの内容を変えるとバージョンが変わるべき")
+	}
+	if unusedCalendar.Version() != DefaultMetricDefinition().Version() {
+		t.Error("祝日を除外しない場合は休日カレンダーでバージョンが変わらないべき")
+	}
+}
@@ -13,6 +13,9 @@ type PullRequestMetrics struct {
 	
 	// 総Pull Request数
 	TotalPullRequests int `json:"totalPullRequests"`
+	
+	// 平均値の計算から除外したbot作成のPull Request数
+	AutomationPullRequests int `json:"automationPullRequests"`
 }
 
 // ValidationDetails はバリデーションエラーの詳細情報
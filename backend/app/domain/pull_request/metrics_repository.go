@@ -0,0 +1,69 @@
+package pull_request
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMetricsNotFound は更新対象のPRメトリクスが存在しないことを表す
+var ErrMetricsNotFound = errors.New("pr metrics not found")
+
+// MetricsRepository はPRメトリクスの永続化の抽象化
+// PRはPR IDで識別し、保存先固有の内部IDは扱わない
+type MetricsRepository interface {
+	// Save はPRメトリクスを保存（同じPR IDのデータは上書き）
+	Save(ctx context.Context, metrics *PRMetrics) error
+
+	// SaveBatch は複数のPRメトリクスをまとめて保存（既存のPR IDは上書きしない）
+	SaveBatch(ctx context.Context, metricsList []*PRMetrics) error
+
+	// FindByPRID はPR IDによりPRメトリクスを取得（存在しない場合は nil）
+	FindByPRID(ctx context.Context, prID string) (*PRMetrics, error)
+
+	// FindByDateRange は作成日時が範囲内のPRメトリクスを作成日時の新しい順に取得
+	// developers / repositories が空の場合は絞り込まない
+	FindByDateRange(ctx context.Context, startDate, endDate time.Time, developers []string, repositories []string) ([]*PRMetrics, error)
+
+	// FindByDeveloper は開発者によりPRメトリクスを取得
+	FindByDeveloper(ctx context.Context, developer string, startDate, endDate time.Time) ([]*PRMetrics, error)
+
+	// FindByRepository はリポジトリによりPRメトリクスを取得
+	FindByRepository(ctx context.Context, repository string, startDate, endDate time.Time) ([]*PRMetrics, error)
+
+	// Update は既存のPRメトリクスを更新（存在しない場合は ErrMetricsNotFound）
+	Update(ctx context.Context, metrics *PRMetrics) error
+
+	// DeleteOldData は収集から retentionDays 日を超えたデータを削除し、削除件数を返す
+	DeleteOldData(ctx context.Context, retentionDays int) (int64, error)
+
+	// FindCollectedBefore は収集日時が cutoff より前のPRメトリクスを収集日時の古い順に取得
+	FindCollectedBefore(ctx context.Context, cutoff time.Time) ([]*PRMetrics, error)
+
+	// FindCollectedSince は収集日時が since 以降のPRメトリクスを収集日時の古い順に取得
+	// 再収集したPRも収集日時が更新されるため、前回の処理以降に変化したPRの検出に使う
+	FindCollectedSince(ctx context.Context, since time.Time) ([]*PRMetrics, error)
+
+	// DeleteCollectedBefore は収集日時が cutoff より前のPRメトリクスを削除し、削除件数を返す
+	DeleteCollectedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// FindReviewEvents はPR IDのレビューイベントを発生日時の順に取得（PRが存在しない場合は空）
+	FindReviewEvents(ctx context.Context, prID string) ([]ReviewEvent, error)
+
+	// FindReviewEventsByPRIDs は複数PRのレビューイベントをPR IDごとに発生日時の順に取得（イベントのないPRは含めない）
+	FindReviewEventsByPRIDs(ctx context.Context, prIDs []string) (map[string][]ReviewEvent, error)
+
+	// GetStatistics は保存済みデータの統計情報を取得
+	GetStatistics(ctx context.Context) (*MetricsStatistics, error)
+}
+
+// MetricsStatistics は保存済みPRメトリクスの統計情報
+type MetricsStatistics struct {
+	TotalRecords       int64     `json:"totalRecords"`
+	UniqueDevelopers   int64     `json:"uniqueDevelopers"`
+	UniqueRepositories int64     `json:"uniqueRepositories"`
+	OldestRecord       time.Time `json:"oldestRecord"`
+	NewestRecord       time.Time `json:"newestRecord"`
+	AvgCycleTime       *float64  `json:"avgCycleTime"` // 秒
+	AvgComplexity      *float64  `json:"avgComplexity"`
+}
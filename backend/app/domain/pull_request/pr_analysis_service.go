@@ -4,11 +4,26 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 )
 
 // PRAnalysisService はPull Requestの分析を行うサービス
+// リポジトリごとに適用する計算定義で分析する
 type PRAnalysisService struct {
+	definitions DefinitionSource
+	
+	// 定義バージョンごとの分析器（設定の変更で新しい定義になった場合に作り直す）
+	mu        sync.Mutex
+	analyzers map[string]*definitionAnalyzers
+	
+	changeDetector *ChangeFailureDetector
+}
+
+// definitionAnalyzers は1つの計算定義で作成した分析器
+type definitionAnalyzers struct {
+	definition         MetricDefinition
+	version            string
 	complexityAnalyzer *PRComplexityAnalyzer
 	cycleTimeCalc      *CycleTimeCalculator
 	reviewTimeAnalyzer *ReviewTimeAnalyzer
@@ -16,13 +31,69 @@ type PRAnalysisService struct {
 
 // NewPRAnalysisService は新しいPR分析サービスを作成
 func NewPRAnalysisService() *PRAnalysisService {
+	return NewPRAnalysisServiceWithDefinition(DefaultMetricDefinition())
+}
+
+// NewPRAnalysisServiceWithCycleTimeConfig はサイクルタイム設定を指定してPR分析サービスを作成
+func NewPRAnalysisServiceWithCycleTimeConfig(cycleTimeConfig CycleTimeConfig) *PRAnalysisService {
+	return NewPRAnalysisServiceWithDefinition(MetricDefinition{
+		CycleTime:  cycleTimeConfig,
+		Complexity: DefaultComplexityConfig(),
+	})
+}
+
+// NewPRAnalysisServiceWithDefinition は計算設定を指定してPR分析サービスを作成
+func NewPRAnalysisServiceWithDefinition(definition MetricDefinition) *PRAnalysisService {
+	return NewPRAnalysisServiceWithSource(staticDefinition(definition.withDefaults()))
+}
+
+// NewPRAnalysisServiceWithSource はリポジトリごとの計算定義の取得元を指定してPR分析サービスを作成
+func NewPRAnalysisServiceWithSource(definitions DefinitionSource) *PRAnalysisService {
 	return &PRAnalysisService{
-		complexityAnalyzer: NewPRComplexityAnalyzer(),
-		cycleTimeCalc:      NewCycleTimeCalculator(),
-		reviewTimeAnalyzer: NewReviewTimeAnalyzer(),
+		definitions:    definitions,
+		analyzers:      make(map[string]*definitionAnalyzers),
+		changeDetector: NewChangeFailureDetector(nil),
 	}
 }
 
+// WithChangeFailureDetector はリバート・ホットフィックスの判定に使う判定器を設定
+func (s *PRAnalysisService) WithChangeFailureDetector(detector *ChangeFailureDetector) *PRAnalysisService {
+	s.changeDetector = detector
+	return s
+}
+
+// DefinitionVersion は全体の計算定義のバージョンを返す
+func (s *PRAnalysisService) DefinitionVersion() string {
+	return s.analyzersFor("").version
+}
+
+// DefinitionVersionFor はリポジトリに適用する計算定義のバージョンを返す
+func (s *PRAnalysisService) DefinitionVersionFor(repository string) string {
+	return s.analyzersFor(repository).version
+}
+
+// analyzersFor はリポジトリに適用する計算定義の分析器を返す
+func (s *PRAnalysisService) analyzersFor(repository string) *definitionAnalyzers {
+	definition := s.definitions.DefinitionFor(repository)
+	version := definition.Version()
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if analyzers, exists := s.analyzers[version]; exists {
+		return analyzers
+	}
+	analyzers := &definitionAnalyzers{
+		definition:         definition,
+		version:            version,
+		complexityAnalyzer: NewPRComplexityAnalyzerWithConfig(definition.Complexity),
+		cycleTimeCalc:      NewCycleTimeCalculatorWithConfig(definition.CycleTime),
+		reviewTimeAnalyzer: NewReviewTimeAnalyzerWithConfig(definition.Review),
+	}
+	s.analyzers[version] = analyzers
+	return analyzers
+}
+
 // AnalyzePR はPull Requestの包括的な分析を実行
 func (s *PRAnalysisService) AnalyzePR(ctx context.Context, pr PullRequest, reviewEvents []ReviewEvent, fileChanges []FileChangeMetrics) (*PRMetrics, error) {
 	// 基本的なPRメトリクスの構築
@@ -34,26 +105,64 @@ func (s *PRAnalysisService) AnalyzePR(ctx context.Context, pr PullRequest, revie
 		Repository: pr.Repository.Name,
 		CreatedAt:  pr.CreatedAt,
 		MergedAt:   pr.MergedAt,
+		Labels:     pr.Labels,
+		IsBot:      pr.Author.IsBot,
+		
+		BaseBranch:     pr.BaseRefName,
+		HeadBranch:     pr.HeadRefName,
+		MergeCommitSHA: pr.MergeCommitSHA,
+		ChangeFailure:  s.changeDetector.Detect(pr),
 	}
 	
+	analyzers := s.analyzersFor(pr.Repository.Name)
+	
 	// サイズメトリクスの計算
 	metrics.SizeMetrics = s.calculateSizeMetrics(pr, fileChanges)
 	
 	// 時間メトリクスの計算
-	metrics.TimeMetrics = s.cycleTimeCalc.CalculateTimeMetrics(pr, reviewEvents)
+	metrics.TimeMetrics = analyzers.cycleTimeCalc.CalculateTimeMetrics(pr, reviewEvents)
 	
 	// 品質メトリクスの計算
-	metrics.QualityMetrics = s.reviewTimeAnalyzer.CalculateQualityMetrics(pr, reviewEvents)
+	metrics.QualityMetrics = analyzers.reviewTimeAnalyzer.CalculateQualityMetrics(pr, reviewEvents)
+	
+	// サイズカテゴリの決定（複雑度のサイズ補正に使うため先に決める）
+	metrics.SizeCategory = analyzers.definition.Size.Category(metrics.SizeMetrics.LinesChanged)
 	
 	// 複雑度スコアの計算
-	metrics.ComplexityScore = s.complexityAnalyzer.AnalyzeComplexity(metrics)
+	metrics.ComplexityScore = analyzers.complexityAnalyzer.AnalyzeComplexity(metrics)
 	
-	// サイズカテゴリの決定
-	metrics.SizeCategory = metrics.CalculateSizeCategory()
+	metrics.DefinitionVersion = analyzers.version
 	
 	return metrics, nil
 }
 
+// Recalculate は保存済みのメトリクスとレビューイベントから、現在の定義で時間メトリクスと複雑度を計算し直す
+// サイズ・品質メトリクスは収集時の値を使い、サイズカテゴリはリポジトリの閾値で決め直す。元のメトリクスは変更しない
+func (s *PRAnalysisService) Recalculate(metrics *PRMetrics, reviewEvents []ReviewEvent) *PRMetrics {
+	recalculated := *metrics
+	pr := PullRequest{
+		ID:         metrics.PRID,
+		Author:     Author{Login: metrics.Author},
+		Repository: RepositoryInfo{Name: metrics.Repository},
+		CreatedAt:  metrics.CreatedAt,
+		MergedAt:   metrics.MergedAt,
+	}
+	
+	s.ApplyDefinition(&recalculated, pr, reviewEvents)
+	return &recalculated
+}
+
+// ApplyDefinition はリポジトリに適用する計算定義で、メトリクスの時間メトリクス・サイズカテゴリ・複雑度と定義バージョンを設定する
+// 収集時にも再計算時と同じ定義（休日カレンダー・勤務時間・リポジトリごとの設定を含む）を使うためのもの
+func (s *PRAnalysisService) ApplyDefinition(metrics *PRMetrics, pr PullRequest, reviewEvents []ReviewEvent) {
+	analyzers := s.analyzersFor(metrics.Repository)
+	
+	metrics.TimeMetrics = analyzers.cycleTimeCalc.CalculateTimeMetrics(pr, reviewEvents)
+	metrics.SizeCategory = analyzers.definition.Size.Category(metrics.SizeMetrics.LinesChanged)
+	metrics.ComplexityScore = analyzers.complexityAnalyzer.AnalyzeComplexity(metrics)
+	metrics.DefinitionVersion = analyzers.version
+}
+
 // AnalyzeBatch は複数のPRを一括で分析
 func (s *PRAnalysisService) AnalyzeBatch(ctx context.Context, prs []PullRequest) ([]*PRMetrics, error) {
 	results := make([]*PRMetrics, 0, len(prs))
@@ -117,6 +226,13 @@ func (s *PRAnalysisService) analyzeBasicPR(pr PullRequest) *PRMetrics {
 		Repository: pr.Repository.Name,
 		CreatedAt:  pr.CreatedAt,
 		MergedAt:   pr.MergedAt,
+		Labels:     pr.Labels,
+		IsBot:      pr.Author.IsBot,
+		
+		BaseBranch:     pr.BaseRefName,
+		HeadBranch:     pr.HeadRefName,
+		MergeCommitSHA: pr.MergeCommitSHA,
+		ChangeFailure:  s.changeDetector.Detect(pr),
 	}
 	
 	// 基本的なサイズメトリクス
@@ -172,17 +288,20 @@ func (s *PRAnalysisService) analyzeBasicPR(pr PullRequest) *PRMetrics {
 		ApproversInvolved:     []string{},
 	}
 	
-	// 複雑度スコア（基本値）
-	metrics.ComplexityScore = s.complexityAnalyzer.AnalyzeComplexity(metrics)
+	analyzers := s.analyzersFor(pr.Repository.Name)
 	
 	// サイズカテゴリ
-	metrics.SizeCategory = metrics.CalculateSizeCategory()
+	metrics.SizeCategory = analyzers.definition.Size.Category(metrics.SizeMetrics.LinesChanged)
+	
+	// 複雑度スコア（基本値）
+	metrics.ComplexityScore = analyzers.complexityAnalyzer.AnalyzeComplexity(metrics)
 	
 	return metrics
 }
 
 // GenerateInsights はPRの分析結果から洞察を生成
 func (s *PRAnalysisService) GenerateInsights(metrics *PRMetrics) *PRInsights {
+	analyzers := s.analyzersFor(metrics.Repository)
 	insights := &PRInsights{
 		PRID: metrics.PRID,
 	}
@@ -197,7 +316,7 @@ func (s *PRAnalysisService) GenerateInsights(metrics *PRMetrics) *PRInsights {
 		})
 		
 		// 分割提案
-		if suggestions := s.complexityAnalyzer.SuggestOptimalSplit(metrics); len(suggestions) > 0 {
+		if suggestions := analyzers.complexityAnalyzer.SuggestOptimalSplit(metrics); len(suggestions) > 0 {
 			insights.SplitSuggestions = suggestions
 		}
 	}
@@ -226,7 +345,7 @@ func (s *PRAnalysisService) GenerateInsights(metrics *PRMetrics) *PRInsights {
 	}
 	
 	// 複雑度関連の洞察
-	complexityLevel := s.complexityAnalyzer.GetComplexityLevel(metrics.ComplexityScore)
+	complexityLevel := analyzers.complexityAnalyzer.GetComplexityLevel(metrics.ComplexityScore)
 	if complexityLevel == ComplexityLevelHigh || complexityLevel == ComplexityLevelVeryHigh {
 		insights.ComplexityIssues = append(insights.ComplexityIssues, ComplexityIssue{
 			Type:        ComplexityIssueTypeHighComplexity,
@@ -277,7 +396,7 @@ func (s *PRAnalysisService) generateRecommendedActions(metrics *PRMetrics) []Rec
 	}
 	
 	// 複雑度ベースの推奨
-	complexityLevel := s.complexityAnalyzer.GetComplexityLevel(metrics.ComplexityScore)
+	complexityLevel := s.analyzersFor(metrics.Repository).complexityAnalyzer.GetComplexityLevel(metrics.ComplexityScore)
 	if complexityLevel >= ComplexityLevelHigh {
 		actions = append(actions, RecommendedAction{
 			Type:        ActionTypeSimplifyChanges,
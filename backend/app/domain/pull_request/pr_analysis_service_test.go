@@ -0,0 +1,51 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPRAnalysisService_Recalculate(t *testing.T) {
+	// 2024-01-12 は金曜日
+	createdAt := time.Date(2024, 1, 12, 17, 0, 0, 0, time.UTC)
+	approvedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	mergedAt := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	stored := &PRMetrics{
+		PRID:        "PR_1",
+		CreatedAt:   createdAt,
+		MergedAt:    &mergedAt,
+		SizeMetrics: PRSizeMetrics{LinesAdded: 40, LinesDeleted: 10, LinesChanged: 50, FilesChanged: 2},
+		TimeMetrics: PRTimeMetrics{TotalCycleTime: durationPtr(time.Hour)},
+	}
+	events := []ReviewEvent{
+		{Type: ReviewEventTypeApproved, CreatedAt: approvedAt, Actor: "reviewer1"},
+	}
+
+	definition := DefaultMetricDefinition()
+	definition.CycleTime.UseBusinessHours = true
+	definition.CycleTime.ExcludeWeekends = true
+	definition.CycleTime.Timezone = time.UTC
+	service := NewPRAnalysisServiceWithDefinition(definition)
+
+	recalculated := service.Recalculate(stored, events)
+
+	if recalculated.DefinitionVersion != definition.Version() {
+		t.Errorf("DefinitionVersion = %q, want %q", recalculated.DefinitionVersion, definition.Version())
+	}
+	// 金曜17時〜18時と月曜9時〜11時の営業時間のみを数える
+	if recalculated.TimeMetrics.TotalCycleTime == nil || *recalculated.TimeMetrics.TotalCycleTime != 3*time.Hour {
+		t.Errorf("TotalCycleTime = %v, want 3h", recalculated.TimeMetrics.TotalCycleTime)
+	}
+	if recalculated.TimeMetrics.TimeToApproval == nil || *recalculated.TimeMetrics.TimeToApproval != 2*time.Hour {
+		t.Errorf("TimeToApproval = %v, want 2h", recalculated.TimeMetrics.TimeToApproval)
+	}
+	if recalculated.ComplexityScore <= 0 {
+		t.Errorf("ComplexityScore = %v, want positive", recalculated.ComplexityScore)
+	}
+	if recalculated.SizeMetrics.LinesChanged != 50 {
+		t.Errorf("SizeMetrics.LinesChanged = %d, want 50", recalculated.SizeMetrics.LinesChanged)
+	}
+	if *stored.TimeMetrics.TotalCycleTime != time.Hour || stored.DefinitionVersion != "" {
+		t.Error("元のメトリクスが変更された")
+	}
+}
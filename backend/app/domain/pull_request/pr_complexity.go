@@ -38,6 +38,18 @@ func NewPRComplexityAnalyzer() *PRComplexityAnalyzer {
 	}
 }
 
+// NewPRComplexityAnalyzerWithConfig は設定を指定して複雑度分析器を作成
+func NewPRComplexityAnalyzerWithConfig(config ComplexityConfig) *PRComplexityAnalyzer {
+	return &PRComplexityAnalyzer{
+		config: config,
+	}
+}
+
+// DefaultComplexityConfig はデフォルトの複雑度設定を返す
+func DefaultComplexityConfig() ComplexityConfig {
+	return getDefaultComplexityConfig()
+}
+
 // getDefaultComplexityConfig はデフォルトの複雑度設定を返す
 func getDefaultComplexityConfig() ComplexityConfig {
 	return ComplexityConfig{
@@ -160,8 +172,12 @@ func (analyzer *PRComplexityAnalyzer) calculateFileTypeComplexity(metrics *PRMet
 }
 
 // calculateSizeComplexity はサイズによる複雑度を計算
+// サイズカテゴリが決まっている場合はそれを使い、未設定の場合はデフォルトの閾値で判定する
 func (analyzer *PRComplexityAnalyzer) calculateSizeComplexity(metrics *PRMetrics) float64 {
-	sizeCategory := metrics.CalculateSizeCategory()
+	sizeCategory := metrics.SizeCategory
+	if sizeCategory == "" {
+		sizeCategory = metrics.CalculateSizeCategory()
+	}
 	multiplier, exists := analyzer.config.LineSizeMultipliers[sizeCategory]
 	if !exists {
 		multiplier = 1.0
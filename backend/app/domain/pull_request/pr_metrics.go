@@ -1,6 +1,7 @@
 package pull_request
 
 import (
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,16 @@ type PRMetrics struct {
 	Repository   string    `json:"repository"`
 	CreatedAt    time.Time `json:"createdAt"`
 	MergedAt     *time.Time `json:"mergedAt,omitempty"`
+	Labels       []string   `json:"labels"`
+	IsBot        bool       `json:"isBot"` // botが作成したPR
+
+	// ブランチ・マージコミット（リバート・ホットフィックスの元PRの特定に使う）
+	BaseBranch     string `json:"baseBranch,omitempty"`
+	HeadBranch     string `json:"headBranch,omitempty"`
+	MergeCommitSHA string `json:"mergeCommitSha,omitempty"`
+
+	// リバート・ホットフィックスの判定結果
+	ChangeFailure ChangeFailureSignals `json:"changeFailure"`
 
 	// サイズメトリクス
 	SizeMetrics PRSizeMetrics `json:"sizeMetrics"`
@@ -29,6 +40,12 @@ type PRMetrics struct {
 	
 	// PRサイズ分類
 	SizeCategory PRSizeCategory `json:"sizeCategory"`
+
+	// 計算に使った定義バージョン（MetricDefinition.Version）。空の場合は定義によらない収集時の値
+	DefinitionVersion string `json:"definitionVersion,omitempty"`
+
+	// レビューイベント（収集時に設定し、保存後は MetricsRepository.FindReviewEvents で取得する）
+	ReviewEvents []ReviewEvent `json:"reviewEvents,omitempty"`
 }
 
 // PRSizeMetrics はPRのサイズ関連メトリクス
@@ -75,6 +92,11 @@ type PRTimeMetrics struct {
 	// その他の時間
 	FirstCommitToMerge *time.Duration `json:"firstCommitToMerge,omitempty"`
 	
+	// ドラフト関連
+	TimeInDraft      *time.Duration `json:"timeInDraft,omitempty"`      // ドラフト状態の合計時間
+	ReadyForReviewAt *time.Time     `json:"readyForReviewAt,omitempty"` // 最後にレビュー可能になった時刻
+	DraftIntervals   []DraftInterval `json:"draftIntervals,omitempty"`
+	
 	// 時間帯分析
 	CreatedHour int `json:"createdHour"` // 作成時刻（0-23）
 	MergedHour  *int `json:"mergedHour,omitempty"` // マージ時刻（0-23）
@@ -87,6 +109,7 @@ type PRQualityMetrics struct {
 	ReviewRoundCount      int `json:"reviewRoundCount"`
 	ReviewerCount         int `json:"reviewerCount"`
 	ReviewersInvolved     []string `json:"reviewersInvolved"`
+	BotReviewersInvolved  []string `json:"botReviewersInvolved,omitempty"` // レビュアー数から除外したbot
 	
 	// 修正関連
 	CommitCount           int `json:"commitCount"`
@@ -116,21 +139,9 @@ const (
 // ドメインロジック: PRメトリクス計算
 
 // CalculateSizeCategory はPRサイズカテゴリを計算
+// デフォルトの閾値を使う。リポジトリごとの閾値は SizeThresholds.Category を使う
 func (m *PRMetrics) CalculateSizeCategory() PRSizeCategory {
-	totalLines := m.SizeMetrics.LinesChanged
-	
-	switch {
-	case totalLines <= 50:
-		return PRSizeXSmall
-	case totalLines <= 100:
-		return PRSizeSmall
-	case totalLines <= 300:
-		return PRSizeMedium
-	case totalLines <= 600:
-		return PRSizeLarge
-	default:
-		return PRSizeXLarge
-	}
+	return DefaultSizeThresholds().Category(m.SizeMetrics.LinesChanged)
 }
 
 // IsLargePR は大きすぎるPRかを判定
@@ -188,12 +199,57 @@ func (m *PRMetrics) GetDominantFileType() string {
 	return dominantType
 }
 
+// HasLabel は指定ラベルが付与されているかを判定（大文字小文字は区別しない）
+func (m *PRMetrics) HasLabel(label string) bool {
+	for _, l := range m.Labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesLabelFilter はラベルの包含・除外条件に一致するかを判定
+// includeが空の場合は包含条件なしとみなす
+func (m *PRMetrics) MatchesLabelFilter(include, exclude []string) bool {
+	for _, label := range exclude {
+		if m.HasLabel(label) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, label := range include {
+		if m.HasLabel(label) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterMetricsByLabels はラベル条件に一致するPRメトリクスのみを返す
+func FilterMetricsByLabels(metrics []*PRMetrics, include, exclude []string) []*PRMetrics {
+	if len(include) == 0 && len(exclude) == 0 {
+		return metrics
+	}
+	
+	filtered := make([]*PRMetrics, 0, len(metrics))
+	for _, m := range metrics {
+		if m.MatchesLabelFilter(include, exclude) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
 // ReviewEvent はレビューイベントの情報
 type ReviewEvent struct {
 	Type      ReviewEventType `json:"type"`
 	CreatedAt time.Time       `json:"createdAt"`
 	Actor     string          `json:"actor"`
 	Reviewer  string          `json:"reviewer,omitempty"`
+	IsBot     bool            `json:"isBot,omitempty"` // Actor がbotかどうか
 }
 
 // ReviewEventType はレビューイベントのタイプ
@@ -201,10 +257,12 @@ type ReviewEventType string
 
 const (
 	ReviewEventTypeRequested         ReviewEventType = "requested"
+	ReviewEventTypeRequestRemoved    ReviewEventType = "request_removed"
 	ReviewEventTypeApproved          ReviewEventType = "approved"
 	ReviewEventTypeChangesRequested ReviewEventType = "changes_requested"
 	ReviewEventTypeCommented         ReviewEventType = "commented"
 	ReviewEventTypeDismissed         ReviewEventType = "dismissed"
 	ReviewEventTypeReadyForReview    ReviewEventType = "ready_for_review"
+	ReviewEventTypeConvertedToDraft  ReviewEventType = "converted_to_draft"
 	ReviewEventTypeMerged            ReviewEventType = "merged"
 )
\ No newline at end of file
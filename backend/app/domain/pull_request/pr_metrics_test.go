@@ -388,4 +388,52 @@ func TestPRMetrics_IntegrationTest(t *testing.T) {
 	if metrics.HasLongReviewTime(3 * time.Hour) {
 		t.Error("Expected not to have long review time with 3 hour threshold")
 	}
-}
\ No newline at end of file
+}
+func TestPRMetrics_MatchesLabelFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   []string
+		include  []string
+		exclude  []string
+		expected bool
+	}{
+		{"フィルタなし", []string{"bug"}, nil, nil, true},
+		{"ラベルなしPRはフィルタなしで一致", nil, nil, nil, true},
+		{"包含ラベルに一致", []string{"bug", "backend"}, []string{"bug"}, nil, true},
+		{"包含ラベルは大文字小文字を区別しない", []string{"Bug"}, []string{"bug"}, nil, true},
+		{"包含ラベルに一致しない", []string{"feature"}, []string{"bug"}, nil, false},
+		{"ラベルなしPRは包含条件に一致しない", nil, []string{"bug"}, nil, false},
+		{"除外ラベルに一致", []string{"bug", "chore"}, nil, []string{"chore"}, false},
+		{"除外が包含より優先", []string{"bug", "chore"}, []string{"bug"}, []string{"chore"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &PRMetrics{Labels: tt.labels}
+
+			result := metrics.MatchesLabelFilter(tt.include, tt.exclude)
+			if result != tt.expected {
+				t.Errorf("MatchesLabelFilter() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterMetricsByLabels(t *testing.T) {
+	metrics := []*PRMetrics{
+		{PRID: "pr-1", Labels: []string{"bug"}},
+		{PRID: "pr-2", Labels: []string{"feature"}},
+		{PRID: "pr-3", Labels: []string{"bug", "chore"}},
+		{PRID: "pr-4"},
+	}
+
+	filtered := FilterMetricsByLabels(metrics, []string{"bug"}, []string{"chore"})
+	if len(filtered) != 1 || filtered[0].PRID != "pr-1" {
+		t.Errorf("Expected only pr-1, got %v", filtered)
+	}
+
+	unfiltered := FilterMetricsByLabels(metrics, nil, nil)
+	if len(unfiltered) != len(metrics) {
+		t.Errorf("Expected %d metrics without filter, got %d", len(metrics), len(unfiltered))
+	}
+}
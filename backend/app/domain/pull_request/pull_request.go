@@ -19,11 +19,15 @@ type PullRequest struct {
 	FirstReviewed *time.Time
 	LastApproved  *time.Time
 	MergedAt     *time.Time
+	Labels       []string
+	MergeCommitSHA string   // マージ時に作成されたコミット（未マージの場合は空）
+	CommitMessages []string // PRのコミットメッセージ（詳細取得時のみ設定）
 }
 
 type Author struct {
 	Login     string
 	AvatarURL string
+	IsBot     bool // GitHub App / botアカウントによる作成
 }
 
 type RepositoryInfo struct {
@@ -39,6 +43,11 @@ func (pr PullRequest) IsApproved() bool {
 	return pr.LastApproved != nil
 }
 
+// IsAutomated はbotによって作成されたPRかどうか
+func (pr PullRequest) IsAutomated() bool {
+	return pr.Author.IsBot
+}
+
 func (pr PullRequest) IsMerged() bool {
 	return pr.MergedAt != nil
 }
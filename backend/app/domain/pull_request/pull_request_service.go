@@ -66,8 +66,15 @@ func (s *PullRequestService) CalculateMetrics(pullRequests []PullRequest) PullRe
 	
 	var totalReviewTime, totalApprovalTime, totalMergeTime int64
 	var validReviewCount, validApprovalCount, validMergeCount int
+	var automationCount int
 	
 	for _, pr := range pullRequests {
+		// botのPRは人手のレビュー時間を歪めるため平均から除外
+		if pr.IsAutomated() {
+			automationCount++
+			continue
+		}
+		
 		// レビューまでの時間
 		if reviewTime := s.calculateTimeToFirstReview(pr); reviewTime > 0 {
 			totalReviewTime += reviewTime
@@ -92,6 +99,7 @@ func (s *PullRequestService) CalculateMetrics(pullRequests []PullRequest) PullRe
 		AverageTimeToApproval:    s.safeAverage(totalApprovalTime, validApprovalCount),
 		AverageTimeToMerge:       s.safeAverage(totalMergeTime, validMergeCount),
 		TotalPullRequests:        len(pullRequests),
+		AutomationPullRequests:   automationCount,
 	}
 }
 
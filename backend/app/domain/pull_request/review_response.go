@@ -0,0 +1,110 @@
+package pull_request
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReviewRequestOutcome はレビュー依頼の結果
+type ReviewRequestOutcome string
+
+const (
+	ReviewRequestOutcomeReviewed   ReviewRequestOutcome = "reviewed"   // 依頼されたレビュアーがレビューを提出した
+	ReviewRequestOutcomeRemoved    ReviewRequestOutcome = "removed"    // レビューの前に依頼が取り消された
+	ReviewRequestOutcomeUnanswered ReviewRequestOutcome = "unanswered" // レビューも取り消しもされていない
+)
+
+// ReviewRequest はレビュアー1人への1回のレビュー依頼とその結果
+// 同じレビュアーへの再依頼（レビュー後の再レビュー依頼等）は別の依頼として扱う
+type ReviewRequest struct {
+	PR          *PRMetrics
+	Reviewer    string
+	RequestedAt time.Time
+	Outcome     ReviewRequestOutcome
+	RespondedAt time.Time // レビューまたは取り消しの日時（未対応の場合はゼロ値）
+}
+
+// ResponseTime は依頼からレビューまでの時間（レビューされていない場合は false）
+func (r ReviewRequest) ResponseTime() (time.Duration, bool) {
+	if r.Outcome != ReviewRequestOutcomeReviewed {
+		return 0, false
+	}
+	return r.RespondedAt.Sub(r.RequestedAt), true
+}
+
+// WaitingTime は未対応の依頼の待ち時間（PRがマージ済みの場合はマージまで、それ以外は now まで）
+func (r ReviewRequest) WaitingTime(now time.Time) time.Duration {
+	end := now
+	if r.PR.MergedAt != nil {
+		end = *r.PR.MergedAt
+	}
+	if end.Before(r.RequestedAt) {
+		return 0
+	}
+	return end.Sub(r.RequestedAt)
+}
+
+// CollectReviewRequests はPRのレビューイベントからレビュアーごとのレビュー依頼とその結果を取り出す
+// 依頼はそのレビュアーの次のレビュー（approved・changes_requested・commented）または取り消しで対応済みとなる
+// 対応前に同じレビュアーへ重ねて依頼した場合は最初の依頼のみを数える
+// チームへの依頼（レビュアーのない依頼）、botのレビュー、PR作者自身への依頼は除き、resolver が指定されている場合は正規IDに揃える
+// 結果はPRの順、PR内は依頼日時の順に並ぶ
+func CollectReviewRequests(metrics []*PRMetrics, events map[string][]ReviewEvent, resolver IdentityResolver) []ReviewRequest {
+	resolve := func(login string) string {
+		if resolver == nil {
+			return login
+		}
+		return resolver.Resolve(login)
+	}
+
+	var requests []ReviewRequest
+	for _, pr := range metrics {
+		prEvents := make([]ReviewEvent, len(events[pr.PRID]))
+		copy(prEvents, events[pr.PRID])
+		sort.SliceStable(prEvents, func(i, j int) bool {
+			return prEvents[i].CreatedAt.Before(prEvents[j].CreatedAt)
+		})
+
+		author := resolve(pr.Author)
+		var prRequests []ReviewRequest
+		open := make(map[string]int) // レビュアー → 未対応の依頼の prRequests 内の位置
+		respond := func(reviewer string, outcome ReviewRequestOutcome, at time.Time) {
+			index, exists := open[reviewer]
+			if !exists {
+				return
+			}
+			prRequests[index].Outcome = outcome
+			prRequests[index].RespondedAt = at
+			delete(open, reviewer)
+		}
+
+		for _, event := range prEvents {
+			reviewer := resolve(event.Reviewer)
+			if reviewer == "" || strings.EqualFold(reviewer, author) {
+				continue
+			}
+			switch event.Type {
+			case ReviewEventTypeRequested:
+				if _, exists := open[reviewer]; exists {
+					continue
+				}
+				open[reviewer] = len(prRequests)
+				prRequests = append(prRequests, ReviewRequest{
+					PR:          pr,
+					Reviewer:    reviewer,
+					RequestedAt: event.CreatedAt,
+					Outcome:     ReviewRequestOutcomeUnanswered,
+				})
+			case ReviewEventTypeRequestRemoved:
+				respond(reviewer, ReviewRequestOutcomeRemoved, event.CreatedAt)
+			case ReviewEventTypeApproved, ReviewEventTypeChangesRequested, ReviewEventTypeCommented:
+				if !event.IsBot {
+					respond(reviewer, ReviewRequestOutcomeReviewed, event.CreatedAt)
+				}
+			}
+		}
+		requests = append(requests, prRequests...)
+	}
+	return requests
+}
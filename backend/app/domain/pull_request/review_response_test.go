@@ -0,0 +1,68 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCollectReviewRequests(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return baseTime.Add(time.Duration(hours) * time.Hour)
+	}
+
+	pr := &PRMetrics{PRID: "pr-1", Author: "alice", CreatedAt: baseTime}
+	events := map[string][]ReviewEvent{
+		"pr-1": {
+			{Type: ReviewEventTypeRequested, CreatedAt: at(1), Actor: "alice", Reviewer: "bob"},
+			{Type: ReviewEventTypeRequested, CreatedAt: at(1), Actor: "alice", Reviewer: "carol-work"},
+			{Type: ReviewEventTypeRequested, CreatedAt: at(1), Actor: "alice", Reviewer: "dave"},
+			{Type: ReviewEventTypeRequested, CreatedAt: at(1), Actor: "alice"}, // チームへの依頼
+			// 順不同で保存されていても日時の順に対応付ける
+			{Type: ReviewEventTypeChangesRequested, CreatedAt: at(6), Actor: "bob", Reviewer: "bob"},
+			{Type: ReviewEventTypeApproved, CreatedAt: at(9), Actor: "bob", Reviewer: "bob"},
+			{Type: ReviewEventTypeRequested, CreatedAt: at(8), Actor: "alice", Reviewer: "bob"},
+			{Type: ReviewEventTypeRequested, CreatedAt: at(2), Actor: "alice", Reviewer: "carol"},
+			{Type: ReviewEventTypeRequestRemoved, CreatedAt: at(4), Actor: "alice", Reviewer: "carol"},
+			{Type: ReviewEventTypeCommented, CreatedAt: at(3), Actor: "dave", Reviewer: "dave", IsBot: true},
+		},
+	}
+
+	requests := CollectReviewRequests([]*PRMetrics{pr}, events, mapIdentityResolver{"carol-work": "carol"})
+	if len(requests) != 4 {
+		t.Fatalf("len(requests) = %d, want 4: %+v", len(requests), requests)
+	}
+
+	expected := []struct {
+		reviewer     string
+		requestedAt  time.Time
+		outcome      ReviewRequestOutcome
+		responseTime time.Duration
+	}{
+		{"bob", at(1), ReviewRequestOutcomeReviewed, 5 * time.Hour},
+		{"carol", at(1), ReviewRequestOutcomeRemoved, 0},
+		{"dave", at(1), ReviewRequestOutcomeUnanswered, 0},
+		{"bob", at(8), ReviewRequestOutcomeReviewed, time.Hour},
+	}
+	for i, want := range expected {
+		got := requests[i]
+		if got.Reviewer != want.reviewer || !got.RequestedAt.Equal(want.requestedAt) || got.Outcome != want.outcome {
+			t.Errorf("requests[%d] = %s %v %s, want %s %v %s", i, got.Reviewer, got.RequestedAt, got.Outcome, want.reviewer, want.requestedAt, want.outcome)
+			continue
+		}
+		responseTime, reviewed := got.ResponseTime()
+		if reviewed != (want.outcome == ReviewRequestOutcomeReviewed) || responseTime != want.responseTime {
+			t.Errorf("requests[%d].ResponseTime() = %v, %v, want %v", i, responseTime, reviewed, want.responseTime)
+		}
+	}
+
+	// 未対応の依頼はマージされていなければ現在まで待っている
+	if waiting := requests[2].WaitingTime(at(25)); waiting != 24*time.Hour {
+		t.Errorf("WaitingTime = %v, want 24h", waiting)
+	}
+	mergedAt := at(10)
+	pr.MergedAt = &mergedAt
+	if waiting := requests[2].WaitingTime(at(25)); waiting != 9*time.Hour {
+		t.Errorf("WaitingTime after merge = %v, want 9h", waiting)
+	}
+}
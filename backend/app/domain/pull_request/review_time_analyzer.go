@@ -8,7 +8,8 @@ import (
 
 // ReviewTimeAnalyzer はレビュー時間を分析するサービス
 type ReviewTimeAnalyzer struct {
-	config ReviewTimeConfig
+	config           ReviewTimeConfig
+	identityResolver IdentityResolver
 }
 
 // ReviewTimeConfig はレビュー時間分析の設定
@@ -32,6 +33,24 @@ func NewReviewTimeAnalyzer() *ReviewTimeAnalyzer {
 	}
 }
 
+// NewReviewTimeAnalyzerWithConfig は設定を指定してレビュー時間分析器を作成
+func NewReviewTimeAnalyzerWithConfig(config ReviewTimeConfig) *ReviewTimeAnalyzer {
+	return &ReviewTimeAnalyzer{
+		config: config,
+	}
+}
+
+// DefaultReviewTimeConfig はデフォルトの設定を返す
+func DefaultReviewTimeConfig() ReviewTimeConfig {
+	return getDefaultReviewTimeConfig()
+}
+
+// WithIdentityResolver はレビュアー集計で別アカウントを同一人物として扱うよう設定
+func (analyzer *ReviewTimeAnalyzer) WithIdentityResolver(resolver IdentityResolver) *ReviewTimeAnalyzer {
+	analyzer.identityResolver = resolver
+	return analyzer
+}
+
 // getDefaultReviewTimeConfig はデフォルトの設定を返す
 func getDefaultReviewTimeConfig() ReviewTimeConfig {
 	return ReviewTimeConfig{
@@ -60,11 +79,13 @@ func (analyzer *ReviewTimeAnalyzer) CalculateQualityMetrics(pr PullRequest, revi
 	qualityMetrics.CommitCount = 1 // 基本値（実際の実装では詳細なコミット情報から取得）
 	
 	// レビュアー分析
-	reviewers, approvers := analyzer.analyzeReviewers(sortedEvents)
+	humanEvents, botReviewers := splitBotReviewEvents(sortedEvents)
+	reviewers, approvers := analyzer.analyzeReviewers(humanEvents)
 	qualityMetrics.ReviewerCount = len(reviewers)
 	qualityMetrics.ApprovalsReceived = len(approvers)
 	qualityMetrics.ReviewersInvolved = reviewers
 	qualityMetrics.ApproversInvolved = approvers
+	qualityMetrics.BotReviewersInvolved = botReviewers
 	
 	// レビュー効率の計算
 	qualityMetrics.FirstReviewPassRate = analyzer.calculateFirstReviewPassRate(sortedEvents)
@@ -87,6 +108,9 @@ func (analyzer *ReviewTimeAnalyzer) AnalyzeReviewEfficiency(metrics []*PRMetrics
 		TotalPRs: len(metrics),
 	}
 	
+	// 同一人物の複数アカウントを束ねる
+	metrics = ResolveIdentities(metrics, analyzer.identityResolver)
+	
 	// レビュアー別の分析
 	reviewerStats := make(map[string]*ReviewerStatistics)
 	
@@ -206,6 +230,26 @@ func (analyzer *ReviewTimeAnalyzer) analyzeReviewers(events []ReviewEvent) ([]st
 	return reviewerList, approverList
 }
 
+// splitBotReviewEvents はbotによるレビューイベントを除外し、botのレビュアー一覧を返す
+func splitBotReviewEvents(events []ReviewEvent) ([]ReviewEvent, []string) {
+	humanEvents := make([]ReviewEvent, 0, len(events))
+	seen := make(map[string]bool)
+	var botReviewers []string
+	
+	for _, event := range events {
+		if !event.IsBot {
+			humanEvents = append(humanEvents, event)
+			continue
+		}
+		if event.Actor != "" && !seen[event.Actor] {
+			seen[event.Actor] = true
+			botReviewers = append(botReviewers, event.Actor)
+		}
+	}
+	
+	return humanEvents, botReviewers
+}
+
 // calculateFirstReviewPassRate は初回レビュー通過率を計算
 func (analyzer *ReviewTimeAnalyzer) calculateFirstReviewPassRate(events []ReviewEvent) float64 {
 	if len(events) == 0 {
@@ -431,5 +475,7 @@ const (
 	BottleneckTypeMultipleRounds BottleneckType = "multiple_rounds"
 	BottleneckTypeManyComments   BottleneckType = "many_comments"
 	BottleneckTypeLackOfReviewer BottleneckType = "lack_of_reviewer"
+	BottleneckTypeKnowledgeSilo  BottleneckType = "knowledge_silo" // 1人にディレクトリの知識が集中している
+	BottleneckTypeWIPExceeded    BottleneckType = "wip_exceeded"   // 同時にオープンなPRが推奨WIP上限を超えている
 )
 
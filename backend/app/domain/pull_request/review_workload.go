@@ -0,0 +1,101 @@
+package pull_request
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultReviewLoadAlertShare は1人のレビュアーに集中しているとみなす既定のレビュー数の割合
+const DefaultReviewLoadAlertShare = 0.4
+
+// ReviewActivity はレビュアー1人のPR1件に対するレビュー依頼と最初のレビュー
+type ReviewActivity struct {
+	PR          *PRMetrics
+	Reviewer    string
+	Requested   bool      // レビューを依頼されたかどうか
+	RequestedAt time.Time // 最初に依頼された日時
+	Reviewed    bool      // レビューを提出したかどうか
+	ReviewedAt  time.Time // 最初のレビューの日時
+}
+
+// LinesReviewed はレビューしたPRの変更行数（レビューしていない場合は 0）
+func (a ReviewActivity) LinesReviewed() int {
+	if !a.Reviewed {
+		return 0
+	}
+	return a.PR.SizeMetrics.LinesChanged
+}
+
+// CollectReviewActivities はPRのレビューイベントからレビュアーごとの依頼とレビューを取り出す
+// events はPR IDごとのレビューイベントで、イベントのないPRは ReviewersInvolved をレビュー済みとして扱う
+// botのレビューとPR作者自身のレビュー・依頼は除き、resolver が指定されている場合は正規IDに揃える
+// 結果はPRの順、PR内はレビュアー名の順に並ぶ
+func CollectReviewActivities(metrics []*PRMetrics, events map[string][]ReviewEvent, resolver IdentityResolver) []ReviewActivity {
+	resolve := func(login string) string {
+		if resolver == nil {
+			return login
+		}
+		return resolver.Resolve(login)
+	}
+
+	var activities []ReviewActivity
+	for _, pr := range metrics {
+		author := resolve(pr.Author)
+		byReviewer := make(map[string]*ReviewActivity)
+		activity := func(login string) *ReviewActivity {
+			reviewer := resolve(login)
+			if reviewer == "" || strings.EqualFold(reviewer, author) {
+				return nil
+			}
+			if existing, exists := byReviewer[reviewer]; exists {
+				return existing
+			}
+			created := &ReviewActivity{PR: pr, Reviewer: reviewer}
+			byReviewer[reviewer] = created
+			return created
+		}
+
+		if prEvents := events[pr.PRID]; len(prEvents) > 0 {
+			for _, event := range prEvents {
+				switch event.Type {
+				case ReviewEventTypeRequested:
+					if a := activity(event.Reviewer); a != nil && (!a.Requested || event.CreatedAt.Before(a.RequestedAt)) {
+						a.Requested = true
+						a.RequestedAt = event.CreatedAt
+					}
+				case ReviewEventTypeApproved, ReviewEventTypeChangesRequested, ReviewEventTypeCommented:
+					if event.IsBot {
+						continue
+					}
+					if a := activity(event.Reviewer); a != nil && (!a.Reviewed || event.CreatedAt.Before(a.ReviewedAt)) {
+						a.Reviewed = true
+						a.ReviewedAt = event.CreatedAt
+					}
+				}
+			}
+		} else {
+			// イベントを収集していない古いPRは最初のレビューの日時で代用する
+			reviewedAt := pr.CreatedAt
+			if pr.TimeMetrics.TimeToFirstReview != nil {
+				reviewedAt = pr.CreatedAt.Add(*pr.TimeMetrics.TimeToFirstReview)
+			}
+			for _, reviewer := range pr.QualityMetrics.ReviewersInvolved {
+				if a := activity(reviewer); a != nil && !a.Reviewed {
+					a.Reviewed = true
+					a.ReviewedAt = reviewedAt
+				}
+			}
+		}
+
+		reviewers := make([]string, 0, len(byReviewer))
+		for reviewer := range byReviewer {
+			reviewers = append(reviewers, reviewer)
+		}
+		sort.Strings(reviewers)
+		for _, reviewer := range reviewers {
+			activities = append(activities, *byReviewer[reviewer])
+		}
+	}
+	return activities
+}
@@ -0,0 +1,59 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCollectReviewActivities(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	firstReview := 3 * time.Hour
+
+	withEvents := &PRMetrics{PRID: "pr-1", Author: "alice", CreatedAt: baseTime, SizeMetrics: PRSizeMetrics{LinesChanged: 120}}
+	legacy := &PRMetrics{
+		PRID:           "pr-2",
+		Author:         "bob",
+		CreatedAt:      baseTime,
+		SizeMetrics:    PRSizeMetrics{LinesChanged: 40},
+		TimeMetrics:    PRTimeMetrics{TimeToFirstReview: &firstReview},
+		QualityMetrics: PRQualityMetrics{ReviewersInvolved: []string{"carol", "bob"}},
+	}
+	events := map[string][]ReviewEvent{
+		"pr-1": {
+			{Type: ReviewEventTypeRequested, CreatedAt: baseTime.Add(time.Hour), Actor: "alice", Reviewer: "bob"},
+			{Type: ReviewEventTypeRequested, CreatedAt: baseTime.Add(time.Hour), Actor: "alice", Reviewer: "carol-work"},
+			{Type: ReviewEventTypeCommented, CreatedAt: baseTime.Add(2 * time.Hour), Actor: "carol-work", Reviewer: "carol-work"},
+			{Type: ReviewEventTypeApproved, CreatedAt: baseTime.Add(5 * time.Hour), Actor: "carol", Reviewer: "carol"},
+			{Type: ReviewEventTypeCommented, CreatedAt: baseTime.Add(2 * time.Hour), Actor: "alice", Reviewer: "alice"},
+			{Type: ReviewEventTypeCommented, CreatedAt: baseTime.Add(2 * time.Hour), Actor: "lint-bot", Reviewer: "lint-bot", IsBot: true},
+			{Type: ReviewEventTypeMerged, CreatedAt: baseTime.Add(6 * time.Hour), Actor: "alice"},
+		},
+	}
+
+	activities := CollectReviewActivities([]*PRMetrics{withEvents, legacy}, events, mapIdentityResolver{"carol-work": "carol"})
+	if len(activities) != 3 {
+		t.Fatalf("len(activities) = %d, want 3: %+v", len(activities), activities)
+	}
+
+	bob := activities[0]
+	if bob.Reviewer != "bob" || !bob.Requested || bob.Reviewed || bob.LinesReviewed() != 0 {
+		t.Errorf("bob = %+v, want requested but not reviewed", bob)
+	}
+
+	carol := activities[1]
+	if carol.Reviewer != "carol" || !carol.Requested || !carol.Reviewed {
+		t.Fatalf("carol = %+v, want requested and reviewed", carol)
+	}
+	if !carol.ReviewedAt.Equal(baseTime.Add(2*time.Hour)) || carol.LinesReviewed() != 120 {
+		t.Errorf("carol reviewed at %v with %d lines, want first review with 120 lines", carol.ReviewedAt, carol.LinesReviewed())
+	}
+
+	// イベントのないPRはレビュアー一覧から作者を除いて数える
+	fallback := activities[2]
+	if fallback.PR != legacy || fallback.Reviewer != "carol" || fallback.Requested || !fallback.Reviewed {
+		t.Fatalf("fallback = %+v, want carol reviewed pr-2", fallback)
+	}
+	if !fallback.ReviewedAt.Equal(baseTime.Add(firstReview)) {
+		t.Errorf("fallback ReviewedAt = %v, want %v", fallback.ReviewedAt, baseTime.Add(firstReview))
+	}
+}
@@ -0,0 +1,271 @@
+package pull_request
+
+import (
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// authorExpertiseWeight はファイルを変更したPRの作者としての経験の重み
+	authorExpertiseWeight = 1.0
+	// reviewerExpertiseWeight はファイルを変更したPRのレビュアーとしての経験の重み
+	reviewerExpertiseWeight = 0.8
+	// directoryMatchWeight は同じファイルではなく同じディレクトリを変更した場合の重み
+	directoryMatchWeight = 0.5
+	// pendingReviewPenalty は未対応のレビュー依頼1件あたりのスコアの減衰
+	pendingReviewPenalty = 0.25
+	// referenceResponseTime は応答時間の評価の基準（この時間で応答するレビュアーのスコアは半分になる）
+	referenceResponseTime = 24 * time.Hour
+)
+
+// ReviewerRecommendationConfig はレビュアー推薦の設定
+type ReviewerRecommendationConfig struct {
+	HalfLife            time.Duration // 経験の重みが半分になる経過時間
+	PendingReviewMaxAge time.Duration // 未対応として数えるレビュー依頼の期間（閉じられたPRの依頼を数え続けないため）
+	Limit               int           // 推薦するレビュアーの最大数（0 以下の場合は制限なし）
+}
+
+// DefaultReviewerRecommendationConfig はデフォルトのレビュアー推薦の設定を返す
+func DefaultReviewerRecommendationConfig() ReviewerRecommendationConfig {
+	return ReviewerRecommendationConfig{
+		HalfLife:            30 * 24 * time.Hour,
+		PendingReviewMaxAge: 14 * 24 * time.Hour,
+		Limit:               5,
+	}
+}
+
+// ReviewerSuggestion は推薦するレビュアーとスコアの内訳
+type ReviewerSuggestion struct {
+	Reviewer           string         `json:"reviewer"`
+	Score              float64        `json:"score"`     // Expertise × 負荷の係数 × 応答時間の係数
+	Expertise          float64        `json:"expertise"` // 変更対象のパスでの最近の作成・レビュー経験
+	AuthoredPRs        int            `json:"authoredPRs"`
+	ReviewedPRs        int            `json:"reviewedPRs"`
+	MatchedPaths       []string       `json:"matchedPaths"` // 経験のある変更対象のパス
+	LastActivityAt     time.Time      `json:"lastActivityAt"`
+	PendingReviews     int            `json:"pendingReviews"`               // 未対応のレビュー依頼
+	MedianResponseTime *time.Duration `json:"medianResponseTime,omitempty"` // 依頼から最初のレビューまで
+}
+
+// ReviewerRecommender は変更対象のファイルの履歴からレビュアーを推薦する
+type ReviewerRecommender struct {
+	config   ReviewerRecommendationConfig
+	resolver IdentityResolver
+}
+
+// NewReviewerRecommender は新しいレビュアー推薦器を作成
+// resolver が指定されている場合、同一人物の複数アカウントを1人として扱う
+func NewReviewerRecommender(config ReviewerRecommendationConfig, resolver IdentityResolver) *ReviewerRecommender {
+	defaults := DefaultReviewerRecommendationConfig()
+	if config.HalfLife <= 0 {
+		config.HalfLife = defaults.HalfLife
+	}
+	if config.PendingReviewMaxAge <= 0 {
+		config.PendingReviewMaxAge = defaults.PendingReviewMaxAge
+	}
+	return &ReviewerRecommender{config: config, resolver: resolver}
+}
+
+// RecommendForPR はPRの変更ファイルからレビュアーを推薦する
+// PR自身は履歴から除き、作者と既にレビューしたレビュアーは候補にしない
+func (r *ReviewerRecommender) RecommendForPR(pr *PRMetrics, history []*PRMetrics, events map[string][]ReviewEvent, now time.Time) []ReviewerSuggestion {
+	paths := make([]string, 0, len(pr.SizeMetrics.FileChanges))
+	for _, file := range pr.SizeMetrics.FileChanges {
+		paths = append(paths, file.FileName)
+	}
+
+	exclude := []string{pr.Author}
+	for _, activity := range CollectReviewActivities([]*PRMetrics{pr}, events, r.resolver) {
+		if activity.Reviewed {
+			exclude = append(exclude, activity.Reviewer)
+		}
+	}
+
+	others := make([]*PRMetrics, 0, len(history))
+	for _, metric := range history {
+		if metric.PRID != pr.PRID {
+			others = append(others, metric)
+		}
+	}
+	return r.Recommend(paths, exclude, others, events, now)
+}
+
+// Recommend は変更対象のパスを最近作成・レビューした人をスコアの高い順に推薦する
+// history は推薦の根拠にする同じリポジトリのPR、events はそのレビューイベント（未対応の依頼と応答時間の算出に使う）
+// exclude に指定したログイン名（PR作者など）とbotは候補にしない
+func (r *ReviewerRecommender) Recommend(paths []string, exclude []string, history []*PRMetrics, events map[string][]ReviewEvent, now time.Time) []ReviewerSuggestion {
+	excluded := make(map[string]bool)
+	for _, login := range exclude {
+		excluded[strings.ToLower(r.resolve(login))] = true
+	}
+
+	candidates := make(map[string]*ReviewerSuggestion)
+	candidate := func(login string) *ReviewerSuggestion {
+		if existing, exists := candidates[login]; exists {
+			return existing
+		}
+		created := &ReviewerSuggestion{Reviewer: login}
+		candidates[login] = created
+		return created
+	}
+	addExperience := func(login string, pr *PRMetrics, authored bool, at time.Time) {
+		if login == "" || excluded[strings.ToLower(login)] {
+			return
+		}
+		matched, score := matchPaths(paths, pr.SizeMetrics.FileChanges)
+		if score == 0 {
+			return
+		}
+		weight := reviewerExpertiseWeight
+		if authored {
+			weight = authorExpertiseWeight
+		}
+		suggestion := candidate(login)
+		suggestion.Expertise += weight * score * r.decay(now.Sub(at))
+		suggestion.MatchedPaths = mergePaths(suggestion.MatchedPaths, matched)
+		if at.After(suggestion.LastActivityAt) {
+			suggestion.LastActivityAt = at
+		}
+		if authored {
+			suggestion.AuthoredPRs++
+		} else {
+			suggestion.ReviewedPRs++
+		}
+	}
+
+	for _, pr := range history {
+		if pr.IsBot {
+			continue
+		}
+		authoredAt := pr.CreatedAt
+		if pr.MergedAt != nil {
+			authoredAt = *pr.MergedAt
+		}
+		addExperience(r.resolve(pr.Author), pr, true, authoredAt)
+	}
+
+	pending := make(map[string]int)
+	responseTimes := make(map[string][]time.Duration)
+	for _, activity := range CollectReviewActivities(history, events, r.resolver) {
+		if activity.Reviewed {
+			addExperience(activity.Reviewer, activity.PR, false, activity.ReviewedAt)
+		}
+		if !activity.Requested {
+			continue
+		}
+		if activity.Reviewed {
+			if activity.ReviewedAt.After(activity.RequestedAt) {
+				responseTimes[activity.Reviewer] = append(responseTimes[activity.Reviewer], activity.ReviewedAt.Sub(activity.RequestedAt))
+			}
+		} else if activity.PR.MergedAt == nil && now.Sub(activity.RequestedAt) <= r.config.PendingReviewMaxAge {
+			pending[activity.Reviewer]++
+		}
+	}
+
+	suggestions := make([]ReviewerSuggestion, 0, len(candidates))
+	for login, suggestion := range candidates {
+		suggestion.PendingReviews = pending[login]
+		responseTime := referenceResponseTime
+		if median := medianDuration(responseTimes[login]); median != nil {
+			suggestion.MedianResponseTime = median
+			responseTime = *median
+		}
+		loadFactor := 1 / (1 + pendingReviewPenalty*float64(suggestion.PendingReviews))
+		responseFactor := 1 / (1 + responseTime.Hours()/referenceResponseTime.Hours())
+		suggestion.Score = suggestion.Expertise * loadFactor * responseFactor
+		sort.Strings(suggestion.MatchedPaths)
+		suggestions = append(suggestions, *suggestion)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Reviewer < suggestions[j].Reviewer
+	})
+	if r.config.Limit > 0 && len(suggestions) > r.config.Limit {
+		suggestions = suggestions[:r.config.Limit]
+	}
+	return suggestions
+}
+
+// resolve はログイン名を正規IDに揃える
+func (r *ReviewerRecommender) resolve(login string) string {
+	if r.resolver == nil {
+		return login
+	}
+	return r.resolver.Resolve(login)
+}
+
+// decay は経過時間に応じた経験の重み（HalfLife ごとに半分）
+func (r *ReviewerRecommender) decay(age time.Duration) float64 {
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(r.config.HalfLife))
+}
+
+// matchPaths は変更対象のパスのうちPRが変更したものと、その一致度（0〜1）を返す
+// 同じファイルは 1、同じディレクトリのファイルのみの場合は directoryMatchWeight として平均する
+func matchPaths(paths []string, files []FileChangeMetrics) ([]string, float64) {
+	if len(paths) == 0 {
+		return nil, 0
+	}
+	changedFiles := make(map[string]bool)
+	changedDirectories := make(map[string]bool)
+	for _, file := range files {
+		changedFiles[file.FileName] = true
+		changedDirectories[path.Dir(file.FileName)] = true
+	}
+
+	var matched []string
+	var score float64
+	for _, p := range paths {
+		switch {
+		case changedFiles[p]:
+			score += 1
+			matched = append(matched, p)
+		case changedDirectories[path.Dir(p)]:
+			score += directoryMatchWeight
+			matched = append(matched, p)
+		}
+	}
+	return matched, score / float64(len(paths))
+}
+
+// mergePaths は重複を除いてパスを追加する
+func mergePaths(existing, added []string) []string {
+	for _, p := range added {
+		found := false
+		for _, e := range existing {
+			if e == p {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, p)
+		}
+	}
+	return existing
+}
+
+// medianDuration は時間の中央値（データがない場合は nil）
+func medianDuration(durations []time.Duration) *time.Duration {
+	if len(durations) == 0 {
+		return nil
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	return &median
+}
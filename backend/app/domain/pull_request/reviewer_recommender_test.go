@@ -0,0 +1,102 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewerRecommender_Recommend(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * 24 * time.Hour)
+	}
+	pr := func(id, author string, createdAt time.Time, merged bool, files ...string) *PRMetrics {
+		metric := &PRMetrics{PRID: id, Author: author, CreatedAt: createdAt}
+		if merged {
+			mergedAt := createdAt.Add(time.Hour)
+			metric.MergedAt = &mergedAt
+		}
+		for _, file := range files {
+			metric.SizeMetrics.FileChanges = append(metric.SizeMetrics.FileChanges, FileChangeMetrics{FileName: file})
+		}
+		return metric
+	}
+
+	history := []*PRMetrics{
+		pr("pr-1", "alice", daysAgo(3), true, "api/handler.go"),
+		pr("pr-2", "bob", daysAgo(90), true, "api/handler.go"),
+		pr("pr-3", "carol", daysAgo(2), true, "api/router.go"),
+		pr("pr-4", "dave", daysAgo(1), true, "web/index.ts"),
+		pr("pr-5", "erin", daysAgo(1), false, "web/app.ts"),
+		pr("pr-6", "frank", daysAgo(4), true, "api/handler.go"),
+	}
+	history[5].IsBot = true
+	events := map[string][]ReviewEvent{
+		"pr-1": {
+			{Type: ReviewEventTypeRequested, CreatedAt: daysAgo(3), Actor: "alice", Reviewer: "carol"},
+			{Type: ReviewEventTypeApproved, CreatedAt: daysAgo(3).Add(2 * time.Hour), Actor: "carol", Reviewer: "carol"},
+		},
+		// alice はレビュー依頼を溜めている
+		"pr-5": {
+			{Type: ReviewEventTypeRequested, CreatedAt: daysAgo(1), Actor: "erin", Reviewer: "alice"},
+			{Type: ReviewEventTypeRequested, CreatedAt: daysAgo(1), Actor: "erin", Reviewer: "carol"},
+		},
+	}
+
+	recommender := NewReviewerRecommender(ReviewerRecommendationConfig{}, nil)
+	suggestions := recommender.Recommend([]string{"api/handler.go"}, []string{"dave"}, history, events, now)
+
+	reviewers := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		reviewers = append(reviewers, suggestion.Reviewer)
+	}
+	// carol はレビューと同じディレクトリの変更があり応答も速い。bob は経験が古い。dave・erin・botは対象外
+	expected := []string{"carol", "alice", "bob"}
+	if len(reviewers) != len(expected) {
+		t.Fatalf("reviewers = %v, want %v", reviewers, expected)
+	}
+	for i := range expected {
+		if reviewers[i] != expected[i] {
+			t.Fatalf("reviewers = %v, want %v", reviewers, expected)
+		}
+	}
+
+	carol := suggestions[0]
+	if carol.AuthoredPRs != 1 || carol.ReviewedPRs != 1 || carol.PendingReviews != 1 {
+		t.Errorf("carol = %+v, want 1 authored, 1 reviewed and 1 pending", carol)
+	}
+	if carol.MedianResponseTime == nil || *carol.MedianResponseTime != 2*time.Hour {
+		t.Errorf("carol MedianResponseTime = %v, want 2h", carol.MedianResponseTime)
+	}
+	if alice := suggestions[1]; alice.PendingReviews != 1 || alice.MedianResponseTime != nil {
+		t.Errorf("alice = %+v, want 1 pending without response history", alice)
+	}
+}
+
+func TestReviewerRecommender_RecommendForPR(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	merged := now.Add(-48 * time.Hour)
+	files := []FileChangeMetrics{{FileName: "api/handler.go"}}
+
+	target := &PRMetrics{PRID: "pr-new", Author: "alice-work", CreatedAt: now, SizeMetrics: PRSizeMetrics{FileChanges: files}}
+	history := []*PRMetrics{
+		target,
+		{PRID: "pr-1", Author: "alice", CreatedAt: merged, MergedAt: &merged, SizeMetrics: PRSizeMetrics{FileChanges: files}},
+		{PRID: "pr-2", Author: "bob", CreatedAt: merged, MergedAt: &merged, SizeMetrics: PRSizeMetrics{FileChanges: files}},
+		{PRID: "pr-3", Author: "carol", CreatedAt: merged, MergedAt: &merged, SizeMetrics: PRSizeMetrics{FileChanges: files}},
+	}
+	events := map[string][]ReviewEvent{
+		"pr-new": {{Type: ReviewEventTypeCommented, CreatedAt: now, Actor: "bob", Reviewer: "bob"}},
+	}
+
+	recommender := NewReviewerRecommender(ReviewerRecommendationConfig{Limit: 1}, mapIdentityResolver{"alice-work": "alice"})
+	suggestions := recommender.RecommendForPR(target, history, events, now)
+
+	// 作者の別アカウントと既にレビューしたレビュアーは除き、件数は Limit まで
+	if len(suggestions) != 1 || suggestions[0].Reviewer != "carol" {
+		t.Fatalf("suggestions = %+v, want only carol", suggestions)
+	}
+	if len(suggestions[0].MatchedPaths) != 1 || suggestions[0].MatchedPaths[0] != "api/handler.go" {
+		t.Errorf("MatchedPaths = %v, want [api/handler.go]", suggestions[0].MatchedPaths)
+	}
+}
@@ -0,0 +1,128 @@
+package pull_request
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultReworkWindow はマージ後の手戻りとして数える既定の期間
+const DefaultReworkWindow = 21 * 24 * time.Hour
+
+// PRRework はマージしたPRが、その後の一定期間に他のPRで手直しされた量の推定
+type PRRework struct {
+	PR          *PRMetrics
+	LinesAdded  int          // 手戻りの対象になり得る追加行数
+	ReworkLines int          // 後続のPRで書き換えられたと推定される行数
+	Files       []FileRework // 行を追加したファイル（手戻りのないファイルを含む）
+}
+
+// FileRework はファイル単位の手戻りの推定
+type FileRework struct {
+	FileName    string
+	LinesAdded  int
+	ReworkLines int
+	ReworkedBy  []*PRMetrics // 同じファイルの行を削除した後続のPR（マージ順）
+}
+
+// ReworkAnalyzer はマージ後に同じファイルを変更したPRから手戻りを推定する
+type ReworkAnalyzer struct {
+	window time.Duration
+}
+
+// NewReworkAnalyzer は手戻りとして数える期間を指定して分析器を作成（0 以下の場合は既定の期間）
+func NewReworkAnalyzer(window time.Duration) *ReworkAnalyzer {
+	if window <= 0 {
+		window = DefaultReworkWindow
+	}
+	return &ReworkAnalyzer{window: window}
+}
+
+// Window は手戻りとして数える期間を返す
+func (a *ReworkAnalyzer) Window() time.Duration {
+	return a.window
+}
+
+// Analyze は merged の各PRについて、マージ後の期間内に candidates のPRが同じファイルを変更した量を推定する
+// 手戻りの行数はファイルごとに後続のPRの削除行数の合計とし、元のPRの追加行数を上限とする
+// candidates は同じリポジトリでマージ済みのPRのみを使い（merged と重複してよい）、未マージのPRは結果から除く
+func (a *ReworkAnalyzer) Analyze(merged, candidates []*PRMetrics) []PRRework {
+	// リポジトリごとにマージ順に並べた後続PRの候補
+	// 同じPRが重複して渡された場合は最初のものを使う
+	byRepository := make(map[string][]*PRMetrics)
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if candidate.MergedAt == nil || seen[candidate.PRID] {
+			continue
+		}
+		seen[candidate.PRID] = true
+		byRepository[candidate.Repository] = append(byRepository[candidate.Repository], candidate)
+	}
+	for _, prs := range byRepository {
+		sort.SliceStable(prs, func(i, j int) bool {
+			return prs[i].MergedAt.Before(*prs[j].MergedAt)
+		})
+	}
+
+	var results []PRRework
+	for _, pr := range merged {
+		if pr.MergedAt == nil {
+			continue
+		}
+		results = append(results, a.analyzePR(pr, byRepository[pr.Repository]))
+	}
+	return results
+}
+
+// analyzePR は1つのPRの手戻りを推定する
+func (a *ReworkAnalyzer) analyzePR(pr *PRMetrics, laterPRs []*PRMetrics) PRRework {
+	rework := PRRework{PR: pr}
+
+	added := make(map[string]int)
+	var fileNames []string
+	for _, file := range pr.SizeMetrics.FileChanges {
+		if file.LinesAdded == 0 || file.IsDeleted {
+			continue
+		}
+		if _, exists := added[file.FileName]; !exists {
+			fileNames = append(fileNames, file.FileName)
+		}
+		added[file.FileName] += file.LinesAdded
+		rework.LinesAdded += file.LinesAdded
+	}
+
+	windowEnd := pr.MergedAt.Add(a.window)
+	deleted := make(map[string]int)
+	reworkedBy := make(map[string][]*PRMetrics)
+	for _, later := range laterPRs {
+		if later.PRID == pr.PRID || !later.MergedAt.After(*pr.MergedAt) {
+			continue
+		}
+		if later.MergedAt.After(windowEnd) {
+			break
+		}
+		for _, file := range later.SizeMetrics.FileChanges {
+			if _, exists := added[file.FileName]; !exists || file.LinesDeleted == 0 {
+				continue
+			}
+			deleted[file.FileName] += file.LinesDeleted
+			if prs := reworkedBy[file.FileName]; len(prs) == 0 || prs[len(prs)-1] != later {
+				reworkedBy[file.FileName] = append(prs, later)
+			}
+		}
+	}
+
+	for _, fileName := range fileNames {
+		lines := deleted[fileName]
+		if lines > added[fileName] {
+			lines = added[fileName]
+		}
+		rework.ReworkLines += lines
+		rework.Files = append(rework.Files, FileRework{
+			FileName:    fileName,
+			LinesAdded:  added[fileName],
+			ReworkLines: lines,
+			ReworkedBy:  reworkedBy[fileName],
+		})
+	}
+	return rework
+}
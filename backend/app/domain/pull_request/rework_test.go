@@ -0,0 +1,93 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReworkAnalyzer_Analyze(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	mergedPR := func(id, repository string, mergedAt time.Time, files ...FileChangeMetrics) *PRMetrics {
+		return &PRMetrics{
+			PRID:        id,
+			Repository:  repository,
+			CreatedAt:   mergedAt.Add(-time.Hour),
+			MergedAt:    &mergedAt,
+			SizeMetrics: PRSizeMetrics{FileChanges: files},
+		}
+	}
+
+	original := mergedPR("pr-1", "org/api", baseTime,
+		FileChangeMetrics{FileName: "api/handler.go", LinesAdded: 100, LinesDeleted: 10},
+		FileChangeMetrics{FileName: "api/router.go", LinesAdded: 20},
+		FileChangeMetrics{FileName: "api/old.go", LinesDeleted: 50, IsDeleted: true},
+	)
+	firstFix := mergedPR("pr-2", "org/api", baseTime.Add(2*24*time.Hour),
+		FileChangeMetrics{FileName: "api/handler.go", LinesAdded: 30, LinesDeleted: 30},
+	)
+	secondFix := mergedPR("pr-3", "org/api", baseTime.Add(5*24*time.Hour),
+		FileChangeMetrics{FileName: "api/handler.go", LinesAdded: 5, LinesDeleted: 15},
+		FileChangeMetrics{FileName: "api/router.go", LinesAdded: 40, LinesDeleted: 40},
+	)
+	afterWindow := mergedPR("pr-4", "org/api", baseTime.Add(30*24*time.Hour),
+		FileChangeMetrics{FileName: "api/handler.go", LinesDeleted: 50},
+	)
+	otherRepository := mergedPR("pr-5", "org/web", baseTime.Add(24*time.Hour),
+		FileChangeMetrics{FileName: "api/handler.go", LinesDeleted: 50},
+	)
+	unmerged := &PRMetrics{PRID: "pr-6", Repository: "org/api", CreatedAt: baseTime}
+
+	analyzer := NewReworkAnalyzer(0)
+	if analyzer.Window() != DefaultReworkWindow {
+		t.Fatalf("Window() = %v, want %v", analyzer.Window(), DefaultReworkWindow)
+	}
+
+	// 後続のPRが重複して渡されても二重に数えない
+	candidates := []*PRMetrics{original, secondFix, firstFix, afterWindow, otherRepository, firstFix}
+	results := analyzer.Analyze([]*PRMetrics{original, unmerged}, candidates)
+	if len(results) != 1 {
+		t.Fatalf("len(results) = %d, want 1", len(results))
+	}
+
+	rework := results[0]
+	if rework.LinesAdded != 120 {
+		t.Errorf("LinesAdded = %d, want 120", rework.LinesAdded)
+	}
+	// handler.go: 30 + 15 = 45、router.go: 40 を追加行数 20 で頭打ち
+	if rework.ReworkLines != 65 {
+		t.Errorf("ReworkLines = %d, want 65", rework.ReworkLines)
+	}
+	if len(rework.Files) != 2 {
+		t.Fatalf("len(Files) = %d, want 2", len(rework.Files))
+	}
+
+	handler := rework.Files[0]
+	if handler.FileName != "api/handler.go" || handler.ReworkLines != 45 {
+		t.Errorf("handler.go = %+v", handler)
+	}
+	if len(handler.ReworkedBy) != 2 || handler.ReworkedBy[0] != firstFix || handler.ReworkedBy[1] != secondFix {
+		t.Errorf("handler.go reworked by %v, want [pr-2 pr-3] in merge order", handler.ReworkedBy)
+	}
+	if router := rework.Files[1]; router.FileName != "api/router.go" || router.LinesAdded != 20 || router.ReworkLines != 20 {
+		t.Errorf("router.go = %+v", router)
+	}
+}
+
+func TestReworkAnalyzer_Window(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	originalMerged := baseTime
+	fixMerged := baseTime.Add(3 * 24 * time.Hour)
+
+	original := &PRMetrics{PRID: "pr-1", Repository: "org/api", MergedAt: &originalMerged,
+		SizeMetrics: PRSizeMetrics{FileChanges: []FileChangeMetrics{{FileName: "main.go", LinesAdded: 10}}}}
+	fix := &PRMetrics{PRID: "pr-2", Repository: "org/api", MergedAt: &fixMerged,
+		SizeMetrics: PRSizeMetrics{FileChanges: []FileChangeMetrics{{FileName: "main.go", LinesDeleted: 5}}}}
+
+	candidates := []*PRMetrics{original, fix}
+	if results := NewReworkAnalyzer(2*24*time.Hour).Analyze([]*PRMetrics{original}, candidates); results[0].ReworkLines != 0 {
+		t.Errorf("2 day window: ReworkLines = %d, want 0", results[0].ReworkLines)
+	}
+	if results := NewReworkAnalyzer(7*24*time.Hour).Analyze([]*PRMetrics{original}, candidates); results[0].ReworkLines != 5 {
+		t.Errorf("7 day window: ReworkLines = %d, want 5", results[0].ReworkLines)
+	}
+}
@@ -0,0 +1,94 @@
+package pull_request
+
+import (
+	"sort"
+	"time"
+)
+
+// TimelineStepOpened はタイムライン上のPR作成ステップ（GitHubのイベントではない）
+const TimelineStepOpened ReviewEventType = "opened"
+
+// TimelineStep はPRのライフサイクル上の1ステップ
+type TimelineStep struct {
+	Type          ReviewEventType `json:"type"`
+	At            time.Time       `json:"at"`
+	Actor         string          `json:"actor"`
+	Reviewer      string          `json:"reviewer,omitempty"`
+	IsBot         bool            `json:"isBot,omitempty"`
+	SincePrevious time.Duration   `json:"sincePrevious"` // 直前のステップからの経過時間
+	SinceOpened   time.Duration   `json:"sinceOpened"`   // PR作成からの経過時間
+}
+
+// PRTimeline はPRの作成からマージまでのステップを時系列に並べたもの
+type PRTimeline struct {
+	PRID  string         `json:"prId"`
+	Steps []TimelineStep `json:"steps"`
+	// TotalDuration は作成からマージまでの時間（未マージの場合は nil）
+	TotalDuration *time.Duration `json:"totalDuration,omitempty"`
+}
+
+// BuildTimeline はPRメトリクスとレビューイベントからタイムラインを作成
+// 先頭は常に作成ステップ。マージイベントが記録されていない場合は MergedAt から補う
+func BuildTimeline(metrics *PRMetrics, events []ReviewEvent) *PRTimeline {
+	sorted := make([]ReviewEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	steps := make([]TimelineStep, 0, len(sorted)+2)
+	steps = append(steps, TimelineStep{
+		Type:  TimelineStepOpened,
+		At:    metrics.CreatedAt,
+		Actor: metrics.Author,
+		IsBot: metrics.IsBot,
+	})
+
+	var mergedAt *time.Time
+	for _, event := range sorted {
+		if event.Type == ReviewEventTypeMerged {
+			if mergedAt != nil {
+				continue
+			}
+			at := event.CreatedAt
+			mergedAt = &at
+		}
+		steps = append(steps, TimelineStep{
+			Type:     event.Type,
+			At:       event.CreatedAt,
+			Actor:    event.Actor,
+			Reviewer: event.Reviewer,
+			IsBot:    event.IsBot,
+		})
+	}
+
+	if mergedAt == nil && metrics.MergedAt != nil {
+		mergedAt = metrics.MergedAt
+		steps = append(steps, TimelineStep{
+			Type: ReviewEventTypeMerged,
+			At:   *metrics.MergedAt,
+		})
+	}
+
+	for i := range steps {
+		steps[i].SinceOpened = nonNegative(steps[i].At.Sub(metrics.CreatedAt))
+		if i > 0 {
+			steps[i].SincePrevious = nonNegative(steps[i].At.Sub(steps[i-1].At))
+		}
+	}
+
+	timeline := &PRTimeline{PRID: metrics.PRID, Steps: steps}
+	if mergedAt != nil {
+		total := nonNegative(mergedAt.Sub(metrics.CreatedAt))
+		timeline.TotalDuration = &total
+	}
+	return timeline
+}
+
+// nonNegative は負の時間を 0 に丸める（時刻の記録誤差でイベントが作成より前になる場合がある）
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,80 @@
+package pull_request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	mergedAt := baseTime.Add(10 * time.Hour)
+
+	tests := []struct {
+		name          string
+		mergedAt      *time.Time
+		events        []ReviewEvent
+		expectedTypes []ReviewEventType
+		expectedSince []time.Duration
+		expectedTotal *time.Duration
+	}{
+		{
+			name:     "イベントを時系列に並べて経過時間を計算する",
+			mergedAt: &mergedAt,
+			events: []ReviewEvent{
+				{Type: ReviewEventTypeApproved, CreatedAt: baseTime.Add(6 * time.Hour), Actor: "reviewer1"},
+				{Type: ReviewEventTypeRequested, CreatedAt: baseTime.Add(time.Hour), Actor: "author", Reviewer: "reviewer1"},
+				{Type: ReviewEventTypeMerged, CreatedAt: mergedAt, Actor: "author"},
+			},
+			expectedTypes: []ReviewEventType{TimelineStepOpened, ReviewEventTypeRequested, ReviewEventTypeApproved, ReviewEventTypeMerged},
+			expectedSince: []time.Duration{0, time.Hour, 5 * time.Hour, 4 * time.Hour},
+			expectedTotal: durationPtr(10 * time.Hour),
+		},
+		{
+			name:          "マージイベントがない場合は MergedAt から補う",
+			mergedAt:      &mergedAt,
+			events:        []ReviewEvent{{Type: ReviewEventTypeCommented, CreatedAt: baseTime.Add(2 * time.Hour), Actor: "reviewer1"}},
+			expectedTypes: []ReviewEventType{TimelineStepOpened, ReviewEventTypeCommented, ReviewEventTypeMerged},
+			expectedSince: []time.Duration{0, 2 * time.Hour, 8 * time.Hour},
+			expectedTotal: durationPtr(10 * time.Hour),
+		},
+		{
+			name:          "未マージでイベントがない場合は作成のみ",
+			expectedTypes: []ReviewEventType{TimelineStepOpened},
+			expectedSince: []time.Duration{0},
+		},
+		{
+			name: "作成より前のイベントは経過時間を0とする",
+			events: []ReviewEvent{
+				{Type: ReviewEventTypeRequested, CreatedAt: baseTime.Add(-time.Minute), Actor: "author"},
+			},
+			expectedTypes: []ReviewEventType{TimelineStepOpened, ReviewEventTypeRequested},
+			expectedSince: []time.Duration{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &PRMetrics{PRID: "PR_1", Author: "author", CreatedAt: baseTime, MergedAt: tt.mergedAt}
+			timeline := BuildTimeline(metrics, tt.events)
+
+			if len(timeline.Steps) != len(tt.expectedTypes) {
+				t.Fatalf("len(Steps) = %d, want %d", len(timeline.Steps), len(tt.expectedTypes))
+			}
+			for i, step := range timeline.Steps {
+				if step.Type != tt.expectedTypes[i] {
+					t.Errorf("Steps[%d].Type = %s, want %s", i, step.Type, tt.expectedTypes[i])
+				}
+				if step.SincePrevious != tt.expectedSince[i] {
+					t.Errorf("Steps[%d].SincePrevious = %v, want %v", i, step.SincePrevious, tt.expectedSince[i])
+				}
+			}
+
+			switch {
+			case tt.expectedTotal == nil && timeline.TotalDuration != nil:
+				t.Errorf("TotalDuration = %v, want nil", *timeline.TotalDuration)
+			case tt.expectedTotal != nil && (timeline.TotalDuration == nil || *timeline.TotalDuration != *tt.expectedTotal):
+				t.Errorf("TotalDuration = %v, want %v", timeline.TotalDuration, *tt.expectedTotal)
+			}
+		})
+	}
+}
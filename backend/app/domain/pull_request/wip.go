@@ -0,0 +1,107 @@
+package pull_request
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultWIPStaleAfter はマージされていないPRを放置（クローズ）されたとみなすまでの既定の期間
+// PRメトリクスはクローズ日時を持たないため、これを過ぎた未マージのPRはWIPに数えない
+const DefaultWIPStaleAfter = 30 * 24 * time.Hour
+
+// WIPInterval はPRが作業中（オープン）だった期間
+type WIPInterval struct {
+	PR    *PRMetrics
+	Start time.Time // 作成日時
+	End   time.Time // マージ日時。オープン中の場合は基準日時、未マージで放置とみなした場合は作成から staleAfter 後
+	Open  bool      // 基準日時にまだオープンかどうか
+}
+
+// OpenIntervals はPRごとのオープンだった期間を返す（基準日時 asOf より後に作成されたPRは除く）
+// マージされていないPRは asOf までオープンとし、staleAfter が 0 より大きい場合は作成から staleAfter を過ぎた時点で閉じたとみなす
+// asOf より後にマージされたPRは asOf の時点でオープンとする
+func OpenIntervals(metrics []*PRMetrics, asOf time.Time, staleAfter time.Duration) []WIPInterval {
+	intervals := make([]WIPInterval, 0, len(metrics))
+	for _, pr := range metrics {
+		if pr.CreatedAt.After(asOf) {
+			continue
+		}
+		interval := WIPInterval{PR: pr, Start: pr.CreatedAt, End: asOf, Open: true}
+		if pr.MergedAt != nil && !pr.MergedAt.After(asOf) {
+			interval.End = *pr.MergedAt
+			interval.Open = false
+		}
+		if pr.MergedAt == nil && staleAfter > 0 && interval.End.Sub(interval.Start) > staleAfter {
+			interval.End = interval.Start.Add(staleAfter)
+			interval.Open = false
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+// CountOpenAt は日時 at にオープンだったPRの数を返す
+func CountOpenAt(intervals []WIPInterval, at time.Time) int {
+	count := 0
+	for _, interval := range intervals {
+		if !interval.Start.After(at) && (interval.End.After(at) || interval.Open && interval.End.Equal(at)) {
+			count++
+		}
+	}
+	return count
+}
+
+// MeasureWIP は start から end までの時間加重の平均WIPと同時にオープンだったPRの最大数を返す
+// 同じ日時にマージと作成があった場合は同時にオープンだったとみなさない
+func MeasureWIP(intervals []WIPInterval, start, end time.Time) (float64, int) {
+	if !end.After(start) {
+		return 0, 0
+	}
+
+	type change struct {
+		at    time.Time
+		delta int
+	}
+	var changes []change
+	var openDuration time.Duration
+	for _, interval := range intervals {
+		from, to := interval.Start, interval.End
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !to.After(from) {
+			continue
+		}
+		openDuration += to.Sub(from)
+		changes = append(changes, change{from, 1}, change{to, -1})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if !changes[i].at.Equal(changes[j].at) {
+			return changes[i].at.Before(changes[j].at)
+		}
+		return changes[i].delta < changes[j].delta
+	})
+
+	peak, current := 0, 0
+	for _, c := range changes {
+		current += c.delta
+		if current > peak {
+			peak = current
+		}
+	}
+	return float64(openDuration) / float64(end.Sub(start)), peak
+}
+
+// RecommendWIPLimit はリトルの法則（WIP = スループット × リードタイム）から推奨するWIPの上限を返す
+// throughputPerDay は1日あたりのマージ数、leadTime は作成からマージまでの時間（中央値を想定）で、最小値は 1
+func RecommendWIPLimit(throughputPerDay float64, leadTime time.Duration) int {
+	limit := int(math.Ceil(throughputPerDay * leadTime.Hours() / 24))
+	if limit < 1 {
+		return 1
+	}
+	return limit
+}
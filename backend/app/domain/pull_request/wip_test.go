@@ -0,0 +1,104 @@
+package pull_request
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOpenIntervals(t *testing.T) {
+	baseTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := func(days int) time.Time {
+		return baseTime.AddDate(0, 0, days)
+	}
+	pr := func(id string, created int, merged *int) *PRMetrics {
+		metric := &PRMetrics{PRID: id, CreatedAt: day(created)}
+		if merged != nil {
+			mergedAt := day(*merged)
+			metric.MergedAt = &mergedAt
+		}
+		return metric
+	}
+	two, twelve := 2, 12
+
+	asOf := day(10)
+	intervals := OpenIntervals([]*PRMetrics{
+		pr("merged", 0, &two),
+		pr("merged-later", 5, &twelve),
+		pr("open", 8, nil),
+		pr("stale", -40, nil),
+		pr("long-running", -40, &twelve),
+		pr("future", 11, nil),
+	}, asOf, DefaultWIPStaleAfter)
+
+	if len(intervals) != 5 {
+		t.Fatalf("len(intervals) = %d, want 5 (PRs created after asOf excluded)", len(intervals))
+	}
+	expected := []struct {
+		end  time.Time
+		open bool
+	}{
+		{day(2), false},
+		{asOf, true}, // 基準日時より後のマージはまだオープン
+		{asOf, true},
+		{day(-10), false}, // 作成から30日で放置とみなす
+		{asOf, true},      // 後でマージされたPRは放置とみなさない
+	}
+	for i, want := range expected {
+		if !intervals[i].End.Equal(want.end) || intervals[i].Open != want.open {
+			t.Errorf("intervals[%d] = %v open=%v, want %v open=%v", i, intervals[i].End, intervals[i].Open, want.end, want.open)
+		}
+	}
+
+	if open := CountOpenAt(intervals, asOf); open != 3 {
+		t.Errorf("CountOpenAt(asOf) = %d, want 3", open)
+	}
+	if open := CountOpenAt(intervals, day(1)); open != 2 {
+		t.Errorf("CountOpenAt(day 1) = %d, want 2", open)
+	}
+}
+
+func TestMeasureWIP(t *testing.T) {
+	baseTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := func(days int) time.Time {
+		return baseTime.AddDate(0, 0, days)
+	}
+	intervals := []WIPInterval{
+		{Start: day(0), End: day(4)},
+		{Start: day(2), End: day(6)},
+		// 前のPRのマージと同時に作成されたPRは同時にオープンとみなさない
+		{Start: day(6), End: day(8)},
+		{Start: day(-5), End: day(1)},
+	}
+
+	average, peak := MeasureWIP(intervals, day(0), day(10))
+	// 4 + 4 + 2 + 1 日 = 11 日分 / 10 日
+	if math.Abs(average-1.1) > 1e-9 {
+		t.Errorf("average = %v, want 1.1", average)
+	}
+	if peak != 2 {
+		t.Errorf("peak = %d, want 2", peak)
+	}
+
+	if average, peak := MeasureWIP(intervals, day(20), day(10)); average != 0 || peak != 0 {
+		t.Errorf("MeasureWIP(empty range) = %v, %d, want 0, 0", average, peak)
+	}
+}
+
+func TestRecommendWIPLimit(t *testing.T) {
+	tests := []struct {
+		throughput float64
+		leadTime   time.Duration
+		expected   int
+	}{
+		{1.5, 48 * time.Hour, 3},
+		{0.5, 36 * time.Hour, 1},
+		{2, 30 * time.Hour, 3},
+		{0, 48 * time.Hour, 1},
+	}
+	for _, tt := range tests {
+		if got := RecommendWIPLimit(tt.throughput, tt.leadTime); got != tt.expected {
+			t.Errorf("RecommendWIPLimit(%v, %v) = %d, want %d", tt.throughput, tt.leadTime, got, tt.expected)
+		}
+	}
+}
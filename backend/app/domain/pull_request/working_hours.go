@@ -0,0 +1,107 @@
+package pull_request
+
+import (
+	"fmt"
+	"time"
+)
+
+// WorkingHours はチーム・開発者ごとの勤務時間の上書き設定
+// 未指定の項目はサイクルタイム計算の設定（開発者の場合はチームの設定）を使う
+type WorkingHours struct {
+	Timezone        string `json:"timezone,omitempty"` // IANA 名
+	BusinessStart   *int   `json:"businessStart,omitempty"`
+	BusinessEnd     *int   `json:"businessEnd,omitempty"`
+	ExcludeWeekends *bool  `json:"excludeWeekends,omitempty"`
+	HolidayCalendar string `json:"holidayCalendar,omitempty"` // 休日カレンダー名
+}
+
+// WorkingHoursResolver は開発者に適用する勤務時間の上書き設定を返す
+type WorkingHoursResolver interface {
+	// WorkingHoursFor は指定時刻に login に適用する設定を返す（設定がない場合は false）
+	WorkingHoursFor(login string, at time.Time) (WorkingHours, bool)
+}
+
+// Validate は勤務時間の設定の妥当性を検証
+func (w WorkingHours) Validate() error {
+	if w.Timezone != "" {
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return NewValidationError(fmt.Sprintf("invalid timezone: %s", w.Timezone), w.Timezone)
+		}
+	}
+	if w.BusinessStart != nil && (*w.BusinessStart < 0 || *w.BusinessStart > 23) {
+		return NewValidationError("businessStart must be within 0-23", *w.BusinessStart)
+	}
+	if w.BusinessEnd != nil && (*w.BusinessEnd < 1 || *w.BusinessEnd > 24) {
+		return NewValidationError("businessEnd must be within 1-24", *w.BusinessEnd)
+	}
+	if w.BusinessStart != nil && w.BusinessEnd != nil && *w.BusinessStart >= *w.BusinessEnd {
+		return NewValidationError("businessStart must be before businessEnd", nil)
+	}
+	return nil
+}
+
+// Override は other で指定された項目を上書きした設定を返す
+func (w WorkingHours) Override(other WorkingHours) WorkingHours {
+	if other.Timezone != "" {
+		w.Timezone = other.Timezone
+	}
+	if other.BusinessStart != nil {
+		w.BusinessStart = other.BusinessStart
+	}
+	if other.BusinessEnd != nil {
+		w.BusinessEnd = other.BusinessEnd
+	}
+	if other.ExcludeWeekends != nil {
+		w.ExcludeWeekends = other.ExcludeWeekends
+	}
+	if other.HolidayCalendar != "" {
+		w.HolidayCalendar = other.HolidayCalendar
+	}
+	return w
+}
+
+// workSchedule は営業時間の計算に使う勤務地・勤務時間・休日
+type workSchedule struct {
+	location        *time.Location
+	businessStart   int
+	businessEnd     int
+	excludeWeekends bool
+	holidays        *HolidayCalendar // nil の場合は祝日を除外しない
+}
+
+// isDayOff は day（勤務地の日付）が休みかどうか
+func (s workSchedule) isDayOff(day time.Time) bool {
+	if s.excludeWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+		return true
+	}
+	return s.holidays.IsHoliday(day)
+}
+
+// businessDuration は start から end までのうち勤務時間に含まれる時間を返す
+func (s workSchedule) businessDuration(start, end time.Time) time.Duration {
+	start = start.In(s.location)
+	end = end.In(s.location)
+	if !start.Before(end) {
+		return 0
+	}
+
+	total := time.Duration(0)
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, s.location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if s.isDayOff(day) {
+			continue
+		}
+
+		workStart := time.Date(day.Year(), day.Month(), day.Day(), s.businessStart, 0, 0, 0, s.location)
+		if workStart.Before(start) {
+			workStart = start
+		}
+		workEnd := time.Date(day.Year(), day.Month(), day.Day(), s.businessEnd, 0, 0, 0, s.location)
+		if workEnd.After(end) {
+			workEnd = end
+		}
+		if workStart.Before(workEnd) {
+			total += workEnd.Sub(workStart)
+		}
+	}
+	return total
+}
@@ -0,0 +1,79 @@
+package team
+
+import (
+	"strings"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// FilterMetrics はPR作成時点でチームに所属していた作者のPRのみを返す
+// resolver が指定されている場合、別アカウントも同一人物として所属を判定する
+func (t Team) FilterMetrics(metrics []*prDomain.PRMetrics, resolver prDomain.IdentityResolver) []*prDomain.PRMetrics {
+	filtered := make([]*prDomain.PRMetrics, 0, len(metrics))
+	for _, metric := range metrics {
+		if t.IsMemberAtResolved(metric.Author, metric.CreatedAt, resolver) {
+			filtered = append(filtered, metric)
+		}
+	}
+	return filtered
+}
+
+// IsMemberAtResolved は別名解決を考慮して指定時刻の所属を判定
+func (t Team) IsMemberAtResolved(login string, at time.Time, resolver prDomain.IdentityResolver) bool {
+	if resolver == nil {
+		return t.IsMemberAt(login, at)
+	}
+
+	for _, member := range t.Members {
+		if member.IsActiveAt(at) && member.matchesLogin(login, resolver) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMemberDuringResolved は別名解決を考慮して、期間中に一度でも所属していたかを判定
+// 開発者ごとの集計データなど、PR単位ではない値をチームで絞り込むために使う
+func (t Team) IsMemberDuringResolved(login string, start, end time.Time, resolver prDomain.IdentityResolver) bool {
+	for _, member := range t.Members {
+		if member.IsActiveDuring(start, end) && member.matchesLogin(login, resolver) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLogin はメンバーとログイン名が同一人物かどうか（大文字・小文字は区別しない）
+func (m Membership) matchesLogin(login string, resolver prDomain.IdentityResolver) bool {
+	if strings.EqualFold(m.Login, login) {
+		return true
+	}
+	return resolver != nil && strings.EqualFold(resolver.Resolve(m.Login), resolver.Resolve(login))
+}
+
+// LoginExpander は別名を同一人物の全ログイン名に展開する
+type LoginExpander interface {
+	ExpandLogins(alias string) []string
+}
+
+// ExpandedLogins は過去を含む全メンバーのログイン名を、同一人物の別アカウントまで展開して返す
+// PRの取得条件に使うため、別アカウントで作成したPRも取りこぼさない。expander が nil の場合は AllLogins と同じ
+func (t Team) ExpandedLogins(expander LoginExpander) []string {
+	if expander == nil {
+		return t.AllLogins()
+	}
+
+	var logins []string
+	seen := make(map[string]bool)
+	for _, member := range t.AllLogins() {
+		for _, login := range expander.ExpandLogins(member) {
+			key := strings.ToLower(login)
+			if !seen[key] {
+				seen[key] = true
+				logins = append(logins, login)
+			}
+		}
+	}
+	return logins
+}
@@ -0,0 +1,202 @@
+package team
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// MemberSource はチームの現在のメンバー一覧を提供する（GitHubのorganization.team.members等）
+type MemberSource interface {
+	FetchTeamMembers(ctx context.Context, org, teamSlug string) ([]string, error)
+}
+
+// Roster はチーム定義の一覧
+// 実行時に API から更新されるため、並行アクセスに対して安全
+type Roster struct {
+	mu    sync.RWMutex
+	teams map[string]Team
+}
+
+// NewRoster はチーム定義から新しいロスターを作成
+func NewRoster(teams []Team) (*Roster, error) {
+	roster := &Roster{
+		teams: make(map[string]Team),
+	}
+	for _, team := range teams {
+		if err := roster.Register(team); err != nil {
+			return nil, err
+		}
+	}
+	return roster, nil
+}
+
+// Register はチームを登録（同じ名前が存在する場合は置き換え）
+func (r *Roster) Register(team Team) error {
+	if err := team.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.teams[team.Name] = copyTeam(team)
+	return nil
+}
+
+// Remove はチームを削除
+func (r *Roster) Remove(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.teams[name]; !exists {
+		return false
+	}
+	delete(r.teams, name)
+	return true
+}
+
+// Get は名前からチームを取得
+func (r *Roster) Get(name string) (Team, bool) {
+	if r == nil {
+		return Team{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	team, exists := r.teams[name]
+	if !exists {
+		return Team{}, false
+	}
+	return copyTeam(team), true
+}
+
+// List は登録済みのチームを名前順で返す
+func (r *Roster) List() []Team {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := make([]Team, 0, len(r.teams))
+	for _, team := range r.teams {
+		teams = append(teams, copyTeam(team))
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+	return teams
+}
+
+// TeamsOf は指定時刻にログイン名が所属していたチーム名を返す
+func (r *Roster) TeamsOf(login string, at time.Time) []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	for name, team := range r.teams {
+		if team.IsMemberAt(login, at) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WorkingHoursFor は指定時刻にログイン名へ適用する勤務時間を返す
+// 所属していたチームの設定に個人の設定を重ね、複数のチームに所属していた場合は名前順で最初のチームを使う
+func (r *Roster) WorkingHoursFor(login string, at time.Time) (prDomain.WorkingHours, bool) {
+	if r == nil {
+		return prDomain.WorkingHours{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.teams))
+	for name := range r.teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		team := r.teams[name]
+		for _, member := range team.Members {
+			if !strings.EqualFold(member.Login, login) || !member.IsActiveAt(at) {
+				continue
+			}
+			if team.WorkingHours == nil && member.WorkingHours == nil {
+				return prDomain.WorkingHours{}, false
+			}
+			var hours prDomain.WorkingHours
+			if team.WorkingHours != nil {
+				hours = *team.WorkingHours
+			}
+			if member.WorkingHours != nil {
+				hours = hours.Override(*member.WorkingHours)
+			}
+			return hours, true
+		}
+	}
+	return prDomain.WorkingHours{}, false
+}
+
+// Sync はGitHubチーム等の現在のメンバー一覧でチームの所属情報を更新
+func (r *Roster) Sync(ctx context.Context, name string, source MemberSource, now time.Time) (*SyncResult, error) {
+	team, exists := r.Get(name)
+	if !exists {
+		return nil, &TeamError{Type: "NOT_FOUND", Message: fmt.Sprintf("team %q not found", name)}
+	}
+
+	org, slug, ok := team.GitHubTeamSlug()
+	if !ok {
+		return nil, &TeamError{Type: "VALIDATION_ERROR", Message: fmt.Sprintf("team %q has no githubTeam to sync from", name)}
+	}
+
+	logins, err := source.FetchTeamMembers(ctx, org, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch members of %s/%s: %w", org, slug, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 取得中に削除・更新された場合に備えて最新の定義に適用する
+	latest, exists := r.teams[name]
+	if !exists {
+		return nil, &TeamError{Type: "NOT_FOUND", Message: fmt.Sprintf("team %q not found", name)}
+	}
+	latest = copyTeam(latest)
+	joined, left := latest.SyncMembers(logins, now)
+	r.teams[name] = latest
+
+	return &SyncResult{Team: name, Joined: joined, Left: left, SyncedAt: now}, nil
+}
+
+// SyncResult はメンバー同期の結果
+type SyncResult struct {
+	Team     string    `json:"team"`
+	Joined   []string  `json:"joined"`
+	Left     []string  `json:"left"`
+	SyncedAt time.Time `json:"syncedAt"`
+}
+
+// copyTeam はメンバー一覧を含めてチームを複製
+func copyTeam(team Team) Team {
+	copied := team
+	copied.Members = make([]Membership, len(team.Members))
+	copy(copied.Members, team.Members)
+	return copied
+}
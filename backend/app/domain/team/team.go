@@ -0,0 +1,174 @@
+package team
+
+import (
+	"strings"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// Team は開発チーム
+// メンバーの所属期間を保持し、過去の集計が組織変更で書き換わらないようにする
+type Team struct {
+	Name       string       `json:"name"`
+	GitHubTeam string       `json:"githubTeam,omitempty"` // "org/team-slug" 形式。同期元のGitHubチーム
+	Members    []Membership `json:"members"`
+
+	// メンバーの勤務時間（nil の場合は全体の設定を使う）
+	WorkingHours *prDomain.WorkingHours `json:"workingHours,omitempty"`
+}
+
+// Membership はチームへの所属期間
+type Membership struct {
+	Login    string     `json:"login"`
+	JoinedAt time.Time  `json:"joinedAt"`
+	LeftAt   *time.Time `json:"leftAt,omitempty"` // nil の場合は現在も所属
+
+	// 個人の勤務時間（チームの設定より優先）
+	WorkingHours *prDomain.WorkingHours `json:"workingHours,omitempty"`
+}
+
+// IsActiveAt は指定時刻に所属していたかどうか（JoinedAt <= at < LeftAt）
+func (m Membership) IsActiveAt(at time.Time) bool {
+	if at.Before(m.JoinedAt) {
+		return false
+	}
+	return m.LeftAt == nil || at.Before(*m.LeftAt)
+}
+
+// IsActiveDuring は期間（start <= t <= end）中に一度でも所属していたかどうか
+func (m Membership) IsActiveDuring(start, end time.Time) bool {
+	if end.Before(m.JoinedAt) {
+		return false
+	}
+	return m.LeftAt == nil || start.Before(*m.LeftAt)
+}
+
+// Validate はチーム定義の妥当性を検証
+func (t Team) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return &TeamError{Type: "VALIDATION_ERROR", Message: "team name is required"}
+	}
+	if t.GitHubTeam != "" {
+		if _, _, ok := t.GitHubTeamSlug(); !ok {
+			return &TeamError{Type: "VALIDATION_ERROR", Message: "githubTeam must be in org/team-slug format"}
+		}
+	}
+	if t.WorkingHours != nil {
+		if err := t.WorkingHours.Validate(); err != nil {
+			return &TeamError{Type: "VALIDATION_ERROR", Message: "invalid workingHours: " + err.Error()}
+		}
+	}
+	for _, member := range t.Members {
+		if strings.TrimSpace(member.Login) == "" {
+			return &TeamError{Type: "VALIDATION_ERROR", Message: "member login is required"}
+		}
+		if member.LeftAt != nil && member.LeftAt.Before(member.JoinedAt) {
+			return &TeamError{Type: "VALIDATION_ERROR", Message: "leftAt must be after joinedAt: " + member.Login}
+		}
+		if member.WorkingHours != nil {
+			if err := member.WorkingHours.Validate(); err != nil {
+				return &TeamError{Type: "VALIDATION_ERROR", Message: "invalid workingHours of " + member.Login + ": " + err.Error()}
+			}
+		}
+	}
+	return nil
+}
+
+// GitHubTeamSlug は同期元のGitHub組織名とチームスラッグを返す
+func (t Team) GitHubTeamSlug() (org string, slug string, ok bool) {
+	parts := strings.Split(t.GitHubTeam, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// IsMemberAt は指定時刻にログイン名がチームに所属していたかどうか
+func (t Team) IsMemberAt(login string, at time.Time) bool {
+	for _, member := range t.Members {
+		if strings.EqualFold(member.Login, login) && member.IsActiveAt(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// MembersAt は指定時刻に所属していたメンバーのログイン名を返す
+func (t Team) MembersAt(at time.Time) []string {
+	var logins []string
+	seen := make(map[string]bool)
+	for _, member := range t.Members {
+		key := strings.ToLower(member.Login)
+		if member.IsActiveAt(at) && !seen[key] {
+			seen[key] = true
+			logins = append(logins, member.Login)
+		}
+	}
+	return logins
+}
+
+// AllLogins は過去を含む全メンバーのログイン名を返す
+func (t Team) AllLogins() []string {
+	var logins []string
+	seen := make(map[string]bool)
+	for _, member := range t.Members {
+		key := strings.ToLower(member.Login)
+		if !seen[key] {
+			seen[key] = true
+			logins = append(logins, member.Login)
+		}
+	}
+	return logins
+}
+
+// SyncMembers は現在のメンバー一覧（GitHubチーム等）と所属情報を突き合わせる
+// 新規メンバーは now から所属、一覧にいなくなったメンバーは now で離脱として記録する
+// 過去の所属期間は変更しない
+func (t *Team) SyncMembers(currentLogins []string, now time.Time) (joined []string, left []string) {
+	current := make(map[string]string)
+	for _, login := range currentLogins {
+		current[strings.ToLower(login)] = login
+	}
+
+	active := make(map[string]bool)
+	for i := range t.Members {
+		member := &t.Members[i]
+		if member.LeftAt != nil {
+			continue
+		}
+		key := strings.ToLower(member.Login)
+		if _, exists := current[key]; exists {
+			active[key] = true
+			continue
+		}
+		leftAt := now
+		member.LeftAt = &leftAt
+		left = append(left, member.Login)
+	}
+
+	for key, login := range current {
+		if active[key] {
+			continue
+		}
+		t.Members = append(t.Members, Membership{Login: login, JoinedAt: now})
+		joined = append(joined, login)
+	}
+
+	return joined, left
+}
+
+// TeamError はチーム管理で発生するエラー
+type TeamError struct {
+	Type    string
+	Message string
+}
+
+func (e *TeamError) Error() string {
+	return e.Type + ": " + e.Message
+}
+
+// IsNotFound はチームが存在しないエラーかどうかを判定
+func (e *TeamError) IsNotFound() bool {
+	return e.Type == "NOT_FOUND"
+}
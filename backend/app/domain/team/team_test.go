@@ -0,0 +1,317 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func datePtr(year int, month time.Month, day int) *time.Time {
+	t := date(year, month, day)
+	return &t
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func newTestTeam() Team {
+	return Team{
+		Name:       "platform",
+		GitHubTeam: "example/platform",
+		Members: []Membership{
+			{Login: "alice", JoinedAt: date(2024, 1, 1)},
+			{Login: "bob", JoinedAt: date(2024, 1, 1), LeftAt: datePtr(2024, 3, 1)},
+			{Login: "carol", JoinedAt: date(2024, 2, 15)},
+		},
+	}
+}
+
+func TestMembership_IsActiveAt(t *testing.T) {
+	membership := Membership{Login: "bob", JoinedAt: date(2024, 1, 1), LeftAt: datePtr(2024, 3, 1)}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"所属開始前", date(2023, 12, 31), false},
+		{"所属開始時刻ちょうど", date(2024, 1, 1), true},
+		{"所属期間中", date(2024, 2, 1), true},
+		{"離脱時刻ちょうどは含まない", date(2024, 3, 1), false},
+		{"離脱後", date(2024, 4, 1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := membership.IsActiveAt(tt.at); result != tt.expected {
+				t.Errorf("IsActiveAt(%v) = %v, want %v", tt.at, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMembership_IsActiveDuring(t *testing.T) {
+	membership := Membership{Login: "bob", JoinedAt: date(2024, 1, 1), LeftAt: datePtr(2024, 3, 1)}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		expected   bool
+	}{
+		{"所属開始前に終わる期間", date(2023, 12, 1), date(2023, 12, 31), false},
+		{"所属開始日に終わる期間", date(2023, 12, 1), date(2024, 1, 1), true},
+		{"所属期間と重なる期間", date(2024, 2, 1), date(2024, 3, 31), true},
+		{"離脱時刻から始まる期間", date(2024, 3, 1), date(2024, 3, 31), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := membership.IsActiveDuring(tt.start, tt.end); result != tt.expected {
+				t.Errorf("IsActiveDuring(%v, %v) = %v, want %v", tt.start, tt.end, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTeam_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		team    Team
+		wantErr bool
+	}{
+		{"正常", newTestTeam(), false},
+		{"チーム名なし", Team{}, true},
+		{"GitHubチームの形式不正", Team{Name: "platform", GitHubTeam: "platform"}, true},
+		{"離脱日が所属日より前", Team{Name: "platform", Members: []Membership{
+			{Login: "alice", JoinedAt: date(2024, 2, 1), LeftAt: datePtr(2024, 1, 1)},
+		}}, true},
+		{"勤務時間のタイムゾーン不正", Team{Name: "platform", WorkingHours: &prDomain.WorkingHours{Timezone: "Mars/Olympus"}}, true},
+		{"メンバーの勤務時間の開始と終了が逆", Team{Name: "platform", Members: []Membership{
+			{Login: "alice", JoinedAt: date(2024, 1, 1), WorkingHours: &prDomain.WorkingHours{BusinessStart: intPtr(18), BusinessEnd: intPtr(9)}},
+		}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.team.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTeam_SyncMembers(t *testing.T) {
+	team := newTestTeam()
+	now := date(2024, 5, 1)
+
+	// alice は離脱、dave は新規、carol は継続、bob は既に離脱済み
+	joined, left := team.SyncMembers([]string{"Carol", "dave"}, now)
+
+	if len(joined) != 1 || joined[0] != "dave" {
+		t.Errorf("joined = %v, want [dave]", joined)
+	}
+	if len(left) != 1 || left[0] != "alice" {
+		t.Errorf("left = %v, want [alice]", left)
+	}
+
+	if team.IsMemberAt("alice", now) {
+		t.Error("alice should have left at sync time")
+	}
+	if !team.IsMemberAt("alice", date(2024, 4, 30)) {
+		t.Error("alice's past membership should be preserved")
+	}
+	if !team.IsMemberAt("dave", now) || team.IsMemberAt("dave", date(2024, 4, 30)) {
+		t.Error("dave should be a member only from sync time")
+	}
+	if bob := team.Members[1]; !bob.LeftAt.Equal(date(2024, 3, 1)) {
+		t.Errorf("bob's LeftAt should not change, got %v", bob.LeftAt)
+	}
+}
+
+func TestTeam_FilterMetrics(t *testing.T) {
+	team := newTestTeam()
+	metrics := []*prDomain.PRMetrics{
+		{PRID: "1", Author: "alice", CreatedAt: date(2024, 1, 10)},
+		{PRID: "2", Author: "bob", CreatedAt: date(2024, 2, 10)},
+		{PRID: "3", Author: "bob", CreatedAt: date(2024, 3, 10)},     // 離脱後
+		{PRID: "4", Author: "carol", CreatedAt: date(2024, 2, 1)},    // 所属前
+		{PRID: "5", Author: "carol", CreatedAt: date(2024, 2, 20)},
+		{PRID: "6", Author: "alice-alt", CreatedAt: date(2024, 2, 20)}, // 別アカウント
+		{PRID: "7", Author: "eve", CreatedAt: date(2024, 2, 20)},
+	}
+
+	t.Run("所属期間で絞り込む", func(t *testing.T) {
+		assertPRIDs(t, team.FilterMetrics(metrics, nil), []string{"1", "2", "5"})
+	})
+
+	t.Run("別アカウントも同一人物として扱う", func(t *testing.T) {
+		resolver := aliasResolver{"alice-alt": "alice"}
+		assertPRIDs(t, team.FilterMetrics(metrics, resolver), []string{"1", "2", "5", "6"})
+	})
+}
+
+func TestRoster_Sync(t *testing.T) {
+	roster, err := NewRoster([]Team{newTestTeam(), {Name: "mobile"}})
+	if err != nil {
+		t.Fatalf("NewRoster() error = %v", err)
+	}
+	now := date(2024, 5, 1)
+
+	t.Run("GitHubチームのメンバーで更新", func(t *testing.T) {
+		source := &fakeMemberSource{members: []string{"alice", "carol", "dave"}}
+		result, err := roster.Sync(context.Background(), "platform", source, now)
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if source.org != "example" || source.slug != "platform" {
+			t.Errorf("fetched %s/%s, want example/platform", source.org, source.slug)
+		}
+		if len(result.Joined) != 1 || result.Joined[0] != "dave" || len(result.Left) != 0 {
+			t.Errorf("Sync() = joined %v left %v, want joined [dave] left []", result.Joined, result.Left)
+		}
+
+		team, _ := roster.Get("platform")
+		if !team.IsMemberAt("dave", now) {
+			t.Error("dave should be registered in the roster")
+		}
+	})
+
+	t.Run("存在しないチーム", func(t *testing.T) {
+		_, err := roster.Sync(context.Background(), "unknown", &fakeMemberSource{}, now)
+		var teamErr *TeamError
+		if !errors.As(err, &teamErr) || !teamErr.IsNotFound() {
+			t.Errorf("Sync() error = %v, want NOT_FOUND", err)
+		}
+	})
+
+	t.Run("GitHubチーム未設定", func(t *testing.T) {
+		if _, err := roster.Sync(context.Background(), "mobile", &fakeMemberSource{}, now); err == nil {
+			t.Error("Sync() should fail without githubTeam")
+		}
+	})
+
+	t.Run("取得失敗時は変更しない", func(t *testing.T) {
+		source := &fakeMemberSource{err: errors.New("api error")}
+		if _, err := roster.Sync(context.Background(), "platform", source, now.AddDate(0, 1, 0)); err == nil {
+			t.Fatal("Sync() should return fetch error")
+		}
+		team, _ := roster.Get("platform")
+		if !team.IsMemberAt("alice", now.AddDate(0, 1, 0)) {
+			t.Error("members should not change on fetch error")
+		}
+	})
+}
+
+func TestRoster_TeamsOf(t *testing.T) {
+	roster, err := NewRoster([]Team{
+		newTestTeam(),
+		{Name: "mobile", Members: []Membership{{Login: "bob", JoinedAt: date(2024, 3, 1)}}},
+	})
+	if err != nil {
+		t.Fatalf("NewRoster() error = %v", err)
+	}
+
+	if teams := roster.TeamsOf("bob", date(2024, 2, 1)); strings.Join(teams, ",") != "platform" {
+		t.Errorf("TeamsOf(bob, Feb) = %v, want [platform]", teams)
+	}
+	if teams := roster.TeamsOf("bob", date(2024, 4, 1)); strings.Join(teams, ",") != "mobile" {
+		t.Errorf("TeamsOf(bob, Apr) = %v, want [mobile]", teams)
+	}
+}
+
+func TestRoster_Nil(t *testing.T) {
+	var roster *Roster
+
+	if teams := roster.List(); len(teams) != 0 {
+		t.Errorf("List() = %v, want empty", teams)
+	}
+	if teams := roster.TeamsOf("bob", date(2024, 2, 1)); len(teams) != 0 {
+		t.Errorf("TeamsOf(bob) = %v, want empty", teams)
+	}
+	if _, exists := roster.Get("platform"); exists {
+		t.Error("Get(platform) exists = true, want false")
+	}
+}
+
+func TestRoster_WorkingHoursFor(t *testing.T) {
+	start, end := 10, 19
+	platform := newTestTeam()
+	platform.WorkingHours = &prDomain.WorkingHours{Timezone: "Asia/Tokyo", BusinessStart: &start, BusinessEnd: &end}
+	platform.Members[2].WorkingHours = &prDomain.WorkingHours{Timezone: "Europe/London"}
+	roster, err := NewRoster([]Team{
+		platform,
+		{Name: "mobile", Members: []Membership{{Login: "bob", JoinedAt: date(2024, 3, 1)}}},
+	})
+	if err != nil {
+		t.Fatalf("NewRoster() error = %v", err)
+	}
+
+	hours, ok := roster.WorkingHoursFor("alice", date(2024, 2, 1))
+	if !ok || hours.Timezone != "Asia/Tokyo" || *hours.BusinessStart != 10 {
+		t.Errorf("WorkingHoursFor(alice) = %+v, %v, want team working hours", hours, ok)
+	}
+
+	hours, ok = roster.WorkingHoursFor("Carol", date(2024, 3, 1))
+	if !ok || hours.Timezone != "Europe/London" || *hours.BusinessEnd != 19 {
+		t.Errorf("WorkingHoursFor(carol) = %+v, %v, want member timezone over team hours", hours, ok)
+	}
+
+	if _, ok := roster.WorkingHoursFor("bob", date(2024, 4, 1)); ok {
+		t.Error("WorkingHoursFor(bob, Apr) should not return working hours of a team without settings")
+	}
+	if _, ok := roster.WorkingHoursFor("carol", date(2024, 1, 1)); ok {
+		t.Error("WorkingHoursFor(carol, Jan) should not return working hours before joining")
+	}
+}
+
+type fakeMemberSource struct {
+	members []string
+	err     error
+	org     string
+	slug    string
+}
+
+func (s *fakeMemberSource) FetchTeamMembers(ctx context.Context, org, teamSlug string) ([]string, error) {
+	s.org, s.slug = org, teamSlug
+	return s.members, s.err
+}
+
+type aliasResolver map[string]string
+
+func (r aliasResolver) Resolve(login string) string {
+	if id, exists := r[login]; exists {
+		return id
+	}
+	return login
+}
+
+func (r aliasResolver) ResolveAll(logins []string) []string {
+	resolved := make([]string, 0, len(logins))
+	for _, login := range logins {
+		resolved = append(resolved, r.Resolve(login))
+	}
+	return resolved
+}
+
+func assertPRIDs(t *testing.T, metrics []*prDomain.PRMetrics, expected []string) {
+	t.Helper()
+	ids := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		ids = append(ids, metric.PRID)
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Errorf("PR IDs = %v, want %v", ids, expected)
+	}
+}
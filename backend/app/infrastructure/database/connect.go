@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrNotConfigured はデータベースの接続先が設定されていないことを表す
+	ErrNotConfigured = errors.New("database is not configured")
+	// ErrUnavailable はデータベースに接続できないことを表す
+	ErrUnavailable = errors.New("database is unavailable")
+)
+
+// PoolConfig は接続プールとタイムアウトの設定
+type PoolConfig struct {
+	MaxOpenConns    int           // 最大接続数（0は無制限）
+	MaxIdleConns    int           // アイドル状態で保持する最大接続数
+	ConnMaxLifetime time.Duration // 接続の最大再利用時間（0は無制限）
+	ConnectTimeout  time.Duration // 接続確立のタイムアウト
+	QueryTimeout    time.Duration // クエリ1件あたりのタイムアウト（PostgreSQL のみ）
+}
+
+// Connect は接続プールを設定してデータベースに接続し、ping で疎通を確認する
+// ping に失敗した場合も *sql.DB を返し、エラーは ErrUnavailable をラップする
+// （プールは次のクエリで再接続を試みるため、呼び出し側は縮退運転を選べる）
+func Connect(ctx context.Context, databaseURL string, pool PoolConfig) (*sql.DB, Dialect, error) {
+	dialect, dsn, err := ParseDatabaseURL(databaseURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dialect.Name() == "postgres" {
+		if dsn, err = postgresDSNWithTimeouts(dsn, pool); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	db, err := openDSN(dialect, dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dialect.Name() != "sqlite" {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+
+	if err := Ping(ctx, db, pool.ConnectTimeout); err != nil {
+		return db, dialect, err
+	}
+	return db, dialect, nil
+}
+
+// Ping はタイムアウト付きでデータベースの疎通を確認する
+func Ping(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		if errors.Is(err, ErrNotConfigured) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return nil
+}
+
+// Disconnected は常に cause を返す *sql.DB を作成する
+// 接続先が未設定でもリポジトリを組み立てられるようにし、各APIはエラーとして扱える
+func Disconnected(cause error) *sql.DB {
+	return sql.OpenDB(unavailableConnector{err: cause})
+}
+
+// IsUnavailable はエラーがデータベースに接続できないことによるものかを判定する
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrUnavailable) || errors.Is(err, ErrNotConfigured) ||
+		errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// 08: connection_exception, 53300: too_many_connections, 57P01-03: 停止・起動中
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "53300", "57P01", "57P02", "57P03":
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+
+	return false
+}
+
+// IsTimeout はエラーがクエリのタイムアウトによるものかを判定する
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	// 57014: query_canceled（statement_timeout 超過）
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "57014"
+}
+
+// postgresDSNWithTimeouts は接続URLに接続タイムアウトとステートメントタイムアウトを追加する
+// URLで明示的に指定されている値は上書きしない
+func postgresDSNWithTimeouts(dsn string, pool PoolConfig) (string, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return "", fmt.Errorf("invalid database URL: %s", redactURL(dsn))
+	}
+
+	query := parsed.Query()
+	if pool.ConnectTimeout > 0 && query.Get("connect_timeout") == "" {
+		// connect_timeout は秒単位
+		seconds := int(math.Ceil(pool.ConnectTimeout.Seconds()))
+		query.Set("connect_timeout", strconv.Itoa(seconds))
+	}
+	if pool.QueryTimeout > 0 && query.Get("statement_timeout") == "" {
+		query.Set("statement_timeout", strconv.FormatInt(pool.QueryTimeout.Milliseconds(), 10))
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), nil
+}
+
+// unavailableConnector は接続要求に常にエラーを返すコネクタ
+type unavailableConnector struct {
+	err error
+}
+
+func (c unavailableConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, c.err
+}
+
+func (c unavailableConnector) Driver() driver.Driver {
+	return unavailableDriver{err: c.err}
+}
+
+type unavailableDriver struct {
+	err error
+}
+
+func (d unavailableDriver) Open(string) (driver.Conn, error) {
+	return nil, d.err
+}
@@ -0,0 +1,235 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Dialect はデータベースごとのSQL方言の差異を吸収する
+// リポジトリのクエリは PostgreSQL 形式（$1, $2 ...）で記述し、実行時に各方言へ変換する
+type Dialect interface {
+	// Name は方言名（"postgres" / "sqlite"）
+	Name() string
+	// DriverName は database/sql に登録されたドライバ名
+	DriverName() string
+	// Rebind は $n 形式のプレースホルダを方言のプレースホルダに変換
+	Rebind(query string) string
+	// BindArg は引数をドライバが扱える形式に変換（時刻・文字列スライス等）
+	BindArg(value interface{}) interface{}
+	// AnyOf は列の値が文字列スライス引数（$index）のいずれかに一致する条件式を返す
+	AnyOf(column string, index int) string
+	// UpsertClause は一意制約の衝突時に updateColumns を新しい値で更新する句を返す
+	// extraAssignments には "version = t.version + 1" のような任意の代入式を指定できる
+	UpsertClause(conflictColumns []string, updateColumns []string, extraAssignments ...string) string
+	// IgnoreConflictClause は一意制約の衝突時に何もしない句を返す
+	IgnoreConflictClause(conflictColumns []string) string
+	// ColumnType は論理的な列の型を方言の型名に変換
+	ColumnType(kind ColumnKind) string
+	// DateTrunc は時刻列を集計期間の開始時刻に切り捨てる式を返す（週は月曜始まり）
+	DateTrunc(period string, column string) string
+	// CurrentTimestamp は現在時刻を表す式を返す
+	CurrentTimestamp() string
+}
+
+// ColumnKind は論理的な列の型
+type ColumnKind string
+
+const (
+	ColumnKindText      ColumnKind = "text"
+	ColumnKindInteger   ColumnKind = "integer"
+	ColumnKindBigInt    ColumnKind = "bigint"
+	ColumnKindFloat     ColumnKind = "float"
+	ColumnKindBoolean   ColumnKind = "boolean"
+	ColumnKindTimestamp ColumnKind = "timestamp"
+	ColumnKindJSON      ColumnKind = "json"
+)
+
+// placeholderPattern は PostgreSQL 形式のプレースホルダ
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+// Postgres は PostgreSQL の方言を返す
+func Postgres() Dialect {
+	return postgresDialect{}
+}
+
+// SQLite は SQLite の方言を返す
+func SQLite() Dialect {
+	return sqliteDialect{}
+}
+
+// DialectByName は方言名から方言を返す
+func DialectByName(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case "postgres", "postgresql":
+		return Postgres(), nil
+	case "sqlite", "sqlite3":
+		return SQLite(), nil
+	default:
+		return nil, fmt.Errorf("unsupported database dialect: %s", name)
+	}
+}
+
+// postgresDialect は PostgreSQL の方言
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string       { return "postgres" }
+func (postgresDialect) DriverName() string { return "postgres" }
+
+func (postgresDialect) Rebind(query string) string {
+	return query
+}
+
+func (postgresDialect) BindArg(value interface{}) interface{} {
+	if values, ok := value.([]string); ok {
+		return pq.Array(values)
+	}
+	return value
+}
+
+func (postgresDialect) AnyOf(column string, index int) string {
+	return fmt.Sprintf("%s = ANY($%d)", column, index)
+}
+
+func (postgresDialect) UpsertClause(conflictColumns []string, updateColumns []string, extraAssignments ...string) string {
+	return upsertClause(conflictColumns, updateColumns, extraAssignments)
+}
+
+func (postgresDialect) IgnoreConflictClause(conflictColumns []string) string {
+	return fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", strings.Join(conflictColumns, ", "))
+}
+
+func (postgresDialect) ColumnType(kind ColumnKind) string {
+	switch kind {
+	case ColumnKindInteger:
+		return "INTEGER"
+	case ColumnKindBigInt:
+		return "BIGINT"
+	case ColumnKindFloat:
+		return "DOUBLE PRECISION"
+	case ColumnKindBoolean:
+		return "BOOLEAN"
+	case ColumnKindTimestamp:
+		return "TIMESTAMPTZ"
+	case ColumnKindJSON:
+		return "JSONB"
+	default:
+		return "TEXT"
+	}
+}
+
+func (postgresDialect) DateTrunc(period string, column string) string {
+	return fmt.Sprintf("DATE_TRUNC('%s', %s)", truncUnit(period), column)
+}
+
+func (postgresDialect) CurrentTimestamp() string {
+	return "NOW()"
+}
+
+// sqliteDialect は SQLite の方言
+// 時刻はUTCの固定長文字列で保存し、文字列比較でも時系列順になるようにする
+type sqliteDialect struct{}
+
+// sqliteTimeFormat は SQLite に保存する時刻の形式（ドライバが読み込み時に time.Time へ変換できる形式）
+const sqliteTimeFormat = "2006-01-02 15:04:05.000000000-07:00"
+
+func (sqliteDialect) Name() string       { return "sqlite" }
+func (sqliteDialect) DriverName() string { return "sqlite" }
+
+func (sqliteDialect) Rebind(query string) string {
+	// ?NNN は番号指定のプレースホルダのため、同じ番号の再利用や順不同の参照も保たれる
+	return placeholderPattern.ReplaceAllString(query, "?$1")
+}
+
+func (sqliteDialect) BindArg(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(sqliteTimeFormat)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC().Format(sqliteTimeFormat)
+	case []string:
+		// json_each で展開するためJSON配列として渡す
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "[]"
+		}
+		return string(encoded)
+	default:
+		return value
+	}
+}
+
+func (sqliteDialect) AnyOf(column string, index int) string {
+	return fmt.Sprintf("%s IN (SELECT value FROM json_each($%d))", column, index)
+}
+
+func (sqliteDialect) UpsertClause(conflictColumns []string, updateColumns []string, extraAssignments ...string) string {
+	// SQLite 3.24 以降は PostgreSQL と同じ ON CONFLICT 構文を持つ
+	return upsertClause(conflictColumns, updateColumns, extraAssignments)
+}
+
+func (sqliteDialect) IgnoreConflictClause(conflictColumns []string) string {
+	return fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", strings.Join(conflictColumns, ", "))
+}
+
+func (sqliteDialect) ColumnType(kind ColumnKind) string {
+	switch kind {
+	case ColumnKindInteger, ColumnKindBigInt:
+		return "INTEGER"
+	case ColumnKindFloat:
+		return "REAL"
+	case ColumnKindBoolean:
+		return "BOOLEAN"
+	case ColumnKindTimestamp:
+		// 宣言型が DATETIME の列はドライバが time.Time として読み込む
+		return "DATETIME"
+	default:
+		return "TEXT"
+	}
+}
+
+func (sqliteDialect) DateTrunc(period string, column string) string {
+	switch truncUnit(period) {
+	case "week":
+		return fmt.Sprintf("date(%s, '-6 days', 'weekday 1')", column)
+	case "month":
+		return fmt.Sprintf("strftime('%%Y-%%m-01', %s)", column)
+	default:
+		return fmt.Sprintf("date(%s)", column)
+	}
+}
+
+func (sqliteDialect) CurrentTimestamp() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+// upsertClause は ON CONFLICT ... DO UPDATE SET 句を組み立てる
+func upsertClause(conflictColumns []string, updateColumns []string, extraAssignments []string) string {
+	assignments := make([]string, 0, len(updateColumns)+len(extraAssignments))
+	for _, column := range updateColumns {
+		assignments = append(assignments, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+	assignments = append(assignments, extraAssignments...)
+
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET\n\t\t\t%s",
+		strings.Join(conflictColumns, ", "), strings.Join(assignments, ",\n\t\t\t"))
+}
+
+// truncUnit は集計期間名を切り捨て単位に変換
+func truncUnit(period string) string {
+	switch strings.ToLower(period) {
+	case "weekly", "week":
+		return "week"
+	case "monthly", "month":
+		return "month"
+	default:
+		return "day"
+	}
+}
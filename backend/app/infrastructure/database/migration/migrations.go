@@ -0,0 +1,503 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github-stats-metrics/domain/analytics"
+	"github-stats-metrics/infrastructure/database"
+)
+
+// column は CREATE TABLE / ADD COLUMN で使う列定義
+type column struct {
+	name         string
+	kind         database.ColumnKind
+	nullable     bool
+	defaultValue string
+}
+
+// Migrations は組み込みのマイグレーション一覧を返す
+// 適用済みのマイグレーションは変更せず、スキーマの変更は常に新しい番号で追加すること
+func Migrations() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "create_pr_metrics",
+			Up: func(dialect database.Dialect) []string {
+				return concat(
+					createTable(dialect, analytics.GetPRMetricsSchema(), prMetricsColumns),
+					createIndexes(dialect, analytics.GetPRMetricsSchema(),
+						"idx_pr_metrics_pr_id", "idx_pr_metrics_created_at", "idx_pr_metrics_merged_at",
+						"idx_pr_metrics_author", "idx_pr_metrics_repository",
+						"idx_pr_metrics_author_period", "idx_pr_metrics_repo_period",
+						"idx_pr_metrics_complexity", "idx_pr_metrics_size_category", "idx_pr_metrics_date_range",
+					),
+					createTable(dialect, analytics.GetFileChangeSchema(), fileChangeColumns),
+					createIndexes(dialect, analytics.GetFileChangeSchema(),
+						"idx_file_changes_pr_metrics_id", "idx_file_changes_file_type", "idx_file_changes_file_name",
+					),
+					createTable(dialect, analytics.GetReviewEventSchema(), reviewEventColumns),
+					createIndexes(dialect, analytics.GetReviewEventSchema(),
+						"idx_review_events_pr_metrics_id", "idx_review_events_type_created", "idx_review_events_reviewer",
+					),
+				)
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{
+					dropTable(analytics.GetReviewEventSchema()),
+					dropTable(analytics.GetFileChangeSchema()),
+					dropTable(analytics.GetPRMetricsSchema()),
+				}
+			},
+		},
+		{
+			Version: 2,
+			Name:    "create_aggregated_metrics",
+			Up: func(dialect database.Dialect) []string {
+				return concat(
+					createTable(dialect, analytics.GetAggregatedMetricsSchema(), aggregatedMetricsColumns),
+					createIndexes(dialect, analytics.GetAggregatedMetricsSchema(),
+						"uk_aggregated_metrics_unique", "idx_aggregated_metrics_level", "idx_aggregated_metrics_period",
+						"idx_aggregated_metrics_target", "idx_aggregated_metrics_time_range",
+						"idx_aggregated_metrics_level_target", "idx_aggregated_metrics_period_target",
+						"idx_aggregated_metrics_generated_at", "idx_aggregated_metrics_year_month",
+						"idx_aggregated_metrics_week_of_year",
+					),
+				)
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{dropTable(analytics.GetAggregatedMetricsSchema())}
+			},
+		},
+		{
+			Version: 3,
+			Name:    "create_trend_and_bottleneck_data",
+			Up: func(dialect database.Dialect) []string {
+				return concat(
+					createTable(dialect, analytics.GetTrendDataSchema(), trendDataColumns),
+					createIndexes(dialect, analytics.GetTrendDataSchema(),
+						"uk_trend_data_unique", "idx_trend_data_metric_type", "idx_trend_data_level",
+						"idx_trend_data_target", "idx_trend_data_period", "idx_trend_data_trend",
+					),
+					createTable(dialect, analytics.GetBottleneckDataSchema(), bottleneckDataColumns),
+					createIndexes(dialect, analytics.GetBottleneckDataSchema(),
+						"idx_bottleneck_data_pr_id", "idx_bottleneck_data_type", "idx_bottleneck_data_severity",
+						"idx_bottleneck_data_status", "idx_bottleneck_data_author", "idx_bottleneck_data_repository",
+						"idx_bottleneck_data_detected_at", "idx_bottleneck_data_active",
+					),
+				)
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{
+					dropTable(analytics.GetBottleneckDataSchema()),
+					dropTable(analytics.GetTrendDataSchema()),
+				}
+			},
+		},
+		{
+			Version: 4,
+			Name:    "add_pr_metrics_labels_and_bot",
+			Up: func(dialect database.Dialect) []string {
+				return []string{
+					addColumn(dialect, analytics.GetPRMetricsSchema(), column{"labels_json", database.ColumnKindJSON, false, "'[]'"}),
+					addColumn(dialect, analytics.GetPRMetricsSchema(), column{"is_bot", database.ColumnKindBoolean, false, "FALSE"}),
+				}
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{
+					dropColumn(analytics.GetPRMetricsSchema(), "is_bot"),
+					dropColumn(analytics.GetPRMetricsSchema(), "labels_json"),
+				}
+			},
+		},
+		{
+			// パーティションキーを含む一意キーのみを作成する
+			// テーブルの作り替えはデータ量に応じて時間がかかるため、migrate partition で明示的に行う
+			Version: 5,
+			Name:    "partition_pr_metrics",
+			Up: func(dialect database.Dialect) []string {
+				return createIndexes(dialect, analytics.GetPRMetricsSchema(), "uk_pr_metrics_pr_id_created_at")
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{dropIndex("uk_pr_metrics_pr_id_created_at")}
+			},
+		},
+		{
+			Version: 6,
+			Name:    "add_review_events_is_bot",
+			Up: func(dialect database.Dialect) []string {
+				return []string{
+					addColumn(dialect, analytics.GetReviewEventSchema(), column{"is_bot", database.ColumnKindBoolean, false, "FALSE"}),
+				}
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{dropColumn(analytics.GetReviewEventSchema(), "is_bot")}
+			},
+		},
+		{
+			Version: 7,
+			Name:    "create_aggregation_job_runs",
+			Up: func(dialect database.Dialect) []string {
+				return concat(
+					createTable(dialect, analytics.GetAggregationJobRunSchema(), aggregationJobRunColumns),
+					createIndexes(dialect, analytics.GetAggregationJobRunSchema(), "idx_aggregation_job_runs_status_started"),
+				)
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{dropTable(analytics.GetAggregationJobRunSchema())}
+			},
+		},
+		{
+			Version: 8,
+			Name:    "add_pr_metrics_definition_version",
+			Up: func(dialect database.Dialect) []string {
+				return []string{
+					addColumn(dialect, analytics.GetPRMetricsSchema(), column{"definition_version", database.ColumnKindText, false, "''"}),
+				}
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{dropColumn(analytics.GetPRMetricsSchema(), "definition_version")}
+			},
+		},
+		{
+			Version: 9,
+			Name:    "add_trend_data_aggregation_period",
+			Up: func(dialect database.Dialect) []string {
+				return []string{
+					addColumn(dialect, analytics.GetTrendDataSchema(), column{"aggregation_period", database.ColumnKindText, false, "''"}),
+				}
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{dropColumn(analytics.GetTrendDataSchema(), "aggregation_period")}
+			},
+		},
+		{
+			Version: 10,
+			Name:    "add_pr_metrics_change_failure",
+			Up: func(dialect database.Dialect) []string {
+				return []string{
+					addColumn(dialect, analytics.GetPRMetricsSchema(), column{"base_branch", database.ColumnKindText, false, "''"}),
+					addColumn(dialect, analytics.GetPRMetricsSchema(), column{"head_branch", database.ColumnKindText, false, "''"}),
+					addColumn(dialect, analytics.GetPRMetricsSchema(), column{"merge_commit_sha", database.ColumnKindText, false, "''"}),
+					addColumn(dialect, analytics.GetPRMetricsSchema(), column{"change_failure_json", database.ColumnKindJSON, false, "'{}'"}),
+				}
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{
+					dropColumn(analytics.GetPRMetricsSchema(), "change_failure_json"),
+					dropColumn(analytics.GetPRMetricsSchema(), "merge_commit_sha"),
+					dropColumn(analytics.GetPRMetricsSchema(), "head_branch"),
+					dropColumn(analytics.GetPRMetricsSchema(), "base_branch"),
+				}
+			},
+		},
+		{
+			Version: 11,
+			Name:    "create_pr_metrics_ids",
+			Up: func(dialect database.Dialect) []string {
+				return concat(
+					createTable(dialect, analytics.GetPRMetricsIDSchema(), prMetricsIDColumns),
+					[]string{
+						// 既存のPRは最も古い作成日時の行を残し、作成日時の違いで重複した行は関連データとともに削除する
+						"INSERT INTO pr_metrics_ids (pr_id, created_at) SELECT pr_id, MIN(created_at) FROM pr_metrics GROUP BY pr_id",
+						"DELETE FROM file_changes WHERE pr_metrics_id IN (" + duplicatePRMetricsIDs + ")",
+						"DELETE FROM review_events WHERE pr_metrics_id IN (" + duplicatePRMetricsIDs + ")",
+						"DELETE FROM pr_metrics WHERE id IN (" + duplicatePRMetricsIDs + ")",
+					},
+				)
+			},
+			Down: func(dialect database.Dialect) []string {
+				return []string{dropTable(analytics.GetPRMetricsIDSchema())}
+			},
+		},
+	}
+}
+
+// duplicatePRMetricsIDs は pr_metrics_ids に登録した作成日時と異なる pr_metrics の行のIDを返す副問い合わせ
+const duplicatePRMetricsIDs = "SELECT m.id FROM pr_metrics m JOIN pr_metrics_ids i ON i.pr_id = m.pr_id WHERE m.created_at <> i.created_at"
+
+// prMetricsSecondaryIndexes は pr_metrics の一意でないインデックス
+var prMetricsSecondaryIndexes = []string{
+	"idx_pr_metrics_created_at", "idx_pr_metrics_merged_at",
+	"idx_pr_metrics_author", "idx_pr_metrics_repository",
+	"idx_pr_metrics_author_period", "idx_pr_metrics_repo_period",
+	"idx_pr_metrics_complexity", "idx_pr_metrics_size_category", "idx_pr_metrics_date_range",
+}
+
+// partitionPRMetrics は pr_metrics を created_at による範囲パーティションへ作り替える文を返す
+// 一意制約にはパーティションキーを含める必要があるため、主キーは (id, created_at)、
+// 保存時の衝突判定は (pr_id, created_at) で行い、PR IDの一意性は pr_metrics_ids で保証する。
+// 既存データは既定パーティションへ移し、月次パーティションはパーティション管理が作成する
+func partitionPRMetrics(dialect database.Dialect) []string {
+	schema := analytics.GetPRMetricsSchema()
+	return concat(
+		[]string{
+			"ALTER TABLE pr_metrics RENAME TO pr_metrics_unpartitioned",
+			"CREATE TABLE pr_metrics (LIKE pr_metrics_unpartitioned INCLUDING DEFAULTS) PARTITION BY RANGE (created_at)",
+			"CREATE TABLE pr_metrics_default PARTITION OF pr_metrics DEFAULT",
+			"INSERT INTO pr_metrics SELECT * FROM pr_metrics_unpartitioned",
+			"DROP TABLE pr_metrics_unpartitioned",
+			"ALTER TABLE pr_metrics ADD CONSTRAINT pk_pr_metrics PRIMARY KEY (id, created_at)",
+		},
+		createIndexes(dialect, schema, "uk_pr_metrics_pr_id_created_at"),
+		createIndexes(dialect, schema, prMetricsSecondaryIndexes...),
+	)
+}
+
+// unpartitionPRMetrics は pr_metrics をパーティションなしのテーブルに戻す文を返す
+// 切り離し済みのパーティションは残るため、必要に応じて手動で削除する
+func unpartitionPRMetrics(dialect database.Dialect) []string {
+	schema := analytics.GetPRMetricsSchema()
+	return concat(
+		[]string{
+			"ALTER TABLE pr_metrics RENAME TO pr_metrics_partitioned",
+			"CREATE TABLE pr_metrics (LIKE pr_metrics_partitioned INCLUDING DEFAULTS)",
+			"INSERT INTO pr_metrics SELECT * FROM pr_metrics_partitioned",
+			"DROP TABLE pr_metrics_partitioned",
+			"ALTER TABLE pr_metrics ADD CONSTRAINT pk_pr_metrics PRIMARY KEY (id)",
+		},
+		createIndexes(dialect, schema, "idx_pr_metrics_pr_id", "uk_pr_metrics_pr_id_created_at"),
+		createIndexes(dialect, schema, prMetricsSecondaryIndexes...),
+	)
+}
+
+// prMetricsColumns は pr_metrics の初期カラム（labels_json / is_bot は 4、definition_version は 8、ブランチ・リバート判定は 10 で追加）
+var prMetricsColumns = []column{
+	{"id", database.ColumnKindText, false, ""},
+	{"pr_id", database.ColumnKindText, false, ""},
+	{"pr_number", database.ColumnKindInteger, false, ""},
+	{"title", database.ColumnKindText, false, ""},
+	{"author", database.ColumnKindText, false, ""},
+	{"repository", database.ColumnKindText, false, ""},
+	{"created_at", database.ColumnKindTimestamp, false, ""},
+	{"merged_at", database.ColumnKindTimestamp, true, ""},
+	{"collected_at", database.ColumnKindTimestamp, false, ""},
+	{"size_metrics_json", database.ColumnKindJSON, false, ""},
+	{"total_cycle_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"time_to_first_review_seconds", database.ColumnKindBigInt, true, ""},
+	{"time_to_approval_seconds", database.ColumnKindBigInt, true, ""},
+	{"time_to_merge_seconds", database.ColumnKindBigInt, true, ""},
+	{"time_metrics_json", database.ColumnKindJSON, false, ""},
+	{"review_comment_count", database.ColumnKindInteger, false, "0"},
+	{"review_round_count", database.ColumnKindInteger, false, "0"},
+	{"reviewer_count", database.ColumnKindInteger, false, "0"},
+	{"first_review_pass_rate", database.ColumnKindFloat, false, "0"},
+	{"quality_metrics_json", database.ColumnKindJSON, false, ""},
+	{"complexity_score", database.ColumnKindFloat, false, "0"},
+	{"size_category", database.ColumnKindText, false, ""},
+	{"year_month", database.ColumnKindText, false, ""},
+	{"week_of_year", database.ColumnKindText, false, ""},
+	{"day_of_year", database.ColumnKindText, false, ""},
+}
+
+var prMetricsIDColumns = []column{
+	{"pr_id", database.ColumnKindText, false, ""},
+	{"created_at", database.ColumnKindTimestamp, false, ""},
+}
+
+var fileChangeColumns = []column{
+	{"id", database.ColumnKindText, false, ""},
+	{"pr_metrics_id", database.ColumnKindText, false, ""},
+	{"file_name", database.ColumnKindText, false, ""},
+	{"file_type", database.ColumnKindText, false, ""},
+	{"lines_added", database.ColumnKindInteger, false, "0"},
+	{"lines_deleted", database.ColumnKindInteger, false, "0"},
+	{"is_new_file", database.ColumnKindBoolean, false, "FALSE"},
+	{"is_deleted", database.ColumnKindBoolean, false, "FALSE"},
+	{"is_renamed", database.ColumnKindBoolean, false, "FALSE"},
+	{"collected_at", database.ColumnKindTimestamp, false, ""},
+}
+
+// reviewEventColumns は review_events の初期カラム（is_bot は 6 で追加）
+var reviewEventColumns = []column{
+	{"id", database.ColumnKindText, false, ""},
+	{"pr_metrics_id", database.ColumnKindText, false, ""},
+	{"event_type", database.ColumnKindText, false, ""},
+	{"created_at", database.ColumnKindTimestamp, false, ""},
+	{"actor", database.ColumnKindText, false, ""},
+	{"reviewer", database.ColumnKindText, true, ""},
+	{"collected_at", database.ColumnKindTimestamp, false, ""},
+}
+
+var aggregatedMetricsColumns = []column{
+	{"id", database.ColumnKindText, false, ""},
+	{"aggregation_level", database.ColumnKindText, false, ""},
+	{"aggregation_period", database.ColumnKindText, false, ""},
+	{"target_id", database.ColumnKindText, false, ""},
+	{"target_name", database.ColumnKindText, false, ""},
+	{"period_start", database.ColumnKindTimestamp, false, ""},
+	{"period_end", database.ColumnKindTimestamp, false, ""},
+	{"total_prs", database.ColumnKindInteger, false, "0"},
+	{"merged_prs", database.ColumnKindInteger, false, "0"},
+	{"closed_prs", database.ColumnKindInteger, false, "0"},
+	{"avg_cycle_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"median_cycle_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"p95_cycle_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"avg_review_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"median_review_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"p95_review_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"avg_approval_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"median_approval_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"p95_approval_time_seconds", database.ColumnKindBigInt, true, ""},
+	{"avg_lines_changed", database.ColumnKindFloat, false, "0"},
+	{"median_lines_changed", database.ColumnKindFloat, false, "0"},
+	{"avg_files_changed", database.ColumnKindFloat, false, "0"},
+	{"median_files_changed", database.ColumnKindFloat, false, "0"},
+	{"avg_review_comments", database.ColumnKindFloat, false, "0"},
+	{"avg_review_rounds", database.ColumnKindFloat, false, "0"},
+	{"first_pass_rate", database.ColumnKindFloat, false, "0"},
+	{"avg_complexity_score", database.ColumnKindFloat, false, "0"},
+	{"median_complexity_score", database.ColumnKindFloat, false, "0"},
+	{"prs_per_day", database.ColumnKindFloat, false, "0"},
+	{"lines_per_day", database.ColumnKindFloat, false, "0"},
+	{"throughput", database.ColumnKindFloat, false, "0"},
+	{"cycle_time_trend", database.ColumnKindText, false, "''"},
+	{"review_time_trend", database.ColumnKindText, false, "''"},
+	{"quality_trend", database.ColumnKindText, false, "''"},
+	{"generated_at", database.ColumnKindTimestamp, false, ""},
+	{"updated_at", database.ColumnKindTimestamp, false, ""},
+	{"version", database.ColumnKindInteger, false, "1"},
+	{"detailed_stats_json", database.ColumnKindJSON, false, ""},
+	{"year_month", database.ColumnKindText, false, ""},
+	{"week_of_year", database.ColumnKindText, false, ""},
+	{"day_of_year", database.ColumnKindText, false, ""},
+}
+
+// trendDataColumns は trend_data の初期カラム（aggregation_period は 9 で追加）
+var trendDataColumns = []column{
+	{"id", database.ColumnKindText, false, ""},
+	{"metric_type", database.ColumnKindText, false, ""},
+	{"aggregation_level", database.ColumnKindText, false, ""},
+	{"target_id", database.ColumnKindText, false, ""},
+	{"period_start", database.ColumnKindTimestamp, false, ""},
+	{"period_end", database.ColumnKindTimestamp, false, ""},
+	{"slope", database.ColumnKindFloat, false, "0"},
+	{"intercept", database.ColumnKindFloat, false, "0"},
+	{"correlation_coeff", database.ColumnKindFloat, false, "0"},
+	{"trend", database.ColumnKindText, false, ""},
+	{"confidence", database.ColumnKindFloat, false, "0"},
+	{"data_points", database.ColumnKindInteger, false, "0"},
+	{"start_value", database.ColumnKindFloat, false, "0"},
+	{"end_value", database.ColumnKindFloat, false, "0"},
+	{"change_percent", database.ColumnKindFloat, false, "0"},
+	{"generated_at", database.ColumnKindTimestamp, false, ""},
+	{"time_series_data", database.ColumnKindJSON, false, ""},
+}
+
+var bottleneckDataColumns = []column{
+	{"id", database.ColumnKindText, false, ""},
+	{"type", database.ColumnKindText, false, ""},
+	{"pr_id", database.ColumnKindText, false, ""},
+	{"severity", database.ColumnKindText, false, ""},
+	{"description", database.ColumnKindText, false, "''"},
+	{"value", database.ColumnKindFloat, false, "0"},
+	{"threshold", database.ColumnKindFloat, false, "0"},
+	{"detected_at", database.ColumnKindTimestamp, false, ""},
+	{"resolved_at", database.ColumnKindTimestamp, true, ""},
+	{"status", database.ColumnKindText, false, ""},
+	{"author", database.ColumnKindText, false, ""},
+	{"repository", database.ColumnKindText, false, ""},
+	{"created_at", database.ColumnKindTimestamp, false, ""},
+	{"updated_at", database.ColumnKindTimestamp, false, ""},
+}
+
+var aggregationJobRunColumns = []column{
+	{"id", database.ColumnKindText, false, ""},
+	{"status", database.ColumnKindText, false, ""},
+	{"started_at", database.ColumnKindTimestamp, false, ""},
+	{"finished_at", database.ColumnKindTimestamp, false, ""},
+	{"collected_since", database.ColumnKindTimestamp, true, ""},
+	{"changed_prs", database.ColumnKindInteger, false, "0"},
+	{"periods", database.ColumnKindInteger, false, "0"},
+	{"records", database.ColumnKindInteger, false, "0"},
+	{"error_message", database.ColumnKindText, true, ""},
+}
+
+// createTable はテーブルを作成する。"pk_" で始まるインデックス定義を主キー制約として使う
+func createTable(dialect database.Dialect, schema analytics.PRMetricsStorageSchema, columns []column) []string {
+	definitions := make([]string, 0, len(columns)+1)
+	for _, col := range columns {
+		definitions = append(definitions, columnDefinition(dialect, col))
+	}
+	for _, index := range schema.Indexes {
+		if strings.HasPrefix(index.Name, "pk_") {
+			definitions = append(definitions, fmt.Sprintf("CONSTRAINT %s PRIMARY KEY (%s)", index.Name, strings.Join(index.Columns, ", ")))
+		}
+	}
+
+	return []string{fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", schema.TableName, strings.Join(definitions, ",\n\t"))}
+}
+
+// createIndexes はスキーマ定義のうち指定した名前のインデックスを作成する
+// スキーマ定義にない名前はマイグレーションの記述ミスなので panic する
+func createIndexes(dialect database.Dialect, schema analytics.PRMetricsStorageSchema, names ...string) []string {
+	indexes := make(map[string]analytics.IndexDefinition, len(schema.Indexes))
+	for _, index := range schema.Indexes {
+		indexes[index.Name] = index
+	}
+
+	statements := make([]string, 0, len(names))
+	for _, name := range names {
+		index, ok := indexes[name]
+		if !ok {
+			panic(fmt.Sprintf("index %s is not defined in %s schema", name, schema.TableName))
+		}
+		statements = append(statements, createIndex(dialect, schema.TableName, index))
+	}
+	return statements
+}
+
+// createIndex はインデックス定義から CREATE INDEX 文を組み立てる
+// btree 以外のインデックス方式は PostgreSQL でのみ指定する（SQLite は常に B-Tree）
+func createIndex(dialect database.Dialect, table string, index analytics.IndexDefinition) string {
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+
+	method := ""
+	if index.Type != "" && index.Type != analytics.IndexTypeBTree && dialect.Name() == "postgres" {
+		method = fmt.Sprintf(" USING %s", index.Type)
+	}
+
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s%s (%s)", unique, index.Name, table, method, strings.Join(index.Columns, ", "))
+}
+
+// addColumn は既存テーブルに列を追加する
+func addColumn(dialect database.Dialect, schema analytics.PRMetricsStorageSchema, col column) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", schema.TableName, columnDefinition(dialect, col))
+}
+
+// dropColumn は既存テーブルから列を削除する
+func dropColumn(schema analytics.PRMetricsStorageSchema, name string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", schema.TableName, name)
+}
+
+// dropIndex はインデックスを削除する
+func dropIndex(name string) string {
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s", name)
+}
+
+// dropTable はテーブルを削除する（インデックスも合わせて削除される）
+func dropTable(schema analytics.PRMetricsStorageSchema) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s", schema.TableName)
+}
+
+func columnDefinition(dialect database.Dialect, col column) string {
+	definition := fmt.Sprintf("%s %s", col.name, dialect.ColumnType(col.kind))
+	if !col.nullable {
+		definition += " NOT NULL"
+	}
+	if col.defaultValue != "" {
+		definition += " DEFAULT " + col.defaultValue
+	}
+	return definition
+}
+
+func concat(groups ...[]string) []string {
+	var result []string
+	for _, group := range groups {
+		result = append(result, group...)
+	}
+	return result
+}
@@ -0,0 +1,244 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github-stats-metrics/infrastructure/database"
+)
+
+// Migration は番号付きのスキーマ変更
+// Up / Down は方言に応じたSQL文を返し、1つのトランザクション内で実行される
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(dialect database.Dialect) []string
+	Down    func(dialect database.Dialect) []string
+}
+
+// MigrationStatus はマイグレーションの適用状況
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Migrator は schema_migrations テーブルで適用済みバージョンを管理しながらマイグレーションを実行する
+type Migrator struct {
+	db         *database.DB
+	migrations []Migration
+}
+
+// NewMigrator は組み込みのマイグレーションを実行する Migrator を作成
+func NewMigrator(db *sql.DB, dialect database.Dialect) *Migrator {
+	return NewMigratorWithMigrations(db, dialect, Migrations())
+}
+
+// NewMigratorWithMigrations は任意のマイグレーション一覧を実行する Migrator を作成
+func NewMigratorWithMigrations(db *sql.DB, dialect database.Dialect, migrations []Migration) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{
+		db:         database.New(db, dialect),
+		migrations: sorted,
+	}
+}
+
+// Up は未適用のマイグレーションを古い順にすべて適用し、適用したものを返す
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(ctx, migration, migration.Up, true); err != nil {
+			return result, err
+		}
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+// Down は適用済みのマイグレーションを新しい順に steps 件だけ取り消し、取り消したものを返す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("steps must be positive: %d", steps)
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(result) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return result, fmt.Errorf("migration %d_%s cannot be reverted", migration.Version, migration.Name)
+		}
+		if err := m.apply(ctx, migration, migration.Down, false); err != nil {
+			return result, err
+		}
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+// Status はすべてのマイグレーションの適用状況を古い順に返す
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Pending は未適用のマイグレーション数を返す
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// validate はバージョン番号の重複と Up の未定義を検出する
+func (m *Migrator) validate() error {
+	seen := make(map[int64]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("migration %s has invalid version %d", migration.Name, migration.Version)
+		}
+		if seen[migration.Version] {
+			return fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %d_%s has no up statements", migration.Version, migration.Name)
+		}
+		seen[migration.Version] = true
+	}
+	return nil
+}
+
+// ensureTable は schema_migrations テーブルを作成する
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	dialect := m.db.Dialect()
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version %s NOT NULL PRIMARY KEY,
+			name %s NOT NULL,
+			applied_at %s NOT NULL
+		)`,
+		dialect.ColumnType(database.ColumnKindBigInt),
+		dialect.ColumnType(database.ColumnKindText),
+		dialect.ColumnType(database.ColumnKindTimestamp),
+	)
+
+	if _, err := m.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// appliedVersions は適用済みバージョンと適用日時を返す
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int64]time.Time, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, database.ScanTime(&appliedAt)); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// apply はマイグレーションのSQLと schema_migrations の更新を1つのトランザクションで実行する
+func (m *Migrator) apply(ctx context.Context, migration Migration, statements func(database.Dialect) []string, up bool) error {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range statements(m.db.Dialect()) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("migration %d_%s (%s) failed: %w", migration.Version, migration.Name, direction, err)
+		}
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+			migration.Version, migration.Name, time.Now().UTC(),
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github-stats-metrics/domain/analytics"
+	"github-stats-metrics/infrastructure/database"
+)
+
+func openTestDB(t *testing.T) (*sql.DB, database.Dialect) {
+	t.Helper()
+
+	db, dialect, err := database.Open("sqlite::memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, dialect
+}
+
+// storageTables は永続化モデルとスキーマ定義の対応
+var storageTables = []struct {
+	schema analytics.PRMetricsStorageSchema
+	model  interface{}
+}{
+	{analytics.GetPRMetricsSchema(), analytics.PRMetricsStorage{}},
+	{analytics.GetPRMetricsIDSchema(), analytics.PRMetricsIDStorage{}},
+	{analytics.GetFileChangeSchema(), analytics.FileChangeStorage{}},
+	{analytics.GetReviewEventSchema(), analytics.ReviewEventStorage{}},
+	{analytics.GetAggregatedMetricsSchema(), analytics.AggregatedMetricsStorage{}},
+	{analytics.GetTrendDataSchema(), analytics.TrendDataStorage{}},
+	{analytics.GetBottleneckDataSchema(), analytics.BottleneckDataStorage{}},
+}
+
+func TestMigrator_SchemaMatchesStorageDefinitions(t *testing.T) {
+	db, dialect := openTestDB(t)
+	ctx := context.Background()
+
+	applied, err := NewMigrator(db, dialect).Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, len(Migrations()))
+
+	for _, table := range storageTables {
+		t.Run(table.schema.TableName, func(t *testing.T) {
+			columns, primaryKey := tableColumns(t, db, table.schema.TableName)
+			assert.ElementsMatch(t, modelColumns(table.model), columns, "カラムが永続化モデルの db タグと一致しない")
+
+			indexes := tableIndexes(t, db, table.schema.TableName)
+			for _, index := range table.schema.Indexes {
+				if strings.HasPrefix(index.Name, "pk_") {
+					assert.Equal(t, index.Columns, primaryKey, "主キー %s", index.Name)
+					continue
+				}
+
+				actual, ok := indexes[index.Name]
+				if !assert.True(t, ok, "インデックス %s がない", index.Name) {
+					continue
+				}
+				assert.Equal(t, index.Columns, actual.columns, "インデックス %s のカラム", index.Name)
+				assert.Equal(t, index.Unique, actual.unique, "インデックス %s の一意性", index.Name)
+				delete(indexes, index.Name)
+			}
+			assert.Empty(t, indexes, "スキーマ定義にないインデックスがある")
+		})
+	}
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	db, dialect := openTestDB(t)
+	ctx := context.Background()
+	migrator := NewMigrator(db, dialect)
+
+	t.Run("未適用の状態", func(t *testing.T) {
+		pending, err := migrator.Pending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, len(Migrations()), pending)
+	})
+
+	t.Run("適用済みのマイグレーションは再適用しない", func(t *testing.T) {
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			assert.True(t, status.Applied, "%d_%s", status.Version, status.Name)
+			assert.NotNil(t, status.AppliedAt)
+		}
+	})
+
+	t.Run("新しい順に取り消す", func(t *testing.T) {
+		reverted, err := migrator.Down(ctx, 8)
+		require.NoError(t, err)
+		require.Len(t, reverted, 8)
+		assert.Equal(t, int64(11), reverted[0].Version)
+		assert.Equal(t, int64(10), reverted[1].Version)
+		assert.Equal(t, int64(9), reverted[2].Version)
+		assert.Equal(t, int64(8), reverted[3].Version)
+		assert.Equal(t, int64(7), reverted[4].Version)
+		assert.Equal(t, int64(6), reverted[5].Version)
+		assert.Equal(t, int64(5), reverted[6].Version)
+		assert.Equal(t, int64(4), reverted[7].Version)
+
+		var idTables int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'pr_metrics_ids'`).Scan(&idTables))
+		assert.Zero(t, idTables)
+
+		trendColumns, _ := tableColumns(t, db, "trend_data")
+		assert.NotContains(t, trendColumns, "aggregation_period")
+
+		eventColumns, _ := tableColumns(t, db, "review_events")
+		assert.NotContains(t, eventColumns, "is_bot")
+
+		assert.NotContains(t, tableIndexes(t, db, "pr_metrics"), "uk_pr_metrics_pr_id_created_at")
+		columns, _ := tableColumns(t, db, "pr_metrics")
+		assert.NotContains(t, columns, "labels_json")
+		assert.NotContains(t, columns, "definition_version")
+		assert.NotContains(t, columns, "change_failure_json")
+		assert.NotContains(t, columns, "merge_commit_sha")
+
+		pending, err := migrator.Pending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 8, pending)
+	})
+
+	t.Run("すべて取り消した後に再適用できる", func(t *testing.T) {
+		reverted, err := migrator.Down(ctx, len(Migrations()))
+		require.NoError(t, err)
+		assert.Len(t, reverted, len(Migrations())-8)
+
+		var tables int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name != 'schema_migrations'`).Scan(&tables))
+		assert.Zero(t, tables)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, len(Migrations()))
+	})
+}
+
+func TestMigrator_PRMetricsIDsBackfill(t *testing.T) {
+	db, dialect := openTestDB(t)
+	ctx := context.Background()
+
+	migrations := Migrations()
+	require.Equal(t, int64(11), migrations[10].Version)
+	_, err := NewMigratorWithMigrations(db, dialect, migrations[:10]).Up(ctx)
+	require.NoError(t, err)
+
+	// パーティション化して pr_id の一意インデックスがなくなったテーブルで、作成日時の精度違いで同じPRが2行に分かれた状態
+	_, err = db.Exec(`DROP INDEX idx_pr_metrics_pr_id`)
+	require.NoError(t, err)
+	for _, row := range []struct{ id, prID, createdAt string }{
+		{"pr_1_a", "pr_1", "2024-01-15 09:00:00.5"},
+		{"pr_1_b", "pr_1", "2024-01-15 09:00:00"},
+		{"pr_2_a", "pr_2", "2024-01-16 09:00:00"},
+	} {
+		_, err := db.Exec(`INSERT INTO pr_metrics (id, pr_id, pr_number, title, author, repository, created_at, collected_at,
+			size_metrics_json, time_metrics_json, quality_metrics_json, size_category, year_month, week_of_year, day_of_year)
+			VALUES (?, ?, 1, 'title', 'author', 'repo', ?, ?, '{}', '{}', '{}', 'small', '2024-01', '2024-W03', '2024-015')`,
+			row.id, row.prID, row.createdAt, row.createdAt)
+		require.NoError(t, err)
+		_, err = db.Exec(`INSERT INTO review_events (id, pr_metrics_id, event_type, created_at, actor, collected_at)
+			VALUES (?, ?, 'APPROVED', ?, 'reviewer', ?)`, "event_"+row.id, row.id, row.createdAt, row.createdAt)
+		require.NoError(t, err)
+	}
+
+	_, err = NewMigrator(db, dialect).Up(ctx)
+	require.NoError(t, err)
+
+	var ids []string
+	rows, err := db.Query(`SELECT id FROM pr_metrics ORDER BY id`)
+	require.NoError(t, err)
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+	assert.Equal(t, []string{"pr_1_b", "pr_2_a"}, ids, "同じPRは最初の作成日時の行だけを残す")
+
+	var events, claimed int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM review_events`).Scan(&events))
+	assert.Equal(t, 2, events, "削除した行のレビューイベントも削除する")
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM pr_metrics_ids`).Scan(&claimed))
+	assert.Equal(t, 2, claimed)
+
+	_, err = db.Exec(`INSERT INTO pr_metrics_ids (pr_id, created_at) VALUES ('pr_1', '2024-02-01 00:00:00')`)
+	assert.Error(t, err, "PR IDは一意")
+}
+
+func TestMigrator_PartitionRequiresPostgres(t *testing.T) {
+	db, dialect := openTestDB(t)
+	migrator := NewMigrator(db, dialect)
+
+	_, err := migrator.Partition(context.Background())
+	assert.ErrorIs(t, err, ErrPartitionUnsupported)
+	_, err = migrator.Unpartition(context.Background())
+	assert.ErrorIs(t, err, ErrPartitionUnsupported)
+}
+
+func TestMigrator_FailedMigrationIsRolledBack(t *testing.T) {
+	db, dialect := openTestDB(t)
+	ctx := context.Background()
+
+	migrator := NewMigratorWithMigrations(db, dialect, []Migration{
+		{
+			Version: 1,
+			Name:    "broken",
+			Up: func(database.Dialect) []string {
+				return []string{"CREATE TABLE partial (id TEXT)", "NOT VALID SQL"}
+			},
+		},
+	})
+
+	_, err := migrator.Up(ctx)
+	require.Error(t, err)
+
+	var tables int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'partial'`).Scan(&tables))
+	assert.Zero(t, tables, "失敗したマイグレーションの変更が残っている")
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending)
+}
+
+func TestMigrator_DuplicateVersion(t *testing.T) {
+	db, dialect := openTestDB(t)
+	noop := func(database.Dialect) []string { return nil }
+
+	migrator := NewMigratorWithMigrations(db, dialect, []Migration{
+		{Version: 1, Name: "a", Up: noop},
+		{Version: 1, Name: "b", Up: noop},
+	})
+
+	_, err := migrator.Up(context.Background())
+	assert.Error(t, err)
+}
+
+// modelColumns は永続化モデルの db タグを列名として返す
+func modelColumns(model interface{}) []string {
+	modelType := reflect.TypeOf(model)
+	columns := make([]string, 0, modelType.NumField())
+	for i := 0; i < modelType.NumField(); i++ {
+		if tag := modelType.Field(i).Tag.Get("db"); tag != "" {
+			columns = append(columns, tag)
+		}
+	}
+	return columns
+}
+
+// tableColumns はテーブルの列名と主キーの列を返す
+func tableColumns(t *testing.T, db *sql.DB, table string) ([]string, []string) {
+	t.Helper()
+
+	rows, err := db.Query(`SELECT name, pk FROM pragma_table_info(?)`, table)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var columns []string
+	primaryKey := map[int]string{}
+	for rows.Next() {
+		var name string
+		var pk int
+		require.NoError(t, rows.Scan(&name, &pk))
+		columns = append(columns, name)
+		if pk > 0 {
+			primaryKey[pk] = name
+		}
+	}
+	require.NoError(t, rows.Err())
+
+	positions := make([]int, 0, len(primaryKey))
+	for position := range primaryKey {
+		positions = append(positions, position)
+	}
+	sort.Ints(positions)
+	pkColumns := make([]string, 0, len(positions))
+	for _, position := range positions {
+		pkColumns = append(pkColumns, primaryKey[position])
+	}
+
+	return columns, pkColumns
+}
+
+type indexInfo struct {
+	columns []string
+	unique  bool
+}
+
+// tableIndexes は明示的に作成したインデックスを返す（主キーの自動インデックスは除く）
+func tableIndexes(t *testing.T, db *sql.DB, table string) map[string]indexInfo {
+	t.Helper()
+
+	rows, err := db.Query(`SELECT name, "unique" FROM pragma_index_list(?) WHERE origin = 'c'`, table)
+	require.NoError(t, err)
+
+	indexes := map[string]indexInfo{}
+	for rows.Next() {
+		var name string
+		var unique bool
+		require.NoError(t, rows.Scan(&name, &unique))
+		indexes[name] = indexInfo{unique: unique}
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+
+	for name, info := range indexes {
+		columnRows, err := db.Query(`SELECT name FROM pragma_index_info(?) ORDER BY seqno`, name)
+		require.NoError(t, err)
+		for columnRows.Next() {
+			var column string
+			require.NoError(t, columnRows.Scan(&column))
+			info.columns = append(info.columns, column)
+		}
+		require.NoError(t, columnRows.Err())
+		columnRows.Close()
+		indexes[name] = info
+	}
+
+	return indexes
+}
@@ -0,0 +1,78 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github-stats-metrics/infrastructure/database"
+)
+
+var (
+	// ErrPartitionUnsupported はパーティション化できない方言であることを表す
+	ErrPartitionUnsupported = errors.New("pr_metrics partitioning requires PostgreSQL")
+	// ErrPendingMigrations は未適用のマイグレーションがあることを表す
+	ErrPendingMigrations = errors.New("apply pending migrations before changing pr_metrics partitioning")
+)
+
+// Partition は pr_metrics を created_at による範囲パーティションへ作り替える（PostgreSQL のみ）
+// テーブル全体を作り直すため自動では実行せず、migrate partition で明示的に実行する。
+// PR IDの一意性を pr_metrics_ids で保証するため、すべてのマイグレーションを適用してから実行する。
+// すでにパーティション化されている場合は何もせず false を返す
+func (m *Migrator) Partition(ctx context.Context) (bool, error) {
+	return m.changePartitioning(ctx, true, partitionPRMetrics)
+}
+
+// Unpartition は pr_metrics をパーティションなしのテーブルに戻す（PostgreSQL のみ）
+// マイグレーション 5 より前に戻す場合は先に実行する。パーティション化されていない場合は何もせず false を返す
+func (m *Migrator) Unpartition(ctx context.Context) (bool, error) {
+	return m.changePartitioning(ctx, false, unpartitionPRMetrics)
+}
+
+// changePartitioning は pr_metrics のパーティション化の状態が partitioned でない場合に statements を1つのトランザクションで実行する
+func (m *Migrator) changePartitioning(ctx context.Context, partitioned bool, statements func(database.Dialect) []string) (bool, error) {
+	dialect := m.db.Dialect()
+	if dialect.Name() != "postgres" {
+		return false, ErrPartitionUnsupported
+	}
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return false, err
+	}
+	if pending > 0 {
+		return false, fmt.Errorf("%w: %d pending", ErrPendingMigrations, pending)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 作り替え中の書き込みを待たせる
+	if _, err := tx.ExecContext(ctx, "LOCK TABLE pr_metrics IN ACCESS EXCLUSIVE MODE"); err != nil {
+		return false, fmt.Errorf("failed to lock pr_metrics: %w", err)
+	}
+
+	var current bool
+	if err := tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = to_regclass('pr_metrics'))`,
+	).Scan(&current); err != nil {
+		return false, fmt.Errorf("failed to check partitioning of pr_metrics: %w", err)
+	}
+	if current == partitioned {
+		return false, nil
+	}
+
+	for _, statement := range statements(dialect) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return false, fmt.Errorf("failed to change pr_metrics partitioning: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
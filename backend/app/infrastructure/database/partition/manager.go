@@ -0,0 +1,360 @@
+package partition
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-stats-metrics/domain/analytics"
+)
+
+// パーティション化したテーブル（PostgreSQL のみ、migrate partition で作成）
+const (
+	parentTable      = "pr_metrics"
+	defaultPartition = "pr_metrics_default"
+)
+
+// monthlyPartitionPattern は月次パーティション名（pr_metrics_y2024m01）
+var monthlyPartitionPattern = regexp.MustCompile(`^pr_metrics_y(\d{4})m(\d{2})$`)
+
+// sqlStatement はトランザクション内で順に実行するSQL
+type sqlStatement struct {
+	query string
+	args  []interface{}
+}
+
+// MaintenanceResult はパーティション保守の結果
+type MaintenanceResult struct {
+	Created  []string `json:"created"`
+	Detached []string `json:"detached"`
+}
+
+// Manager は pr_metrics の月次パーティションを作成・切り離しする
+// パーティションは created_at の月（UTC）単位で、範囲外のデータは既定パーティションに入る。
+// 切り離しの判定はデータ保持（collected_at）の保持期間を優先する（DetachBefore 参照）
+type Manager struct {
+	db            *sql.DB
+	strategy      analytics.PartitionStrategy
+	premakeMonths int
+	now           func() time.Time
+}
+
+// NewManager は新しいパーティション管理を作成
+// premakeMonths は当月に加えて事前に作成しておく月数
+func NewManager(db *sql.DB, strategy analytics.PartitionStrategy, premakeMonths int) *Manager {
+	return &Manager{
+		db:            db,
+		strategy:      strategy,
+		premakeMonths: premakeMonths,
+		now:           time.Now,
+	}
+}
+
+// IsPartitioned は pr_metrics がパーティション化されているかを返す
+func (m *Manager) IsPartitioned(ctx context.Context) (bool, error) {
+	var partitioned bool
+	err := m.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = to_regclass($1))`,
+		parentTable,
+	).Scan(&partitioned)
+	if err != nil {
+		return false, fmt.Errorf("failed to check partitioning of %s: %w", parentTable, err)
+	}
+	return partitioned, nil
+}
+
+// Maintain は不足している月次パーティションを作成し、retentionDays を過ぎたパーティションを切り離す
+// retentionDays が 0 以下の場合は切り離さない。パーティション化されていない場合は何もしない
+func (m *Manager) Maintain(ctx context.Context, retentionDays int) (*MaintenanceResult, error) {
+	result := &MaintenanceResult{Created: []string{}, Detached: []string{}}
+
+	partitioned, err := m.IsPartitioned(ctx)
+	if err != nil || !partitioned {
+		return result, err
+	}
+
+	if m.strategy.AutoCreatePartitions {
+		if result.Created, err = m.EnsurePartitions(ctx); err != nil {
+			return result, err
+		}
+	}
+
+	if m.strategy.PartitionMaintenanceEnabled && retentionDays > 0 {
+		cutoff := m.now().AddDate(0, 0, -retentionDays)
+		if result.Detached, err = m.DetachBefore(ctx, cutoff); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// EnsurePartitions は当月から premakeMonths 先までと、既定パーティションにデータがある月のパーティションを作成
+// 既定パーティションのデータは作成したパーティションへ移す
+func (m *Manager) EnsurePartitions(ctx context.Context) ([]string, error) {
+	partitions, err := m.ListPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(partitions))
+	for _, partition := range partitions {
+		if partition.Attached {
+			existing[partition.Name] = true
+		}
+	}
+
+	defaultMonths, err := m.defaultPartitionMonths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	created := []string{}
+	for _, month := range plannedMonths(m.now(), m.premakeMonths, defaultMonths) {
+		name := partitionName(month)
+		if existing[name] {
+			continue
+		}
+		if err := m.createPartition(ctx, month); err != nil {
+			return created, err
+		}
+		created = append(created, name)
+	}
+	return created, nil
+}
+
+// DetachBefore は範囲の終わりが cutoff 以前の月次パーティションを切り離す
+// 切り離したパーティションは独立したテーブルとして残り、検索対象から外れる。
+// パーティションは created_at、データ保持は collected_at で判定するため、保持期間を優先し、
+// cutoff 以降に収集した行が残っているパーティションは切り離さない
+func (m *Manager) DetachBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	partitions, err := m.ListPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	detached := []string{}
+	for _, partition := range partitions {
+		if !partition.Attached || partition.RangeEnd == nil || partition.RangeEnd.After(cutoff) {
+			continue
+		}
+		ok, err := m.detachPartition(ctx, partition, cutoff)
+		if err != nil {
+			return detached, err
+		}
+		if ok {
+			detached = append(detached, partition.Name)
+		}
+	}
+	return detached, nil
+}
+
+// detachPartition は月次パーティションを1つのトランザクションで切り離す
+// 関連する file_changes・review_events の行は同じ月のアーカイブテーブル（file_changes_y2024m01 など）へ移し、
+// 切り離したPRを保存し直せるよう pr_metrics_ids からも削除する。
+// cutoff 以降に収集した行がある場合は何もせず false を返す
+func (m *Manager) detachPartition(ctx context.Context, partition analytics.PartitionInfo, cutoff time.Time) (bool, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction for partition %s: %w", partition.Name, err)
+	}
+	defer tx.Rollback()
+
+	var retained bool
+	if err := tx.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE collected_at >= $1)`, partition.Name), cutoff,
+	).Scan(&retained); err != nil {
+		return false, fmt.Errorf("failed to check retention of partition %s: %w", partition.Name, err)
+	}
+	if retained {
+		return false, nil
+	}
+
+	var statements []sqlStatement
+	for _, table := range []string{"file_changes", "review_events"} {
+		archive := archiveTableName(table, partition.Name)
+		statements = append(statements,
+			sqlStatement{query: fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (LIKE %s INCLUDING DEFAULTS)", archive, table)},
+			sqlStatement{query: fmt.Sprintf(
+				"WITH moved AS (DELETE FROM %s WHERE pr_metrics_id IN (SELECT id FROM %s) RETURNING *) INSERT INTO %s SELECT * FROM moved",
+				table, partition.Name, archive,
+			)},
+		)
+	}
+	statements = append(statements,
+		sqlStatement{
+			query: `DELETE FROM pr_metrics_ids WHERE created_at >= $1 AND created_at < $2`,
+			args:  []interface{}{*partition.RangeStart, *partition.RangeEnd},
+		},
+		sqlStatement{query: fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", parentTable, partition.Name)},
+	)
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement.query, statement.args...); err != nil {
+			return false, fmt.Errorf("failed to detach partition %s: %w", partition.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit detaching partition %s: %w", partition.Name, err)
+	}
+	return true, nil
+}
+
+// ListPartitions はパーティションと切り離し済みの月次パーティションを名前順に返す
+// 行数は統計情報による推定値
+func (m *Manager) ListPartitions(ctx context.Context) ([]analytics.PartitionInfo, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT c.relname, COALESCE(pg_get_expr(c.relpartbound, c.oid), ''), c.relispartition,
+			   GREATEST(c.reltuples, 0)::BIGINT, pg_total_relation_size(c.oid)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_inherits i ON i.inhrelid = c.oid
+		WHERE n.nspname = current_schema() AND c.relkind = 'r'
+		  AND (i.inhparent = to_regclass($1) OR (NOT c.relispartition AND c.relname LIKE 'pr\_metrics\_y%'))
+		ORDER BY c.relname
+	`, parentTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", parentTable, err)
+	}
+	defer rows.Close()
+
+	partitions := []analytics.PartitionInfo{}
+	for rows.Next() {
+		var partition analytics.PartitionInfo
+		if err := rows.Scan(&partition.Name, &partition.Bound, &partition.Attached, &partition.EstimatedRows, &partition.SizeBytes); err != nil {
+			return nil, fmt.Errorf("failed to scan partition: %w", err)
+		}
+		partition.IsDefault = partition.Bound == "DEFAULT"
+		if start, ok := parsePartitionName(partition.Name); ok {
+			end := start.AddDate(0, 1, 0)
+			partition.RangeStart = &start
+			partition.RangeEnd = &end
+		}
+		partitions = append(partitions, partition)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate partitions: %w", err)
+	}
+	return partitions, nil
+}
+
+// defaultPartitionMonths は既定パーティションにデータがある月（UTC）を返す
+func (m *Manager) defaultPartitionMonths(ctx context.Context) ([]time.Time, error) {
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT DISTINCT DATE_TRUNC('month', created_at AT TIME ZONE 'UTC') FROM %s`, defaultPartition,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", defaultPartition, err)
+	}
+	defer rows.Close()
+
+	var months []time.Time
+	for rows.Next() {
+		var month time.Time
+		if err := rows.Scan(&month); err != nil {
+			return nil, fmt.Errorf("failed to scan month: %w", err)
+		}
+		months = append(months, month)
+	}
+	return months, rows.Err()
+}
+
+// createPartition は月次パーティションを作成して接続する
+// 既定パーティションに同じ月のデータがあると接続できないため、同じトランザクションで移す
+func (m *Manager) createPartition(ctx context.Context, month time.Time) error {
+	name := partitionName(month)
+	start := monthStart(month)
+	end := start.AddDate(0, 1, 0)
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for partition %s: %w", name, err)
+	}
+	defer tx.Rollback()
+
+	statements := []sqlStatement{
+		{query: fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (LIKE %s INCLUDING DEFAULTS)", name, parentTable)},
+		{
+			query: fmt.Sprintf(
+				"WITH moved AS (DELETE FROM %s WHERE created_at >= $1 AND created_at < $2 RETURNING *) INSERT INTO %s SELECT * FROM moved",
+				defaultPartition, name,
+			),
+			args: []interface{}{start, end},
+		},
+		{query: fmt.Sprintf(
+			"ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')",
+			parentTable, name, start.Format(time.RFC3339), end.Format(time.RFC3339),
+		)},
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement.query, statement.args...); err != nil {
+			return fmt.Errorf("failed to create partition %s: %w", name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit partition %s: %w", name, err)
+	}
+	return nil
+}
+
+// plannedMonths は作成すべき月を古い順に重複なく返す
+func plannedMonths(now time.Time, premakeMonths int, defaultMonths []time.Time) []time.Time {
+	seen := make(map[time.Time]bool)
+	var months []time.Time
+	add := func(month time.Time) {
+		month = monthStart(month)
+		if !seen[month] {
+			seen[month] = true
+			months = append(months, month)
+		}
+	}
+
+	for _, month := range defaultMonths {
+		add(month)
+	}
+	current := monthStart(now)
+	for i := 0; i <= premakeMonths; i++ {
+		add(current.AddDate(0, i, 0))
+	}
+
+	sort.Slice(months, func(i, j int) bool {
+		return months[i].Before(months[j])
+	})
+	return months
+}
+
+// partitionName は月次パーティションのテーブル名を返す
+func partitionName(month time.Time) string {
+	month = monthStart(month)
+	return fmt.Sprintf("%s_y%04dm%02d", parentTable, month.Year(), int(month.Month()))
+}
+
+// archiveTableName は切り離した月次パーティションの関連データを移すテーブル名を返す（file_changes_y2024m01 など）
+func archiveTableName(table, partition string) string {
+	return table + strings.TrimPrefix(partition, parentTable)
+}
+
+// parsePartitionName は月次パーティション名から範囲の開始を返す
+func parsePartitionName(name string) (time.Time, bool) {
+	matches := monthlyPartitionPattern.FindStringSubmatch(name)
+	if matches == nil {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(matches[1])
+	month, _ := strconv.Atoi(matches[2])
+	if month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// monthStart は時刻が属する月の初日（UTC）を返す
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
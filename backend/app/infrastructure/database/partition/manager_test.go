@@ -0,0 +1,177 @@
+package partition
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github-stats-metrics/domain/analytics"
+)
+
+func TestPlannedMonths(t *testing.T) {
+	now := time.Date(2024, 11, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		premakeMonths int
+		defaultMonths []time.Time
+		expected      []string
+	}{
+		{
+			name:          "当月と先の月を作成する",
+			premakeMonths: 2,
+			expected:      []string{"pr_metrics_y2024m11", "pr_metrics_y2024m12", "pr_metrics_y2025m01"},
+		},
+		{
+			name:          "既定パーティションにデータがある月も古い順に含める",
+			premakeMonths: 0,
+			defaultMonths: []time.Time{
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expected: []string{"pr_metrics_y2023m12", "pr_metrics_y2024m03", "pr_metrics_y2024m11"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, month := range plannedMonths(now, tt.premakeMonths, tt.defaultMonths) {
+				names = append(names, partitionName(month))
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestParsePartitionName(t *testing.T) {
+	start, ok := parsePartitionName("pr_metrics_y2024m02")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), start)
+
+	for _, name := range []string{"pr_metrics_default", "pr_metrics_y2024m13", "pr_metrics", "other_y2024m01"} {
+		_, ok := parsePartitionName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestManager_Maintain(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	manager := NewManager(db, analytics.GetRecommendedPartitionStrategy(), 1)
+	manager.now = func() time.Time { return time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC) }
+
+	partitionColumns := []string{"relname", "bound", "relispartition", "reltuples", "size"}
+	listQuery := regexp.QuoteMeta("SELECT c.relname")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM pg_partitioned_table")).
+		WithArgs("pr_metrics").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	// 作成: 11月は作成済み、12月を作成
+	mock.ExpectQuery(listQuery).
+		WillReturnRows(sqlmock.NewRows(partitionColumns).
+			AddRow("pr_metrics_default", "DEFAULT", true, 10, 8192).
+			AddRow("pr_metrics_y2024m01", "FOR VALUES FROM ('2024-01-01 00:00:00+00') TO ('2024-02-01 00:00:00+00')", true, 5, 16384).
+			AddRow("pr_metrics_y2024m11", "FOR VALUES FROM ('2024-11-01 00:00:00+00') TO ('2024-12-01 00:00:00+00')", true, 0, 8192))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT DATE_TRUNC('month', created_at AT TIME ZONE 'UTC') FROM pr_metrics_default")).
+		WillReturnRows(sqlmock.NewRows([]string{"month"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS pr_metrics_y2024m12 (LIKE pr_metrics INCLUDING DEFAULTS)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("WITH moved AS (DELETE FROM pr_metrics_default WHERE created_at >= $1 AND created_at < $2 RETURNING *) INSERT INTO pr_metrics_y2024m12")).
+		WithArgs(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE pr_metrics ATTACH PARTITION pr_metrics_y2024m12 FOR VALUES FROM ('2024-12-01T00:00:00Z') TO ('2025-01-01T00:00:00Z')")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// 切り離し: 90日前より前に終わる1月・2月のうち、保持期間内に収集した行がない1月のみ
+	cutoff := time.Date(2024, 8, 17, 0, 0, 0, 0, time.UTC)
+	retentionQuery := func(partition string) string {
+		return regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM " + partition + " WHERE collected_at >= $1)")
+	}
+	mock.ExpectQuery(listQuery).
+		WillReturnRows(sqlmock.NewRows(partitionColumns).
+			AddRow("pr_metrics_default", "DEFAULT", true, 10, 8192).
+			AddRow("pr_metrics_y2024m01", "FOR VALUES FROM ('2024-01-01 00:00:00+00') TO ('2024-02-01 00:00:00+00')", true, 5, 16384).
+			AddRow("pr_metrics_y2024m02", "FOR VALUES FROM ('2024-02-01 00:00:00+00') TO ('2024-03-01 00:00:00+00')", true, 2, 16384).
+			AddRow("pr_metrics_y2024m11", "FOR VALUES FROM ('2024-11-01 00:00:00+00') TO ('2024-12-01 00:00:00+00')", true, 0, 8192).
+			AddRow("pr_metrics_y2024m12", "FOR VALUES FROM ('2024-12-01 00:00:00+00') TO ('2025-01-01 00:00:00+00')", true, 0, 8192))
+	mock.ExpectBegin()
+	mock.ExpectQuery(retentionQuery("pr_metrics_y2024m01")).
+		WithArgs(cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	for _, table := range []string{"file_changes", "review_events"} {
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS " + table + "_y2024m01 (LIKE " + table + " INCLUDING DEFAULTS)")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("WITH moved AS (DELETE FROM " + table + " WHERE pr_metrics_id IN (SELECT id FROM pr_metrics_y2024m01) RETURNING *) INSERT INTO " + table + "_y2024m01")).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM pr_metrics_ids WHERE created_at >= $1 AND created_at < $2")).
+		WithArgs(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE pr_metrics DETACH PARTITION pr_metrics_y2024m01")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	// 2月は作成日時が古くても最近収集し直した行があるため切り離さない
+	mock.ExpectBegin()
+	mock.ExpectQuery(retentionQuery("pr_metrics_y2024m02")).
+		WithArgs(cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	result, err := manager.Maintain(context.Background(), 90)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr_metrics_y2024m12"}, result.Created)
+	assert.Equal(t, []string{"pr_metrics_y2024m01"}, result.Detached)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestManager_DetachBefore_RollsBackOnFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	cutoff := time.Date(2024, 8, 17, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.relname")).
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "bound", "relispartition", "reltuples", "size"}).
+			AddRow("pr_metrics_y2024m01", "FOR VALUES FROM ('2024-01-01 00:00:00+00') TO ('2024-02-01 00:00:00+00')", true, 5, 16384))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM pr_metrics_y2024m01 WHERE collected_at >= $1)")).
+		WithArgs(cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS file_changes_y2024m01")).
+		WillReturnError(errors.New("permission denied"))
+	mock.ExpectRollback()
+
+	detached, err := NewManager(db, analytics.GetRecommendedPartitionStrategy(), 1).DetachBefore(context.Background(), cutoff)
+	require.Error(t, err)
+	assert.Empty(t, detached, "切り離しと関連データの移動はまとめて取り消す")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestManager_Maintain_NotPartitioned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM pg_partitioned_table")).
+		WithArgs("pr_metrics").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	result, err := NewManager(db, analytics.GetRecommendedPartitionStrategy(), 3).Maintain(context.Background(), 90)
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Empty(t, result.Detached)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
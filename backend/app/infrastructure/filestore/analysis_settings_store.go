@@ -0,0 +1,47 @@
+package filestore
+
+import (
+	"sync"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// AnalysisSettingsFileStore はPR分析の設定をJSONファイルで永続化する
+type AnalysisSettingsFileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAnalysisSettingsFileStore は新しい分析設定ファイルストアを作成
+func NewAnalysisSettingsFileStore(path string) *AnalysisSettingsFileStore {
+	return &AnalysisSettingsFileStore{path: path}
+}
+
+// Load はファイルから分析設定を読み込み（ファイルが存在しない場合は空）
+func (s *AnalysisSettingsFileStore) Load() (prDomain.AnalysisSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var settings prDomain.AnalysisSettings
+	if _, err := readJSONFile(s.path, &settings); err != nil {
+		return prDomain.AnalysisSettings{}, err
+	}
+	return settings, nil
+}
+
+// Save は分析設定をファイルへ書き込み
+func (s *AnalysisSettingsFileStore) Save(settings prDomain.AnalysisSettings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return writeJSONFile(s.path, settings)
+}
+
+// LoadRegistry はファイルの設定を計算定義に重ねたレジストリを構築
+func (s *AnalysisSettingsFileStore) LoadRegistry(base prDomain.MetricDefinition) (*prDomain.AnalysisSettingsRegistry, error) {
+	settings, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return prDomain.NewAnalysisSettingsRegistry(base, settings)
+}
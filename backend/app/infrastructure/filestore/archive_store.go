@@ -0,0 +1,186 @@
+package filestore
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github-stats-metrics/application/retention"
+)
+
+// アーカイブファイルの拡張子
+const (
+	archiveExtension           = ".jsonl"
+	compressedArchiveExtension = ".jsonl.gz"
+)
+
+// ArchiveFileStore は削除前のデータをJSON Lines形式のファイルとして保存する
+// 1ファイルが1回のアーカイブに対応し、既存のファイルは上書きしない
+type ArchiveFileStore struct {
+	dir      string
+	compress bool
+}
+
+var _ retention.Archive = (*ArchiveFileStore)(nil)
+
+// NewArchiveFileStore は新しいアーカイブファイルストアを作成
+// compress が true の場合は gzip で圧縮して保存する
+func NewArchiveFileStore(dir string, compress bool) *ArchiveFileStore {
+	return &ArchiveFileStore{dir: dir, compress: compress}
+}
+
+// Write はレコードを1行1件で書き出し、拡張子付きのアーカイブ名を返す
+func (s *ArchiveFileStore) Write(ctx context.Context, name string, records []retention.ArchiveRecord) (string, error) {
+	fileName := name + archiveExtension
+	if s.compress {
+		fileName = name + compressedArchiveExtension
+	}
+	if err := validateArchiveName(fileName); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory %s: %w", s.dir, err)
+	}
+	path := filepath.Join(s.dir, fileName)
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("archive %s already exists", fileName)
+	}
+
+	// 書き込み途中のファイルが一覧に出ないよう一時ファイル経由で置き換える
+	tmpPath := path + ".tmp"
+	if err := writeArchiveFile(tmpPath, records, s.compress); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write archive %s: %w", fileName, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to replace archive %s: %w", fileName, err)
+	}
+	return fileName, nil
+}
+
+// Read はアーカイブのレコードを読み込む（拡張子で圧縮の有無を判定）
+func (s *ArchiveFileStore) Read(ctx context.Context, name string) ([]retention.ArchiveRecord, error) {
+	if err := validateArchiveName(name); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", retention.ErrArchiveNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to open archive %s: %w", name, err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(name, compressedArchiveExtension) {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", name, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	var records []retention.ArchiveRecord
+	decoder := json.NewDecoder(reader)
+	for {
+		var record retention.ArchiveRecord
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse archive %s: %w", name, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// List は保存済みアーカイブを名前順に返す（ディレクトリがない場合は空）
+func (s *ArchiveFileStore) List(ctx context.Context) ([]retention.ArchiveInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []retention.ArchiveInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to list archives in %s: %w", s.dir, err)
+	}
+
+	archives := []retention.ArchiveInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || validateArchiveName(entry.Name()) != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat archive %s: %w", entry.Name(), err)
+		}
+		archives = append(archives, retention.ArchiveInfo{
+			Name:      entry.Name(),
+			Size:      info.Size(),
+			CreatedAt: info.ModTime(),
+		})
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].Name < archives[j].Name
+	})
+	return archives, nil
+}
+
+// writeArchiveFile はレコードをJSON Lines形式で書き込む
+func writeArchiveFile(path string, records []retention.ArchiveRecord, compress bool) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	var writer io.Writer = buffered
+	var gzipWriter *gzip.Writer
+	if compress {
+		gzipWriter = gzip.NewWriter(buffered)
+		writer = gzipWriter
+	}
+
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			return err
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// validateArchiveName はアーカイブ名がディレクトリ外を指さないアーカイブファイル名かを検証
+func validateArchiveName(name string) error {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("%w: %q", retention.ErrInvalidArchiveName, name)
+	}
+	if !strings.HasSuffix(name, archiveExtension) && !strings.HasSuffix(name, compressedArchiveExtension) {
+		return fmt.Errorf("%w: %q", retention.ErrInvalidArchiveName, name)
+	}
+	return nil
+}
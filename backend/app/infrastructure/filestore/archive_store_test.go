@@ -0,0 +1,88 @@
+package filestore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/application/retention"
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+func TestArchiveFileStore(t *testing.T) {
+	ctx := context.Background()
+	records := []retention.ArchiveRecord{
+		{
+			Kind:      retention.RecordKindPRMetrics,
+			PRMetrics: &prDomain.PRMetrics{PRID: "pr-1", Author: "alice", CreatedAt: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			Kind: retention.RecordKindAggregatedMetrics,
+			Aggregated: &analyticsApp.AggregatedRecord{
+				Level:     analyticsApp.AggregationLevelDeveloper,
+				Developer: &analyticsApp.DeveloperMetrics{Developer: "alice", Period: analyticsApp.AggregationPeriodMonthly, TotalPRs: 3},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		compress bool
+		wantName string
+	}{
+		{name: "gzip圧縮", compress: true, wantName: "pr_metrics-20240601T000000Z.jsonl.gz"},
+		{name: "非圧縮", compress: false, wantName: "pr_metrics-20240601T000000Z.jsonl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewArchiveFileStore(filepath.Join(t.TempDir(), "archive"), tt.compress)
+
+			name, err := store.Write(ctx, "pr_metrics-20240601T000000Z", records)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+
+			loaded, err := store.Read(ctx, name)
+			require.NoError(t, err)
+			require.Len(t, loaded, 2)
+			assert.Equal(t, "pr-1", loaded[0].PRMetrics.PRID)
+			assert.True(t, loaded[0].PRMetrics.CreatedAt.Equal(records[0].PRMetrics.CreatedAt))
+			require.NotNil(t, loaded[1].Aggregated.Developer)
+			assert.Equal(t, 3, loaded[1].Aggregated.Developer.TotalPRs)
+
+			archives, err := store.List(ctx)
+			require.NoError(t, err)
+			require.Len(t, archives, 1)
+			assert.Equal(t, tt.wantName, archives[0].Name)
+			assert.Positive(t, archives[0].Size)
+
+			_, err = store.Write(ctx, "pr_metrics-20240601T000000Z", records)
+			assert.Error(t, err, "既存のアーカイブは上書きしない")
+		})
+	}
+}
+
+func TestArchiveFileStore_InvalidName(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewArchiveFileStore(filepath.Join(dir, "archive"), true)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.jsonl"), []byte("{}\n"), 0o644))
+
+	for _, name := range []string{"../secret.jsonl", "", ".hidden.jsonl", "notes.txt", "sub/archive.jsonl"} {
+		_, err := store.Read(ctx, name)
+		assert.ErrorIs(t, err, retention.ErrInvalidArchiveName, name)
+	}
+
+	_, err := store.Read(ctx, "missing.jsonl.gz")
+	assert.ErrorIs(t, err, retention.ErrArchiveNotFound)
+
+	archives, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, archives)
+}
@@ -0,0 +1,23 @@
+package filestore
+
+import (
+	"fmt"
+	"os"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// LoadCodeOwners はローカルのCODEOWNERSファイルを読み込み
+func LoadCodeOwners(path string) (*prDomain.CodeOwners, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	codeOwners, err := prDomain.ParseCodeOwners(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return codeOwners, nil
+}
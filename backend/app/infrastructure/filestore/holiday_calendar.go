@@ -0,0 +1,149 @@
+package filestore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// maxHolidayEventDays は1つの予定から休日として取り込む最大日数
+const maxHolidayEventDays = 366
+
+// LoadHolidayCalendar はiCalendar（.ics）ファイルから休日カレンダーを読み込み
+func LoadHolidayCalendar(name, path string) (*prDomain.HolidayCalendar, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	calendar, err := ParseICalendar(name, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return calendar, nil
+}
+
+// ParseICalendar はiCalendar形式の予定（VEVENT）を休日として読み込み
+// DTSTART の日付から DTEND の前日までを休日とし、時刻付きの予定も日付単位で扱う
+func ParseICalendar(name string, r io.Reader) (*prDomain.HolidayCalendar, error) {
+	lines, err := unfoldICalendarLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		holidays    []prDomain.Holiday
+		inCalendar  bool
+		inEvent     bool
+		start, end  string
+		summary     string
+		eventNumber int
+	)
+	for _, line := range lines {
+		property, value := splitICalendarProperty(line)
+		switch {
+		case property == "BEGIN" && value == "VCALENDAR":
+			inCalendar = true
+		case property == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			start, end, summary = "", "", ""
+			eventNumber++
+		case property == "END" && value == "VEVENT":
+			inEvent = false
+			days, err := icalendarEventDays(start, end)
+			if err != nil {
+				return nil, fmt.Errorf("event %d: %w", eventNumber, err)
+			}
+			for _, day := range days {
+				holidays = append(holidays, prDomain.Holiday{Date: day, Name: summary})
+			}
+		case !inEvent:
+			continue
+		case property == "DTSTART":
+			start = value
+		case property == "DTEND":
+			end = value
+		case property == "SUMMARY":
+			summary = unescapeICalendarText(value)
+		}
+	}
+
+	if !inCalendar {
+		return nil, fmt.Errorf("BEGIN:VCALENDAR not found")
+	}
+	return prDomain.NewHolidayCalendar(name, holidays), nil
+}
+
+// unfoldICalendarLines は空白で始まる継続行を前の行につなげて返す
+func unfoldICalendarLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// splitICalendarProperty はプロパティ名（パラメータを除く）と値に分ける
+func splitICalendarProperty(line string) (string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return strings.ToUpper(line), ""
+	}
+	name := line[:colon]
+	if semicolon := strings.Index(name, ";"); semicolon >= 0 {
+		name = name[:semicolon]
+	}
+	return strings.ToUpper(name), line[colon+1:]
+}
+
+// icalendarEventDays は予定の開始日から終了日の前日までの日付を返す（終了日がない場合は開始日のみ）
+func icalendarEventDays(start, end string) ([]time.Time, error) {
+	startDate, err := parseICalendarDate(start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART %q: %w", start, err)
+	}
+	if end == "" {
+		return []time.Time{startDate}, nil
+	}
+	endDate, err := parseICalendarDate(end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTEND %q: %w", end, err)
+	}
+
+	days := []time.Time{startDate}
+	for day := startDate.AddDate(0, 0, 1); day.Before(endDate) && len(days) < maxHolidayEventDays; day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseICalendarDate は DATE（20240101）または DATE-TIME（20240101T000000Z）の日付部分を読み込み
+func parseICalendarDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("date must start with YYYYMMDD")
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+// unescapeICalendarText はテキスト値のエスケープを戻す
+func unescapeICalendarText(value string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ")
+	return replacer.Replace(value)
+}
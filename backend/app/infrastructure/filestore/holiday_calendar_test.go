@@ -0,0 +1,52 @@
+package filestore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseICalendar(t *testing.T) {
+	t.Run("終日の予定と複数日の予定を休日として読み込む", func(t *testing.T) {
+		ics := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20251229",
+			"DTEND;VALUE=DATE:20260101",
+			"SUMMARY:年末休暇",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART:20250815T000000Z",
+			"SUMMARY:創立記念日\\, 全社",
+			" 休業",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		calendar, err := ParseICalendar("company", strings.NewReader(ics))
+		require.NoError(t, err)
+		assert.Equal(t, "company", calendar.Name())
+
+		holidays := calendar.Holidays()
+		require.Len(t, holidays, 4)
+		assert.Equal(t, time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC), holidays[0].Date)
+		assert.Equal(t, "創立記念日, 全社休業", holidays[0].Name)
+		assert.True(t, calendar.IsHoliday(time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC)))
+		assert.False(t, calendar.IsHoliday(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)), "DTEND の日は含まない")
+	})
+
+	t.Run("日付が不正な場合はエラー", func(t *testing.T) {
+		ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2025\nEND:VEVENT\nEND:VCALENDAR\n"
+		_, err := ParseICalendar("company", strings.NewReader(ics))
+		assert.Error(t, err)
+	})
+
+	t.Run("iCalendar形式でない場合はエラー", func(t *testing.T) {
+		_, err := ParseICalendar("company", strings.NewReader(`{"holidays": []}`))
+		assert.Error(t, err)
+	})
+}
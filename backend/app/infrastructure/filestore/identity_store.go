@@ -0,0 +1,55 @@
+package filestore
+
+import (
+	"sync"
+
+	developerDomain "github-stats-metrics/domain/developer"
+)
+
+// identityFile はIDエイリアス定義ファイルの形式
+type identityFile struct {
+	Developers []developerDomain.Developer `json:"developers"`
+}
+
+// IdentityFileStore はIDエイリアス定義をJSONファイルで永続化する
+type IdentityFileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewIdentityFileStore は新しいIDエイリアスファイルストアを作成
+func NewIdentityFileStore(path string) *IdentityFileStore {
+	return &IdentityFileStore{path: path}
+}
+
+// Load はファイルから開発者定義を読み込み（ファイルが存在しない場合は空）
+func (s *IdentityFileStore) Load() ([]developerDomain.Developer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var file identityFile
+	if _, err := readJSONFile(s.path, &file); err != nil {
+		return nil, err
+	}
+	if file.Developers == nil {
+		return []developerDomain.Developer{}, nil
+	}
+	return file.Developers, nil
+}
+
+// Save は開発者定義をファイルへ書き込み
+func (s *IdentityFileStore) Save(developers []developerDomain.Developer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return writeJSONFile(s.path, identityFile{Developers: developers})
+}
+
+// LoadIdentityRegistry はファイルからIDレジストリを構築
+func (s *IdentityFileStore) LoadIdentityRegistry() (*developerDomain.IdentityRegistry, error) {
+	developers, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return developerDomain.NewIdentityRegistry(developers)
+}
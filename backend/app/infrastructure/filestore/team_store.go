@@ -0,0 +1,55 @@
+package filestore
+
+import (
+	"sync"
+
+	teamDomain "github-stats-metrics/domain/team"
+)
+
+// teamRosterFile はチーム定義ファイルの形式
+type teamRosterFile struct {
+	Teams []teamDomain.Team `json:"teams"`
+}
+
+// TeamFileStore はチーム定義をJSONファイルで永続化する
+type TeamFileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewTeamFileStore は新しいチーム定義ファイルストアを作成
+func NewTeamFileStore(path string) *TeamFileStore {
+	return &TeamFileStore{path: path}
+}
+
+// Load はファイルからチーム定義を読み込み（ファイルが存在しない場合は空）
+func (s *TeamFileStore) Load() ([]teamDomain.Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var file teamRosterFile
+	if _, err := readJSONFile(s.path, &file); err != nil {
+		return nil, err
+	}
+	if file.Teams == nil {
+		return []teamDomain.Team{}, nil
+	}
+	return file.Teams, nil
+}
+
+// Save はチーム定義をファイルへ書き込み
+func (s *TeamFileStore) Save(teams []teamDomain.Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return writeJSONFile(s.path, teamRosterFile{Teams: teams})
+}
+
+// LoadRoster はファイルからチームロスターを構築
+func (s *TeamFileStore) LoadRoster() (*teamDomain.Roster, error) {
+	teams, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return teamDomain.NewRoster(teams)
+}
@@ -0,0 +1,67 @@
+package github_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/shared/config"
+	"github-stats-metrics/shared/logger"
+)
+
+// codeOwnersSource はGitHubのデフォルトブランチからCODEOWNERSを取得する
+type codeOwnersSource struct {
+	repository *repository
+}
+
+// NewCodeOwnersSource はGitHubリポジトリのCODEOWNERSの取得元を作成
+func NewCodeOwnersSource(cfg *config.Config) prDomain.CodeOwnersSource {
+	client, err := createClient(cfg)
+	levelLogger := logger.NewLevelLogger()
+	if err != nil {
+		levelLogger.Error("Failed to create GitHub client for CODEOWNERS", "error", err)
+	}
+	return &codeOwnersSource{
+		repository: &repository{client: client, config: cfg, logger: levelLogger},
+	}
+}
+
+// FetchCodeOwners は "owner/name" 形式のリポジトリのCODEOWNERSを取得（ファイルがない場合は nil）
+func (s *codeOwnersSource) FetchCodeOwners(ctx context.Context, repositoryName string) (*prDomain.CodeOwners, error) {
+	if s.repository.client == nil {
+		return nil, errors.New("GitHub client is not initialized")
+	}
+	owner, name, ok := strings.Cut(repositoryName, "/")
+	if !ok || owner == "" || name == "" {
+		return nil, fmt.Errorf("repository must be in owner/name format: %s", repositoryName)
+	}
+
+	query := CodeOwnersQuery{}
+	variables := map[string]interface{}{
+		"owner":           githubv4.String(owner),
+		"name":            githubv4.String(name),
+		"githubDirectory": githubv4.String("HEAD:" + prDomain.CodeOwnersLocations[0]),
+		"root":            githubv4.String("HEAD:" + prDomain.CodeOwnersLocations[1]),
+		"docsDirectory":   githubv4.String("HEAD:" + prDomain.CodeOwnersLocations[2]),
+	}
+	if err := s.repository.client.Query(ctx, &query, variables); err != nil {
+		return nil, s.repository.handleGitHubAPIError(err)
+	}
+
+	// GitHubと同じく .github/、ルート、docs/ の順に最初に見つかったファイルを使う
+	for _, blob := range []codeOwnersBlob{query.Repository.GitHubDirectory, query.Repository.Root, query.Repository.DocsDirectory} {
+		if blob == nil {
+			continue
+		}
+		codeOwners, err := prDomain.ParseCodeOwners(strings.NewReader(string(blob.Blob.Text)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CODEOWNERS of %s: %w", repositoryName, err)
+		}
+		return codeOwners, nil
+	}
+	return nil, nil
+}
@@ -15,6 +15,7 @@ type githubv4PullRequest struct {
 	BaseRefName githubv4.String
 	HeadRefName githubv4.String
 	Author      struct {
+		Typename  githubv4.String `graphql:"__typename"`
 		Login     githubv4.String
 		AvatarURL githubv4.URI `graphql:"avatarUrl(size:72)"`
 	}
@@ -36,6 +37,11 @@ type githubv4PullRequest struct {
 		}
 	} `graphql:"LastApprovedAt: reviews(last: 1, states: APPROVED)"`
 	MergedAt githubv4.DateTime
+	Labels   struct {
+		Nodes []struct {
+			Name githubv4.String
+		}
+	} `graphql:"labels(first: 20)"`
 }
 
 // convertToDomain はGitHub APIレスポンスをDomainモデルに変換
@@ -49,6 +55,7 @@ func convertToDomain(apiPR githubv4PullRequest) domain.PullRequest {
 		Author: domain.Author{
 			Login:     string(apiPR.Author.Login),
 			AvatarURL: apiPR.Author.AvatarURL.String(),
+			IsBot:     string(apiPR.Author.Typename) == domain.BotAuthorType,
 		},
 		Repository: domain.RepositoryInfo{
 			Name: string(apiPR.Repository.Name),
@@ -57,6 +64,11 @@ func convertToDomain(apiPR githubv4PullRequest) domain.PullRequest {
 		Additions: int(apiPR.Additions),
 		Deletions: int(apiPR.Deletions),
 		CreatedAt: apiPR.CreatedAt.Time,
+		Labels:    make([]string, 0, len(apiPR.Labels.Nodes)),
+	}
+	
+	for _, label := range apiPR.Labels.Nodes {
+		pr.Labels = append(pr.Labels, string(label.Name))
 	}
 	
 	// オプション値の適切な変換
@@ -19,27 +19,46 @@ import (
 
 // repository はprDomain.Repositoryインターフェースの実装
 type repository struct {
-	client *githubv4.Client
-	config *config.Config
-	logger *logger.LevelLogger
+	client         *githubv4.Client
+	config         *config.Config
+	logger         *logger.LevelLogger
+	botDetector    *prDomain.BotDetector
+	changeDetector *prDomain.ChangeFailureDetector
+	analysis       *prDomain.PRAnalysisService
 }
 
 // NewRepository はGitHub APIを使用するRepository実装を作成
+// メトリクスは環境変数の計算定義で計算する
 func NewRepository(cfg *config.Config) prDomain.Repository {
+	return newRepository(cfg, prDomain.NewPRAnalysisServiceWithDefinition(cfg.Metrics.Definition))
+}
+
+// NewRepositoryWithDefinitions はリポジトリごとの計算定義の取得元を指定してRepository実装を作成
+// 収集したPRのメトリクスは、再計算と同じくリポジトリに適用する定義で計算し、定義バージョンを記録する
+func NewRepositoryWithDefinitions(cfg *config.Config, definitions prDomain.DefinitionSource) prDomain.Repository {
+	return newRepository(cfg, prDomain.NewPRAnalysisServiceWithSource(definitions))
+}
+
+func newRepository(cfg *config.Config, analysis *prDomain.PRAnalysisService) prDomain.Repository {
 	client, err := createClient(cfg)
 	levelLogger := logger.NewLevelLogger()
+	botDetector := prDomain.NewBotDetector(cfg.GitHub.BotAccounts)
+	changeDetector := prDomain.NewChangeFailureDetector(cfg.GitHub.ReleaseBranches)
 	
 	if err != nil {
 		levelLogger.Error("Failed to create GitHub client", "error", err)
 		// エラーを含むリポジトリを返す（実行時にエラーを返す）
-		return &repository{client: nil, config: cfg, logger: levelLogger}
+		return &repository{client: nil, config: cfg, logger: levelLogger, botDetector: botDetector, changeDetector: changeDetector, analysis: analysis}
 	}
 	
 	levelLogger.Info("GitHub API client initialized successfully")
 	return &repository{
-		client: client,
-		config: cfg,
-		logger: levelLogger,
+		client:         client,
+		config:         cfg,
+		logger:         levelLogger,
+		botDetector:    botDetector,
+		changeDetector: changeDetector,
+		analysis:       analysis,
 	}
 }
 
@@ -125,6 +144,7 @@ func (r *repository) fetchPullRequests(ctx context.Context, queryParametes prDom
 		// 検索結果をDomainモデルに変換
 		for _, node := range query.Search.Nodes {
 			domainPR := convertToDomain(node.Pr)
+			r.botDetector.MarkPullRequest(&domainPR, string(node.Pr.Author.Typename))
 			array = append(array, domainPR)
 		}
 
@@ -343,10 +363,11 @@ func (r *repository) GetPullRequestByID(ctx context.Context, id string) (*prDoma
 	}
 	
 	domainPR := convertExtendedToDomain(query.Node.PullRequest)
+	r.botDetector.MarkPullRequest(&domainPR, string(query.Node.PullRequest.Author.Typename))
 	return &domainPR, nil
 }
 
-// GetPullRequestWithMetrics は詳細メトリクス付きでPRを取得
+// GetPullRequestWithMetrics は詳細メトリクスとレビューイベント付きでPRを取得
 func (r *repository) GetPullRequestWithMetrics(ctx context.Context, id string) (*prDomain.PRMetrics, error) {
 	if r.client == nil {
 		return nil, errors.New("GitHub client is not initialized")
@@ -361,7 +382,15 @@ func (r *repository) GetPullRequestWithMetrics(ctx context.Context, id string) (
 		return nil, r.handleGitHubAPIError(err)
 	}
 	
-	prMetrics := convertToPRMetrics(query.Node.PullRequest)
+	// 時間メトリクスの計算と、保存時にタイムラインとして永続化するため、レビューイベントも取得する
+	reviewEvents, err := r.GetReviewTimeline(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	prMetrics := convertToPRMetrics(query.Node.PullRequest, reviewEvents, r.analysis, r.botDetector, r.changeDetector)
+	prMetrics.ReviewEvents = reviewEvents
+
 	return prMetrics, nil
 }
 
@@ -441,12 +470,23 @@ func (r *repository) GetReviewTimeline(ctx context.Context, prId string) ([]prDo
 				allEvents = append(allEvents, event)
 			}
 			
+			if !item.ReviewRequestRemovedEvent.CreatedAt.Time.IsZero() {
+				event := prDomain.ReviewEvent{
+					Type:      prDomain.ReviewEventTypeRequestRemoved,
+					CreatedAt: item.ReviewRequestRemovedEvent.CreatedAt.Time,
+					Actor:     string(item.ReviewRequestRemovedEvent.Actor.Login),
+					Reviewer:  string(item.ReviewRequestRemovedEvent.RequestedReviewer.User.Login),
+				}
+				allEvents = append(allEvents, event)
+			}
+			
 			if !item.PullRequestReview.CreatedAt.Time.IsZero() {
 				event := prDomain.ReviewEvent{
 					Type:      convertReviewState(item.PullRequestReview.State),
 					CreatedAt: item.PullRequestReview.CreatedAt.Time,
 					Actor:     string(item.PullRequestReview.Author.Login),
 					Reviewer:  string(item.PullRequestReview.Author.Login),
+					IsBot:     string(item.PullRequestReview.Author.Typename) == prDomain.BotAuthorType,
 				}
 				allEvents = append(allEvents, event)
 			}
@@ -460,6 +500,15 @@ func (r *repository) GetReviewTimeline(ctx context.Context, prId string) ([]prDo
 				allEvents = append(allEvents, event)
 			}
 			
+			if !item.ConvertToDraftEvent.CreatedAt.Time.IsZero() {
+				event := prDomain.ReviewEvent{
+					Type:      prDomain.ReviewEventTypeConvertedToDraft,
+					CreatedAt: item.ConvertToDraftEvent.CreatedAt.Time,
+					Actor:     string(item.ConvertToDraftEvent.Actor.Login),
+				}
+				allEvents = append(allEvents, event)
+			}
+			
 			if !item.MergedEvent.CreatedAt.Time.IsZero() {
 				event := prDomain.ReviewEvent{
 					Type:      prDomain.ReviewEventTypeMerged,
@@ -477,6 +526,7 @@ func (r *repository) GetReviewTimeline(ctx context.Context, prId string) ([]prDo
 		cursor = githubv4.NewString(query.Node.PullRequest.TimelineItems.PageInfo.EndCursor)
 	}
 	
+	r.botDetector.MarkReviewEvents(allEvents)
 	return allEvents, nil
 }
 
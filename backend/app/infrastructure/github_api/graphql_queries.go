@@ -36,8 +36,14 @@ type ExtendedPullRequest struct {
 	CreatedAt   githubv4.DateTime
 	MergedAt    githubv4.DateTime
 	
+	// マージコミット（リバートのコミット参照から元PRを特定するため）
+	MergeCommit struct {
+		Oid githubv4.GitObjectID
+	}
+	
 	// 作者情報
 	Author struct {
+		Typename  githubv4.String `graphql:"__typename"`
 		Login     githubv4.String
 		AvatarURL githubv4.URI `graphql:"avatarUrl(size:72)"`
 	}
@@ -47,6 +53,13 @@ type ExtendedPullRequest struct {
 		Name githubv4.String
 	}
 	
+	// ラベル情報
+	Labels struct {
+		Nodes []struct {
+			Name githubv4.String
+		}
+	} `graphql:"labels(first: 20)"`
+	
 	// サイズメトリクス
 	Additions   githubv4.Int
 	Deletions   githubv4.Int
@@ -70,7 +83,8 @@ type ExtendedPullRequest struct {
 			CreatedAt githubv4.DateTime
 			State     githubv4.PullRequestReviewState
 			Author struct {
-				Login githubv4.String
+				Typename githubv4.String `graphql:"__typename"`
+				Login    githubv4.String
 			}
 			Comments struct {
 				TotalCount githubv4.Int
@@ -98,6 +112,7 @@ type ExtendedPullRequest struct {
 		Nodes []struct {
 			Commit struct {
 				MessageHeadline githubv4.String
+				MessageBody     githubv4.String
 				CommittedDate   githubv4.DateTime
 				Author struct {
 					Name githubv4.String
@@ -255,7 +270,8 @@ type ReviewTimelineQuery struct {
 						CreatedAt githubv4.DateTime
 						State     githubv4.PullRequestReviewState
 						Author struct {
-							Login githubv4.String
+							Typename githubv4.String `graphql:"__typename"`
+							Login    githubv4.String
 						}
 						SubmittedAt githubv4.DateTime
 					} `graphql:"... on PullRequestReview"`
@@ -279,6 +295,14 @@ type ReviewTimelineQuery struct {
 						}
 					} `graphql:"... on ReadyForReviewEvent"`
 					
+					// ドラフト変更イベント
+					ConvertToDraftEvent struct {
+						CreatedAt githubv4.DateTime
+						Actor struct {
+							Login githubv4.String
+						}
+					} `graphql:"... on ConvertToDraftEvent"`
+					
 					// マージイベント
 					MergedEvent struct {
 						CreatedAt githubv4.DateTime
@@ -288,11 +312,44 @@ type ReviewTimelineQuery struct {
 						MergeRefName githubv4.String
 					} `graphql:"... on MergedEvent"`
 				}
-			} `graphql:"timelineItems(first: 100, after: $cursor, itemTypes: [PULL_REQUEST_REVIEW, REVIEW_REQUESTED_EVENT, REVIEW_REQUEST_REMOVED_EVENT, PULL_REQUEST_REVIEW_COMMENT, READY_FOR_REVIEW_EVENT, MERGED_EVENT])"`
+			} `graphql:"timelineItems(first: 100, after: $cursor, itemTypes: [PULL_REQUEST_REVIEW, REVIEW_REQUESTED_EVENT, REVIEW_REQUEST_REMOVED_EVENT, PULL_REQUEST_REVIEW_COMMENT, READY_FOR_REVIEW_EVENT, CONVERT_TO_DRAFT_EVENT, MERGED_EVENT])"`
 		} `graphql:"... on PullRequest"`
 	} `graphql:"node(id: $prId)"`
 }
 
+// TeamMembersQuery はGitHubチームのメンバー一覧取得用のクエリ
+type TeamMembersQuery struct {
+	Organization struct {
+		Team struct {
+			Members struct {
+				PageInfo struct {
+					HasNextPage githubv4.Boolean
+					EndCursor   githubv4.String
+				}
+				Nodes []struct {
+					Login githubv4.String
+				}
+			} `graphql:"members(first: 100, after: $cursor)"`
+		} `graphql:"team(slug: $teamSlug)"`
+	} `graphql:"organization(login: $org)"`
+}
+
+// codeOwnersBlob はCODEOWNERSファイルの内容（ファイルがない場合は nil）
+type codeOwnersBlob *struct {
+	Blob struct {
+		Text githubv4.String
+	} `graphql:"... on Blob"`
+}
+
+// CodeOwnersQuery はGitHubがCODEOWNERSを探す3か所のファイル取得用のクエリ
+type CodeOwnersQuery struct {
+	Repository struct {
+		GitHubDirectory codeOwnersBlob `graphql:"githubDirectory: object(expression: $githubDirectory)"`
+		Root            codeOwnersBlob `graphql:"root: object(expression: $root)"`
+		DocsDirectory   codeOwnersBlob `graphql:"docsDirectory: object(expression: $docsDirectory)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
 // getLimitedQuery は制限されたフィールドのみを取得するクエリ（レート制限対策）
 func getLimitedQuery() interface{} {
 	return &struct {
@@ -21,6 +21,7 @@ func convertExtendedToDomain(apiPR ExtendedPullRequest) prDomain.PullRequest {
 		Author: prDomain.Author{
 			Login:     string(apiPR.Author.Login),
 			AvatarURL: apiPR.Author.AvatarURL.String(),
+			IsBot:     string(apiPR.Author.Typename) == prDomain.BotAuthorType,
 		},
 		Repository: prDomain.RepositoryInfo{
 			Name: string(apiPR.Repository.Name),
@@ -29,6 +30,10 @@ func convertExtendedToDomain(apiPR ExtendedPullRequest) prDomain.PullRequest {
 		Additions: int(apiPR.Additions),
 		Deletions: int(apiPR.Deletions),
 		CreatedAt: apiPR.CreatedAt.Time,
+		Labels:    extractLabelNames(apiPR),
+		
+		MergeCommitSHA: string(apiPR.MergeCommit.Oid),
+		CommitMessages: extractCommitMessages(apiPR),
 	}
 	
 	// マージ時刻
@@ -59,8 +64,32 @@ func convertExtendedToDomain(apiPR ExtendedPullRequest) prDomain.PullRequest {
 	return pr
 }
 
+// extractLabelNames はPRに付与されたラベル名を抽出
+func extractLabelNames(apiPR ExtendedPullRequest) []string {
+	labels := make([]string, 0, len(apiPR.Labels.Nodes))
+	for _, label := range apiPR.Labels.Nodes {
+		labels = append(labels, string(label.Name))
+	}
+	return labels
+}
+
+// extractCommitMessages はPRのコミットメッセージ（見出しと本文）を抽出
+func extractCommitMessages(apiPR ExtendedPullRequest) []string {
+	messages := make([]string, 0, len(apiPR.Commits.Nodes))
+	for _, node := range apiPR.Commits.Nodes {
+		message := string(node.Commit.MessageHeadline)
+		if body := string(node.Commit.MessageBody); body != "" {
+			message += "\n\n" + body
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
 // convertToPRMetrics はGitHub APIレスポンスをPRMetricsに変換
-func convertToPRMetrics(apiPR ExtendedPullRequest) *prDomain.PRMetrics {
+// 時間メトリクス・サイズカテゴリ・複雑度は、タイムラインのレビューイベントからリポジトリの計算定義で求める
+// （ドラフトを経由したPRは最後に ready_for_review になった時刻からレビュー待ちを計測する）
+func convertToPRMetrics(apiPR ExtendedPullRequest, reviewEvents []prDomain.ReviewEvent, analysis *prDomain.PRAnalysisService, botDetector *prDomain.BotDetector, changeDetector *prDomain.ChangeFailureDetector) *prDomain.PRMetrics {
 	// 基本情報
 	metrics := &prDomain.PRMetrics{
 		PRID:       string(apiPR.Id),
@@ -69,8 +98,18 @@ func convertToPRMetrics(apiPR ExtendedPullRequest) *prDomain.PRMetrics {
 		Author:     string(apiPR.Author.Login),
 		Repository: string(apiPR.Repository.Name),
 		CreatedAt:  apiPR.CreatedAt.Time,
+		Labels:     extractLabelNames(apiPR),
+		IsBot:      botDetector.IsBot(string(apiPR.Author.Login), string(apiPR.Author.Typename)),
+		
+		BaseBranch:     string(apiPR.BaseRefName),
+		HeadBranch:     string(apiPR.HeadRefName),
+		MergeCommitSHA: string(apiPR.MergeCommit.Oid),
 	}
 	
+	// リバート・ホットフィックスの判定
+	domainPR := convertExtendedToDomain(apiPR)
+	metrics.ChangeFailure = changeDetector.Detect(domainPR)
+	
 	if !apiPR.MergedAt.Time.IsZero() {
 		metrics.MergedAt = &apiPR.MergedAt.Time
 	}
@@ -78,14 +117,11 @@ func convertToPRMetrics(apiPR ExtendedPullRequest) *prDomain.PRMetrics {
 	// サイズメトリクス
 	metrics.SizeMetrics = calculateSizeMetrics(apiPR)
 	
-	// 時間メトリクス
-	metrics.TimeMetrics = calculateTimeMetrics(apiPR)
-	
 	// 品質メトリクス
-	metrics.QualityMetrics = calculateQualityMetrics(apiPR)
+	metrics.QualityMetrics = calculateQualityMetrics(apiPR, botDetector)
 	
-	// サイズカテゴリ
-	metrics.SizeCategory = metrics.CalculateSizeCategory()
+	// 時間メトリクス・サイズカテゴリ・複雑度と定義バージョン
+	analysis.ApplyDefinition(metrics, domainPR, reviewEvents)
 	
 	return metrics
 }
@@ -130,53 +166,8 @@ func calculateSizeMetrics(apiPR ExtendedPullRequest) prDomain.PRSizeMetrics {
 	return sizeMetrics
 }
 
-// calculateTimeMetrics は時間関連メトリクスを計算
-func calculateTimeMetrics(apiPR ExtendedPullRequest) prDomain.PRTimeMetrics {
-	timeMetrics := prDomain.PRTimeMetrics{
-		CreatedHour: apiPR.CreatedAt.Time.Hour(),
-	}
-	
-	if !apiPR.MergedAt.Time.IsZero() {
-		mergedHour := apiPR.MergedAt.Time.Hour()
-		timeMetrics.MergedHour = &mergedHour
-		
-		// 全体サイクルタイム
-		totalCycle := apiPR.MergedAt.Time.Sub(apiPR.CreatedAt.Time)
-		timeMetrics.TotalCycleTime = &totalCycle
-	}
-	
-	// レビュー関連時間の計算
-	if len(apiPR.Reviews.Nodes) > 0 {
-		reviews := apiPR.Reviews.Nodes
-		sort.Slice(reviews, func(i, j int) bool {
-			return reviews[i].CreatedAt.Time.Before(reviews[j].CreatedAt.Time)
-		})
-		
-		// 最初のレビューまでの時間
-		firstReviewTime := reviews[0].CreatedAt.Time.Sub(apiPR.CreatedAt.Time)
-		timeMetrics.TimeToFirstReview = &firstReviewTime
-		
-		// 最後の承認までの時間
-		for i := len(reviews) - 1; i >= 0; i-- {
-			if reviews[i].State == githubv4.PullRequestReviewStateApproved {
-				approvalTime := reviews[i].CreatedAt.Time.Sub(apiPR.CreatedAt.Time)
-				timeMetrics.TimeToApproval = &approvalTime
-				
-				// 承認からマージまでの時間
-				if !apiPR.MergedAt.Time.IsZero() {
-					mergeTime := apiPR.MergedAt.Time.Sub(reviews[i].CreatedAt.Time)
-					timeMetrics.TimeToMerge = &mergeTime
-				}
-				break
-			}
-		}
-	}
-	
-	return timeMetrics
-}
-
 // calculateQualityMetrics は品質関連メトリクスを計算
-func calculateQualityMetrics(apiPR ExtendedPullRequest) prDomain.PRQualityMetrics {
+func calculateQualityMetrics(apiPR ExtendedPullRequest, botDetector *prDomain.BotDetector) prDomain.PRQualityMetrics {
 	qualityMetrics := prDomain.PRQualityMetrics{
 		ReviewCommentCount: int(apiPR.ReviewComments.TotalCount),
 		CommitCount:        int(apiPR.Commits.TotalCount),
@@ -185,10 +176,17 @@ func calculateQualityMetrics(apiPR ExtendedPullRequest) prDomain.PRQualityMetric
 	// レビュアー情報
 	reviewers := make(map[string]bool)
 	approvers := make(map[string]bool)
+	botReviewers := make(map[string]bool)
 	reviewRounds := 0
 	
 	for _, review := range apiPR.Reviews.Nodes {
 		reviewer := string(review.Author.Login)
+		
+		// botのレビューはレビュアー数に含めない
+		if botDetector.IsBot(reviewer, string(review.Author.Typename)) {
+			botReviewers[reviewer] = true
+			continue
+		}
 		reviewers[reviewer] = true
 		
 		if review.State == githubv4.PullRequestReviewStateApproved {
@@ -212,6 +210,9 @@ func calculateQualityMetrics(apiPR ExtendedPullRequest) prDomain.PRQualityMetric
 	for approver := range approvers {
 		qualityMetrics.ApproversInvolved = append(qualityMetrics.ApproversInvolved, approver)
 	}
+	for botReviewer := range botReviewers {
+		qualityMetrics.BotReviewersInvolved = append(qualityMetrics.BotReviewersInvolved, botReviewer)
+	}
 	
 	// ファイルあたりの平均コメント数
 	if int(apiPR.ChangedFiles) > 0 {
@@ -0,0 +1,82 @@
+package github_api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToPRMetrics_ReviewStartsAtLastReadyForReview(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(7 * time.Hour)
+
+	apiPR := ExtendedPullRequest{
+		Id:        "PR_1",
+		Number:    1,
+		CreatedAt: githubv4.DateTime{Time: createdAt},
+		MergedAt:  githubv4.DateTime{Time: mergedAt},
+		URL:       githubv4.URI{URL: &url.URL{Scheme: "https", Host: "github.com", Path: "/org/repo/pull/1"}},
+	}
+	apiPR.Author.Login = "author"
+	apiPR.Author.AvatarURL = githubv4.URI{URL: &url.URL{Scheme: "https", Host: "avatars.githubusercontent.com"}}
+	apiPR.Repository.Name = "repo"
+
+	// ドラフトで作成 → ready → ドラフトに戻す → ready
+	events := []prDomain.ReviewEvent{
+		{Type: prDomain.ReviewEventTypeReadyForReview, CreatedAt: createdAt.Add(1 * time.Hour), Actor: "author"},
+		{Type: prDomain.ReviewEventTypeCommented, CreatedAt: createdAt.Add(90 * time.Minute), Actor: "reviewer1", Reviewer: "reviewer1"},
+		{Type: prDomain.ReviewEventTypeConvertedToDraft, CreatedAt: createdAt.Add(2 * time.Hour), Actor: "author"},
+		{Type: prDomain.ReviewEventTypeReadyForReview, CreatedAt: createdAt.Add(4 * time.Hour), Actor: "author"},
+		{Type: prDomain.ReviewEventTypeCommented, CreatedAt: createdAt.Add(5 * time.Hour), Actor: "reviewer1", Reviewer: "reviewer1"},
+		{Type: prDomain.ReviewEventTypeApproved, CreatedAt: createdAt.Add(6 * time.Hour), Actor: "reviewer1", Reviewer: "reviewer1"},
+	}
+
+	t.Run("レビュー待ちは最後に ready_for_review になった時刻から計測する", func(t *testing.T) {
+		analysis := prDomain.NewPRAnalysisService()
+		metrics := convertToPRMetrics(apiPR, events, analysis, prDomain.NewBotDetector(nil), prDomain.NewChangeFailureDetector(nil))
+
+		timeMetrics := metrics.TimeMetrics
+		require.NotNil(t, timeMetrics.ReadyForReviewAt)
+		assert.Equal(t, createdAt.Add(4*time.Hour), *timeMetrics.ReadyForReviewAt)
+		require.NotNil(t, timeMetrics.TimeToFirstReview)
+		assert.Equal(t, 1*time.Hour, *timeMetrics.TimeToFirstReview)
+		require.NotNil(t, timeMetrics.TimeToApproval)
+		assert.Equal(t, 2*time.Hour, *timeMetrics.TimeToApproval)
+		require.NotNil(t, timeMetrics.TimeInDraft)
+		assert.Equal(t, 3*time.Hour, *timeMetrics.TimeInDraft)
+		assert.Len(t, timeMetrics.DraftIntervals, 2)
+		require.NotNil(t, timeMetrics.TotalCycleTime)
+		assert.Equal(t, 7*time.Hour, *timeMetrics.TotalCycleTime)
+	})
+
+	t.Run("旧定義ではPR作成時刻から計測する", func(t *testing.T) {
+		config := prDomain.DefaultCycleTimeConfig()
+		config.UseLegacyReviewStart = true
+		analysis := prDomain.NewPRAnalysisServiceWithCycleTimeConfig(config)
+		metrics := convertToPRMetrics(apiPR, events, analysis, prDomain.NewBotDetector(nil), prDomain.NewChangeFailureDetector(nil))
+
+		require.NotNil(t, metrics.TimeMetrics.TimeToFirstReview)
+		assert.Equal(t, 90*time.Minute, *metrics.TimeMetrics.TimeToFirstReview)
+	})
+
+	t.Run("リポジトリごとの分析設定で計算し、定義バージョンを記録する", func(t *testing.T) {
+		legacy := true
+		registry, err := prDomain.NewAnalysisSettingsRegistry(prDomain.DefaultMetricDefinition(), prDomain.AnalysisSettings{
+			Repositories: map[string]prDomain.DefinitionOverride{"repo": {LegacyReviewStart: &legacy}},
+		})
+		require.NoError(t, err)
+
+		analysis := prDomain.NewPRAnalysisServiceWithSource(registry)
+		metrics := convertToPRMetrics(apiPR, events, analysis, prDomain.NewBotDetector(nil), prDomain.NewChangeFailureDetector(nil))
+
+		require.NotNil(t, metrics.TimeMetrics.TimeToFirstReview)
+		assert.Equal(t, 90*time.Minute, *metrics.TimeMetrics.TimeToFirstReview)
+		assert.Equal(t, registry.DefinitionFor("repo").Version(), metrics.DefinitionVersion)
+		assert.NotEqual(t, registry.DefinitionFor("").Version(), metrics.DefinitionVersion)
+	})
+}
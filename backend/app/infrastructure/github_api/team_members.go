@@ -0,0 +1,63 @@
+package github_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shurcooL/githubv4"
+
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/shared/config"
+	"github-stats-metrics/shared/logger"
+)
+
+// teamMemberSource はGitHubのorganization.team.membersからメンバーを取得する
+type teamMemberSource struct {
+	repository *repository
+}
+
+// NewTeamMemberSource はGitHubチームのメンバー取得元を作成
+func NewTeamMemberSource(cfg *config.Config) teamDomain.MemberSource {
+	client, err := createClient(cfg)
+	levelLogger := logger.NewLevelLogger()
+	if err != nil {
+		levelLogger.Error("Failed to create GitHub client for team sync", "error", err)
+	}
+	return &teamMemberSource{
+		repository: &repository{client: client, config: cfg, logger: levelLogger},
+	}
+}
+
+// FetchTeamMembers はGitHubチームの現在のメンバーのログイン名を取得
+func (s *teamMemberSource) FetchTeamMembers(ctx context.Context, org, teamSlug string) ([]string, error) {
+	if s.repository.client == nil {
+		return nil, errors.New("GitHub client is not initialized")
+	}
+
+	var logins []string
+	cursor := (*githubv4.String)(nil)
+
+	for {
+		query := TeamMembersQuery{}
+		variables := map[string]interface{}{
+			"org":      githubv4.String(org),
+			"teamSlug": githubv4.String(teamSlug),
+			"cursor":   cursor,
+		}
+
+		if err := s.repository.client.Query(ctx, &query, variables); err != nil {
+			return nil, s.repository.handleGitHubAPIError(err)
+		}
+
+		for _, member := range query.Organization.Team.Members.Nodes {
+			logins = append(logins, string(member.Login))
+		}
+
+		if !query.Organization.Team.Members.PageInfo.HasNextPage {
+			break
+		}
+		cursor = githubv4.NewString(query.Organization.Team.Members.PageInfo.EndCursor)
+	}
+
+	return logins, nil
+}
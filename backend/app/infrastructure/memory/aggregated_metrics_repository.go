@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/domain/analytics"
+)
+
+// defaultTeamTargetID は集計対象全員を表すチーム集計のターゲットID
+const defaultTeamTargetID = "team"
+
+// aggregatedKey は集計データの一意キー
+type aggregatedKey struct {
+	level       string
+	period      analyticsApp.AggregationPeriod
+	targetID    string
+	periodStart time.Time
+	periodEnd   time.Time
+}
+
+// aggregatedRecord は保存済みの集計データ
+// metrics には集計レベルに応じた *TeamMetrics などの複製を保持する
+type aggregatedRecord struct {
+	key         aggregatedKey
+	generatedAt time.Time
+	metrics     interface{}
+}
+
+// aggregatedMetricsRepository はメモリ内集計メトリクスの実装
+// 集計結果は保存時に構造体を複製して保持する
+type aggregatedMetricsRepository struct {
+	mu      sync.RWMutex
+	records map[aggregatedKey]*aggregatedRecord
+	now     func() time.Time
+}
+
+// NewAggregatedMetricsRepository はメモリ内集計メトリクスRepositoryを作成
+func NewAggregatedMetricsRepository() analyticsApp.AggregatedMetricsRepository {
+	return &aggregatedMetricsRepository{
+		records: make(map[aggregatedKey]*aggregatedRecord),
+		now:     time.Now,
+	}
+}
+
+// SaveTeamMetrics はチームメトリクスを保存
+func (r *aggregatedMetricsRepository) SaveTeamMetrics(ctx context.Context, metrics *analyticsApp.TeamMetrics) error {
+	copied := *metrics
+	r.save(analyticsApp.AggregationLevelTeam, metrics.Period, teamTargetID(metrics.Team), metrics.DateRange, metrics.GeneratedAt, &copied)
+	return nil
+}
+
+// SaveDeveloperMetrics は開発者メトリクスを保存
+func (r *aggregatedMetricsRepository) SaveDeveloperMetrics(ctx context.Context, metrics *analyticsApp.DeveloperMetrics) error {
+	copied := *metrics
+	r.save(analyticsApp.AggregationLevelDeveloper, metrics.Period, metrics.Developer, metrics.DateRange, metrics.GeneratedAt, &copied)
+	return nil
+}
+
+// SaveRepositoryMetrics はリポジトリメトリクスを保存
+func (r *aggregatedMetricsRepository) SaveRepositoryMetrics(ctx context.Context, metrics *analyticsApp.RepositoryMetrics) error {
+	copied := *metrics
+	r.save(analyticsApp.AggregationLevelRepository, metrics.Period, metrics.Repository, metrics.DateRange, metrics.GeneratedAt, &copied)
+	return nil
+}
+
+// SaveLabelMetrics はラベルメトリクスを保存
+func (r *aggregatedMetricsRepository) SaveLabelMetrics(ctx context.Context, metrics *analyticsApp.LabelMetrics) error {
+	copied := *metrics
+	r.save(analyticsApp.AggregationLevelLabel, metrics.Period, metrics.Label, metrics.DateRange, metrics.GeneratedAt, &copied)
+	return nil
+}
+
+// FindTeamMetrics は集計対象全員のチームメトリクスを取得
+func (r *aggregatedMetricsRepository) FindTeamMetrics(ctx context.Context, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.TeamMetrics, error) {
+	return r.FindTeamMetricsByName(ctx, "", period, startDate, endDate)
+}
+
+// FindTeamMetricsByName は指定チームのメトリクスを取得（空の場合は集計対象全員）
+func (r *aggregatedMetricsRepository) FindTeamMetricsByName(ctx context.Context, team string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.TeamMetrics, error) {
+	var metricsList []*analyticsApp.TeamMetrics
+	for _, record := range r.find(analyticsApp.AggregationLevelTeam, period, teamTargetID(team), startDate, endDate) {
+		copied := *record.metrics.(*analyticsApp.TeamMetrics)
+		metricsList = append(metricsList, &copied)
+	}
+	return metricsList, nil
+}
+
+// FindDeveloperMetrics は開発者メトリクスを取得
+func (r *aggregatedMetricsRepository) FindDeveloperMetrics(ctx context.Context, developer string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.DeveloperMetrics, error) {
+	var metricsList []*analyticsApp.DeveloperMetrics
+	for _, record := range r.find(analyticsApp.AggregationLevelDeveloper, period, developer, startDate, endDate) {
+		copied := *record.metrics.(*analyticsApp.DeveloperMetrics)
+		metricsList = append(metricsList, &copied)
+	}
+	return metricsList, nil
+}
+
+// FindRepositoryMetrics はリポジトリメトリクスを取得
+func (r *aggregatedMetricsRepository) FindRepositoryMetrics(ctx context.Context, repository string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.RepositoryMetrics, error) {
+	var metricsList []*analyticsApp.RepositoryMetrics
+	for _, record := range r.find(analyticsApp.AggregationLevelRepository, period, repository, startDate, endDate) {
+		copied := *record.metrics.(*analyticsApp.RepositoryMetrics)
+		metricsList = append(metricsList, &copied)
+	}
+	return metricsList, nil
+}
+
+// FindAllDeveloperMetrics は全開発者のメトリクスを取得
+func (r *aggregatedMetricsRepository) FindAllDeveloperMetrics(ctx context.Context, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) (map[string]*analyticsApp.DeveloperMetrics, error) {
+	result := make(map[string]*analyticsApp.DeveloperMetrics)
+	for _, record := range r.find(analyticsApp.AggregationLevelDeveloper, period, "", startDate, endDate) {
+		copied := *record.metrics.(*analyticsApp.DeveloperMetrics)
+		result[copied.Developer] = &copied
+	}
+	return result, nil
+}
+
+// FindAllRepositoryMetrics は全リポジトリのメトリクスを取得
+func (r *aggregatedMetricsRepository) FindAllRepositoryMetrics(ctx context.Context, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) (map[string]*analyticsApp.RepositoryMetrics, error) {
+	result := make(map[string]*analyticsApp.RepositoryMetrics)
+	for _, record := range r.find(analyticsApp.AggregationLevelRepository, period, "", startDate, endDate) {
+		copied := *record.metrics.(*analyticsApp.RepositoryMetrics)
+		result[copied.Repository] = &copied
+	}
+	return result, nil
+}
+
+// FindAllLabelMetrics は全ラベルのメトリクスを取得
+func (r *aggregatedMetricsRepository) FindAllLabelMetrics(ctx context.Context, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) (map[analyticsApp.LabelMetricsKey]*analyticsApp.LabelMetrics, error) {
+	result := make(map[analyticsApp.LabelMetricsKey]*analyticsApp.LabelMetrics)
+	for _, record := range r.find(analyticsApp.AggregationLevelLabel, period, "", startDate, endDate) {
+		copied := *record.metrics.(*analyticsApp.LabelMetrics)
+		result[analyticsApp.NewLabelMetricsKey(&copied)] = &copied
+	}
+	return result, nil
+}
+
+// DeleteOldAggregatedData は生成から保持期間を過ぎた集計データを期間別に削除
+func (r *aggregatedMetricsRepository) DeleteOldAggregatedData(ctx context.Context, retentionPolicy analytics.DataRetentionPolicy) (int64, error) {
+	retentionDays := map[analyticsApp.AggregationPeriod]int{
+		analyticsApp.AggregationPeriodDaily:   retentionPolicy.DailyAggregationRetentionDays,
+		analyticsApp.AggregationPeriodWeekly:  retentionPolicy.WeeklyAggregationRetentionDays,
+		analyticsApp.AggregationPeriodMonthly: retentionPolicy.MonthlyAggregationRetentionDays,
+	}
+
+	now := r.now()
+	var deleted int64
+	for period, days := range retentionDays {
+		if days <= 0 {
+			continue
+		}
+		count, err := r.DeleteGeneratedBefore(ctx, period, now.AddDate(0, 0, -days))
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+	return deleted, nil
+}
+
+// FindGeneratedBefore は生成日時が cutoff より前の集計データを生成日時の古い順に取得
+func (r *aggregatedMetricsRepository) FindGeneratedBefore(ctx context.Context, period analyticsApp.AggregationPeriod, cutoff time.Time) ([]*analyticsApp.AggregatedRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []*aggregatedRecord
+	for key, record := range r.records {
+		if key.period == period && record.generatedAt.Before(cutoff) {
+			records = append(records, record)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].generatedAt.Before(records[j].generatedAt)
+	})
+
+	result := make([]*analyticsApp.AggregatedRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, toAggregatedRecord(record))
+	}
+	return result, nil
+}
+
+// DeleteGeneratedBefore は生成日時が cutoff より前の集計データを削除
+func (r *aggregatedMetricsRepository) DeleteGeneratedBefore(ctx context.Context, period analyticsApp.AggregationPeriod, cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for key, record := range r.records {
+		if key.period == period && record.generatedAt.Before(cutoff) {
+			delete(r.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// GetAggregatedStatistics は集計レベル・期間別の統計情報を取得
+func (r *aggregatedMetricsRepository) GetAggregatedStatistics(ctx context.Context) (*analyticsApp.AggregatedStatistics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &analyticsApp.AggregatedStatistics{
+		LevelStats: make(map[string]*analyticsApp.AggregationLevelStats),
+	}
+	targets := make(map[aggregatedKey]map[string]bool)
+
+	for key := range r.records {
+		levelStats := stats.LevelStats[key.level]
+		if levelStats == nil {
+			levelStats = &analyticsApp.AggregationLevelStats{
+				PeriodStats: make(map[string]*analyticsApp.PeriodStats),
+			}
+			stats.LevelStats[key.level] = levelStats
+		}
+
+		periodStats := levelStats.PeriodStats[string(key.period)]
+		if periodStats == nil {
+			periodStats = &analyticsApp.PeriodStats{
+				OldestPeriod: key.periodStart,
+				NewestPeriod: key.periodEnd,
+			}
+			levelStats.PeriodStats[string(key.period)] = periodStats
+		}
+
+		periodStats.RecordCount++
+		if key.periodStart.Before(periodStats.OldestPeriod) {
+			periodStats.OldestPeriod = key.periodStart
+		}
+		if key.periodEnd.After(periodStats.NewestPeriod) {
+			periodStats.NewestPeriod = key.periodEnd
+		}
+
+		group := aggregatedKey{level: key.level, period: key.period}
+		if targets[group] == nil {
+			targets[group] = make(map[string]bool)
+		}
+		targets[group][key.targetID] = true
+	}
+
+	for group, targetIDs := range targets {
+		stats.LevelStats[group.level].PeriodStats[string(group.period)].UniqueTargets = int64(len(targetIDs))
+	}
+
+	return stats, nil
+}
+
+// save は集計データを一意キーで上書き保存する
+func (r *aggregatedMetricsRepository) save(level string, period analyticsApp.AggregationPeriod, targetID string, dateRange analyticsApp.DateRange, generatedAt time.Time, metrics interface{}) {
+	key := aggregatedKey{
+		level:       level,
+		period:      period,
+		targetID:    targetID,
+		periodStart: dateRange.Start.UTC(),
+		periodEnd:   dateRange.End.UTC(),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records[key] = &aggregatedRecord{key: key, generatedAt: generatedAt, metrics: metrics}
+}
+
+// find は条件に一致する集計データを期間開始の新しい順に返す（targetID が空の場合は全対象）
+func (r *aggregatedMetricsRepository) find(level string, period analyticsApp.AggregationPeriod, targetID string, startDate, endDate time.Time) []*aggregatedRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []*aggregatedRecord
+	for key, record := range r.records {
+		if key.level != level || key.period != period {
+			continue
+		}
+		if targetID != "" && key.targetID != targetID {
+			continue
+		}
+		if key.periodStart.Before(startDate) || key.periodEnd.After(endDate) {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].key.periodStart.After(records[j].key.periodStart)
+	})
+	return records
+}
+
+// toAggregatedRecord は保存済みの集計データを複製して集計レベル共通の形に変換
+func toAggregatedRecord(record *aggregatedRecord) *analyticsApp.AggregatedRecord {
+	result := &analyticsApp.AggregatedRecord{Level: record.key.level}
+	switch metrics := record.metrics.(type) {
+	case *analyticsApp.TeamMetrics:
+		copied := *metrics
+		result.Team = &copied
+	case *analyticsApp.DeveloperMetrics:
+		copied := *metrics
+		result.Developer = &copied
+	case *analyticsApp.RepositoryMetrics:
+		copied := *metrics
+		result.Repository = &copied
+	case *analyticsApp.LabelMetrics:
+		copied := *metrics
+		result.Label = &copied
+	}
+	return result
+}
+
+// teamTargetID はチーム名を集計データのターゲットIDに変換
+func teamTargetID(team string) string {
+	if team == "" {
+		return defaultTeamTargetID
+	}
+	return team
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/infrastructure/storagetest"
+)
+
+func TestAggregatedMetricsRepository_Conformance(t *testing.T) {
+	storagetest.RunAggregatedMetricsRepositoryTests(t, func(t *testing.T) analyticsApp.AggregatedMetricsRepository {
+		return NewAggregatedMetricsRepository()
+	})
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+)
+
+// aggregationJobRunRepository はメモリ内の集計ジョブ実行記録の実装
+type aggregationJobRunRepository struct {
+	mu   sync.RWMutex
+	runs []analyticsApp.AggregationJobRun
+}
+
+// NewAggregationJobRunRepository はメモリ内の実行記録Repositoryを作成
+func NewAggregationJobRunRepository() analyticsApp.AggregationJobRunRepository {
+	return &aggregationJobRunRepository{}
+}
+
+// SaveRun は実行記録を保存
+func (r *aggregationJobRunRepository) SaveRun(ctx context.Context, run *analyticsApp.AggregationJobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.runs = append(r.runs, copyJobRun(run))
+	return nil
+}
+
+// FindLatestSucceeded は最後に成功した実行記録を取得
+func (r *aggregationJobRunRepository) FindLatestSucceeded(ctx context.Context) (*analyticsApp.AggregationJobRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, run := range r.sortedRuns() {
+		if run.Status == analyticsApp.AggregationRunSucceeded {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindRecentRuns は実行記録を開始日時の新しい順に取得
+func (r *aggregationJobRunRepository) FindRecentRuns(ctx context.Context, limit int) ([]*analyticsApp.AggregationJobRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runs := r.sortedRuns()
+	if limit >= 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// sortedRuns は実行記録の複製を開始日時の新しい順に返す（ロックは呼び出し側で取得する）
+func (r *aggregationJobRunRepository) sortedRuns() []*analyticsApp.AggregationJobRun {
+	runs := make([]*analyticsApp.AggregationJobRun, 0, len(r.runs))
+	for i := range r.runs {
+		copied := copyJobRun(&r.runs[i])
+		runs = append(runs, &copied)
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+	return runs
+}
+
+func copyJobRun(run *analyticsApp.AggregationJobRun) analyticsApp.AggregationJobRun {
+	copied := *run
+	if run.CollectedSince != nil {
+		since := *run.CollectedSince
+		copied.CollectedSince = &since
+	}
+	return copied
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/infrastructure/storagetest"
+)
+
+func TestAggregationJobRunRepository_Conformance(t *testing.T) {
+	storagetest.RunAggregationJobRunRepositoryTests(t, func(t *testing.T) analyticsApp.AggregationJobRunRepository {
+		return NewAggregationJobRunRepository()
+	})
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+)
+
+// bottleneckRepository はメモリ内のボトルネック履歴の実装
+type bottleneckRepository struct {
+	mu      sync.RWMutex
+	records map[string]analyticsApp.BottleneckRecord
+}
+
+// NewBottleneckRepository はメモリ内のボトルネック履歴Repositoryを作成
+func NewBottleneckRepository() analyticsApp.BottleneckRepository {
+	return &bottleneckRepository{
+		records: make(map[string]analyticsApp.BottleneckRecord),
+	}
+}
+
+// SaveBottlenecks はボトルネックを保存
+func (r *bottleneckRepository) SaveBottlenecks(ctx context.Context, records []*analyticsApp.BottleneckRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range records {
+		r.records[record.ID] = copyBottleneckRecord(record)
+	}
+	return nil
+}
+
+// FindBottlenecksByPRIDs は指定したPRのボトルネックを取得
+func (r *bottleneckRepository) FindBottlenecksByPRIDs(ctx context.Context, prIDs []string) ([]*analyticsApp.BottleneckRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	targets := make(map[string]bool, len(prIDs))
+	for _, prID := range prIDs {
+		targets[prID] = true
+	}
+
+	var records []*analyticsApp.BottleneckRecord
+	for _, record := range r.records {
+		if targets[record.PRID] {
+			copied := copyBottleneckRecord(&record)
+			records = append(records, &copied)
+		}
+	}
+	sortBottlenecks(records)
+	return records, nil
+}
+
+// FindBottlenecks は条件に合うボトルネックを最初の検出日時の新しい順に取得
+func (r *bottleneckRepository) FindBottlenecks(ctx context.Context, filter analyticsApp.BottleneckFilter) ([]*analyticsApp.BottleneckRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := make(map[string]bool, len(filter.Authors))
+	for _, author := range filter.Authors {
+		authors[strings.ToLower(author)] = true
+	}
+	var records []*analyticsApp.BottleneckRecord
+	for _, record := range r.records {
+		if filter.Status != "" && record.Status != filter.Status {
+			continue
+		}
+		if filter.Type != "" && record.Type != filter.Type {
+			continue
+		}
+		if filter.Repository != "" && record.Repository != filter.Repository {
+			continue
+		}
+		if len(authors) > 0 && !authors[strings.ToLower(record.Author)] {
+			continue
+		}
+		if !filter.SeenSince.IsZero() && record.FirstSeenAt.Before(filter.SeenSince) {
+			continue
+		}
+		if !filter.SeenUntil.IsZero() && record.FirstSeenAt.After(filter.SeenUntil) {
+			continue
+		}
+		copied := copyBottleneckRecord(&record)
+		records = append(records, &copied)
+	}
+
+	sortBottlenecks(records)
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+// sortBottlenecks は最初の検出日時の新しい順（同時刻はID順）に並べる
+func sortBottlenecks(records []*analyticsApp.BottleneckRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		if !records[i].FirstSeenAt.Equal(records[j].FirstSeenAt) {
+			return records[i].FirstSeenAt.After(records[j].FirstSeenAt)
+		}
+		return records[i].ID < records[j].ID
+	})
+}
+
+func copyBottleneckRecord(record *analyticsApp.BottleneckRecord) analyticsApp.BottleneckRecord {
+	copied := *record
+	if record.ResolvedAt != nil {
+		resolvedAt := *record.ResolvedAt
+		copied.ResolvedAt = &resolvedAt
+	}
+	return copied
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/infrastructure/storagetest"
+)
+
+func TestBottleneckRepository_Conformance(t *testing.T) {
+	storagetest.RunBottleneckRepositoryTests(t, func(t *testing.T) analyticsApp.BottleneckRepository {
+		return NewBottleneckRepository()
+	})
+}
@@ -0,0 +1,325 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// prMetricsRecord は保存済みのPRメトリクスと収集日時
+// レビューイベントはSQL実装と同じくPRメトリクスとは別に保持する
+type prMetricsRecord struct {
+	metrics      *prDomain.PRMetrics
+	reviewEvents []prDomain.ReviewEvent
+	collectedAt  time.Time
+}
+
+// prMetricsRepository はメモリ内PRメトリクスの実装
+// デモモードやテスト用で、プロセス終了時にデータは失われる
+type prMetricsRepository struct {
+	mu      sync.RWMutex
+	records map[string]*prMetricsRecord // PR ID → レコード
+	now     func() time.Time
+}
+
+// NewPRMetricsRepository はメモリ内PRメトリクスRepositoryを作成
+func NewPRMetricsRepository() prDomain.MetricsRepository {
+	return &prMetricsRepository{
+		records: make(map[string]*prMetricsRecord),
+		now:     time.Now,
+	}
+}
+
+// Save はPRメトリクスを保存（同じPR IDのデータは上書き）
+func (r *prMetricsRepository) Save(ctx context.Context, metrics *prDomain.PRMetrics) error {
+	stored, err := copyPRMetrics(metrics)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record := newPRMetricsRecord(stored, r.now())
+	// SQL実装と同じく、イベントが指定されていない場合は既存のイベントを残す
+	if existing, ok := r.records[metrics.PRID]; ok && len(record.reviewEvents) == 0 {
+		record.reviewEvents = existing.reviewEvents
+	}
+	r.records[metrics.PRID] = record
+	return nil
+}
+
+// SaveBatch は複数のPRメトリクスを保存（既存のPR IDは上書きしない）
+func (r *prMetricsRepository) SaveBatch(ctx context.Context, metricsList []*prDomain.PRMetrics) error {
+	// 途中で失敗した場合に一部だけ保存されないよう、先にすべて複製する
+	copies := make([]*prDomain.PRMetrics, 0, len(metricsList))
+	for _, metrics := range metricsList {
+		stored, err := copyPRMetrics(metrics)
+		if err != nil {
+			return err
+		}
+		copies = append(copies, stored)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	collectedAt := r.now()
+	for _, stored := range copies {
+		if _, exists := r.records[stored.PRID]; exists {
+			continue
+		}
+		r.records[stored.PRID] = newPRMetricsRecord(stored, collectedAt)
+	}
+	return nil
+}
+
+// FindByPRID はPR IDによりPRメトリクスを取得
+func (r *prMetricsRepository) FindByPRID(ctx context.Context, prID string) (*prDomain.PRMetrics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.records[prID]
+	if !ok {
+		return nil, nil
+	}
+	return copyPRMetrics(record.metrics)
+}
+
+// FindByDateRange は日付範囲によりPRメトリクスを作成日時の新しい順に取得
+func (r *prMetricsRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time, developers []string, repositories []string) ([]*prDomain.PRMetrics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	developerSet := toSet(developers)
+	repositorySet := toSet(repositories)
+
+	var metricsList []*prDomain.PRMetrics
+	for _, record := range r.records {
+		metrics := record.metrics
+		if metrics.CreatedAt.Before(startDate) || metrics.CreatedAt.After(endDate) {
+			continue
+		}
+		if len(developerSet) > 0 && !developerSet[metrics.Author] {
+			continue
+		}
+		if len(repositorySet) > 0 && !repositorySet[metrics.Repository] {
+			continue
+		}
+
+		copied, err := copyPRMetrics(metrics)
+		if err != nil {
+			return nil, err
+		}
+		metricsList = append(metricsList, copied)
+	}
+
+	sort.SliceStable(metricsList, func(i, j int) bool {
+		return metricsList[i].CreatedAt.After(metricsList[j].CreatedAt)
+	})
+	return metricsList, nil
+}
+
+// FindByDeveloper は開発者によりPRメトリクスを取得
+func (r *prMetricsRepository) FindByDeveloper(ctx context.Context, developer string, startDate, endDate time.Time) ([]*prDomain.PRMetrics, error) {
+	return r.FindByDateRange(ctx, startDate, endDate, []string{developer}, nil)
+}
+
+// FindByRepository はリポジトリによりPRメトリクスを取得
+func (r *prMetricsRepository) FindByRepository(ctx context.Context, repository string, startDate, endDate time.Time) ([]*prDomain.PRMetrics, error) {
+	return r.FindByDateRange(ctx, startDate, endDate, nil, []string{repository})
+}
+
+// Update はPR IDが一致するPRメトリクスを更新
+func (r *prMetricsRepository) Update(ctx context.Context, metrics *prDomain.PRMetrics) error {
+	stored, err := copyPRMetrics(metrics)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.records[metrics.PRID]
+	if !ok {
+		return fmt.Errorf("%w: %s", prDomain.ErrMetricsNotFound, metrics.PRID)
+	}
+	// Update はレビューイベントを変更しない
+	stored.ReviewEvents = nil
+	r.records[metrics.PRID] = &prMetricsRecord{metrics: stored, reviewEvents: existing.reviewEvents, collectedAt: r.now()}
+	return nil
+}
+
+// DeleteOldData は収集から retentionDays 日を超えたデータを削除
+func (r *prMetricsRepository) DeleteOldData(ctx context.Context, retentionDays int) (int64, error) {
+	return r.DeleteCollectedBefore(ctx, r.now().AddDate(0, 0, -retentionDays))
+}
+
+// FindCollectedBefore は収集日時が cutoff より前のPRメトリクスを収集日時の古い順に取得
+func (r *prMetricsRepository) FindCollectedBefore(ctx context.Context, cutoff time.Time) ([]*prDomain.PRMetrics, error) {
+	return r.findCollected(func(collectedAt time.Time) bool {
+		return collectedAt.Before(cutoff)
+	})
+}
+
+// FindCollectedSince は収集日時が since 以降のPRメトリクスを収集日時の古い順に取得
+func (r *prMetricsRepository) FindCollectedSince(ctx context.Context, since time.Time) ([]*prDomain.PRMetrics, error) {
+	return r.findCollected(func(collectedAt time.Time) bool {
+		return !collectedAt.Before(since)
+	})
+}
+
+// findCollected は収集日時が条件に一致するPRメトリクスを収集日時の古い順に取得
+func (r *prMetricsRepository) findCollected(match func(collectedAt time.Time) bool) ([]*prDomain.PRMetrics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []*prMetricsRecord
+	for _, record := range r.records {
+		if match(record.collectedAt) {
+			records = append(records, record)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].collectedAt.Before(records[j].collectedAt)
+	})
+
+	metricsList := make([]*prDomain.PRMetrics, 0, len(records))
+	for _, record := range records {
+		copied, err := copyPRMetrics(record.metrics)
+		if err != nil {
+			return nil, err
+		}
+		metricsList = append(metricsList, copied)
+	}
+	return metricsList, nil
+}
+
+// DeleteCollectedBefore は収集日時が cutoff より前のPRメトリクスを削除
+func (r *prMetricsRepository) DeleteCollectedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for prID, record := range r.records {
+		if record.collectedAt.Before(cutoff) {
+			delete(r.records, prID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// FindReviewEvents はPR IDのレビューイベントを発生日時の順に取得
+func (r *prMetricsRepository) FindReviewEvents(ctx context.Context, prID string) ([]prDomain.ReviewEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []prDomain.ReviewEvent{}
+	if record, ok := r.records[prID]; ok {
+		events = append(events, record.reviewEvents...)
+	}
+	return events, nil
+}
+
+// FindReviewEventsByPRIDs は複数PRのレビューイベントをPR IDごとに発生日時の順に取得
+func (r *prMetricsRepository) FindReviewEventsByPRIDs(ctx context.Context, prIDs []string) (map[string][]prDomain.ReviewEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	eventsByPR := make(map[string][]prDomain.ReviewEvent)
+	for _, prID := range prIDs {
+		if record, ok := r.records[prID]; ok && len(record.reviewEvents) > 0 {
+			eventsByPR[prID] = append([]prDomain.ReviewEvent(nil), record.reviewEvents...)
+		}
+	}
+	return eventsByPR, nil
+}
+
+// GetStatistics は保存済みデータの統計情報を取得
+func (r *prMetricsRepository) GetStatistics(ctx context.Context) (*prDomain.MetricsStatistics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &prDomain.MetricsStatistics{TotalRecords: int64(len(r.records))}
+	developers := make(map[string]bool)
+	repositories := make(map[string]bool)
+	var cycleTimeSum, complexitySum float64
+	var cycleTimeCount int
+
+	for _, record := range r.records {
+		metrics := record.metrics
+		developers[metrics.Author] = true
+		repositories[metrics.Repository] = true
+
+		if stats.OldestRecord.IsZero() || metrics.CreatedAt.Before(stats.OldestRecord) {
+			stats.OldestRecord = metrics.CreatedAt
+		}
+		if metrics.CreatedAt.After(stats.NewestRecord) {
+			stats.NewestRecord = metrics.CreatedAt
+		}
+
+		// SQL実装と同様に秒単位に切り捨てて平均する
+		if metrics.TimeMetrics.TotalCycleTime != nil {
+			cycleTimeSum += float64(int64(metrics.TimeMetrics.TotalCycleTime.Seconds()))
+			cycleTimeCount++
+		}
+		complexitySum += metrics.ComplexityScore
+	}
+
+	stats.UniqueDevelopers = int64(len(developers))
+	stats.UniqueRepositories = int64(len(repositories))
+	if cycleTimeCount > 0 {
+		avg := cycleTimeSum / float64(cycleTimeCount)
+		stats.AvgCycleTime = &avg
+	}
+	if len(r.records) > 0 {
+		avg := complexitySum / float64(len(r.records))
+		stats.AvgComplexity = &avg
+	}
+
+	return stats, nil
+}
+
+// newPRMetricsRecord は複製済みのPRメトリクスからレコードを作成する
+// レビューイベントはPRメトリクスから外し、発生日時の順に並べて保持する
+func newPRMetricsRecord(stored *prDomain.PRMetrics, collectedAt time.Time) *prMetricsRecord {
+	events := stored.ReviewEvents
+	stored.ReviewEvents = nil
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return &prMetricsRecord{metrics: stored, reviewEvents: events, collectedAt: collectedAt}
+}
+
+// copyPRMetrics はPRメトリクスを複製する
+// SQL実装と同じくJSONを経由するため、保存・取得で得られる値が一致する
+func copyPRMetrics(metrics *prDomain.PRMetrics) (*prDomain.PRMetrics, error) {
+	data, err := json.Marshal(metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy pr metrics: %w", err)
+	}
+
+	var copied prDomain.PRMetrics
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy pr metrics: %w", err)
+	}
+	if copied.Labels == nil {
+		copied.Labels = []string{}
+	}
+	return &copied, nil
+}
+
+// toSet は文字列のスライスを集合に変換する
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
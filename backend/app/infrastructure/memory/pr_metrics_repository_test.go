@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/infrastructure/storagetest"
+)
+
+func TestPRMetricsRepository_Conformance(t *testing.T) {
+	storagetest.RunPRMetricsRepositoryTests(t, func(t *testing.T) prDomain.MetricsRepository {
+		return NewPRMetricsRepository()
+	})
+}
+
+func TestPRMetricsRepository_DeleteOldData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo := NewPRMetricsRepository().(*prMetricsRepository)
+
+	repo.now = func() time.Time { return now.AddDate(0, 0, -100) }
+	require.NoError(t, repo.Save(ctx, &prDomain.PRMetrics{PRID: "old", CreatedAt: now}))
+	repo.now = func() time.Time { return now }
+	require.NoError(t, repo.Save(ctx, &prDomain.PRMetrics{PRID: "recent", CreatedAt: now}))
+
+	deleted, err := repo.DeleteOldData(ctx, 90)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	result, err := repo.FindByPRID(ctx, "old")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestPRMetricsRepository_ConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPRMetricsRepository()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			metrics := &prDomain.PRMetrics{
+				PRID:      fmt.Sprintf("pr-%d", i),
+				Author:    "alice",
+				CreatedAt: start.Add(time.Duration(i) * time.Hour),
+			}
+			assert.NoError(t, repo.Save(ctx, metrics))
+			_, err := repo.FindByDateRange(ctx, start, start.AddDate(0, 1, 0), nil, nil)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	stats, err := repo.GetStatistics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), stats.TotalRecords)
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+)
+
+// trendSnapshotKey はトレンド分析のスナップショットを一意に識別するキー
+type trendSnapshotKey struct {
+	metricType  string
+	team        string
+	period      analyticsApp.AggregationPeriod
+	periodStart time.Time
+	periodEnd   time.Time
+}
+
+// trendSnapshotRepository はメモリ内のトレンド分析スナップショットの実装
+type trendSnapshotRepository struct {
+	mu        sync.RWMutex
+	snapshots map[trendSnapshotKey]analyticsApp.TrendSnapshot
+}
+
+// NewTrendSnapshotRepository はメモリ内のトレンド分析スナップショットRepositoryを作成
+func NewTrendSnapshotRepository() analyticsApp.TrendSnapshotRepository {
+	return &trendSnapshotRepository{
+		snapshots: make(map[trendSnapshotKey]analyticsApp.TrendSnapshot),
+	}
+}
+
+// SaveTrendSnapshots はスナップショットを保存
+func (r *trendSnapshotRepository) SaveTrendSnapshots(ctx context.Context, snapshots []*analyticsApp.TrendSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, snapshot := range snapshots {
+		key := trendSnapshotKey{
+			metricType:  snapshot.MetricType,
+			team:        snapshot.Team,
+			period:      snapshot.Period,
+			periodStart: snapshot.DateRange.Start.UTC(),
+			periodEnd:   snapshot.DateRange.End.UTC(),
+		}
+		r.snapshots[key] = copyTrendSnapshot(snapshot)
+	}
+	return nil
+}
+
+// FindTrendSnapshots は期間が範囲に収まるスナップショットを期間の新しい順に取得
+func (r *trendSnapshotRepository) FindTrendSnapshots(ctx context.Context, team string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.TrendSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var snapshots []*analyticsApp.TrendSnapshot
+	for key, snapshot := range r.snapshots {
+		if key.team != team || key.period != period {
+			continue
+		}
+		if key.periodStart.Before(startDate) || key.periodEnd.After(endDate) {
+			continue
+		}
+		copied := copyTrendSnapshot(&snapshot)
+		snapshots = append(snapshots, &copied)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if !snapshots[i].DateRange.Start.Equal(snapshots[j].DateRange.Start) {
+			return snapshots[i].DateRange.Start.After(snapshots[j].DateRange.Start)
+		}
+		return snapshots[i].MetricType < snapshots[j].MetricType
+	})
+	return snapshots, nil
+}
+
+func copyTrendSnapshot(snapshot *analyticsApp.TrendSnapshot) analyticsApp.TrendSnapshot {
+	copied := *snapshot
+	copied.Series = append([]analyticsApp.TrendPoint(nil), snapshot.Series...)
+	return copied
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/infrastructure/storagetest"
+)
+
+func TestTrendSnapshotRepository_Conformance(t *testing.T) {
+	storagetest.RunTrendSnapshotRepositoryTests(t, func(t *testing.T) analyticsApp.TrendSnapshotRepository {
+		return NewTrendSnapshotRepository()
+	})
+}
@@ -9,20 +9,48 @@ import (
 
 	analyticsApp "github-stats-metrics/application/analytics"
 	"github-stats-metrics/domain/analytics"
+	"github-stats-metrics/infrastructure/database"
 )
 
 // AggregatedMetricsRepository は集計済みメトリクスの永続化を担当するリポジトリ
 type AggregatedMetricsRepository struct {
-	db *sql.DB
+	db *database.DB
 }
 
-// NewAggregatedMetricsRepository は新しい集計メトリクスリポジトリを作成
+var _ analyticsApp.AggregatedMetricsRepository = (*AggregatedMetricsRepository)(nil)
+
+// NewAggregatedMetricsRepository は新しい集計メトリクスリポジトリを作成（PostgreSQL）
 func NewAggregatedMetricsRepository(db *sql.DB) *AggregatedMetricsRepository {
+	return NewAggregatedMetricsRepositoryWithDialect(db, database.Postgres())
+}
+
+// NewAggregatedMetricsRepositoryWithDialect は指定した方言で集計メトリクスリポジトリを作成
+func NewAggregatedMetricsRepositoryWithDialect(db *sql.DB, dialect database.Dialect) *AggregatedMetricsRepository {
 	return &AggregatedMetricsRepository{
-		db: db,
+		db: database.New(db, dialect),
 	}
 }
 
+// aggregatedMetricsConflictColumns は集計データの一意キー
+var aggregatedMetricsConflictColumns = []string{
+	"aggregation_level", "aggregation_period", "target_id", "period_start", "period_end",
+}
+
+// aggregatedMetricsUpdateColumns は一意キーの衝突時に上書きする列
+var aggregatedMetricsUpdateColumns = []string{
+	"target_name", "total_prs", "merged_prs", "closed_prs",
+	"avg_cycle_time_seconds", "median_cycle_time_seconds", "p95_cycle_time_seconds",
+	"avg_review_time_seconds", "median_review_time_seconds", "p95_review_time_seconds",
+	"avg_approval_time_seconds", "median_approval_time_seconds", "p95_approval_time_seconds",
+	"avg_lines_changed", "median_lines_changed", "avg_files_changed", "median_files_changed",
+	"avg_review_comments", "avg_review_rounds", "first_pass_rate",
+	"avg_complexity_score", "median_complexity_score",
+	"prs_per_day", "lines_per_day", "throughput",
+	"cycle_time_trend", "review_time_trend", "quality_trend",
+	"updated_at", "detailed_stats_json",
+	"year_month", "week_of_year", "day_of_year",
+}
+
 // SaveTeamMetrics はチームメトリクスを保存
 func (repo *AggregatedMetricsRepository) SaveTeamMetrics(ctx context.Context, metrics *analyticsApp.TeamMetrics) error {
 	storage, err := repo.convertTeamMetricsToStorage(metrics)
@@ -53,9 +81,24 @@ func (repo *AggregatedMetricsRepository) SaveRepositoryMetrics(ctx context.Conte
 	return repo.saveAggregatedMetrics(ctx, storage)
 }
 
-// FindTeamMetrics はチームメトリクスを取得
+// SaveLabelMetrics はラベル別メトリクスを保存
+func (repo *AggregatedMetricsRepository) SaveLabelMetrics(ctx context.Context, metrics *analyticsApp.LabelMetrics) error {
+	storage, err := repo.convertLabelMetricsToStorage(metrics)
+	if err != nil {
+		return fmt.Errorf("failed to convert label metrics to storage: %w", err)
+	}
+
+	return repo.saveAggregatedMetrics(ctx, storage)
+}
+
+// FindTeamMetrics は集計対象全員のチームメトリクスを取得
 func (repo *AggregatedMetricsRepository) FindTeamMetrics(ctx context.Context, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.TeamMetrics, error) {
-	storageList, err := repo.findAggregatedMetrics(ctx, "team", string(period), "", startDate, endDate)
+	return repo.FindTeamMetricsByName(ctx, "", period, startDate, endDate)
+}
+
+// FindTeamMetricsByName は指定チームのメトリクスを取得（空の場合は集計対象全員）
+func (repo *AggregatedMetricsRepository) FindTeamMetricsByName(ctx context.Context, team string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.TeamMetrics, error) {
+	storageList, err := repo.findAggregatedMetrics(ctx, "team", string(period), teamTargetID(team), startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +191,25 @@ func (repo *AggregatedMetricsRepository) FindAllRepositoryMetrics(ctx context.Co
 	return result, nil
 }
 
+// FindAllLabelMetrics は全ラベルのメトリクスを取得
+func (repo *AggregatedMetricsRepository) FindAllLabelMetrics(ctx context.Context, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) (map[analyticsApp.LabelMetricsKey]*analyticsApp.LabelMetrics, error) {
+	storageList, err := repo.findAggregatedMetrics(ctx, "label", string(period), "", startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[analyticsApp.LabelMetricsKey]*analyticsApp.LabelMetrics)
+	for _, storage := range storageList {
+		metrics, err := repo.convertStorageToLabelMetrics(storage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert storage to label metrics: %w", err)
+		}
+		result[analyticsApp.NewLabelMetricsKey(metrics)] = metrics
+	}
+
+	return result, nil
+}
+
 // FindLatestMetrics は最新の集計メトリクスを取得
 func (repo *AggregatedMetricsRepository) FindLatestMetrics(ctx context.Context, aggregationLevel, targetID string, period analyticsApp.AggregationPeriod) (*analytics.AggregatedMetricsStorage, error) {
 	query := `
@@ -242,6 +304,34 @@ func (repo *AggregatedMetricsRepository) DeleteOldAggregatedData(ctx context.Con
 	return totalDeleted, nil
 }
 
+// FindGeneratedBefore は生成日時が cutoff より前の集計データを生成日時の古い順に取得
+func (repo *AggregatedMetricsRepository) FindGeneratedBefore(ctx context.Context, period analyticsApp.AggregationPeriod, cutoff time.Time) ([]*analyticsApp.AggregatedRecord, error) {
+	query := aggregatedMetricsSelectQuery + `
+		WHERE aggregation_period = $1 AND generated_at < $2
+		ORDER BY generated_at, id
+	`
+	storageList, err := repo.queryAggregatedMetrics(ctx, query, string(period), cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*analyticsApp.AggregatedRecord, 0, len(storageList))
+	for _, storage := range storageList {
+		record, err := repo.convertStorageToRecord(storage)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// DeleteGeneratedBefore は生成日時が cutoff より前の集計データを削除
+func (repo *AggregatedMetricsRepository) DeleteGeneratedBefore(ctx context.Context, period analyticsApp.AggregationPeriod, cutoff time.Time) (int64, error) {
+	return repo.deleteOldAggregatedDataByPeriod(ctx, string(period), cutoff)
+}
+
 // GetAggregatedStatistics は集計データの統計情報を取得
 func (repo *AggregatedMetricsRepository) GetAggregatedStatistics(ctx context.Context) (*AggregatedRepositoryStatistics, error) {
 	query := `
@@ -272,7 +362,7 @@ func (repo *AggregatedMetricsRepository) GetAggregatedStatistics(ctx context.Con
 		var recordCount, uniqueTargets int64
 		var oldestPeriod, newestPeriod time.Time
 
-		err := rows.Scan(&level, &period, &recordCount, &oldestPeriod, &newestPeriod, &uniqueTargets)
+		err := rows.Scan(&level, &period, &recordCount, database.ScanTime(&oldestPeriod), database.ScanTime(&newestPeriod), &uniqueTargets)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan aggregated statistics row: %w", err)
 		}
@@ -299,22 +389,13 @@ func (repo *AggregatedMetricsRepository) GetAggregatedStatistics(ctx context.Con
 }
 
 // AggregatedRepositoryStatistics は集計データリポジトリの統計情報
-type AggregatedRepositoryStatistics struct {
-	LevelStats map[string]*AggregationLevelStats `json:"levelStats"`
-}
+type AggregatedRepositoryStatistics = analyticsApp.AggregatedStatistics
 
 // AggregationLevelStats は集計レベル別の統計情報
-type AggregationLevelStats struct {
-	PeriodStats map[string]*PeriodStats `json:"periodStats"`
-}
+type AggregationLevelStats = analyticsApp.AggregationLevelStats
 
 // PeriodStats は期間別の統計情報
-type PeriodStats struct {
-	RecordCount   int64     `json:"recordCount"`
-	UniqueTargets int64     `json:"uniqueTargets"`
-	OldestPeriod  time.Time `json:"oldestPeriod"`
-	NewestPeriod  time.Time `json:"newestPeriod"`
-}
+type PeriodStats = analyticsApp.PeriodStats
 
 // プライベートメソッド
 
@@ -336,44 +417,10 @@ func (repo *AggregatedMetricsRepository) saveAggregatedMetrics(ctx context.Conte
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
 			$17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
-			$31, $32, $33, $34, $35, $36, $37, $38, $39, $40
-		) ON CONFLICT (aggregation_level, aggregation_period, target_id, period_start, period_end) 
-		DO UPDATE SET
-			target_name = EXCLUDED.target_name,
-			total_prs = EXCLUDED.total_prs,
-			merged_prs = EXCLUDED.merged_prs,
-			closed_prs = EXCLUDED.closed_prs,
-			avg_cycle_time_seconds = EXCLUDED.avg_cycle_time_seconds,
-			median_cycle_time_seconds = EXCLUDED.median_cycle_time_seconds,
-			p95_cycle_time_seconds = EXCLUDED.p95_cycle_time_seconds,
-			avg_review_time_seconds = EXCLUDED.avg_review_time_seconds,
-			median_review_time_seconds = EXCLUDED.median_review_time_seconds,
-			p95_review_time_seconds = EXCLUDED.p95_review_time_seconds,
-			avg_approval_time_seconds = EXCLUDED.avg_approval_time_seconds,
-			median_approval_time_seconds = EXCLUDED.median_approval_time_seconds,
-			p95_approval_time_seconds = EXCLUDED.p95_approval_time_seconds,
-			avg_lines_changed = EXCLUDED.avg_lines_changed,
-			median_lines_changed = EXCLUDED.median_lines_changed,
-			avg_files_changed = EXCLUDED.avg_files_changed,
-			median_files_changed = EXCLUDED.median_files_changed,
-			avg_review_comments = EXCLUDED.avg_review_comments,
-			avg_review_rounds = EXCLUDED.avg_review_rounds,
-			first_pass_rate = EXCLUDED.first_pass_rate,
-			avg_complexity_score = EXCLUDED.avg_complexity_score,
-			median_complexity_score = EXCLUDED.median_complexity_score,
-			prs_per_day = EXCLUDED.prs_per_day,
-			lines_per_day = EXCLUDED.lines_per_day,
-			throughput = EXCLUDED.throughput,
-			cycle_time_trend = EXCLUDED.cycle_time_trend,
-			review_time_trend = EXCLUDED.review_time_trend,
-			quality_trend = EXCLUDED.quality_trend,
-			updated_at = EXCLUDED.updated_at,
-			version = aggregated_metrics.version + 1,
-			detailed_stats_json = EXCLUDED.detailed_stats_json,
-			year_month = EXCLUDED.year_month,
-			week_of_year = EXCLUDED.week_of_year,
-			day_of_year = EXCLUDED.day_of_year
-	`
+			$31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41
+		)
+	` + repo.db.Dialect().UpsertClause(aggregatedMetricsConflictColumns, aggregatedMetricsUpdateColumns,
+		"version = aggregated_metrics.version + 1")
 
 	_, err := repo.db.ExecContext(ctx, query,
 		storage.ID, storage.AggregationLevel, storage.AggregationPeriod,
@@ -394,8 +441,8 @@ func (repo *AggregatedMetricsRepository) saveAggregatedMetrics(ctx context.Conte
 	return err
 }
 
-func (repo *AggregatedMetricsRepository) findAggregatedMetrics(ctx context.Context, aggregationLevel, period, targetID string, startDate, endDate time.Time) ([]*analytics.AggregatedMetricsStorage, error) {
-	query := `
+// aggregatedMetricsSelectQuery は集計データ取得の共通SELECT句
+const aggregatedMetricsSelectQuery = `
 		SELECT id, aggregation_level, aggregation_period, target_id, target_name,
 			   period_start, period_end, total_prs, merged_prs, closed_prs,
 			   avg_cycle_time_seconds, median_cycle_time_seconds, p95_cycle_time_seconds,
@@ -409,6 +456,10 @@ func (repo *AggregatedMetricsRepository) findAggregatedMetrics(ctx context.Conte
 			   generated_at, updated_at, version, detailed_stats_json,
 			   year_month, week_of_year, day_of_year
 		FROM aggregated_metrics
+`
+
+func (repo *AggregatedMetricsRepository) findAggregatedMetrics(ctx context.Context, aggregationLevel, period, targetID string, startDate, endDate time.Time) ([]*analytics.AggregatedMetricsStorage, error) {
+	query := aggregatedMetricsSelectQuery + `
 		WHERE aggregation_level = $1 AND aggregation_period = $2
 		  AND period_start >= $3 AND period_end <= $4
 	`
@@ -424,6 +475,10 @@ func (repo *AggregatedMetricsRepository) findAggregatedMetrics(ctx context.Conte
 
 	query += " ORDER BY period_start DESC"
 
+	return repo.queryAggregatedMetrics(ctx, query, args...)
+}
+
+func (repo *AggregatedMetricsRepository) queryAggregatedMetrics(ctx context.Context, query string, args ...interface{}) ([]*analytics.AggregatedMetricsStorage, error) {
 	rows, err := repo.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query aggregated metrics: %w", err)
@@ -499,12 +554,19 @@ func (repo *AggregatedMetricsRepository) convertTeamMetricsToStorage(metrics *an
 	weekOfYear := fmt.Sprintf("%d-W%02d", year, week)
 	dayOfYear := metrics.DateRange.Start.Format("2006-002")
 
+	id := fmt.Sprintf("team_%s_%s", string(metrics.Period), periodIDSuffix(metrics.DateRange))
+	targetName := "Team"
+	if metrics.Team != "" {
+		id = fmt.Sprintf("team_%s_%s_%s", metrics.Team, string(metrics.Period), periodIDSuffix(metrics.DateRange))
+		targetName = metrics.Team
+	}
+
 	return &analytics.AggregatedMetricsStorage{
-		ID:               fmt.Sprintf("team_%s_%d", string(metrics.Period), time.Now().Unix()),
+		ID:               id,
 		AggregationLevel: "team",
 		AggregationPeriod: string(metrics.Period),
-		TargetID:         "team",
-		TargetName:       "Team",
+		TargetID:         teamTargetID(metrics.Team),
+		TargetName:       targetName,
 		PeriodStart:      metrics.DateRange.Start,
 		PeriodEnd:        metrics.DateRange.End,
 		TotalPRs:         metrics.TotalPRs,
@@ -583,7 +645,7 @@ func (repo *AggregatedMetricsRepository) convertDeveloperMetricsToStorage(metric
 	dayOfYear := metrics.DateRange.Start.Format("2006-002")
 
 	return &analytics.AggregatedMetricsStorage{
-		ID:               fmt.Sprintf("dev_%s_%s_%d", metrics.Developer, string(metrics.Period), time.Now().Unix()),
+		ID:               fmt.Sprintf("dev_%s_%s_%s", metrics.Developer, string(metrics.Period), periodIDSuffix(metrics.DateRange)),
 		AggregationLevel: "developer",
 		AggregationPeriod: string(metrics.Period),
 		TargetID:         metrics.Developer,
@@ -661,7 +723,7 @@ func (repo *AggregatedMetricsRepository) convertRepositoryMetricsToStorage(metri
 	dayOfYear := metrics.DateRange.Start.Format("2006-002")
 
 	return &analytics.AggregatedMetricsStorage{
-		ID:               fmt.Sprintf("repo_%s_%s_%d", metrics.Repository, string(metrics.Period), time.Now().Unix()),
+		ID:               fmt.Sprintf("repo_%s_%s_%s", metrics.Repository, string(metrics.Period), periodIDSuffix(metrics.DateRange)),
 		AggregationLevel: "repository",
 		AggregationPeriod: string(metrics.Period),
 		TargetID:         metrics.Repository,
@@ -719,24 +781,105 @@ func (repo *AggregatedMetricsRepository) convertRepositoryMetricsToStorage(metri
 	}, nil
 }
 
+func (repo *AggregatedMetricsRepository) convertLabelMetricsToStorage(metrics *analyticsApp.LabelMetrics) (*analytics.AggregatedMetricsStorage, error) {
+	detailedStatsJSON, err := json.Marshal(map[string]interface{}{
+		"cycleTimeStats":  metrics.CycleTimeStats,
+		"reviewStats":     metrics.ReviewStats,
+		"sizeStats":       metrics.SizeStats,
+		"qualityStats":    metrics.QualityStats,
+		"complexityStats": metrics.ComplexityStats,
+		"contributors":    metrics.Contributors,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal detailed stats: %w", err)
+	}
+
+	// 期間フィールドの生成
+	yearMonth := metrics.DateRange.Start.Format("2006-01")
+	year, week := metrics.DateRange.Start.ISOWeek()
+	weekOfYear := fmt.Sprintf("%d-W%02d", year, week)
+	dayOfYear := metrics.DateRange.Start.Format("2006-002")
+
+	return &analytics.AggregatedMetricsStorage{
+		ID:                fmt.Sprintf("label_%s_%s_%s", metrics.Label, string(metrics.Period), periodIDSuffix(metrics.DateRange)),
+		AggregationLevel:  "label",
+		AggregationPeriod: string(metrics.Period),
+		TargetID:          metrics.Label,
+		TargetName:        metrics.Label,
+		PeriodStart:       metrics.DateRange.Start,
+		PeriodEnd:         metrics.DateRange.End,
+		TotalPRs:          metrics.TotalPRs,
+		MergedPRs:         metrics.TotalPRs,
+		ClosedPRs:         0,
+
+		AvgCycleTimeSeconds:    repo.durationToSecondsPtr(metrics.CycleTimeStats.TotalCycleTime.Mean),
+		MedianCycleTimeSeconds: repo.durationToSecondsPtr(metrics.CycleTimeStats.TotalCycleTime.Median),
+		P95CycleTimeSeconds:    repo.durationToSecondsPtr(metrics.CycleTimeStats.TotalCycleTime.Percentiles.P95),
+
+		AvgReviewTimeSeconds:    repo.durationToSecondsPtr(metrics.CycleTimeStats.TimeToFirstReview.Mean),
+		MedianReviewTimeSeconds: repo.durationToSecondsPtr(metrics.CycleTimeStats.TimeToFirstReview.Median),
+		P95ReviewTimeSeconds:    repo.durationToSecondsPtr(metrics.CycleTimeStats.TimeToFirstReview.Percentiles.P95),
+
+		AvgApprovalTimeSeconds:    repo.durationToSecondsPtr(metrics.CycleTimeStats.TimeToApproval.Mean),
+		MedianApprovalTimeSeconds: repo.durationToSecondsPtr(metrics.CycleTimeStats.TimeToApproval.Median),
+		P95ApprovalTimeSeconds:    repo.durationToSecondsPtr(metrics.CycleTimeStats.TimeToApproval.Percentiles.P95),
+
+		AvgLinesChanged:    metrics.SizeStats.LinesChanged.Mean,
+		MedianLinesChanged: metrics.SizeStats.LinesChanged.Median,
+		AvgFilesChanged:    metrics.SizeStats.FilesChanged.Mean,
+		MedianFilesChanged: metrics.SizeStats.FilesChanged.Median,
+
+		AvgReviewComments: metrics.ReviewStats.CommentCount.Mean,
+		AvgReviewRounds:   metrics.ReviewStats.RoundCount.Mean,
+		FirstPassRate:     metrics.ReviewStats.FirstReviewPassRate.Mean,
+
+		AvgComplexityScore:    metrics.ComplexityStats.ComplexityScore.Mean,
+		MedianComplexityScore: metrics.ComplexityStats.ComplexityScore.Median,
+
+		PRsPerDay:   float64(metrics.TotalPRs) / repo.calculateDays(metrics.DateRange),
+		LinesPerDay: float64(metrics.SizeStats.LinesChanged.Sum) / repo.calculateDays(metrics.DateRange),
+		Throughput:  float64(metrics.TotalPRs),
+
+		// トレンド情報（ラベルメトリクスには含まれないため空）
+		CycleTimeTrend:  "stable",
+		ReviewTimeTrend: "stable",
+		QualityTrend:    "stable",
+
+		GeneratedAt: metrics.GeneratedAt,
+		UpdatedAt:   time.Now(),
+		Version:     1,
+
+		DetailedStatsJSON: string(detailedStatsJSON),
+		YearMonth:         yearMonth,
+		WeekOfYear:        weekOfYear,
+		DayOfYear:         dayOfYear,
+	}, nil
+}
+
 func (repo *AggregatedMetricsRepository) convertStorageToTeamMetrics(storage *analytics.AggregatedMetricsStorage) (*analyticsApp.TeamMetrics, error) {
-	// 詳細統計の復元
-	var detailedStats map[string]interface{}
-	if err := json.Unmarshal([]byte(storage.DetailedStatsJSON), &detailedStats); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal detailed stats: %w", err)
+	team := ""
+	if storage.TargetID != defaultTeamTargetID {
+		team = storage.TargetID
 	}
 
-	return &analyticsApp.TeamMetrics{
+	metrics := &analyticsApp.TeamMetrics{
+		Team:        team,
 		Period:      analyticsApp.AggregationPeriod(storage.AggregationPeriod),
 		TotalPRs:    storage.TotalPRs,
 		DateRange:   analyticsApp.DateRange{Start: storage.PeriodStart, End: storage.PeriodEnd},
 		GeneratedAt: storage.GeneratedAt,
-		// 詳細統計は必要に応じて復元
-	}, nil
+	}
+
+	// アーカイブ・復元で欠けないよう詳細統計も復元
+	if err := unmarshalDetailedStats(storage, metrics); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
 }
 
 func (repo *AggregatedMetricsRepository) convertStorageToDeveloperMetrics(storage *analytics.AggregatedMetricsStorage) (*analyticsApp.DeveloperMetrics, error) {
-	return &analyticsApp.DeveloperMetrics{
+	metrics := &analyticsApp.DeveloperMetrics{
 		Developer:   storage.TargetID,
 		Period:      analyticsApp.AggregationPeriod(storage.AggregationPeriod),
 		TotalPRs:    storage.TotalPRs,
@@ -747,21 +890,101 @@ func (repo *AggregatedMetricsRepository) convertStorageToDeveloperMetrics(storag
 			LinesPerDay: storage.LinesPerDay,
 			Throughput:  storage.Throughput,
 		},
-	}, nil
+	}
+
+	if err := unmarshalDetailedStats(storage, metrics); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
 }
 
 func (repo *AggregatedMetricsRepository) convertStorageToRepositoryMetrics(storage *analytics.AggregatedMetricsStorage) (*analyticsApp.RepositoryMetrics, error) {
-	return &analyticsApp.RepositoryMetrics{
+	metrics := &analyticsApp.RepositoryMetrics{
 		Repository:  storage.TargetID,
 		Period:      analyticsApp.AggregationPeriod(storage.AggregationPeriod),
 		TotalPRs:    storage.TotalPRs,
 		DateRange:   analyticsApp.DateRange{Start: storage.PeriodStart, End: storage.PeriodEnd},
 		GeneratedAt: storage.GeneratedAt,
-	}, nil
+	}
+
+	if err := unmarshalDetailedStats(storage, metrics); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+func (repo *AggregatedMetricsRepository) convertStorageToLabelMetrics(storage *analytics.AggregatedMetricsStorage) (*analyticsApp.LabelMetrics, error) {
+	metrics := &analyticsApp.LabelMetrics{
+		Label:       storage.TargetID,
+		Period:      analyticsApp.AggregationPeriod(storage.AggregationPeriod),
+		TotalPRs:    storage.TotalPRs,
+		DateRange:   analyticsApp.DateRange{Start: storage.PeriodStart, End: storage.PeriodEnd},
+		GeneratedAt: storage.GeneratedAt,
+	}
+
+	// ラベル間の比較に使うため詳細統計も復元
+	if err := unmarshalDetailedStats(storage, metrics); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+// convertStorageToRecord は集計レベルに応じて集計データを変換
+func (repo *AggregatedMetricsRepository) convertStorageToRecord(storage *analytics.AggregatedMetricsStorage) (*analyticsApp.AggregatedRecord, error) {
+	record := &analyticsApp.AggregatedRecord{Level: storage.AggregationLevel}
+	var err error
+	switch storage.AggregationLevel {
+	case analyticsApp.AggregationLevelTeam:
+		record.Team, err = repo.convertStorageToTeamMetrics(storage)
+	case analyticsApp.AggregationLevelDeveloper:
+		record.Developer, err = repo.convertStorageToDeveloperMetrics(storage)
+	case analyticsApp.AggregationLevelRepository:
+		record.Repository, err = repo.convertStorageToRepositoryMetrics(storage)
+	case analyticsApp.AggregationLevelLabel:
+		record.Label, err = repo.convertStorageToLabelMetrics(storage)
+	default:
+		return nil, fmt.Errorf("unknown aggregation level: %s", storage.AggregationLevel)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s metrics %s: %w", storage.AggregationLevel, storage.ID, err)
+	}
+	return record, nil
+}
+
+// unmarshalDetailedStats は詳細統計JSONをメトリクスへ展開する
+// 詳細統計のキーは各メトリクスのJSONタグと一致している
+func unmarshalDetailedStats(storage *analytics.AggregatedMetricsStorage, metrics interface{}) error {
+	if storage.DetailedStatsJSON == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(storage.DetailedStatsJSON), metrics); err != nil {
+		return fmt.Errorf("failed to unmarshal detailed stats: %w", err)
+	}
+	return nil
 }
 
 // ユーティリティメソッド
 
+// defaultTeamTargetID は集計対象全員を表すチーム集計のターゲットID
+const defaultTeamTargetID = "team"
+
+// teamTargetID はチーム名を集計データのターゲットIDに変換
+func teamTargetID(team string) string {
+	if team == "" {
+		return defaultTeamTargetID
+	}
+	return team
+}
+
+// periodIDSuffix は集計期間をIDの末尾に変換
+// 同じ対象の異なる期間を同時に保存してもIDが衝突しないよう、期間の開始・終了から生成する
+func periodIDSuffix(dateRange analyticsApp.DateRange) string {
+	return fmt.Sprintf("%d_%d", dateRange.Start.Unix(), dateRange.End.Unix())
+}
+
 func (repo *AggregatedMetricsRepository) durationToSecondsPtr(d time.Duration) *int64 {
 	if d == 0 {
 		return nil
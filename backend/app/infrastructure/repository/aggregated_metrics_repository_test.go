@@ -152,7 +152,7 @@ func TestAggregatedMetricsRepository_FindTeamMetrics(t *testing.T) {
 	)
 
 	mock.ExpectQuery(`SELECT .+ FROM aggregated_metrics WHERE aggregation_level = .+ AND aggregation_period = .+ AND period_start >= .+ AND period_end <= .+ ORDER BY period_start DESC`).
-		WithArgs("team", string(period), startDate, endDate).
+		WithArgs("team", string(period), startDate, endDate, "team").
 		WillReturnRows(rows)
 
 	result, err := repo.FindTeamMetrics(context.Background(), period, startDate, endDate)
@@ -394,7 +394,7 @@ func TestAggregatedMetricsRepository_EdgeCases(t *testing.T) {
 		rows := createAggregatedMetricsRows()
 
 		mock.ExpectQuery(`SELECT .+ FROM aggregated_metrics WHERE aggregation_level = .+ AND aggregation_period = .+ AND period_start >= .+ AND period_end <= .+ ORDER BY period_start DESC`).
-			WithArgs("team", "daily", startDate, endDate).
+			WithArgs("team", "daily", startDate, endDate, "team").
 			WillReturnRows(rows)
 
 		result, err := repo.FindTeamMetrics(context.Background(), analyticsApp.AggregationPeriodDaily, startDate, endDate)
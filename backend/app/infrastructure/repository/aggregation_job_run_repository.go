@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/domain/analytics"
+	"github-stats-metrics/infrastructure/database"
+)
+
+// AggregationJobRunRepository は集計ジョブの実行記録の永続化を担当するリポジトリ
+type AggregationJobRunRepository struct {
+	db *database.DB
+}
+
+var _ analyticsApp.AggregationJobRunRepository = (*AggregationJobRunRepository)(nil)
+
+// NewAggregationJobRunRepository は新しい実行記録リポジトリを作成（PostgreSQL）
+func NewAggregationJobRunRepository(db *sql.DB) *AggregationJobRunRepository {
+	return NewAggregationJobRunRepositoryWithDialect(db, database.Postgres())
+}
+
+// NewAggregationJobRunRepositoryWithDialect は指定した方言で実行記録リポジトリを作成
+func NewAggregationJobRunRepositoryWithDialect(db *sql.DB, dialect database.Dialect) *AggregationJobRunRepository {
+	return &AggregationJobRunRepository{
+		db: database.New(db, dialect),
+	}
+}
+
+const aggregationJobRunSelectQuery = `
+	SELECT id, status, started_at, finished_at, collected_since,
+	       changed_prs, periods, records, error_message
+	FROM aggregation_job_runs
+`
+
+// SaveRun は実行記録を保存
+func (repo *AggregationJobRunRepository) SaveRun(ctx context.Context, run *analyticsApp.AggregationJobRun) error {
+	storage := convertJobRunToStorage(run)
+
+	query := `
+		INSERT INTO aggregation_job_runs (
+			id, status, started_at, finished_at, collected_since,
+			changed_prs, periods, records, error_message
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := repo.db.ExecContext(ctx, query,
+		storage.ID, storage.Status, storage.StartedAt, storage.FinishedAt, storage.CollectedSince,
+		storage.ChangedPRs, storage.Periods, storage.Records, storage.ErrorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save aggregation job run: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatestSucceeded は最後に成功した実行記録を取得
+func (repo *AggregationJobRunRepository) FindLatestSucceeded(ctx context.Context) (*analyticsApp.AggregationJobRun, error) {
+	query := aggregationJobRunSelectQuery + ` WHERE status = $1 ORDER BY started_at DESC LIMIT 1`
+
+	runs, err := repo.queryJobRuns(ctx, query, analyticsApp.AggregationRunSucceeded)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+
+	return runs[0], nil
+}
+
+// FindRecentRuns は実行記録を開始日時の新しい順に取得
+func (repo *AggregationJobRunRepository) FindRecentRuns(ctx context.Context, limit int) ([]*analyticsApp.AggregationJobRun, error) {
+	query := aggregationJobRunSelectQuery + ` ORDER BY started_at DESC LIMIT $1`
+
+	return repo.queryJobRuns(ctx, query, limit)
+}
+
+func (repo *AggregationJobRunRepository) queryJobRuns(ctx context.Context, query string, args ...interface{}) ([]*analyticsApp.AggregationJobRun, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query aggregation job runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*analyticsApp.AggregationJobRun
+	for rows.Next() {
+		var storage analytics.AggregationJobRunStorage
+		if err := rows.Scan(
+			&storage.ID, &storage.Status, &storage.StartedAt, &storage.FinishedAt, &storage.CollectedSince,
+			&storage.ChangedPRs, &storage.Periods, &storage.Records, &storage.ErrorMessage,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan aggregation job run row: %w", err)
+		}
+		runs = append(runs, convertJobRunFromStorage(&storage))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate aggregation job runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func convertJobRunToStorage(run *analyticsApp.AggregationJobRun) *analytics.AggregationJobRunStorage {
+	storage := &analytics.AggregationJobRunStorage{
+		ID:             run.ID,
+		Status:         run.Status,
+		StartedAt:      run.StartedAt,
+		FinishedAt:     run.FinishedAt,
+		CollectedSince: run.CollectedSince,
+		ChangedPRs:     run.ChangedPRs,
+		Periods:        run.Periods,
+		Records:        run.Records,
+	}
+	if run.Error != "" {
+		message := run.Error
+		storage.ErrorMessage = &message
+	}
+	return storage
+}
+
+func convertJobRunFromStorage(storage *analytics.AggregationJobRunStorage) *analyticsApp.AggregationJobRun {
+	run := &analyticsApp.AggregationJobRun{
+		ID:             storage.ID,
+		Status:         storage.Status,
+		StartedAt:      storage.StartedAt,
+		FinishedAt:     storage.FinishedAt,
+		CollectedSince: storage.CollectedSince,
+		ChangedPRs:     storage.ChangedPRs,
+		Periods:        storage.Periods,
+		Records:        storage.Records,
+	}
+	if storage.ErrorMessage != nil {
+		run.Error = *storage.ErrorMessage
+	}
+	return run
+}
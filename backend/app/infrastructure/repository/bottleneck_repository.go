@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/domain/analytics"
+	"github-stats-metrics/infrastructure/database"
+)
+
+// BottleneckRepository はボトルネック履歴の永続化を担当するリポジトリ
+type BottleneckRepository struct {
+	db *database.DB
+}
+
+var _ analyticsApp.BottleneckRepository = (*BottleneckRepository)(nil)
+
+// NewBottleneckRepository は新しいボトルネック履歴リポジトリを作成（PostgreSQL）
+func NewBottleneckRepository(db *sql.DB) *BottleneckRepository {
+	return NewBottleneckRepositoryWithDialect(db, database.Postgres())
+}
+
+// NewBottleneckRepositoryWithDialect は指定した方言でボトルネック履歴リポジトリを作成
+func NewBottleneckRepositoryWithDialect(db *sql.DB, dialect database.Dialect) *BottleneckRepository {
+	return &BottleneckRepository{
+		db: database.New(db, dialect),
+	}
+}
+
+// bottleneckDataUpdateColumns はIDの衝突時に上書きする列（created_at は最初の保存時の値を残す）
+var bottleneckDataUpdateColumns = []string{
+	"type", "pr_id", "severity", "description", "value", "threshold",
+	"detected_at", "resolved_at", "status", "author", "repository", "updated_at",
+}
+
+const bottleneckDataSelectQuery = `
+	SELECT id, type, pr_id, severity, description, value, threshold,
+	       detected_at, resolved_at, status, author, repository, created_at, updated_at
+	FROM bottleneck_data
+`
+
+// SaveBottlenecks はボトルネックを保存
+func (repo *BottleneckRepository) SaveBottlenecks(ctx context.Context, records []*analyticsApp.BottleneckRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO bottleneck_data (
+			id, type, pr_id, severity, description, value, threshold,
+			detected_at, resolved_at, status, author, repository, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	` + repo.db.Dialect().UpsertClause([]string{"id"}, bottleneckDataUpdateColumns)
+
+	for _, record := range records {
+		storage := convertBottleneckToStorage(record)
+		_, err := tx.ExecContext(ctx, query,
+			storage.ID, storage.Type, storage.PRID, storage.Severity, storage.Description, storage.Value, storage.Threshold,
+			storage.DetectedAt, storage.ResolvedAt, storage.Status, storage.Author, storage.Repository,
+			storage.CreatedAt, storage.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save bottleneck %s: %w", storage.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindBottlenecksByPRIDs は指定したPRのボトルネックを取得
+func (repo *BottleneckRepository) FindBottlenecksByPRIDs(ctx context.Context, prIDs []string) ([]*analyticsApp.BottleneckRecord, error) {
+	if len(prIDs) == 0 {
+		return nil, nil
+	}
+
+	query := bottleneckDataSelectQuery + ` WHERE ` + repo.db.Dialect().AnyOf("pr_id", 1) + ` ORDER BY detected_at DESC, id`
+	return repo.queryBottlenecks(ctx, query, prIDs)
+}
+
+// FindBottlenecks は条件に合うボトルネックを最初の検出日時の新しい順に取得
+func (repo *BottleneckRepository) FindBottlenecks(ctx context.Context, filter analyticsApp.BottleneckFilter) ([]*analyticsApp.BottleneckRecord, error) {
+	query := bottleneckDataSelectQuery + ` WHERE 1 = 1`
+	var args []interface{}
+	argIndex := 1
+
+	conditions := []struct {
+		column string
+		value  interface{}
+		use    bool
+	}{
+		{"status = ", filter.Status, filter.Status != ""},
+		{"type = ", filter.Type, filter.Type != ""},
+		{"repository = ", filter.Repository, filter.Repository != ""},
+		{"detected_at >= ", filter.SeenSince, !filter.SeenSince.IsZero()},
+		{"detected_at <= ", filter.SeenUntil, !filter.SeenUntil.IsZero()},
+	}
+	for _, condition := range conditions {
+		if !condition.use {
+			continue
+		}
+		query += fmt.Sprintf(" AND %s$%d", condition.column, argIndex)
+		args = append(args, condition.value)
+		argIndex++
+	}
+	if len(filter.Authors) > 0 {
+		authors := make([]string, 0, len(filter.Authors))
+		for _, author := range filter.Authors {
+			authors = append(authors, strings.ToLower(author))
+		}
+		query += " AND " + repo.db.Dialect().AnyOf("LOWER(author)", argIndex)
+		args = append(args, authors)
+		argIndex++
+	}
+
+	query += " ORDER BY detected_at DESC, id"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+	}
+
+	return repo.queryBottlenecks(ctx, query, args...)
+}
+
+func (repo *BottleneckRepository) queryBottlenecks(ctx context.Context, query string, args ...interface{}) ([]*analyticsApp.BottleneckRecord, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bottlenecks: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*analyticsApp.BottleneckRecord
+	for rows.Next() {
+		var storage analytics.BottleneckDataStorage
+		if err := rows.Scan(
+			&storage.ID, &storage.Type, &storage.PRID, &storage.Severity, &storage.Description, &storage.Value, &storage.Threshold,
+			&storage.DetectedAt, &storage.ResolvedAt, &storage.Status, &storage.Author, &storage.Repository,
+			&storage.CreatedAt, &storage.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan bottleneck row: %w", err)
+		}
+		records = append(records, convertBottleneckFromStorage(&storage))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate bottlenecks: %w", err)
+	}
+
+	return records, nil
+}
+
+func convertBottleneckToStorage(record *analyticsApp.BottleneckRecord) *analytics.BottleneckDataStorage {
+	return &analytics.BottleneckDataStorage{
+		ID:          record.ID,
+		Type:        record.Type,
+		PRID:        record.PRID,
+		Severity:    record.Severity,
+		Description: record.Description,
+		Value:       record.Value,
+		Threshold:   record.Threshold,
+		DetectedAt:  record.FirstSeenAt,
+		ResolvedAt:  record.ResolvedAt,
+		Status:      record.Status,
+		Author:      record.Author,
+		Repository:  record.Repository,
+		CreatedAt:   record.FirstSeenAt,
+		UpdatedAt:   record.UpdatedAt,
+	}
+}
+
+func convertBottleneckFromStorage(storage *analytics.BottleneckDataStorage) *analyticsApp.BottleneckRecord {
+	return &analyticsApp.BottleneckRecord{
+		ID:          storage.ID,
+		Type:        storage.Type,
+		PRID:        storage.PRID,
+		Severity:    storage.Severity,
+		Description: storage.Description,
+		Value:       storage.Value,
+		Threshold:   storage.Threshold,
+		Author:      storage.Author,
+		Repository:  storage.Repository,
+		Status:      storage.Status,
+		FirstSeenAt: storage.DetectedAt,
+		ResolvedAt:  storage.ResolvedAt,
+		UpdatedAt:   storage.UpdatedAt,
+	}
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/infrastructure/database/migration"
+	"github-stats-metrics/infrastructure/storagetest"
+)
+
+// testBackends は共通テストを実行するデータベース
+// PostgreSQL は TEST_POSTGRES_URL が設定されている場合のみ対象にする
+func testBackends(t *testing.T) map[string]func(t *testing.T) (*sql.DB, database.Dialect) {
+	backends := map[string]func(t *testing.T) (*sql.DB, database.Dialect){
+		"sqlite": openTestSQLite,
+	}
+	if url := os.Getenv("TEST_POSTGRES_URL"); url != "" {
+		backends["postgres"] = func(t *testing.T) (*sql.DB, database.Dialect) {
+			return openTestPostgres(t, url)
+		}
+	}
+	return backends
+}
+
+// openTestPostgres はマイグレーション済みのPostgreSQLを空の状態にして返す
+func openTestPostgres(t *testing.T, url string) (*sql.DB, database.Dialect) {
+	t.Helper()
+
+	db, dialect, err := database.Open(url)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	_, err = migration.NewMigrator(db, dialect).Up(ctx)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `TRUNCATE pr_metrics, pr_metrics_ids, file_changes, review_events, aggregated_metrics, aggregation_job_runs, trend_data, bottleneck_data`)
+	require.NoError(t, err)
+
+	return db, dialect
+}
+
+func TestPRMetricsRepository_Conformance(t *testing.T) {
+	for name, open := range testBackends(t) {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			storagetest.RunPRMetricsRepositoryTests(t, func(t *testing.T) prDomain.MetricsRepository {
+				db, dialect := open(t)
+				return NewPRMetricsRepositoryWithDialect(db, dialect)
+			})
+		})
+	}
+}
+
+// TestPRMetricsRepository_PartitionedConformance はパーティション化した pr_metrics で共通テストを実行する
+// パーティション化は PostgreSQL のみのため TEST_POSTGRES_URL が設定されている場合のみ実行する
+func TestPRMetricsRepository_PartitionedConformance(t *testing.T) {
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+
+	ctx := context.Background()
+	db, dialect := openTestPostgres(t, url)
+	migrator := migration.NewMigrator(db, dialect)
+	partitioned, err := migrator.Partition(ctx)
+	require.NoError(t, err)
+	if partitioned {
+		t.Cleanup(func() {
+			_, err := migrator.Unpartition(ctx)
+			require.NoError(t, err)
+		})
+	}
+
+	storagetest.RunPRMetricsRepositoryTests(t, func(t *testing.T) prDomain.MetricsRepository {
+		db, dialect := openTestPostgres(t, url)
+		return NewPRMetricsRepositoryWithDialect(db, dialect)
+	})
+}
+
+func TestAggregatedMetricsRepository_Conformance(t *testing.T) {
+	for name, open := range testBackends(t) {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			storagetest.RunAggregatedMetricsRepositoryTests(t, func(t *testing.T) analyticsApp.AggregatedMetricsRepository {
+				db, dialect := open(t)
+				return NewAggregatedMetricsRepositoryWithDialect(db, dialect)
+			})
+		})
+	}
+}
+
+func TestAggregationJobRunRepository_Conformance(t *testing.T) {
+	for name, open := range testBackends(t) {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			storagetest.RunAggregationJobRunRepositoryTests(t, func(t *testing.T) analyticsApp.AggregationJobRunRepository {
+				db, dialect := open(t)
+				return NewAggregationJobRunRepositoryWithDialect(db, dialect)
+			})
+		})
+	}
+}
+
+func TestTrendSnapshotRepository_Conformance(t *testing.T) {
+	for name, open := range testBackends(t) {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			storagetest.RunTrendSnapshotRepositoryTests(t, func(t *testing.T) analyticsApp.TrendSnapshotRepository {
+				db, dialect := open(t)
+				return NewTrendSnapshotRepositoryWithDialect(db, dialect)
+			})
+		})
+	}
+}
+
+func TestBottleneckRepository_Conformance(t *testing.T) {
+	for name, open := range testBackends(t) {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			storagetest.RunBottleneckRepositoryTests(t, func(t *testing.T) analyticsApp.BottleneckRepository {
+				db, dialect := open(t)
+				return NewBottleneckRepositoryWithDialect(db, dialect)
+			})
+		})
+	}
+}
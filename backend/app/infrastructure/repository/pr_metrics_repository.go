@@ -9,20 +9,47 @@ import (
 
 	"github-stats-metrics/domain/analytics"
 	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/infrastructure/database"
 )
 
 // PRMetricsRepository はPRメトリクスの永続化を担当するリポジトリ
 type PRMetricsRepository struct {
-	db *sql.DB
+	db *database.DB
 }
 
-// NewPRMetricsRepository は新しいPRメトリクスリポジトリを作成
+var _ prDomain.MetricsRepository = (*PRMetricsRepository)(nil)
+
+// NewPRMetricsRepository は新しいPRメトリクスリポジトリを作成（PostgreSQL）
 func NewPRMetricsRepository(db *sql.DB) *PRMetricsRepository {
+	return NewPRMetricsRepositoryWithDialect(db, database.Postgres())
+}
+
+// NewPRMetricsRepositoryWithDialect は指定した方言でPRメトリクスリポジトリを作成
+func NewPRMetricsRepositoryWithDialect(db *sql.DB, dialect database.Dialect) *PRMetricsRepository {
 	return &PRMetricsRepository{
-		db: db,
+		db: database.New(db, dialect),
 	}
 }
 
+// prMetricsConflictColumns は同一PRの判定に使う一意キー
+// PostgreSQL ではパーティションキー（created_at）を含む一意制約しか作れないため両方を指定する。
+// created_at は pr_metrics_ids に登録した最初の保存時の値を使うため、取得のたびに作成日時の精度が違っても同じ行になる
+var prMetricsConflictColumns = []string{"pr_id", "created_at"}
+
+// claimedCreatedAt は pr_metrics_ids に登録した作成日時を $2 のPR IDで参照し、未登録の場合は $7 を使う式
+const claimedCreatedAt = `COALESCE((SELECT created_at FROM pr_metrics_ids WHERE pr_id = $2), $7)`
+
+// prMetricsUpdateColumns は同一PRの衝突時に上書きする列
+var prMetricsUpdateColumns = []string{
+	"pr_number", "title", "author", "repository", "merged_at", "collected_at",
+	"size_metrics_json", "total_cycle_time_seconds", "time_to_first_review_seconds",
+	"time_to_approval_seconds", "time_to_merge_seconds", "time_metrics_json",
+	"review_comment_count", "review_round_count", "reviewer_count", "first_review_pass_rate",
+	"quality_metrics_json", "complexity_score", "size_category",
+	"year_month", "week_of_year", "day_of_year", "labels_json", "is_bot", "definition_version",
+	"base_branch", "head_branch", "merge_commit_sha", "change_failure_json",
+}
+
 // Save はPRメトリクスを保存
 func (repo *PRMetricsRepository) Save(ctx context.Context, metrics *prDomain.PRMetrics) error {
 	storage, err := repo.convertToStorage(metrics)
@@ -40,11 +67,20 @@ func (repo *PRMetricsRepository) Save(ctx context.Context, metrics *prDomain.PRM
 		return fmt.Errorf("failed to save file changes: %w", err)
 	}
 
+	// レビューイベントの保存
+	if err := repo.saveReviewEvents(ctx, metrics.PRID, metrics.ReviewEvents); err != nil {
+		return fmt.Errorf("failed to save review events: %w", err)
+	}
+
 	return nil
 }
 
 // SaveBatch は複数のPRメトリクスを一括保存
 func (repo *PRMetricsRepository) SaveBatch(ctx context.Context, metricsList []*prDomain.PRMetrics) error {
+	if len(metricsList) == 0 {
+		return nil
+	}
+
 	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -66,6 +102,11 @@ func (repo *PRMetricsRepository) SaveBatch(ctx context.Context, metricsList []*p
 		if err := repo.saveFileChangesWithTx(ctx, tx, storage.ID, metrics.SizeMetrics.FileChanges); err != nil {
 			return fmt.Errorf("failed to save file changes: %w", err)
 		}
+
+		// レビューイベントの保存
+		if err := repo.saveReviewEventsWithTx(ctx, tx, storage, metrics.ReviewEvents); err != nil {
+			return fmt.Errorf("failed to save review events: %w", err)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -81,6 +122,9 @@ func (repo *PRMetricsRepository) FindByID(ctx context.Context, id string) (*prDo
 	if err != nil {
 		return nil, err
 	}
+	if storage == nil {
+		return nil, nil
+	}
 
 	metrics, err := repo.convertFromStorage(storage)
 	if err != nil {
@@ -98,7 +142,8 @@ func (repo *PRMetricsRepository) FindByPRID(ctx context.Context, prID string) (*
 			   time_to_approval_seconds, time_to_merge_seconds, time_metrics_json,
 			   review_comment_count, review_round_count, reviewer_count, first_review_pass_rate,
 			   quality_metrics_json, complexity_score, size_category,
-			   year_month, week_of_year, day_of_year
+			   year_month, week_of_year, day_of_year, labels_json, is_bot, definition_version,
+			   base_branch, head_branch, merge_commit_sha, change_failure_json
 		FROM pr_metrics
 		WHERE pr_id = $1
 	`
@@ -112,6 +157,8 @@ func (repo *PRMetricsRepository) FindByPRID(ctx context.Context, prID string) (*
 		&storage.ReviewCommentCount, &storage.ReviewRoundCount, &storage.ReviewerCount,
 		&storage.FirstReviewPassRate, &storage.QualityMetricsJSON, &storage.ComplexityScore,
 		&storage.SizeCategory, &storage.YearMonth, &storage.WeekOfYear, &storage.DayOfYear,
+		&storage.LabelsJSON, &storage.IsBot, &storage.DefinitionVersion,
+		&storage.BaseBranch, &storage.HeadBranch, &storage.MergeCommitSHA, &storage.ChangeFailureJSON,
 	)
 
 	if err != nil {
@@ -126,14 +173,7 @@ func (repo *PRMetricsRepository) FindByPRID(ctx context.Context, prID string) (*
 
 // FindByDateRange は日付範囲によりPRメトリクスを取得
 func (repo *PRMetricsRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time, developers []string, repositories []string) ([]*prDomain.PRMetrics, error) {
-	query := `
-		SELECT id, pr_id, pr_number, title, author, repository, created_at, merged_at, collected_at,
-			   size_metrics_json, total_cycle_time_seconds, time_to_first_review_seconds,
-			   time_to_approval_seconds, time_to_merge_seconds, time_metrics_json,
-			   review_comment_count, review_round_count, reviewer_count, first_review_pass_rate,
-			   quality_metrics_json, complexity_score, size_category,
-			   year_month, week_of_year, day_of_year
-		FROM pr_metrics
+	query := prMetricsSelectQuery + `
 		WHERE created_at >= $1 AND created_at <= $2
 	`
 
@@ -142,53 +182,24 @@ func (repo *PRMetricsRepository) FindByDateRange(ctx context.Context, startDate,
 
 	// 開発者フィルタ
 	if len(developers) > 0 {
-		query += fmt.Sprintf(" AND author = ANY($%d)", argIndex)
+		query += " AND " + repo.db.Dialect().AnyOf("author", argIndex)
 		args = append(args, developers)
 		argIndex++
 	}
 
 	// リポジトリフィルタ
 	if len(repositories) > 0 {
-		query += fmt.Sprintf(" AND repository = ANY($%d)", argIndex)
+		query += " AND " + repo.db.Dialect().AnyOf("repository", argIndex)
 		args = append(args, repositories)
 		argIndex++
 	}
 
 	query += " ORDER BY created_at DESC"
 
-	rows, err := repo.db.QueryContext(ctx, query, args...)
+	metricsList, err := repo.queryPRMetrics(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query pr metrics by date range: %w", err)
 	}
-	defer rows.Close()
-
-	var metricsList []*prDomain.PRMetrics
-	for rows.Next() {
-		var storage analytics.PRMetricsStorage
-		err := rows.Scan(
-			&storage.ID, &storage.PRID, &storage.PRNumber, &storage.Title, &storage.Author,
-			&storage.Repository, &storage.CreatedAt, &storage.MergedAt, &storage.CollectedAt,
-			&storage.SizeMetricsJSON, &storage.TotalCycleTimeSeconds, &storage.TimeToFirstReviewSeconds,
-			&storage.TimeToApprovalSeconds, &storage.TimeToMergeSeconds, &storage.TimeMetricsJSON,
-			&storage.ReviewCommentCount, &storage.ReviewRoundCount, &storage.ReviewerCount,
-			&storage.FirstReviewPassRate, &storage.QualityMetricsJSON, &storage.ComplexityScore,
-			&storage.SizeCategory, &storage.YearMonth, &storage.WeekOfYear, &storage.DayOfYear,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan pr metrics row: %w", err)
-		}
-
-		metrics, err := repo.convertFromStorage(&storage)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert from storage model: %w", err)
-		}
-
-		metricsList = append(metricsList, metrics)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to iterate pr metrics rows: %w", err)
-	}
 
 	return metricsList, nil
 }
@@ -203,7 +214,8 @@ func (repo *PRMetricsRepository) FindByRepository(ctx context.Context, repositor
 	return repo.FindByDateRange(ctx, startDate, endDate, nil, []string{repository})
 }
 
-// Update はPRメトリクスを更新
+// Update はPR IDが一致するPRメトリクスを更新
+// 作成日時は同じPRの行を特定するキーのため、最初に保存した値のまま変えない
 func (repo *PRMetricsRepository) Update(ctx context.Context, metrics *prDomain.PRMetrics) error {
 	storage, err := repo.convertToStorage(metrics)
 	if err != nil {
@@ -213,19 +225,21 @@ func (repo *PRMetricsRepository) Update(ctx context.Context, metrics *prDomain.P
 	query := `
 		UPDATE pr_metrics SET
 			pr_number = $2, title = $3, author = $4, repository = $5,
-			created_at = $6, merged_at = $7, collected_at = $8,
+			created_at = COALESCE((SELECT created_at FROM pr_metrics_ids WHERE pr_id = $1), $6), merged_at = $7, collected_at = $8,
 			size_metrics_json = $9, total_cycle_time_seconds = $10,
 			time_to_first_review_seconds = $11, time_to_approval_seconds = $12,
 			time_to_merge_seconds = $13, time_metrics_json = $14,
 			review_comment_count = $15, review_round_count = $16,
 			reviewer_count = $17, first_review_pass_rate = $18,
 			quality_metrics_json = $19, complexity_score = $20,
-			size_category = $21, year_month = $22, week_of_year = $23, day_of_year = $24
-		WHERE id = $1
+			size_category = $21, year_month = $22, week_of_year = $23, day_of_year = $24,
+			labels_json = $25, is_bot = $26, definition_version = $27,
+			base_branch = $28, head_branch = $29, merge_commit_sha = $30, change_failure_json = $31
+		WHERE pr_id = $1
 	`
 
-	_, err = repo.db.ExecContext(ctx, query,
-		storage.ID, storage.PRNumber, storage.Title, storage.Author, storage.Repository,
+	result, err := repo.db.ExecContext(ctx, query,
+		storage.PRID, storage.PRNumber, storage.Title, storage.Author, storage.Repository,
 		storage.CreatedAt, storage.MergedAt, storage.CollectedAt,
 		storage.SizeMetricsJSON, storage.TotalCycleTimeSeconds,
 		storage.TimeToFirstReviewSeconds, storage.TimeToApprovalSeconds,
@@ -234,12 +248,22 @@ func (repo *PRMetricsRepository) Update(ctx context.Context, metrics *prDomain.P
 		storage.ReviewerCount, storage.FirstReviewPassRate,
 		storage.QualityMetricsJSON, storage.ComplexityScore,
 		storage.SizeCategory, storage.YearMonth, storage.WeekOfYear, storage.DayOfYear,
+		storage.LabelsJSON, storage.IsBot, storage.DefinitionVersion,
+		storage.BaseBranch, storage.HeadBranch, storage.MergeCommitSHA, storage.ChangeFailureJSON,
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to update pr metrics: %w", err)
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", prDomain.ErrMetricsNotFound, metrics.PRID)
+	}
+
 	return nil
 }
 
@@ -260,6 +284,10 @@ func (repo *PRMetricsRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to delete review events: %w", err)
 	}
 
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pr_metrics_ids WHERE pr_id IN (SELECT pr_id FROM pr_metrics WHERE id = $1)`, id); err != nil {
+		return fmt.Errorf("failed to delete pr id: %w", err)
+	}
+
 	// メインデータの削除
 	if err := repo.deletePRMetrics(ctx, tx, id); err != nil {
 		return fmt.Errorf("failed to delete pr metrics: %w", err)
@@ -274,10 +302,59 @@ func (repo *PRMetricsRepository) Delete(ctx context.Context, id string) error {
 
 // DeleteOldData は古いデータを削除
 func (repo *PRMetricsRepository) DeleteOldData(ctx context.Context, retentionDays int) (int64, error) {
-	cutoffDate := time.Now().AddDate(0, 0, -retentionDays)
-	
-	query := `DELETE FROM pr_metrics WHERE collected_at < $1`
-	result, err := repo.db.ExecContext(ctx, query, cutoffDate)
+	return repo.DeleteCollectedBefore(ctx, time.Now().AddDate(0, 0, -retentionDays))
+}
+
+// FindCollectedBefore は収集日時が cutoff より前のPRメトリクスを取得
+func (repo *PRMetricsRepository) FindCollectedBefore(ctx context.Context, cutoff time.Time) ([]*prDomain.PRMetrics, error) {
+	query := prMetricsSelectQuery + `
+		WHERE collected_at < $1
+		ORDER BY collected_at
+	`
+
+	metricsList, err := repo.queryPRMetrics(ctx, query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pr metrics collected before cutoff: %w", err)
+	}
+
+	return metricsList, nil
+}
+
+// FindCollectedSince は収集日時が since 以降のPRメトリクスを取得
+func (repo *PRMetricsRepository) FindCollectedSince(ctx context.Context, since time.Time) ([]*prDomain.PRMetrics, error) {
+	query := prMetricsSelectQuery + `
+		WHERE collected_at >= $1
+		ORDER BY collected_at
+	`
+
+	metricsList, err := repo.queryPRMetrics(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pr metrics collected since: %w", err)
+	}
+
+	return metricsList, nil
+}
+
+// DeleteCollectedBefore は収集日時が cutoff より前のPRメトリクスを関連データとともに削除
+func (repo *PRMetricsRepository) DeleteCollectedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 関連データの削除
+	for _, table := range []string{"file_changes", "review_events"} {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE pr_metrics_id IN (SELECT id FROM pr_metrics WHERE collected_at < $1)`, table)
+		if _, err := tx.ExecContext(ctx, query, cutoff); err != nil {
+			return 0, fmt.Errorf("failed to delete old %s: %w", table, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pr_metrics_ids WHERE pr_id IN (SELECT pr_id FROM pr_metrics WHERE collected_at < $1)`, cutoff); err != nil {
+		return 0, fmt.Errorf("failed to delete old pr ids: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM pr_metrics WHERE collected_at < $1`, cutoff)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete old data: %w", err)
 	}
@@ -287,9 +364,102 @@ func (repo *PRMetricsRepository) DeleteOldData(ctx context.Context, retentionDay
 		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return rowsAffected, nil
 }
 
+// FindReviewEvents はPR IDのレビューイベントを発生日時の順に取得
+func (repo *PRMetricsRepository) FindReviewEvents(ctx context.Context, prID string) ([]prDomain.ReviewEvent, error) {
+	query := `
+		SELECT e.event_type, e.created_at, e.actor, e.reviewer, e.is_bot
+		FROM review_events e
+		JOIN pr_metrics m ON m.id = e.pr_metrics_id
+		WHERE m.pr_id = $1
+		ORDER BY e.created_at, e.id
+	`
+
+	rows, err := repo.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []prDomain.ReviewEvent{}
+	for rows.Next() {
+		var storage analytics.ReviewEventStorage
+		if err := rows.Scan(&storage.EventType, &storage.CreatedAt, &storage.Actor, &storage.Reviewer, &storage.IsBot); err != nil {
+			return nil, fmt.Errorf("failed to scan review event row: %w", err)
+		}
+
+		event := prDomain.ReviewEvent{
+			Type:      storage.EventType,
+			CreatedAt: storage.CreatedAt,
+			Actor:     storage.Actor,
+			IsBot:     storage.IsBot,
+		}
+		if storage.Reviewer != nil {
+			event.Reviewer = *storage.Reviewer
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate review event rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// FindReviewEventsByPRIDs は複数PRのレビューイベントをPR IDごとに発生日時の順に取得
+func (repo *PRMetricsRepository) FindReviewEventsByPRIDs(ctx context.Context, prIDs []string) (map[string][]prDomain.ReviewEvent, error) {
+	eventsByPR := make(map[string][]prDomain.ReviewEvent)
+	if len(prIDs) == 0 {
+		return eventsByPR, nil
+	}
+
+	query := `
+		SELECT m.pr_id, e.event_type, e.created_at, e.actor, e.reviewer, e.is_bot
+		FROM review_events e
+		JOIN pr_metrics m ON m.id = e.pr_metrics_id
+		WHERE ` + repo.db.Dialect().AnyOf("m.pr_id", 1) + `
+		ORDER BY m.pr_id, e.created_at, e.id
+	`
+
+	rows, err := repo.db.QueryContext(ctx, query, prIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID string
+		var storage analytics.ReviewEventStorage
+		if err := rows.Scan(&prID, &storage.EventType, &storage.CreatedAt, &storage.Actor, &storage.Reviewer, &storage.IsBot); err != nil {
+			return nil, fmt.Errorf("failed to scan review event row: %w", err)
+		}
+
+		event := prDomain.ReviewEvent{
+			Type:      storage.EventType,
+			CreatedAt: storage.CreatedAt,
+			Actor:     storage.Actor,
+			IsBot:     storage.IsBot,
+		}
+		if storage.Reviewer != nil {
+			event.Reviewer = *storage.Reviewer
+		}
+		eventsByPR[prID] = append(eventsByPR[prID], event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate review event rows: %w", err)
+	}
+
+	return eventsByPR, nil
+}
+
 // GetStatistics はリポジトリの統計情報を取得
 func (repo *PRMetricsRepository) GetStatistics(ctx context.Context) (*RepositoryStatistics, error) {
 	query := `
@@ -309,8 +479,8 @@ func (repo *PRMetricsRepository) GetStatistics(ctx context.Context) (*Repository
 		&stats.TotalRecords,
 		&stats.UniqueDevelopers,
 		&stats.UniqueRepositories,
-		&stats.OldestRecord,
-		&stats.NewestRecord,
+		database.ScanTime(&stats.OldestRecord),
+		database.ScanTime(&stats.NewestRecord),
 		&stats.AvgCycleTime,
 		&stats.AvgComplexity,
 	)
@@ -323,18 +493,62 @@ func (repo *PRMetricsRepository) GetStatistics(ctx context.Context) (*Repository
 }
 
 // RepositoryStatistics はリポジトリの統計情報
-type RepositoryStatistics struct {
-	TotalRecords        int64     `json:"totalRecords"`
-	UniqueDevelopers    int64     `json:"uniqueDevelopers"`
-	UniqueRepositories  int64     `json:"uniqueRepositories"`
-	OldestRecord        time.Time `json:"oldestRecord"`
-	NewestRecord        time.Time `json:"newestRecord"`
-	AvgCycleTime        *float64  `json:"avgCycleTime"`
-	AvgComplexity       *float64  `json:"avgComplexity"`
-}
+type RepositoryStatistics = prDomain.MetricsStatistics
 
 // プライベートメソッド
 
+// prMetricsSelectQuery はPRメトリクスの取得で共通のSELECT句
+const prMetricsSelectQuery = `
+		SELECT id, pr_id, pr_number, title, author, repository, created_at, merged_at, collected_at,
+			   size_metrics_json, total_cycle_time_seconds, time_to_first_review_seconds,
+			   time_to_approval_seconds, time_to_merge_seconds, time_metrics_json,
+			   review_comment_count, review_round_count, reviewer_count, first_review_pass_rate,
+			   quality_metrics_json, complexity_score, size_category,
+			   year_month, week_of_year, day_of_year, labels_json, is_bot, definition_version,
+			   base_branch, head_branch, merge_commit_sha, change_failure_json
+		FROM pr_metrics`
+
+// queryPRMetrics は prMetricsSelectQuery を元にしたクエリを実行し、ドメインモデルに変換する
+func (repo *PRMetricsRepository) queryPRMetrics(ctx context.Context, query string, args ...interface{}) ([]*prDomain.PRMetrics, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var metricsList []*prDomain.PRMetrics
+	for rows.Next() {
+		var storage analytics.PRMetricsStorage
+		err := rows.Scan(
+			&storage.ID, &storage.PRID, &storage.PRNumber, &storage.Title, &storage.Author,
+			&storage.Repository, &storage.CreatedAt, &storage.MergedAt, &storage.CollectedAt,
+			&storage.SizeMetricsJSON, &storage.TotalCycleTimeSeconds, &storage.TimeToFirstReviewSeconds,
+			&storage.TimeToApprovalSeconds, &storage.TimeToMergeSeconds, &storage.TimeMetricsJSON,
+			&storage.ReviewCommentCount, &storage.ReviewRoundCount, &storage.ReviewerCount,
+			&storage.FirstReviewPassRate, &storage.QualityMetricsJSON, &storage.ComplexityScore,
+			&storage.SizeCategory, &storage.YearMonth, &storage.WeekOfYear, &storage.DayOfYear,
+			&storage.LabelsJSON, &storage.IsBot, &storage.DefinitionVersion,
+			&storage.BaseBranch, &storage.HeadBranch, &storage.MergeCommitSHA, &storage.ChangeFailureJSON,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pr metrics row: %w", err)
+		}
+
+		metrics, err := repo.convertFromStorage(&storage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert from storage model: %w", err)
+		}
+
+		metricsList = append(metricsList, metrics)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pr metrics rows: %w", err)
+	}
+
+	return metricsList, nil
+}
+
 func (repo *PRMetricsRepository) convertToStorage(metrics *prDomain.PRMetrics) (*analytics.PRMetricsStorage, error) {
 	// サイズメトリクスをJSONに変換
 	sizeMetricsJSON, err := json.Marshal(metrics.SizeMetrics)
@@ -354,6 +568,21 @@ func (repo *PRMetricsRepository) convertToStorage(metrics *prDomain.PRMetrics) (
 		return nil, fmt.Errorf("failed to marshal quality metrics: %w", err)
 	}
 
+	// ラベルをJSONに変換
+	labels := metrics.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal labels: %w", err)
+	}
+
+	changeFailureJSON, err := json.Marshal(metrics.ChangeFailure)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal change failure signals: %w", err)
+	}
+
 	// 時間を秒に変換
 	var totalCycleTimeSeconds *int64
 	if metrics.TimeMetrics.TotalCycleTime != nil {
@@ -416,6 +645,16 @@ func (repo *PRMetricsRepository) convertToStorage(metrics *prDomain.PRMetrics) (
 		YearMonth:  yearMonth,
 		WeekOfYear: weekOfYear,
 		DayOfYear:  dayOfYear,
+
+		LabelsJSON: string(labelsJSON),
+		IsBot:      metrics.IsBot,
+
+		DefinitionVersion: metrics.DefinitionVersion,
+
+		BaseBranch:        metrics.BaseBranch,
+		HeadBranch:        metrics.HeadBranch,
+		MergeCommitSHA:    metrics.MergeCommitSHA,
+		ChangeFailureJSON: string(changeFailureJSON),
 	}, nil
 }
 
@@ -438,6 +677,22 @@ func (repo *PRMetricsRepository) convertFromStorage(storage *analytics.PRMetrics
 		return nil, fmt.Errorf("failed to unmarshal quality metrics: %w", err)
 	}
 
+	// JSONからラベルを復元（ラベル列追加前のデータは空として扱う）
+	labels := []string{}
+	if storage.LabelsJSON != "" {
+		if err := json.Unmarshal([]byte(storage.LabelsJSON), &labels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal labels: %w", err)
+		}
+	}
+
+	// JSONからリバート・ホットフィックスの判定結果を復元（列追加前のデータは判定なしとして扱う）
+	var changeFailure prDomain.ChangeFailureSignals
+	if storage.ChangeFailureJSON != "" {
+		if err := json.Unmarshal([]byte(storage.ChangeFailureJSON), &changeFailure); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal change failure signals: %w", err)
+		}
+	}
+
 	return &prDomain.PRMetrics{
 		PRID:           storage.PRID,
 		PRNumber:       storage.PRNumber,
@@ -446,15 +701,31 @@ func (repo *PRMetricsRepository) convertFromStorage(storage *analytics.PRMetrics
 		Repository:     storage.Repository,
 		CreatedAt:      storage.CreatedAt,
 		MergedAt:       storage.MergedAt,
+		Labels:         labels,
+		IsBot:          storage.IsBot,
 		SizeMetrics:    sizeMetrics,
 		TimeMetrics:    timeMetrics,
 		QualityMetrics: qualityMetrics,
 		ComplexityScore: storage.ComplexityScore,
 		SizeCategory:   storage.SizeCategory,
+		DefinitionVersion: storage.DefinitionVersion,
+		BaseBranch:     storage.BaseBranch,
+		HeadBranch:     storage.HeadBranch,
+		MergeCommitSHA: storage.MergeCommitSHA,
+		ChangeFailure:  changeFailure,
 	}, nil
 }
 
+// claimPRIDQuery はPR IDを pr_metrics_ids に登録するクエリを返す（登録済みの場合は最初の作成日時を残す）
+func (repo *PRMetricsRepository) claimPRIDQuery() string {
+	return `INSERT INTO pr_metrics_ids (pr_id, created_at) VALUES ($1, $2) ` + repo.db.Dialect().IgnoreConflictClause([]string{"pr_id"})
+}
+
 func (repo *PRMetricsRepository) savePRMetrics(ctx context.Context, storage *analytics.PRMetricsStorage) error {
+	if _, err := repo.db.ExecContext(ctx, repo.claimPRIDQuery(), storage.PRID, storage.CreatedAt); err != nil {
+		return fmt.Errorf("failed to claim pr id: %w", err)
+	}
+
 	query := `
 		INSERT INTO pr_metrics (
 			id, pr_id, pr_number, title, author, repository, created_at, merged_at, collected_at,
@@ -462,35 +733,14 @@ func (repo *PRMetricsRepository) savePRMetrics(ctx context.Context, storage *ana
 			time_to_approval_seconds, time_to_merge_seconds, time_metrics_json,
 			review_comment_count, review_round_count, reviewer_count, first_review_pass_rate,
 			quality_metrics_json, complexity_score, size_category,
-			year_month, week_of_year, day_of_year
+			year_month, week_of_year, day_of_year, labels_json, is_bot, definition_version,
+			base_branch, head_branch, merge_commit_sha, change_failure_json
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
-			$16, $17, $18, $19, $20, $21, $22, $23, $24, $25
-		) ON CONFLICT (pr_id) DO UPDATE SET
-			pr_number = EXCLUDED.pr_number,
-			title = EXCLUDED.title,
-			author = EXCLUDED.author,
-			repository = EXCLUDED.repository,
-			created_at = EXCLUDED.created_at,
-			merged_at = EXCLUDED.merged_at,
-			collected_at = EXCLUDED.collected_at,
-			size_metrics_json = EXCLUDED.size_metrics_json,
-			total_cycle_time_seconds = EXCLUDED.total_cycle_time_seconds,
-			time_to_first_review_seconds = EXCLUDED.time_to_first_review_seconds,
-			time_to_approval_seconds = EXCLUDED.time_to_approval_seconds,
-			time_to_merge_seconds = EXCLUDED.time_to_merge_seconds,
-			time_metrics_json = EXCLUDED.time_metrics_json,
-			review_comment_count = EXCLUDED.review_comment_count,
-			review_round_count = EXCLUDED.review_round_count,
-			reviewer_count = EXCLUDED.reviewer_count,
-			first_review_pass_rate = EXCLUDED.first_review_pass_rate,
-			quality_metrics_json = EXCLUDED.quality_metrics_json,
-			complexity_score = EXCLUDED.complexity_score,
-			size_category = EXCLUDED.size_category,
-			year_month = EXCLUDED.year_month,
-			week_of_year = EXCLUDED.week_of_year,
-			day_of_year = EXCLUDED.day_of_year
-	`
+			$1, $2, $3, $4, $5, $6, ` + claimedCreatedAt + `, $8, $9, $10, $11, $12, $13, $14, $15,
+			$16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28,
+			$29, $30, $31, $32
+		)
+	` + repo.db.Dialect().UpsertClause(prMetricsConflictColumns, prMetricsUpdateColumns)
 
 	_, err := repo.db.ExecContext(ctx, query,
 		storage.ID, storage.PRID, storage.PRNumber, storage.Title, storage.Author,
@@ -502,12 +752,18 @@ func (repo *PRMetricsRepository) savePRMetrics(ctx context.Context, storage *ana
 		storage.ReviewerCount, storage.FirstReviewPassRate,
 		storage.QualityMetricsJSON, storage.ComplexityScore,
 		storage.SizeCategory, storage.YearMonth, storage.WeekOfYear, storage.DayOfYear,
+		storage.LabelsJSON, storage.IsBot, storage.DefinitionVersion,
+		storage.BaseBranch, storage.HeadBranch, storage.MergeCommitSHA, storage.ChangeFailureJSON,
 	)
 
 	return err
 }
 
-func (repo *PRMetricsRepository) savePRMetricsWithTx(ctx context.Context, tx *sql.Tx, storage *analytics.PRMetricsStorage) error {
+func (repo *PRMetricsRepository) savePRMetricsWithTx(ctx context.Context, tx *database.Tx, storage *analytics.PRMetricsStorage) error {
+	if _, err := tx.ExecContext(ctx, repo.claimPRIDQuery(), storage.PRID, storage.CreatedAt); err != nil {
+		return fmt.Errorf("failed to claim pr id: %w", err)
+	}
+
 	query := `
 		INSERT INTO pr_metrics (
 			id, pr_id, pr_number, title, author, repository, created_at, merged_at, collected_at,
@@ -515,12 +771,14 @@ func (repo *PRMetricsRepository) savePRMetricsWithTx(ctx context.Context, tx *sq
 			time_to_approval_seconds, time_to_merge_seconds, time_metrics_json,
 			review_comment_count, review_round_count, reviewer_count, first_review_pass_rate,
 			quality_metrics_json, complexity_score, size_category,
-			year_month, week_of_year, day_of_year
+			year_month, week_of_year, day_of_year, labels_json, is_bot, definition_version,
+			base_branch, head_branch, merge_commit_sha, change_failure_json
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
-			$16, $17, $18, $19, $20, $21, $22, $23, $24, $25
-		) ON CONFLICT (pr_id) DO NOTHING
-	`
+			$1, $2, $3, $4, $5, $6, ` + claimedCreatedAt + `, $8, $9, $10, $11, $12, $13, $14, $15,
+			$16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28,
+			$29, $30, $31, $32
+		)
+	` + repo.db.Dialect().IgnoreConflictClause(prMetricsConflictColumns)
 
 	_, err := tx.ExecContext(ctx, query,
 		storage.ID, storage.PRID, storage.PRNumber, storage.Title, storage.Author,
@@ -532,6 +790,8 @@ func (repo *PRMetricsRepository) savePRMetricsWithTx(ctx context.Context, tx *sq
 		storage.ReviewerCount, storage.FirstReviewPassRate,
 		storage.QualityMetricsJSON, storage.ComplexityScore,
 		storage.SizeCategory, storage.YearMonth, storage.WeekOfYear, storage.DayOfYear,
+		storage.LabelsJSON, storage.IsBot, storage.DefinitionVersion,
+		storage.BaseBranch, storage.HeadBranch, storage.MergeCommitSHA, storage.ChangeFailureJSON,
 	)
 
 	return err
@@ -572,7 +832,7 @@ func (repo *PRMetricsRepository) saveFileChanges(ctx context.Context, prMetricsI
 	return nil
 }
 
-func (repo *PRMetricsRepository) saveFileChangesWithTx(ctx context.Context, tx *sql.Tx, prMetricsID string, fileChanges []prDomain.FileChangeMetrics) error {
+func (repo *PRMetricsRepository) saveFileChangesWithTx(ctx context.Context, tx *database.Tx, prMetricsID string, fileChanges []prDomain.FileChangeMetrics) error {
 	if len(fileChanges) == 0 {
 		return nil
 	}
@@ -582,8 +842,7 @@ func (repo *PRMetricsRepository) saveFileChangesWithTx(ctx context.Context, tx *
 			id, pr_metrics_id, file_name, file_type, lines_added, lines_deleted,
 			is_new_file, is_deleted, is_renamed, collected_at
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		ON CONFLICT (id) DO NOTHING
-	`
+	` + repo.db.Dialect().IgnoreConflictClause([]string{"id"})
 
 	for i, fileChange := range fileChanges {
 		id := fmt.Sprintf("%s_file_%d", prMetricsID, i)
@@ -608,7 +867,8 @@ func (repo *PRMetricsRepository) findStorageByID(ctx context.Context, id string)
 			   time_to_approval_seconds, time_to_merge_seconds, time_metrics_json,
 			   review_comment_count, review_round_count, reviewer_count, first_review_pass_rate,
 			   quality_metrics_json, complexity_score, size_category,
-			   year_month, week_of_year, day_of_year
+			   year_month, week_of_year, day_of_year, labels_json, is_bot, definition_version,
+			   base_branch, head_branch, merge_commit_sha, change_failure_json
 		FROM pr_metrics
 		WHERE id = $1
 	`
@@ -622,6 +882,8 @@ func (repo *PRMetricsRepository) findStorageByID(ctx context.Context, id string)
 		&storage.ReviewCommentCount, &storage.ReviewRoundCount, &storage.ReviewerCount,
 		&storage.FirstReviewPassRate, &storage.QualityMetricsJSON, &storage.ComplexityScore,
 		&storage.SizeCategory, &storage.YearMonth, &storage.WeekOfYear, &storage.DayOfYear,
+		&storage.LabelsJSON, &storage.IsBot, &storage.DefinitionVersion,
+		&storage.BaseBranch, &storage.HeadBranch, &storage.MergeCommitSHA, &storage.ChangeFailureJSON,
 	)
 
 	if err != nil {
@@ -634,19 +896,92 @@ func (repo *PRMetricsRepository) findStorageByID(ctx context.Context, id string)
 	return &storage, nil
 }
 
-func (repo *PRMetricsRepository) deleteFileChanges(ctx context.Context, tx *sql.Tx, prMetricsID string) error {
+func (repo *PRMetricsRepository) deleteFileChanges(ctx context.Context, tx *database.Tx, prMetricsID string) error {
 	query := `DELETE FROM file_changes WHERE pr_metrics_id = $1`
 	_, err := tx.ExecContext(ctx, query, prMetricsID)
 	return err
 }
 
-func (repo *PRMetricsRepository) deleteReviewEvents(ctx context.Context, tx *sql.Tx, prMetricsID string) error {
+func (repo *PRMetricsRepository) deleteReviewEvents(ctx context.Context, tx *database.Tx, prMetricsID string) error {
 	query := `DELETE FROM review_events WHERE pr_metrics_id = $1`
 	_, err := tx.ExecContext(ctx, query, prMetricsID)
 	return err
 }
 
-func (repo *PRMetricsRepository) deletePRMetrics(ctx context.Context, tx *sql.Tx, id string) error {
+// saveReviewEvents はPRのレビューイベントを置き換える
+// 内部IDは既存の行を引き継ぐため、保存後のPRメトリクスから取得する
+func (repo *PRMetricsRepository) saveReviewEvents(ctx context.Context, prID string, events []prDomain.ReviewEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var prMetricsID string
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM pr_metrics WHERE pr_id = $1`, prID).Scan(&prMetricsID); err != nil {
+		return fmt.Errorf("failed to find pr metrics id: %w", err)
+	}
+
+	if err := repo.deleteReviewEvents(ctx, tx, prMetricsID); err != nil {
+		return fmt.Errorf("failed to delete existing review events: %w", err)
+	}
+
+	if err := repo.insertReviewEvents(ctx, tx, prMetricsID, events); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// saveReviewEventsWithTx は新しく保存したPRメトリクスのレビューイベントを保存
+// SaveBatch は既存のPR IDを上書きしないため、既存の行のイベントもそのまま残す
+func (repo *PRMetricsRepository) saveReviewEventsWithTx(ctx context.Context, tx *database.Tx, storage *analytics.PRMetricsStorage, events []prDomain.ReviewEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var prMetricsID string
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM pr_metrics WHERE pr_id = $1`, storage.PRID).Scan(&prMetricsID); err != nil {
+		return fmt.Errorf("failed to find pr metrics id: %w", err)
+	}
+	if prMetricsID != storage.ID {
+		return nil
+	}
+
+	return repo.insertReviewEvents(ctx, tx, prMetricsID, events)
+}
+
+func (repo *PRMetricsRepository) insertReviewEvents(ctx context.Context, tx *database.Tx, prMetricsID string, events []prDomain.ReviewEvent) error {
+	insertQuery := `
+		INSERT INTO review_events (
+			id, pr_metrics_id, event_type, created_at, actor, reviewer, is_bot, collected_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	` + repo.db.Dialect().IgnoreConflictClause([]string{"id"})
+
+	collectedAt := time.Now()
+	for i, event := range events {
+		var reviewer *string
+		if event.Reviewer != "" {
+			reviewer = &event.Reviewer
+		}
+
+		_, err := tx.ExecContext(ctx, insertQuery,
+			fmt.Sprintf("%s_event_%d", prMetricsID, i), prMetricsID, event.Type,
+			event.CreatedAt, event.Actor, reviewer, event.IsBot, collectedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert review event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (repo *PRMetricsRepository) deletePRMetrics(ctx context.Context, tx *database.Tx, id string) error {
 	query := `DELETE FROM pr_metrics WHERE id = $1`
 	_, err := tx.ExecContext(ctx, query, id)
 	return err
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,8 +25,13 @@ func TestPRMetricsRepository_Save(t *testing.T) {
 	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
 	metrics := createTestPRMetricsForRepo(baseTime)
 
+	// PR IDの登録
+	mock.ExpectExec(`INSERT INTO pr_metrics_ids`).
+		WithArgs(metrics.PRID, metrics.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
 	// PRメトリクス保存のSQL期待値
-	mock.ExpectExec(`INSERT INTO pr_metrics`).
+	mock.ExpectExec(`INSERT INTO pr_metrics \(`).
 		WithArgs(
 			sqlmock.AnyArg(), metrics.PRID, metrics.PRNumber, metrics.Title, metrics.Author,
 			metrics.Repository, metrics.CreatedAt, metrics.MergedAt, sqlmock.AnyArg(),
@@ -34,7 +40,8 @@ func TestPRMetricsRepository_Save(t *testing.T) {
 			metrics.QualityMetrics.ReviewRoundCount, metrics.QualityMetrics.ReviewerCount,
 			metrics.QualityMetrics.FirstReviewPassRate, sqlmock.AnyArg(),
 			metrics.ComplexityScore, metrics.SizeCategory, sqlmock.AnyArg(),
-			sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), metrics.IsBot, metrics.DefinitionVersion,
+			metrics.BaseBranch, metrics.HeadBranch, metrics.MergeCommitSHA, sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -79,8 +86,13 @@ func TestPRMetricsRepository_SaveBatch(t *testing.T) {
 
 	// 各メトリクスに対するSQL期待値
 	for i := 0; i < len(metricsList); i++ {
+		// PR IDの登録
+		mock.ExpectExec(`INSERT INTO pr_metrics_ids`).
+			WithArgs(metricsList[i].PRID, metricsList[i].CreatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
 		// PRメトリクス挿入
-		mock.ExpectExec(`INSERT INTO pr_metrics`).
+		mock.ExpectExec(`INSERT INTO pr_metrics \(`).
 			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
@@ -88,6 +100,8 @@ func TestPRMetricsRepository_SaveBatch(t *testing.T) {
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -132,7 +146,8 @@ func TestPRMetricsRepository_FindByID(t *testing.T) {
 		"time_to_approval_seconds", "time_to_merge_seconds", "time_metrics_json",
 		"review_comment_count", "review_round_count", "reviewer_count", "first_review_pass_rate",
 		"quality_metrics_json", "complexity_score", "size_category",
-		"year_month", "week_of_year", "day_of_year",
+		"year_month", "week_of_year", "day_of_year", "labels_json", "is_bot", "definition_version",
+		"base_branch", "head_branch", "merge_commit_sha", "change_failure_json",
 	}).AddRow(
 		storage.ID, storage.PRID, storage.PRNumber, storage.Title, storage.Author,
 		storage.Repository, storage.CreatedAt, storage.MergedAt, storage.CollectedAt,
@@ -141,6 +156,8 @@ func TestPRMetricsRepository_FindByID(t *testing.T) {
 		storage.ReviewCommentCount, storage.ReviewRoundCount, storage.ReviewerCount,
 		storage.FirstReviewPassRate, storage.QualityMetricsJSON, storage.ComplexityScore,
 		storage.SizeCategory, storage.YearMonth, storage.WeekOfYear, storage.DayOfYear,
+		storage.LabelsJSON, storage.IsBot, storage.DefinitionVersion,
+		storage.BaseBranch, storage.HeadBranch, storage.MergeCommitSHA, storage.ChangeFailureJSON,
 	)
 
 	mock.ExpectQuery(`SELECT .+ FROM pr_metrics WHERE id`).
@@ -153,6 +170,7 @@ func TestPRMetricsRepository_FindByID(t *testing.T) {
 	assert.Equal(t, expectedMetrics.PRID, result.PRID)
 	assert.Equal(t, expectedMetrics.PRNumber, result.PRNumber)
 	assert.Equal(t, expectedMetrics.Title, result.Title)
+	assert.Equal(t, expectedMetrics.Labels, result.Labels)
 
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
@@ -179,7 +197,8 @@ func TestPRMetricsRepository_FindByPRID(t *testing.T) {
 		"time_to_approval_seconds", "time_to_merge_seconds", "time_metrics_json",
 		"review_comment_count", "review_round_count", "reviewer_count", "first_review_pass_rate",
 		"quality_metrics_json", "complexity_score", "size_category",
-		"year_month", "week_of_year", "day_of_year",
+		"year_month", "week_of_year", "day_of_year", "labels_json", "is_bot", "definition_version",
+		"base_branch", "head_branch", "merge_commit_sha", "change_failure_json",
 	}).AddRow(
 		storage.ID, storage.PRID, storage.PRNumber, storage.Title, storage.Author,
 		storage.Repository, storage.CreatedAt, storage.MergedAt, storage.CollectedAt,
@@ -188,6 +207,8 @@ func TestPRMetricsRepository_FindByPRID(t *testing.T) {
 		storage.ReviewCommentCount, storage.ReviewRoundCount, storage.ReviewerCount,
 		storage.FirstReviewPassRate, storage.QualityMetricsJSON, storage.ComplexityScore,
 		storage.SizeCategory, storage.YearMonth, storage.WeekOfYear, storage.DayOfYear,
+		storage.LabelsJSON, storage.IsBot, storage.DefinitionVersion,
+		storage.BaseBranch, storage.HeadBranch, storage.MergeCommitSHA, storage.ChangeFailureJSON,
 	)
 
 	mock.ExpectQuery(`SELECT .+ FROM pr_metrics WHERE pr_id`).
@@ -227,7 +248,8 @@ func TestPRMetricsRepository_FindByDateRange(t *testing.T) {
 		"time_to_approval_seconds", "time_to_merge_seconds", "time_metrics_json",
 		"review_comment_count", "review_round_count", "reviewer_count", "first_review_pass_rate",
 		"quality_metrics_json", "complexity_score", "size_category",
-		"year_month", "week_of_year", "day_of_year",
+		"year_month", "week_of_year", "day_of_year", "labels_json", "is_bot", "definition_version",
+		"base_branch", "head_branch", "merge_commit_sha", "change_failure_json",
 	}).AddRow(
 		storage.ID, storage.PRID, storage.PRNumber, storage.Title, storage.Author,
 		storage.Repository, storage.CreatedAt, storage.MergedAt, storage.CollectedAt,
@@ -236,10 +258,12 @@ func TestPRMetricsRepository_FindByDateRange(t *testing.T) {
 		storage.ReviewCommentCount, storage.ReviewRoundCount, storage.ReviewerCount,
 		storage.FirstReviewPassRate, storage.QualityMetricsJSON, storage.ComplexityScore,
 		storage.SizeCategory, storage.YearMonth, storage.WeekOfYear, storage.DayOfYear,
+		storage.LabelsJSON, storage.IsBot, storage.DefinitionVersion,
+		storage.BaseBranch, storage.HeadBranch, storage.MergeCommitSHA, storage.ChangeFailureJSON,
 	)
 
 	mock.ExpectQuery(`SELECT .+ FROM pr_metrics WHERE created_at >= .+ AND created_at <= .+ AND author = ANY.+ AND repository = ANY.+ ORDER BY created_at DESC`).
-		WithArgs(startDate, endDate, developers, repositories).
+		WithArgs(startDate, endDate, pq.Array(developers), pq.Array(repositories)).
 		WillReturnRows(rows)
 
 	result, err := repo.FindByDateRange(context.Background(), startDate, endDate, developers, repositories)
@@ -270,7 +294,8 @@ func TestPRMetricsRepository_Update(t *testing.T) {
 			metrics.QualityMetrics.ReviewRoundCount, metrics.QualityMetrics.ReviewerCount,
 			metrics.QualityMetrics.FirstReviewPassRate, sqlmock.AnyArg(),
 			metrics.ComplexityScore, metrics.SizeCategory, sqlmock.AnyArg(),
-			sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), metrics.IsBot, metrics.DefinitionVersion,
+			metrics.BaseBranch, metrics.HeadBranch, metrics.MergeCommitSHA, sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -300,6 +325,10 @@ func TestPRMetricsRepository_Delete(t *testing.T) {
 		WithArgs("test-id").
 		WillReturnResult(sqlmock.NewResult(0, 5))
 
+	mock.ExpectExec(`DELETE FROM pr_metrics_ids WHERE pr_id IN \(SELECT pr_id FROM pr_metrics WHERE id`).
+		WithArgs("test-id").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
 	// メインデータの削除
 	mock.ExpectExec(`DELETE FROM pr_metrics WHERE id`).
 		WithArgs("test-id").
@@ -324,9 +353,21 @@ func TestPRMetricsRepository_DeleteOldData(t *testing.T) {
 
 	retentionDays := 30
 	
+	// 関連データとあわせて1トランザクションで削除
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM file_changes WHERE pr_metrics_id IN \(SELECT id FROM pr_metrics WHERE collected_at <`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 25))
+	mock.ExpectExec(`DELETE FROM review_events WHERE pr_metrics_id IN \(SELECT id FROM pr_metrics WHERE collected_at <`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 40))
+	mock.ExpectExec(`DELETE FROM pr_metrics_ids WHERE pr_id IN \(SELECT pr_id FROM pr_metrics WHERE collected_at <`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 10))
 	mock.ExpectExec(`DELETE FROM pr_metrics WHERE collected_at <`).
 		WithArgs(sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
 
 	deletedCount, err := repo.DeleteOldData(context.Background(), retentionDays)
 	assert.NoError(t, err)
@@ -537,6 +578,7 @@ func createTestPRMetricsForRepo(baseTime time.Time) *prDomain.PRMetrics {
 		Repository: "test-repo",
 		CreatedAt:  baseTime,
 		MergedAt:   &mergedTime,
+		Labels:     []string{"bug", "backend"},
 		SizeMetrics: prDomain.PRSizeMetrics{
 			LinesAdded:   100,
 			LinesDeleted: 50,
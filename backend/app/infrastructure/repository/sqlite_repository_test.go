@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/infrastructure/database/migration"
+)
+
+// openTestSQLite はマイグレーション済みのインメモリSQLiteを作成
+func openTestSQLite(t *testing.T) (*sql.DB, database.Dialect) {
+	t.Helper()
+
+	db, dialect, err := database.Open("sqlite::memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = migration.NewMigrator(db, dialect).Up(context.Background())
+	require.NoError(t, err)
+
+	return db, dialect
+}
+
+func TestPRMetricsRepository_SQLite(t *testing.T) {
+	db, dialect := openTestSQLite(t)
+	repo := NewPRMetricsRepositoryWithDialect(db, dialect)
+	ctx := context.Background()
+
+	baseTime := time.Date(2024, 1, 15, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	metrics := createTestPRMetricsForRepo(baseTime)
+	require.NoError(t, repo.Save(ctx, metrics))
+
+	t.Run("PR IDで取得", func(t *testing.T) {
+		result, err := repo.FindByPRID(ctx, "pr-123")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.CreatedAt.Equal(metrics.CreatedAt))
+		require.NotNil(t, result.MergedAt)
+		assert.True(t, result.MergedAt.Equal(*metrics.MergedAt))
+		assert.Equal(t, []string{"bug", "backend"}, result.Labels)
+	})
+
+	t.Run("開発者・リポジトリで絞り込み", func(t *testing.T) {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+		result, err := repo.FindByDateRange(ctx, start, end, []string{"other", "test-user"}, []string{"test-repo"})
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+
+		result, err = repo.FindByDateRange(ctx, start, end, []string{"other"}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("同じPRの再保存は上書き", func(t *testing.T) {
+		updated := createTestPRMetricsForRepo(baseTime)
+		updated.Title = "Updated PR"
+		updated.SizeMetrics.FileChanges = nil
+		require.NoError(t, repo.Save(ctx, updated))
+
+		result, err := repo.FindByPRID(ctx, "pr-123")
+		require.NoError(t, err)
+		assert.Equal(t, "Updated PR", result.Title)
+	})
+
+	t.Run("統計情報", func(t *testing.T) {
+		stats, err := repo.GetStatistics(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats.TotalRecords)
+		assert.True(t, stats.OldestRecord.Equal(metrics.CreatedAt))
+	})
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/domain/analytics"
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/shared/utils"
+)
+
+// TrendSnapshotRepository はトレンド分析のスナップショットの永続化を担当するリポジトリ
+type TrendSnapshotRepository struct {
+	db *database.DB
+}
+
+var _ analyticsApp.TrendSnapshotRepository = (*TrendSnapshotRepository)(nil)
+
+// NewTrendSnapshotRepository は新しいトレンド分析スナップショットリポジトリを作成（PostgreSQL）
+func NewTrendSnapshotRepository(db *sql.DB) *TrendSnapshotRepository {
+	return NewTrendSnapshotRepositoryWithDialect(db, database.Postgres())
+}
+
+// NewTrendSnapshotRepositoryWithDialect は指定した方言でトレンド分析スナップショットリポジトリを作成
+func NewTrendSnapshotRepositoryWithDialect(db *sql.DB, dialect database.Dialect) *TrendSnapshotRepository {
+	return &TrendSnapshotRepository{
+		db: database.New(db, dialect),
+	}
+}
+
+// trendDataConflictColumns はトレンドデータの一意キー
+var trendDataConflictColumns = []string{
+	"metric_type", "aggregation_level", "target_id", "period_start", "period_end",
+}
+
+// trendDataUpdateColumns は一意キーの衝突時に上書きする列
+var trendDataUpdateColumns = []string{
+	"aggregation_period", "slope", "intercept", "correlation_coeff", "trend", "confidence",
+	"data_points", "start_value", "end_value", "change_percent", "generated_at", "time_series_data",
+}
+
+const trendDataSelectQuery = `
+	SELECT id, metric_type, aggregation_level, target_id, aggregation_period,
+	       period_start, period_end, slope, intercept, correlation_coeff, trend, confidence,
+	       data_points, start_value, end_value, change_percent, generated_at, time_series_data
+	FROM trend_data
+`
+
+// SaveTrendSnapshots はスナップショットを保存
+func (repo *TrendSnapshotRepository) SaveTrendSnapshots(ctx context.Context, snapshots []*analyticsApp.TrendSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO trend_data (
+			id, metric_type, aggregation_level, target_id, aggregation_period,
+			period_start, period_end, slope, intercept, correlation_coeff, trend, confidence,
+			data_points, start_value, end_value, change_percent, generated_at, time_series_data
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	` + repo.db.Dialect().UpsertClause(trendDataConflictColumns, trendDataUpdateColumns)
+
+	for _, snapshot := range snapshots {
+		storage, err := convertTrendSnapshotToStorage(snapshot)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query,
+			storage.ID, storage.MetricType, storage.AggregationLevel, storage.TargetID, storage.AggregationPeriod,
+			storage.PeriodStart, storage.PeriodEnd, storage.Slope, storage.Intercept, storage.CorrelationCoeff,
+			storage.Trend, storage.Confidence, storage.DataPoints, storage.StartValue, storage.EndValue,
+			storage.ChangePercent, storage.GeneratedAt, storage.TimeSeriesData,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save trend snapshot %s: %w", storage.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindTrendSnapshots は期間が範囲に収まるスナップショットを期間の新しい順に取得
+func (repo *TrendSnapshotRepository) FindTrendSnapshots(ctx context.Context, team string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.TrendSnapshot, error) {
+	query := trendDataSelectQuery + `
+		WHERE aggregation_level = $1 AND target_id = $2 AND aggregation_period = $3
+		  AND period_start >= $4 AND period_end <= $5
+		ORDER BY period_start DESC, metric_type
+	`
+
+	rows, err := repo.db.QueryContext(ctx, query,
+		analyticsApp.AggregationLevelTeam, teamTargetID(team), string(period), startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trend snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*analyticsApp.TrendSnapshot
+	for rows.Next() {
+		var storage analytics.TrendDataStorage
+		if err := rows.Scan(
+			&storage.ID, &storage.MetricType, &storage.AggregationLevel, &storage.TargetID, &storage.AggregationPeriod,
+			&storage.PeriodStart, &storage.PeriodEnd, &storage.Slope, &storage.Intercept, &storage.CorrelationCoeff,
+			&storage.Trend, &storage.Confidence, &storage.DataPoints, &storage.StartValue, &storage.EndValue,
+			&storage.ChangePercent, &storage.GeneratedAt, &storage.TimeSeriesData,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan trend snapshot row: %w", err)
+		}
+
+		snapshot, err := convertTrendSnapshotFromStorage(&storage, team)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate trend snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+func convertTrendSnapshotToStorage(snapshot *analyticsApp.TrendSnapshot) (*analytics.TrendDataStorage, error) {
+	series := snapshot.Series
+	if series == nil {
+		series = []analyticsApp.TrendPoint{}
+	}
+	seriesJSON, err := json.Marshal(series)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal trend series: %w", err)
+	}
+
+	targetID := teamTargetID(snapshot.Team)
+	return &analytics.TrendDataStorage{
+		ID: fmt.Sprintf("trend_%s_%s_%s_%s", snapshot.MetricType, targetID, string(snapshot.Period),
+			periodIDSuffix(snapshot.DateRange)),
+		MetricType:        snapshot.MetricType,
+		AggregationLevel:  analyticsApp.AggregationLevelTeam,
+		TargetID:          targetID,
+		AggregationPeriod: string(snapshot.Period),
+		PeriodStart:       snapshot.DateRange.Start,
+		PeriodEnd:         snapshot.DateRange.End,
+		Slope:             snapshot.Analysis.Slope,
+		Intercept:         snapshot.Analysis.Intercept,
+		CorrelationCoeff:  snapshot.Analysis.CorrelationCoeff,
+		Trend:             snapshot.Analysis.Trend,
+		Confidence:        snapshot.Analysis.Confidence,
+		DataPoints:        snapshot.DataPoints,
+		StartValue:        snapshot.StartValue,
+		EndValue:          snapshot.EndValue,
+		ChangePercent:     snapshot.ChangePercent,
+		GeneratedAt:       snapshot.GeneratedAt,
+		TimeSeriesData:    string(seriesJSON),
+	}, nil
+}
+
+func convertTrendSnapshotFromStorage(storage *analytics.TrendDataStorage, team string) (*analyticsApp.TrendSnapshot, error) {
+	var series []analyticsApp.TrendPoint
+	if err := json.Unmarshal([]byte(storage.TimeSeriesData), &series); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trend series of %s: %w", storage.ID, err)
+	}
+
+	return &analyticsApp.TrendSnapshot{
+		MetricType: storage.MetricType,
+		Team:       team,
+		Period:     analyticsApp.AggregationPeriod(storage.AggregationPeriod),
+		DateRange:  analyticsApp.DateRange{Start: storage.PeriodStart, End: storage.PeriodEnd},
+		Analysis: utils.TrendAnalysis{
+			Slope:            storage.Slope,
+			Intercept:        storage.Intercept,
+			CorrelationCoeff: storage.CorrelationCoeff,
+			Trend:            storage.Trend,
+			Confidence:       storage.Confidence,
+		},
+		DataPoints:    storage.DataPoints,
+		StartValue:    storage.StartValue,
+		EndValue:      storage.EndValue,
+		ChangePercent: storage.ChangePercent,
+		Series:        series,
+		GeneratedAt:   storage.GeneratedAt,
+	}, nil
+}
@@ -0,0 +1,266 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/domain/analytics"
+)
+
+// RunAggregatedMetricsRepositoryTests は集計メトリクスRepositoryの共通テストを実行する
+// newRepo はサブテストごとに空のRepositoryを返す必要がある
+func RunAggregatedMetricsRepositoryTests(t *testing.T, newRepo func(t *testing.T) analyticsApp.AggregatedMetricsRepository) {
+	ctx := context.Background()
+	january := analyticsApp.DateRange{
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
+	}
+	february := analyticsApp.DateRange{
+		Start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC),
+	}
+	wholeRange := analyticsApp.DateRange{Start: january.Start, End: february.End}
+	generatedAt := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("チームメトリクスを保存して取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		metrics := &analyticsApp.TeamMetrics{
+			Period:      analyticsApp.AggregationPeriodMonthly,
+			TotalPRs:    12,
+			DateRange:   january,
+			GeneratedAt: generatedAt,
+		}
+		require.NoError(t, repo.SaveTeamMetrics(ctx, metrics))
+
+		result, err := repo.FindTeamMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Empty(t, result[0].Team)
+		assert.Equal(t, analyticsApp.AggregationPeriodMonthly, result[0].Period)
+		assert.Equal(t, 12, result[0].TotalPRs)
+		assert.True(t, result[0].DateRange.Start.Equal(january.Start))
+		assert.True(t, result[0].DateRange.End.Equal(january.End))
+		assert.True(t, result[0].GeneratedAt.Equal(generatedAt))
+	})
+
+	t.Run("チーム名ごとに区別して保存する", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveTeamMetrics(ctx, newTeamMetrics("", 10, january, generatedAt)))
+		require.NoError(t, repo.SaveTeamMetrics(ctx, newTeamMetrics("platform", 4, january, generatedAt)))
+
+		all, err := repo.FindTeamMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, 10, all[0].TotalPRs)
+
+		platform, err := repo.FindTeamMetricsByName(ctx, "platform", analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Len(t, platform, 1)
+		assert.Equal(t, "platform", platform[0].Team)
+		assert.Equal(t, 4, platform[0].TotalPRs)
+	})
+
+	t.Run("同じ対象・期間の再保存は上書き", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 3, january, generatedAt)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 5, january, generatedAt)))
+
+		result, err := repo.FindDeveloperMetrics(ctx, "alice", analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, 5, result[0].TotalPRs)
+	})
+
+	t.Run("期間範囲と集計期間で絞り込み新しい順に返す", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 3, january, generatedAt)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 5, february, generatedAt)))
+		weekly := newDeveloperMetrics("alice", 1, january, generatedAt)
+		weekly.Period = analyticsApp.AggregationPeriodWeekly
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, weekly))
+
+		result, err := repo.FindDeveloperMetrics(ctx, "alice", analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, 5, result[0].TotalPRs)
+		assert.Equal(t, 3, result[1].TotalPRs)
+
+		result, err = repo.FindDeveloperMetrics(ctx, "alice", analyticsApp.AggregationPeriodMonthly, january.Start, january.End)
+		require.NoError(t, err)
+		require.Len(t, result, 1, "範囲を超える期間は含めない")
+		assert.Equal(t, 3, result[0].TotalPRs)
+	})
+
+	t.Run("全対象のメトリクスを対象ごとに取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 3, january, generatedAt)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("bob", 2, january, generatedAt)))
+		require.NoError(t, repo.SaveRepositoryMetrics(ctx, &analyticsApp.RepositoryMetrics{
+			Repository: "org/api", Period: analyticsApp.AggregationPeriodMonthly, TotalPRs: 5, DateRange: january, GeneratedAt: generatedAt,
+		}))
+		for i, dateRange := range []analyticsApp.DateRange{january, february} {
+			require.NoError(t, repo.SaveLabelMetrics(ctx, &analyticsApp.LabelMetrics{
+				Label: "bug", Period: analyticsApp.AggregationPeriodMonthly, TotalPRs: 2 + i, DateRange: dateRange, GeneratedAt: generatedAt,
+			}))
+		}
+
+		developers, err := repo.FindAllDeveloperMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Len(t, developers, 2)
+		assert.Equal(t, 3, developers["alice"].TotalPRs)
+		assert.Equal(t, 2, developers["bob"].TotalPRs)
+
+		repositories, err := repo.FindAllRepositoryMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Contains(t, repositories, "org/api")
+		assert.Equal(t, 5, repositories["org/api"].TotalPRs)
+
+		labels, err := repo.FindAllLabelMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		require.Len(t, labels, 2, "期間ごとのデータを上書きしない")
+		januaryKey := analyticsApp.LabelMetricsKey{Label: "bug", PeriodStart: january.Start.UTC()}
+		februaryKey := analyticsApp.LabelMetricsKey{Label: "bug", PeriodStart: february.Start.UTC()}
+		require.Contains(t, labels, januaryKey)
+		require.Contains(t, labels, februaryKey)
+		assert.Equal(t, 2, labels[januaryKey].TotalPRs)
+		assert.Equal(t, 3, labels[februaryKey].TotalPRs)
+
+		repository, err := repo.FindRepositoryMetrics(ctx, "org/api", analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		assert.Len(t, repository, 1)
+	})
+
+	t.Run("データがない場合は空の結果を返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		team, err := repo.FindTeamMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		assert.Empty(t, team)
+
+		developers, err := repo.FindAllDeveloperMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		assert.NotNil(t, developers)
+		assert.Empty(t, developers)
+	})
+
+	t.Run("保持期間を過ぎた集計データを期間別に削除する", func(t *testing.T) {
+		repo := newRepo(t)
+		old := generatedAt.AddDate(0, 0, -400)
+
+		oldDaily := newDeveloperMetrics("alice", 1, january, old)
+		oldDaily.Period = analyticsApp.AggregationPeriodDaily
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, oldDaily))
+		recentDaily := newDeveloperMetrics("bob", 1, january, generatedAt)
+		recentDaily.Period = analyticsApp.AggregationPeriodDaily
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, recentDaily))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 1, january, old)))
+
+		deleted, err := repo.DeleteOldAggregatedData(ctx, analytics.DataRetentionPolicy{
+			DailyAggregationRetentionDays:   365,
+			MonthlyAggregationRetentionDays: 1095,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		daily, err := repo.FindAllDeveloperMetrics(ctx, analyticsApp.AggregationPeriodDaily, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		assert.Len(t, daily, 1)
+		assert.Contains(t, daily, "bob")
+
+		monthly, err := repo.FindDeveloperMetrics(ctx, "alice", analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		assert.Len(t, monthly, 1, "保持期間内の月次データは残す")
+	})
+
+	t.Run("生成日時が基準より前の集計データを全集計レベル分取得・削除する", func(t *testing.T) {
+		repo := newRepo(t)
+		old := generatedAt.AddDate(0, 0, -400)
+		cutoff := generatedAt.AddDate(0, 0, -365)
+
+		require.NoError(t, repo.SaveTeamMetrics(ctx, newTeamMetrics("", 10, january, old)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 3, january, old)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("bob", 2, january, generatedAt)))
+		oldDaily := newDeveloperMetrics("carol", 1, january, old)
+		oldDaily.Period = analyticsApp.AggregationPeriodDaily
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, oldDaily))
+
+		records, err := repo.FindGeneratedBefore(ctx, analyticsApp.AggregationPeriodMonthly, cutoff)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		levels := map[string]*analyticsApp.AggregatedRecord{}
+		for _, record := range records {
+			levels[record.Level] = record
+		}
+		require.NotNil(t, levels[analyticsApp.AggregationLevelTeam])
+		require.NotNil(t, levels[analyticsApp.AggregationLevelTeam].Team)
+		assert.Equal(t, 10, levels[analyticsApp.AggregationLevelTeam].Team.TotalPRs)
+		require.NotNil(t, levels[analyticsApp.AggregationLevelDeveloper])
+		require.NotNil(t, levels[analyticsApp.AggregationLevelDeveloper].Developer)
+		assert.Equal(t, "alice", levels[analyticsApp.AggregationLevelDeveloper].Developer.Developer)
+		assert.True(t, levels[analyticsApp.AggregationLevelDeveloper].Developer.GeneratedAt.Equal(old))
+
+		deleted, err := repo.DeleteGeneratedBefore(ctx, analyticsApp.AggregationPeriodMonthly, cutoff)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		monthly, err := repo.FindAllDeveloperMetrics(ctx, analyticsApp.AggregationPeriodMonthly, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		assert.Len(t, monthly, 1)
+		assert.Contains(t, monthly, "bob")
+
+		daily, err := repo.FindAllDeveloperMetrics(ctx, analyticsApp.AggregationPeriodDaily, wholeRange.Start, wholeRange.End)
+		require.NoError(t, err)
+		assert.Contains(t, daily, "carol", "他の集計期間は削除しない")
+	})
+
+	t.Run("集計レベル・期間別の統計情報", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveTeamMetrics(ctx, newTeamMetrics("", 10, january, generatedAt)))
+		require.NoError(t, repo.SaveTeamMetrics(ctx, newTeamMetrics("", 8, february, generatedAt)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 3, january, generatedAt)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("alice", 5, february, generatedAt)))
+		require.NoError(t, repo.SaveDeveloperMetrics(ctx, newDeveloperMetrics("bob", 2, january, generatedAt)))
+
+		stats, err := repo.GetAggregatedStatistics(ctx)
+		require.NoError(t, err)
+
+		require.Contains(t, stats.LevelStats, "team")
+		team := stats.LevelStats["team"].PeriodStats["monthly"]
+		require.NotNil(t, team)
+		assert.Equal(t, int64(2), team.RecordCount)
+		assert.Equal(t, int64(1), team.UniqueTargets)
+		assert.True(t, team.OldestPeriod.Equal(january.Start), "oldest: %v", team.OldestPeriod)
+		assert.True(t, team.NewestPeriod.Equal(february.End), "newest: %v", team.NewestPeriod)
+
+		require.Contains(t, stats.LevelStats, "developer")
+		developer := stats.LevelStats["developer"].PeriodStats["monthly"]
+		require.NotNil(t, developer)
+		assert.Equal(t, int64(3), developer.RecordCount)
+		assert.Equal(t, int64(2), developer.UniqueTargets)
+	})
+}
+
+func newTeamMetrics(team string, totalPRs int, dateRange analyticsApp.DateRange, generatedAt time.Time) *analyticsApp.TeamMetrics {
+	return &analyticsApp.TeamMetrics{
+		Team:        team,
+		Period:      analyticsApp.AggregationPeriodMonthly,
+		TotalPRs:    totalPRs,
+		DateRange:   dateRange,
+		GeneratedAt: generatedAt,
+	}
+}
+
+func newDeveloperMetrics(developer string, totalPRs int, dateRange analyticsApp.DateRange, generatedAt time.Time) *analyticsApp.DeveloperMetrics {
+	return &analyticsApp.DeveloperMetrics{
+		Developer:   developer,
+		Period:      analyticsApp.AggregationPeriodMonthly,
+		TotalPRs:    totalPRs,
+		DateRange:   dateRange,
+		GeneratedAt: generatedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+)
+
+// RunAggregationJobRunRepositoryTests は集計ジョブ実行記録Repositoryの共通テストを実行する
+// newRepo はサブテストごとに空のRepositoryを返す必要がある
+func RunAggregationJobRunRepositoryTests(t *testing.T, newRepo func(t *testing.T) analyticsApp.AggregationJobRunRepository) {
+	ctx := context.Background()
+	baseTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("実行記録を保存して新しい順に取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		since := baseTime.Add(-time.Hour)
+		require.NoError(t, repo.SaveRun(ctx, newJobRun("run-1", analyticsApp.AggregationRunSucceeded, baseTime)))
+		second := newJobRun("run-2", analyticsApp.AggregationRunFailed, baseTime.Add(time.Hour))
+		second.CollectedSince = &since
+		second.Error = "aggregation failed"
+		require.NoError(t, repo.SaveRun(ctx, second))
+
+		runs, err := repo.FindRecentRuns(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Equal(t, "run-2", runs[0].ID)
+		assert.Equal(t, analyticsApp.AggregationRunFailed, runs[0].Status)
+		assert.Equal(t, "aggregation failed", runs[0].Error)
+		require.NotNil(t, runs[0].CollectedSince)
+		assert.True(t, runs[0].CollectedSince.Equal(since))
+		assert.Equal(t, "run-1", runs[1].ID)
+		assert.Nil(t, runs[1].CollectedSince)
+		assert.Empty(t, runs[1].Error)
+		assert.Equal(t, 3, runs[1].ChangedPRs)
+		assert.Equal(t, 2, runs[1].Periods)
+		assert.Equal(t, 5, runs[1].Records)
+		assert.True(t, runs[1].StartedAt.Equal(baseTime))
+		assert.True(t, runs[1].FinishedAt.Equal(baseTime.Add(time.Minute)))
+
+		limited, err := repo.FindRecentRuns(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		assert.Equal(t, "run-2", limited[0].ID)
+	})
+
+	t.Run("最後に成功した実行記録を取得する", func(t *testing.T) {
+		repo := newRepo(t)
+
+		latest, err := repo.FindLatestSucceeded(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, latest)
+
+		require.NoError(t, repo.SaveRun(ctx, newJobRun("run-1", analyticsApp.AggregationRunSucceeded, baseTime)))
+		require.NoError(t, repo.SaveRun(ctx, newJobRun("run-2", analyticsApp.AggregationRunSucceeded, baseTime.Add(time.Hour))))
+		require.NoError(t, repo.SaveRun(ctx, newJobRun("run-3", analyticsApp.AggregationRunFailed, baseTime.Add(2*time.Hour))))
+
+		latest, err = repo.FindLatestSucceeded(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, "run-2", latest.ID)
+	})
+}
+
+func newJobRun(id, status string, startedAt time.Time) *analyticsApp.AggregationJobRun {
+	return &analyticsApp.AggregationJobRun{
+		ID:         id,
+		Status:     status,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(time.Minute),
+		ChangedPRs: 3,
+		Periods:    2,
+		Records:    5,
+	}
+}
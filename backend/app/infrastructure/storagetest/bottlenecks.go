@@ -0,0 +1,141 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+)
+
+// RunBottleneckRepositoryTests はボトルネック履歴Repositoryの共通テストを実行する
+// newRepo はサブテストごとに空のRepositoryを返す必要がある
+func RunBottleneckRepositoryTests(t *testing.T, newRepo func(t *testing.T) analyticsApp.BottleneckRepository) {
+	ctx := context.Background()
+	baseTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("PR IDで状態を問わず取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		resolved := newBottleneckRecord("large_pr", "pr-1", "org/api", baseTime)
+		resolvedAt := baseTime.Add(24 * time.Hour)
+		resolved.Status = analyticsApp.BottleneckStatusResolved
+		resolved.ResolvedAt = &resolvedAt
+		require.NoError(t, repo.SaveBottlenecks(ctx, []*analyticsApp.BottleneckRecord{
+			newBottleneckRecord("long_cycle_time", "pr-1", "org/api", baseTime),
+			resolved,
+			newBottleneckRecord("large_pr", "pr-2", "org/api", baseTime),
+		}))
+
+		records, err := repo.FindBottlenecksByPRIDs(ctx, []string{"pr-1"})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		byID := make(map[string]*analyticsApp.BottleneckRecord)
+		for _, record := range records {
+			byID[record.ID] = record
+		}
+		got := byID[analyticsApp.BottleneckRecordID("large_pr", "pr-1")]
+		require.NotNil(t, got)
+		assert.Equal(t, analyticsApp.BottleneckStatusResolved, got.Status)
+		require.NotNil(t, got.ResolvedAt)
+		assert.True(t, got.ResolvedAt.Equal(resolvedAt))
+		assert.True(t, got.FirstSeenAt.Equal(baseTime))
+		assert.Equal(t, "alice", got.Author)
+		assert.Equal(t, "org/api", got.Repository)
+		assert.Equal(t, "medium", got.Severity)
+		assert.Equal(t, 450.0, got.Value)
+		assert.Equal(t, 300.0, got.Threshold)
+
+		active := byID[analyticsApp.BottleneckRecordID("long_cycle_time", "pr-1")]
+		require.NotNil(t, active)
+		assert.Nil(t, active.ResolvedAt)
+
+		records, err = repo.FindBottlenecksByPRIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("同じIDのボトルネックは上書きされる", func(t *testing.T) {
+		repo := newRepo(t)
+		record := newBottleneckRecord("large_pr", "pr-1", "org/api", baseTime)
+		require.NoError(t, repo.SaveBottlenecks(ctx, []*analyticsApp.BottleneckRecord{record}))
+
+		resolvedAt := baseTime.Add(time.Hour)
+		record.Status = analyticsApp.BottleneckStatusResolved
+		record.ResolvedAt = &resolvedAt
+		record.UpdatedAt = resolvedAt
+		require.NoError(t, repo.SaveBottlenecks(ctx, []*analyticsApp.BottleneckRecord{record}))
+
+		records, err := repo.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, analyticsApp.BottleneckStatusResolved, records[0].Status)
+		assert.True(t, records[0].UpdatedAt.Equal(resolvedAt))
+	})
+
+	t.Run("条件で絞り込み最初の検出日時の新しい順に取得する", func(t *testing.T) {
+		repo := newRepo(t)
+		resolved := newBottleneckRecord("large_pr", "pr-3", "org/web", baseTime.Add(2*time.Hour))
+		resolved.Status = analyticsApp.BottleneckStatusResolved
+		byBob := newBottleneckRecord("long_cycle_time", "pr-2", "org/api", baseTime.Add(time.Hour))
+		byBob.Author = "bob"
+		require.NoError(t, repo.SaveBottlenecks(ctx, []*analyticsApp.BottleneckRecord{
+			newBottleneckRecord("large_pr", "pr-1", "org/api", baseTime),
+			byBob,
+			resolved,
+		}))
+
+		all, err := repo.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{})
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, "pr-3", all[0].PRID)
+		assert.Equal(t, "pr-1", all[2].PRID)
+
+		active, err := repo.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{Status: analyticsApp.BottleneckStatusActive})
+		require.NoError(t, err)
+		assert.Len(t, active, 2)
+
+		byType, err := repo.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{Type: "large_pr", Repository: "org/api"})
+		require.NoError(t, err)
+		require.Len(t, byType, 1)
+		assert.Equal(t, "pr-1", byType[0].PRID)
+
+		byAuthor, err := repo.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{Authors: []string{"Bob", "carol"}})
+		require.NoError(t, err)
+		require.Len(t, byAuthor, 1)
+		assert.Equal(t, "pr-2", byAuthor[0].PRID)
+
+		seen, err := repo.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{
+			SeenSince: baseTime.Add(30 * time.Minute),
+			SeenUntil: baseTime.Add(90 * time.Minute),
+		})
+		require.NoError(t, err)
+		require.Len(t, seen, 1)
+		assert.Equal(t, "pr-2", seen[0].PRID)
+
+		limited, err := repo.FindBottlenecks(ctx, analyticsApp.BottleneckFilter{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		assert.Equal(t, "pr-3", limited[0].PRID)
+	})
+}
+
+func newBottleneckRecord(bottleneckType, prID, repository string, firstSeenAt time.Time) *analyticsApp.BottleneckRecord {
+	return &analyticsApp.BottleneckRecord{
+		ID:          analyticsApp.BottleneckRecordID(bottleneckType, prID),
+		Type:        bottleneckType,
+		PRID:        prID,
+		Severity:    "medium",
+		Description: "大きなPR（450行の変更）",
+		Value:       450,
+		Threshold:   300,
+		Author:      "alice",
+		Repository:  repository,
+		Status:      analyticsApp.BottleneckStatusActive,
+		FirstSeenAt: firstSeenAt,
+		UpdatedAt:   firstSeenAt,
+	}
+}
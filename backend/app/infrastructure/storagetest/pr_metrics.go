@@ -0,0 +1,449 @@
+// Package storagetest はメトリクス保存先の実装が共通の振る舞いを満たすかを検証するテストスイート
+// 各バックエンドのテストから呼び出し、メモリ・SQLite・PostgreSQL で同じ結果になることを確認する
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// RunPRMetricsRepositoryTests はPRメトリクスRepositoryの共通テストを実行する
+// newRepo はサブテストごとに空のRepositoryを返す必要がある
+func RunPRMetricsRepositoryTests(t *testing.T, newRepo func(t *testing.T) prDomain.MetricsRepository) {
+	ctx := context.Background()
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("保存したPRをPR IDで取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		metrics := newPRMetrics("pr-1", "alice", "org/api", base)
+		require.NoError(t, repo.Save(ctx, metrics))
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assertSamePRMetrics(t, metrics, result)
+	})
+
+	t.Run("存在しないPR IDは nil を返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		result, err := repo.FindByPRID(ctx, "missing")
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("同じPR IDの再保存は上書き", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-1", "alice", "org/api", base)))
+
+		updated := newPRMetrics("pr-1", "alice", "org/api", base)
+		updated.Title = "updated"
+		require.NoError(t, repo.Save(ctx, updated))
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, "updated", result.Title)
+
+		stats, err := repo.GetStatistics(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats.TotalRecords)
+	})
+
+	t.Run("作成日時の精度が違う同じPRの再保存も上書き", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-1", "alice", "org/api", base)))
+
+		updated := newPRMetrics("pr-1", "alice", "org/api", base.Add(500*time.Millisecond))
+		updated.Title = "updated"
+		require.NoError(t, repo.Save(ctx, updated))
+		require.NoError(t, repo.SaveBatch(ctx, []*prDomain.PRMetrics{newPRMetrics("pr-1", "alice", "org/api", base.Add(time.Second))}))
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, "updated", result.Title)
+
+		stats, err := repo.GetStatistics(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats.TotalRecords)
+	})
+
+	t.Run("取得結果を変更しても保存済みデータは変わらない", func(t *testing.T) {
+		repo := newRepo(t)
+		metrics := newPRMetrics("pr-1", "alice", "org/api", base)
+		require.NoError(t, repo.Save(ctx, metrics))
+		metrics.Labels[0] = "changed"
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		result.Title = "changed"
+
+		again, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, "PR pr-1", again.Title)
+		assert.Equal(t, []string{"bug", "backend"}, again.Labels)
+	})
+
+	t.Run("一括保存は既存のPRを上書きしない", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-1", "alice", "org/api", base)))
+		require.NoError(t, repo.SaveBatch(ctx, nil))
+
+		existing := newPRMetrics("pr-1", "alice", "org/api", base)
+		existing.Title = "from batch"
+		require.NoError(t, repo.SaveBatch(ctx, []*prDomain.PRMetrics{
+			existing,
+			newPRMetrics("pr-2", "bob", "org/api", base.Add(time.Hour)),
+		}))
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, "PR pr-1", result.Title)
+
+		result, err = repo.FindByPRID(ctx, "pr-2")
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("レビューイベントを保存して発生日時の順に取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		metrics := newPRMetrics("pr-1", "alice", "org/api", base)
+		metrics.ReviewEvents = []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeApproved, CreatedAt: base.Add(3 * time.Hour), Actor: "reviewer", Reviewer: "reviewer"},
+			{Type: prDomain.ReviewEventTypeRequested, CreatedAt: base.Add(time.Hour), Actor: "alice", Reviewer: "reviewer"},
+			{Type: prDomain.ReviewEventTypeCommented, CreatedAt: base.Add(2 * time.Hour), Actor: "lint[bot]", IsBot: true},
+		}
+		require.NoError(t, repo.Save(ctx, metrics))
+
+		events, err := repo.FindReviewEvents(ctx, "pr-1")
+		require.NoError(t, err)
+		assertSameReviewEvents(t, []prDomain.ReviewEvent{
+			metrics.ReviewEvents[1], metrics.ReviewEvents[2], metrics.ReviewEvents[0],
+		}, events)
+
+		// イベントはPRメトリクスの取得結果には含めない
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Empty(t, result.ReviewEvents)
+
+		events, err = repo.FindReviewEvents(ctx, "missing")
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("複数PRのレビューイベントをPR IDごとにまとめて取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		first := newPRMetrics("pr-1", "alice", "org/api", base)
+		first.ReviewEvents = []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeApproved, CreatedAt: base.Add(2 * time.Hour), Actor: "reviewer", Reviewer: "reviewer"},
+			{Type: prDomain.ReviewEventTypeRequested, CreatedAt: base.Add(time.Hour), Actor: "alice", Reviewer: "reviewer"},
+		}
+		second := newPRMetrics("pr-2", "bob", "org/api", base)
+		second.ReviewEvents = []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeCommented, CreatedAt: base.Add(time.Hour), Actor: "carol", Reviewer: "carol"},
+		}
+		withoutEvents := newPRMetrics("pr-3", "bob", "org/api", base)
+		require.NoError(t, repo.SaveBatch(ctx, []*prDomain.PRMetrics{first, second, withoutEvents}))
+
+		events, err := repo.FindReviewEventsByPRIDs(ctx, []string{"pr-1", "pr-3", "missing"})
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+		assertSameReviewEvents(t, []prDomain.ReviewEvent{first.ReviewEvents[1], first.ReviewEvents[0]}, events["pr-1"])
+
+		events, err = repo.FindReviewEventsByPRIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("再保存はレビューイベントを置き換え、一括保存は既存のイベントを残す", func(t *testing.T) {
+		repo := newRepo(t)
+		first := newPRMetrics("pr-1", "alice", "org/api", base)
+		first.ReviewEvents = []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeRequested, CreatedAt: base.Add(time.Hour), Actor: "alice", Reviewer: "reviewer"},
+			{Type: prDomain.ReviewEventTypeCommented, CreatedAt: base.Add(2 * time.Hour), Actor: "reviewer", Reviewer: "reviewer"},
+		}
+		require.NoError(t, repo.Save(ctx, first))
+
+		second := newPRMetrics("pr-1", "alice", "org/api", base)
+		second.ReviewEvents = []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeApproved, CreatedAt: base.Add(4 * time.Hour), Actor: "reviewer", Reviewer: "reviewer"},
+		}
+		require.NoError(t, repo.Save(ctx, second))
+
+		batch := newPRMetrics("pr-1", "alice", "org/api", base)
+		batch.ReviewEvents = []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeDismissed, CreatedAt: base.Add(5 * time.Hour), Actor: "reviewer"},
+		}
+		require.NoError(t, repo.SaveBatch(ctx, []*prDomain.PRMetrics{batch}))
+
+		events, err := repo.FindReviewEvents(ctx, "pr-1")
+		require.NoError(t, err)
+		assertSameReviewEvents(t, second.ReviewEvents, events)
+	})
+
+	t.Run("日付範囲・開発者・リポジトリで絞り込み新しい順に返す", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveBatch(ctx, []*prDomain.PRMetrics{
+			newPRMetrics("pr-1", "alice", "org/api", base),
+			newPRMetrics("pr-2", "bob", "org/api", base.Add(24*time.Hour)),
+			newPRMetrics("pr-3", "alice", "org/web", base.Add(48*time.Hour)),
+			newPRMetrics("pr-4", "alice", "org/api", base.Add(30*24*time.Hour)),
+		}))
+		end := base.Add(48 * time.Hour)
+
+		result, err := repo.FindByDateRange(ctx, base, end, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-3", "pr-2", "pr-1"}, prIDs(result), "範囲の境界を含む")
+
+		result, err = repo.FindByDateRange(ctx, base, end, []string{"alice", "carol"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-3", "pr-1"}, prIDs(result))
+
+		result, err = repo.FindByDateRange(ctx, base, end, []string{"alice"}, []string{"org/api"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, prIDs(result))
+
+		result, err = repo.FindByDeveloper(ctx, "bob", base, end)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-2"}, prIDs(result))
+
+		result, err = repo.FindByRepository(ctx, "org/web", base, end)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-3"}, prIDs(result))
+
+		result, err = repo.FindByDateRange(ctx, base, end, []string{"nobody"}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("更新はPR IDで対象を特定する", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-1", "alice", "org/api", base)))
+
+		updated := newPRMetrics("pr-1", "alice", "org/api", base)
+		updated.ComplexityScore = 9.5
+		updated.DefinitionVersion = "ba9876543210"
+		require.NoError(t, repo.Update(ctx, updated))
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, 9.5, result.ComplexityScore)
+		assert.Equal(t, "ba9876543210", result.DefinitionVersion)
+	})
+
+	t.Run("存在しないPRの更新は ErrMetricsNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		err := repo.Update(ctx, newPRMetrics("missing", "alice", "org/api", base))
+		assert.True(t, errors.Is(err, prDomain.ErrMetricsNotFound), "got %v", err)
+	})
+
+	t.Run("保持期間内のデータは削除しない", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-1", "alice", "org/api", base)))
+
+		deleted, err := repo.DeleteOldData(ctx, 30)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("収集日時が基準の前・以降のPRを取得し、前のPRを削除する", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-1", "alice", "org/api", base)))
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-2", "bob", "org/api", base)))
+
+		past := time.Now().Add(-time.Hour)
+		none, err := repo.FindCollectedBefore(ctx, past)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+
+		recent, err := repo.FindCollectedSince(ctx, past)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pr-1", "pr-2"}, prIDs(recent))
+
+		deleted, err := repo.DeleteCollectedBefore(ctx, past)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		future := time.Now().Add(time.Hour)
+		expiring, err := repo.FindCollectedBefore(ctx, future)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pr-1", "pr-2"}, prIDs(expiring))
+
+		none, err = repo.FindCollectedSince(ctx, future)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+
+		deleted, err = repo.DeleteCollectedBefore(ctx, future)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("削除したPRは別の作成日時で保存し直せる", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Save(ctx, newPRMetrics("pr-1", "alice", "org/api", base)))
+
+		deleted, err := repo.DeleteCollectedBefore(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		recreated := newPRMetrics("pr-1", "alice", "org/api", base.Add(time.Hour))
+		require.NoError(t, repo.Save(ctx, recreated))
+
+		result, err := repo.FindByPRID(ctx, "pr-1")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, base.Add(time.Hour).Equal(result.CreatedAt), "got %v", result.CreatedAt)
+	})
+
+	t.Run("データがない場合の統計情報", func(t *testing.T) {
+		repo := newRepo(t)
+
+		stats, err := repo.GetStatistics(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, stats.TotalRecords)
+		assert.True(t, stats.OldestRecord.IsZero())
+		assert.Nil(t, stats.AvgCycleTime)
+		assert.Nil(t, stats.AvgComplexity)
+	})
+
+	t.Run("統計情報", func(t *testing.T) {
+		repo := newRepo(t)
+		noCycleTime := newPRMetrics("pr-3", "bob", "org/web", base.Add(48*time.Hour))
+		noCycleTime.TimeMetrics.TotalCycleTime = nil
+		noCycleTime.ComplexityScore = 5
+		require.NoError(t, repo.SaveBatch(ctx, []*prDomain.PRMetrics{
+			newPRMetrics("pr-1", "alice", "org/api", base),
+			newPRMetrics("pr-2", "alice", "org/api", base.Add(24*time.Hour)),
+			noCycleTime,
+		}))
+
+		stats, err := repo.GetStatistics(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), stats.TotalRecords)
+		assert.Equal(t, int64(2), stats.UniqueDevelopers)
+		assert.Equal(t, int64(2), stats.UniqueRepositories)
+		assert.True(t, stats.OldestRecord.Equal(base), "oldest: %v", stats.OldestRecord)
+		assert.True(t, stats.NewestRecord.Equal(base.Add(48*time.Hour)), "newest: %v", stats.NewestRecord)
+		require.NotNil(t, stats.AvgCycleTime)
+		assert.InDelta(t, (6 * time.Hour).Seconds(), *stats.AvgCycleTime, 0.001, "サイクルタイムがないPRは平均に含めない")
+		require.NotNil(t, stats.AvgComplexity)
+		assert.InDelta(t, 3.0, *stats.AvgComplexity, 0.001)
+	})
+}
+
+// newPRMetrics はテスト用のPRメトリクスを作成する
+func newPRMetrics(prID, author, repository string, createdAt time.Time) *prDomain.PRMetrics {
+	mergedAt := createdAt.Add(6 * time.Hour)
+	cycleTime := 6 * time.Hour
+	firstReview := 90 * time.Minute
+
+	return &prDomain.PRMetrics{
+		PRID:       prID,
+		PRNumber:   42,
+		Title:      "PR " + prID,
+		Author:     author,
+		Repository: repository,
+		CreatedAt:  createdAt,
+		MergedAt:   &mergedAt,
+		Labels:     []string{"bug", "backend"},
+		BaseBranch:     "main",
+		HeadBranch:     "revert-41-feature",
+		MergeCommitSHA: "0a1b2c3d4e5f",
+		ChangeFailure: prDomain.ChangeFailureSignals{
+			Type:             prDomain.ChangeTypeRevert,
+			Signals:          []string{"branch"},
+			RevertedPRNumber: 41,
+		},
+		SizeMetrics: prDomain.PRSizeMetrics{
+			LinesAdded:   80,
+			LinesDeleted: 20,
+			LinesChanged: 100,
+			FilesChanged: 3,
+			FileChanges: []prDomain.FileChangeMetrics{
+				{FileName: "main.go", FileType: "go", LinesAdded: 80, LinesDeleted: 20},
+			},
+		},
+		TimeMetrics: prDomain.PRTimeMetrics{
+			TotalCycleTime:    &cycleTime,
+			TimeToFirstReview: &firstReview,
+			CreatedHour:       createdAt.Hour(),
+		},
+		QualityMetrics: prDomain.PRQualityMetrics{
+			ReviewCommentCount:  4,
+			ReviewRoundCount:    2,
+			ReviewerCount:       1,
+			ReviewersInvolved:   []string{"reviewer"},
+			FirstReviewPassRate: 0.5,
+		},
+		ComplexityScore:   2,
+		SizeCategory:      prDomain.PRSizeSmall,
+		DefinitionVersion: "0123456789ab",
+	}
+}
+
+// assertSamePRMetrics はどのバックエンドでも保存・復元される項目を比較する
+func assertSamePRMetrics(t *testing.T, expected, actual *prDomain.PRMetrics) {
+	t.Helper()
+
+	assert.Equal(t, expected.PRID, actual.PRID)
+	assert.Equal(t, expected.PRNumber, actual.PRNumber)
+	assert.Equal(t, expected.Title, actual.Title)
+	assert.Equal(t, expected.Author, actual.Author)
+	assert.Equal(t, expected.Repository, actual.Repository)
+	assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt), "createdAt: %v", actual.CreatedAt)
+	if assert.NotNil(t, actual.MergedAt) {
+		assert.True(t, expected.MergedAt.Equal(*actual.MergedAt), "mergedAt: %v", *actual.MergedAt)
+	}
+	assert.Equal(t, expected.Labels, actual.Labels)
+	assert.Equal(t, expected.IsBot, actual.IsBot)
+	assert.Equal(t, expected.SizeMetrics.LinesChanged, actual.SizeMetrics.LinesChanged)
+	assert.Equal(t, expected.SizeMetrics.FileChanges, actual.SizeMetrics.FileChanges)
+	assert.Equal(t, expected.TimeMetrics.TotalCycleTime, actual.TimeMetrics.TotalCycleTime)
+	assert.Equal(t, expected.TimeMetrics.TimeToFirstReview, actual.TimeMetrics.TimeToFirstReview)
+	assert.Equal(t, expected.QualityMetrics.ReviewCommentCount, actual.QualityMetrics.ReviewCommentCount)
+	assert.Equal(t, expected.QualityMetrics.ReviewersInvolved, actual.QualityMetrics.ReviewersInvolved)
+	assert.Equal(t, expected.ComplexityScore, actual.ComplexityScore)
+	assert.Equal(t, expected.SizeCategory, actual.SizeCategory)
+	assert.Equal(t, expected.DefinitionVersion, actual.DefinitionVersion)
+	assert.Equal(t, expected.BaseBranch, actual.BaseBranch)
+	assert.Equal(t, expected.HeadBranch, actual.HeadBranch)
+	assert.Equal(t, expected.MergeCommitSHA, actual.MergeCommitSHA)
+	assert.Equal(t, expected.ChangeFailure, actual.ChangeFailure)
+}
+
+// assertSameReviewEvents はレビューイベントを順序を含めて比較する
+func assertSameReviewEvents(t *testing.T, expected, actual []prDomain.ReviewEvent) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].Type, actual[i].Type)
+		assert.True(t, expected[i].CreatedAt.Equal(actual[i].CreatedAt), "createdAt: %v", actual[i].CreatedAt)
+		assert.Equal(t, expected[i].Actor, actual[i].Actor)
+		assert.Equal(t, expected[i].Reviewer, actual[i].Reviewer)
+		assert.Equal(t, expected[i].IsBot, actual[i].IsBot)
+	}
+}
+
+func prIDs(metricsList []*prDomain.PRMetrics) []string {
+	ids := make([]string, 0, len(metricsList))
+	for _, metrics := range metricsList {
+		ids = append(ids, metrics.PRID)
+	}
+	return ids
+}
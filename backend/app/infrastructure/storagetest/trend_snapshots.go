@@ -0,0 +1,112 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/shared/utils"
+)
+
+// RunTrendSnapshotRepositoryTests はトレンド分析スナップショットRepositoryの共通テストを実行する
+// newRepo はサブテストごとに空のRepositoryを返す必要がある
+func RunTrendSnapshotRepositoryTests(t *testing.T, newRepo func(t *testing.T) analyticsApp.TrendSnapshotRepository) {
+	ctx := context.Background()
+	firstWeek := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	secondWeek := firstWeek.AddDate(0, 0, 7)
+	rangeStart, rangeEnd := firstWeek, secondWeek.AddDate(0, 0, 7)
+
+	t.Run("保存したスナップショットを期間の新しい順に取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveTrendSnapshots(ctx, []*analyticsApp.TrendSnapshot{
+			newTrendSnapshot(analyticsApp.TrendMetricCycleTime, "", firstWeek),
+			newTrendSnapshot(analyticsApp.TrendMetricCycleTime, "", secondWeek),
+			newTrendSnapshot(analyticsApp.TrendMetricQuality, "", secondWeek),
+		}))
+
+		snapshots, err := repo.FindTrendSnapshots(ctx, "", analyticsApp.AggregationPeriodWeekly, rangeStart, rangeEnd)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 3)
+		assert.True(t, snapshots[0].DateRange.Start.Equal(secondWeek))
+		assert.True(t, snapshots[1].DateRange.Start.Equal(secondWeek))
+		assert.True(t, snapshots[2].DateRange.Start.Equal(firstWeek))
+
+		got := snapshots[2]
+		assert.Equal(t, analyticsApp.TrendMetricCycleTime, got.MetricType)
+		assert.Equal(t, analyticsApp.AggregationPeriodWeekly, got.Period)
+		assert.True(t, got.DateRange.End.Equal(firstWeek.AddDate(0, 0, 7)))
+		assert.Equal(t, "increasing", got.Analysis.Trend)
+		assert.InDelta(t, 1.5, got.Analysis.Slope, 1e-9)
+		assert.InDelta(t, 0.9, got.Analysis.Confidence, 1e-9)
+		assert.Equal(t, 2, got.DataPoints)
+		assert.InDelta(t, 50.0, got.ChangePercent, 1e-9)
+		assert.Equal(t, []analyticsApp.TrendPoint{{Date: "2024-03-04", Value: 10}, {Date: "2024-03-05", Value: 15}}, got.Series)
+		assert.True(t, got.GeneratedAt.Equal(firstWeek.AddDate(0, 0, 7)))
+	})
+
+	t.Run("同じメトリクス・チーム・期間のスナップショットは上書きされる", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.SaveTrendSnapshots(ctx, []*analyticsApp.TrendSnapshot{
+			newTrendSnapshot(analyticsApp.TrendMetricCycleTime, "", firstWeek),
+		}))
+		updated := newTrendSnapshot(analyticsApp.TrendMetricCycleTime, "", firstWeek)
+		updated.Analysis.Trend = "stable"
+		require.NoError(t, repo.SaveTrendSnapshots(ctx, []*analyticsApp.TrendSnapshot{updated}))
+
+		snapshots, err := repo.FindTrendSnapshots(ctx, "", analyticsApp.AggregationPeriodWeekly, rangeStart, rangeEnd)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, "stable", snapshots[0].Analysis.Trend)
+	})
+
+	t.Run("チーム・集計期間・範囲で絞り込む", func(t *testing.T) {
+		repo := newRepo(t)
+		monthly := newTrendSnapshot(analyticsApp.TrendMetricCycleTime, "", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		monthly.Period = analyticsApp.AggregationPeriodMonthly
+		monthly.DateRange.End = time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repo.SaveTrendSnapshots(ctx, []*analyticsApp.TrendSnapshot{
+			newTrendSnapshot(analyticsApp.TrendMetricCycleTime, "", firstWeek),
+			newTrendSnapshot(analyticsApp.TrendMetricCycleTime, "backend", firstWeek),
+			monthly,
+		}))
+
+		snapshots, err := repo.FindTrendSnapshots(ctx, "backend", analyticsApp.AggregationPeriodWeekly, rangeStart, rangeEnd)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, "backend", snapshots[0].Team)
+
+		snapshots, err = repo.FindTrendSnapshots(ctx, "", analyticsApp.AggregationPeriodWeekly, secondWeek, rangeEnd)
+		require.NoError(t, err)
+		assert.Empty(t, snapshots, "範囲からはみ出す期間は含まない")
+	})
+}
+
+func newTrendSnapshot(metricType, team string, start time.Time) *analyticsApp.TrendSnapshot {
+	end := start.AddDate(0, 0, 7)
+	return &analyticsApp.TrendSnapshot{
+		MetricType: metricType,
+		Team:       team,
+		Period:     analyticsApp.AggregationPeriodWeekly,
+		DateRange:  analyticsApp.DateRange{Start: start, End: end},
+		Analysis: utils.TrendAnalysis{
+			Slope:            1.5,
+			Intercept:        10,
+			CorrelationCoeff: 0.9,
+			Trend:            "increasing",
+			Confidence:       0.9,
+		},
+		DataPoints:    2,
+		StartValue:    10,
+		EndValue:      15,
+		ChangePercent: 50,
+		Series: []analyticsApp.TrendPoint{
+			{Date: start.Format("2006-01-02"), Value: 10},
+			{Date: start.AddDate(0, 0, 1).Format("2006-01-02"), Value: 15},
+		},
+		GeneratedAt: end,
+	}
+}
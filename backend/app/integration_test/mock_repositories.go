@@ -8,7 +8,6 @@ import (
 	analyticsApp "github-stats-metrics/application/analytics"
 	"github-stats-metrics/domain/analytics"
 	prDomain "github-stats-metrics/domain/pull_request"
-	"github-stats-metrics/infrastructure/repository"
 )
 
 // MockPRMetricsRepository はPRメトリクスリポジトリのモック
@@ -16,15 +15,17 @@ type MockPRMetricsRepository struct {
 	prMetrics      map[string]*prDomain.PRMetrics
 	dateRangeData  []*prDomain.PRMetrics
 	error          error
-	statistics     *repository.RepositoryStatistics
+	statistics     *prDomain.MetricsStatistics
 }
 
+var _ prDomain.MetricsRepository = (*MockPRMetricsRepository)(nil)
+
 // NewMockPRMetricsRepository は新しいモックリポジトリを作成
 func NewMockPRMetricsRepository() *MockPRMetricsRepository {
 	return &MockPRMetricsRepository{
 		prMetrics:     make(map[string]*prDomain.PRMetrics),
 		dateRangeData: make([]*prDomain.PRMetrics, 0),
-		statistics: &repository.RepositoryStatistics{
+		statistics: &prDomain.MetricsStatistics{
 			TotalRecords:       100,
 			UniqueDevelopers:   10,
 			UniqueRepositories: 5,
@@ -130,7 +131,7 @@ func (m *MockPRMetricsRepository) FindByRepository(ctx context.Context, reposito
 }
 
 // GetStatistics はリポジトリの統計情報を取得
-func (m *MockPRMetricsRepository) GetStatistics(ctx context.Context) (*repository.RepositoryStatistics, error) {
+func (m *MockPRMetricsRepository) GetStatistics(ctx context.Context) (*prDomain.MetricsStatistics, error) {
 	if m.error != nil {
 		return nil, m.error
 	}
@@ -158,6 +159,32 @@ func (m *MockPRMetricsRepository) DeleteOldData(ctx context.Context, retentionDa
 	return 0, fmt.Errorf("not implemented")
 }
 
+func (m *MockPRMetricsRepository) FindCollectedBefore(ctx context.Context, cutoff time.Time) ([]*prDomain.PRMetrics, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *MockPRMetricsRepository) FindCollectedSince(ctx context.Context, since time.Time) ([]*prDomain.PRMetrics, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *MockPRMetricsRepository) DeleteCollectedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return 0, fmt.Errorf("not implemented")
+}
+
+func (m *MockPRMetricsRepository) FindReviewEvents(ctx context.Context, prID string) ([]prDomain.ReviewEvent, error) {
+	if m.error != nil {
+		return nil, m.error
+	}
+	return []prDomain.ReviewEvent{}, nil
+}
+
+func (m *MockPRMetricsRepository) FindReviewEventsByPRIDs(ctx context.Context, prIDs []string) (map[string][]prDomain.ReviewEvent, error) {
+	if m.error != nil {
+		return nil, m.error
+	}
+	return map[string][]prDomain.ReviewEvent{}, nil
+}
+
 // MockAggregatedMetricsRepository は集計メトリクスリポジトリのモック
 type MockAggregatedMetricsRepository struct {
 	teamMetrics       []*analyticsApp.TeamMetrics
@@ -166,9 +193,11 @@ type MockAggregatedMetricsRepository struct {
 	allDevMetrics     map[string]*analyticsApp.DeveloperMetrics
 	allRepoMetrics    map[string]*analyticsApp.RepositoryMetrics
 	error             error
-	statistics        *repository.AggregatedRepositoryStatistics
+	statistics        *analyticsApp.AggregatedStatistics
 }
 
+var _ analyticsApp.AggregatedMetricsRepository = (*MockAggregatedMetricsRepository)(nil)
+
 // NewMockAggregatedMetricsRepository は新しいモック集計リポジトリを作成
 func NewMockAggregatedMetricsRepository() *MockAggregatedMetricsRepository {
 	return &MockAggregatedMetricsRepository{
@@ -177,10 +206,10 @@ func NewMockAggregatedMetricsRepository() *MockAggregatedMetricsRepository {
 		repositoryMetrics: make(map[string][]*analyticsApp.RepositoryMetrics),
 		allDevMetrics:     make(map[string]*analyticsApp.DeveloperMetrics),
 		allRepoMetrics:    make(map[string]*analyticsApp.RepositoryMetrics),
-		statistics: &repository.AggregatedRepositoryStatistics{
-			LevelStats: map[string]*repository.AggregationLevelStats{
+		statistics: &analyticsApp.AggregatedStatistics{
+			LevelStats: map[string]*analyticsApp.AggregationLevelStats{
 				"team": {
-					PeriodStats: map[string]*repository.PeriodStats{
+					PeriodStats: map[string]*analyticsApp.PeriodStats{
 						"monthly": {
 							RecordCount:   12,
 							UniqueTargets: 1,
@@ -228,6 +257,11 @@ func (m *MockAggregatedMetricsRepository) FindTeamMetrics(ctx context.Context, p
 	return m.teamMetrics, nil
 }
 
+// FindTeamMetricsByName はチームメトリクスを取得（チーム名は区別しない）
+func (m *MockAggregatedMetricsRepository) FindTeamMetricsByName(ctx context.Context, team string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.TeamMetrics, error) {
+	return m.FindTeamMetrics(ctx, period, startDate, endDate)
+}
+
 // FindDeveloperMetrics は開発者メトリクスを取得
 func (m *MockAggregatedMetricsRepository) FindDeveloperMetrics(ctx context.Context, developer string, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) ([]*analyticsApp.DeveloperMetrics, error) {
 	if m.error != nil {
@@ -260,6 +294,14 @@ func (m *MockAggregatedMetricsRepository) FindAllRepositoryMetrics(ctx context.C
 	return m.allRepoMetrics, nil
 }
 
+// FindAllLabelMetrics は全ラベルのメトリクスを取得
+func (m *MockAggregatedMetricsRepository) FindAllLabelMetrics(ctx context.Context, period analyticsApp.AggregationPeriod, startDate, endDate time.Time) (map[analyticsApp.LabelMetricsKey]*analyticsApp.LabelMetrics, error) {
+	if m.error != nil {
+		return nil, m.error
+	}
+	return map[analyticsApp.LabelMetricsKey]*analyticsApp.LabelMetrics{}, nil
+}
+
 // FindLatestMetrics は最新の集計メトリクスを取得
 func (m *MockAggregatedMetricsRepository) FindLatestMetrics(ctx context.Context, aggregationLevel, targetID string, period analyticsApp.AggregationPeriod) (*analytics.AggregatedMetricsStorage, error) {
 	if m.error != nil {
@@ -269,7 +311,7 @@ func (m *MockAggregatedMetricsRepository) FindLatestMetrics(ctx context.Context,
 }
 
 // GetAggregatedStatistics は集計データの統計情報を取得
-func (m *MockAggregatedMetricsRepository) GetAggregatedStatistics(ctx context.Context) (*repository.AggregatedRepositoryStatistics, error) {
+func (m *MockAggregatedMetricsRepository) GetAggregatedStatistics(ctx context.Context) (*analyticsApp.AggregatedStatistics, error) {
 	if m.error != nil {
 		return nil, m.error
 	}
@@ -289,10 +331,22 @@ func (m *MockAggregatedMetricsRepository) SaveRepositoryMetrics(ctx context.Cont
 	return fmt.Errorf("not implemented")
 }
 
+func (m *MockAggregatedMetricsRepository) SaveLabelMetrics(ctx context.Context, metrics *analyticsApp.LabelMetrics) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (m *MockAggregatedMetricsRepository) DeleteOldAggregatedData(ctx context.Context, retentionPolicy analytics.DataRetentionPolicy) (int64, error) {
 	return 0, fmt.Errorf("not implemented")
 }
 
+func (m *MockAggregatedMetricsRepository) FindGeneratedBefore(ctx context.Context, period analyticsApp.AggregationPeriod, cutoff time.Time) ([]*analyticsApp.AggregatedRecord, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *MockAggregatedMetricsRepository) DeleteGeneratedBefore(ctx context.Context, period analyticsApp.AggregationPeriod, cutoff time.Time) (int64, error) {
+	return 0, fmt.Errorf("not implemented")
+}
+
 // ヘルパー関数
 func floatPtr(f float64) *float64 {
 	return &f
@@ -0,0 +1,124 @@
+package aggregation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	aggregationApp "github-stats-metrics/application/aggregation"
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/infrastructure/database"
+)
+
+const (
+	defaultRunsLimit = 20
+	maxRunsLimit     = 100
+)
+
+// AggregationHandler は事前集計ジョブの管理APIのハンドラー
+type AggregationHandler struct {
+	service *aggregationApp.Service
+}
+
+// NewAggregationHandler は新しい事前集計ハンドラーを作成
+func NewAggregationHandler(service *aggregationApp.Service) *AggregationHandler {
+	return &AggregationHandler{service: service}
+}
+
+// ListRuns は集計ジョブの実行記録を新しい順に返す
+func (h *AggregationHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	limit := defaultRunsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxRunsLimit {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", fmt.Sprintf("limit は1から%dの整数で指定してください", maxRunsLimit), limitStr)
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.service.RecentRuns(r.Context(), limit)
+	if err != nil {
+		log.Printf("Failed to list aggregation runs: %v", err)
+		h.writeDatabaseError(w, err, "集計ジョブの実行記録の取得に失敗しました")
+		return
+	}
+	if runs == nil {
+		runs = []*analyticsApp.AggregationJobRun{}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, JobRunListResponse{
+		Runs:       runs,
+		TotalCount: len(runs),
+	})
+}
+
+// Run は集計ジョブを即時に実行する（full=true の場合はすべての期間を再集計する）
+func (h *AggregationHandler) Run(w http.ResponseWriter, r *http.Request) {
+	full := false
+	if fullStr := r.URL.Query().Get("full"); fullStr != "" {
+		parsed, err := strconv.ParseBool(fullStr)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "full は true または false で指定してください", fullStr)
+			return
+		}
+		full = parsed
+	}
+
+	run, err := h.service.Run(r.Context(), full)
+	if err != nil {
+		log.Printf("Failed to run aggregation: %v", err)
+		h.writeDatabaseError(w, err, "集計ジョブの実行に失敗しました")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, run)
+}
+
+// writeDatabaseError はデータベースエラーをレスポンスに変換する
+// 接続できない場合は一時的な障害として 503 を返す
+func (h *AggregationHandler) writeDatabaseError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, database.ErrNotConfigured):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_NOT_CONFIGURED", "データベースが設定されていません", nil)
+	case database.IsTimeout(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_TIMEOUT", "データベースの応答がタイムアウトしました", nil)
+	case database.IsUnavailable(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "データベースに接続できません", nil)
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", message, nil)
+	}
+}
+
+func (h *AggregationHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+	}
+}
+
+func (h *AggregationHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
+	errorResponse := ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
+
+// RegisterRoutes はルートを登録
+func (h *AggregationHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/admin/aggregation/runs", h.ListRuns).Methods("GET")
+	router.HandleFunc("/api/admin/aggregation/run", h.Run).Methods("POST")
+}
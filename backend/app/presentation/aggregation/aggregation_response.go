@@ -0,0 +1,19 @@
+package aggregation
+
+import (
+	analyticsApp "github-stats-metrics/application/analytics"
+)
+
+// JobRunListResponse は集計ジョブの実行記録一覧のレスポンス
+type JobRunListResponse struct {
+	Runs       []*analyticsApp.AggregationJobRun `json:"runs"`
+	TotalCount int                               `json:"totalCount"`
+}
+
+// ErrorResponse はエラーレスポンス
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
@@ -3,9 +3,12 @@ package analytics
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,24 +16,75 @@ import (
 	"github.com/gorilla/mux"
 
 	analyticsApp "github-stats-metrics/application/analytics"
-	"github-stats-metrics/infrastructure/repository"
+	analyticsDomain "github-stats-metrics/domain/analytics"
+	developerDomain "github-stats-metrics/domain/developer"
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/infrastructure/database"
 )
 
+// ボトルネック履歴の取得件数
+const (
+	defaultBottleneckLimit = 100
+	maxBottleneckLimit     = 500
+)
+
+// 知識の分散の集計範囲
+const (
+	defaultKnowledgeWindowDays = 90
+	maxKnowledgeWindowDays     = 365
+	maxKnowledgeDirectoryDepth = 10
+
+	// knowledgeCreationLookback は集計範囲より前に作成されたPRを取得する期間
+	// 作成からマージまでこれより長くかかったPRは集計に含まれない
+	knowledgeCreationLookback = 30 * 24 * time.Hour
+)
+
+// wipCreationLookback は集計範囲より前に作成されたPRを取得する期間
+// 作成からマージまでこれより長くかかったPRは集計範囲の開始時点のWIPに含まれない
+const wipCreationLookback = 90 * 24 * time.Hour
+
+// PartitionReporter はPRメトリクスのパーティション状態の取得元
+type PartitionReporter interface {
+	ListPartitions(ctx context.Context) ([]analyticsDomain.PartitionInfo, error)
+}
+
 // AnalyticsHandler は集計データのHTTPハンドラー
 type AnalyticsHandler struct {
-	aggregatedRepo    *repository.AggregatedMetricsRepository
+	aggregatedRepo    analyticsApp.AggregatedMetricsRepository
+	trends            analyticsApp.TrendSnapshotRepository
+	bottlenecks       analyticsApp.BottleneckRepository
+	prMetricsRepo     prDomain.MetricsRepository
 	metricsAggregator *analyticsApp.MetricsAggregator
+	teams             *teamDomain.Roster
+	identities        *developerDomain.IdentityRegistry
+	partitions        PartitionReporter
 	presenter         *AnalyticsPresenter
 }
 
 // NewAnalyticsHandler は新しい集計データハンドラーを作成
+// teams が指定されている場合、team パラメータでチーム別の集計データを取得できる
+// identities が指定されている場合、チームの絞り込みはメンバーの別アカウントも同一人物として扱う
+// partitions が指定されている場合、ヘルスチェックにパーティションのサイズを含める
 func NewAnalyticsHandler(
-	aggregatedRepo *repository.AggregatedMetricsRepository,
+	aggregatedRepo analyticsApp.AggregatedMetricsRepository,
+	trends analyticsApp.TrendSnapshotRepository,
+	bottlenecks analyticsApp.BottleneckRepository,
+	prMetricsRepo prDomain.MetricsRepository,
 	metricsAggregator *analyticsApp.MetricsAggregator,
+	teams *teamDomain.Roster,
+	identities *developerDomain.IdentityRegistry,
+	partitions PartitionReporter,
 ) *AnalyticsHandler {
 	return &AnalyticsHandler{
 		aggregatedRepo:    aggregatedRepo,
+		trends:            trends,
+		bottlenecks:       bottlenecks,
+		prMetricsRepo:     prMetricsRepo,
 		metricsAggregator: metricsAggregator,
+		teams:             teams,
+		identities:        identities,
+		partitions:        partitions,
 		presenter:         NewAnalyticsPresenter(),
 	}
 }
@@ -45,12 +99,16 @@ func (h *AnalyticsHandler) GetTeamMetrics(w http.ResponseWriter, r *http.Request
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
 		return
 	}
+	if params.hasLabelFilter() {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
 
 	// チームメトリクスを取得
-	metricsList, err := h.aggregatedRepo.FindTeamMetrics(ctx, params.Period, params.StartDate, params.EndDate)
+	metricsList, err := h.aggregatedRepo.FindTeamMetricsByName(ctx, params.Team, params.Period, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get team metrics: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "チームメトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "チームメトリクスの取得に失敗しました")
 		return
 	}
 
@@ -66,6 +124,7 @@ func (h *AnalyticsHandler) GetTeamMetrics(w http.ResponseWriter, r *http.Request
 }
 
 // GetDeveloperMetrics は開発者メトリクスを取得
+// team を指定した場合、集計期間中にそのチームに所属していなかった期間のデータは返さない
 func (h *AnalyticsHandler) GetDeveloperMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -82,14 +141,22 @@ func (h *AnalyticsHandler) GetDeveloperMetrics(w http.ResponseWriter, r *http.Re
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
 		return
 	}
+	if params.hasLabelFilter() {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
 
 	// 開発者メトリクスを取得
 	metricsList, err := h.aggregatedRepo.FindDeveloperMetrics(ctx, developer, params.Period, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get developer metrics: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "開発者メトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "開発者メトリクスの取得に失敗しました")
 		return
 	}
+	// チームを指定した場合は、集計期間中にチームに所属していた期間のデータのみ
+	if params.Team != "" {
+		metricsList = h.filterDeveloperMetricsByTeam(params.Team, metricsList)
+	}
 
 	if len(metricsList) == 0 {
 		h.writeErrorResponse(w, http.StatusNotFound, "NO_DATA", "指定された開発者のデータが見つかりません", nil)
@@ -103,6 +170,7 @@ func (h *AnalyticsHandler) GetDeveloperMetrics(w http.ResponseWriter, r *http.Re
 }
 
 // GetRepositoryMetrics はリポジトリメトリクスを取得
+// リポジトリ別の集計データはチームで分けていないため team は指定できない
 func (h *AnalyticsHandler) GetRepositoryMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -119,12 +187,21 @@ func (h *AnalyticsHandler) GetRepositoryMetrics(w http.ResponseWriter, r *http.R
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
 		return
 	}
+	if params.hasLabelFilter() {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
+
+	if params.Team != "" {
+		h.writeFilterNotSupported(w, "team", params.Team)
+		return
+	}
 
 	// リポジトリメトリクスを取得
 	metricsList, err := h.aggregatedRepo.FindRepositoryMetrics(ctx, repository, params.Period, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get repository metrics: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "リポジトリメトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "リポジトリメトリクスの取得に失敗しました")
 		return
 	}
 
@@ -149,12 +226,16 @@ func (h *AnalyticsHandler) ListTeamMetrics(w http.ResponseWriter, r *http.Reques
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
 		return
 	}
+	if params.hasLabelFilter() {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
 
 	// チームメトリクス一覧を取得
-	metricsList, err := h.aggregatedRepo.FindTeamMetrics(ctx, params.Period, params.StartDate, params.EndDate)
+	metricsList, err := h.aggregatedRepo.FindTeamMetricsByName(ctx, params.Team, params.Period, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get team metrics list: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "チームメトリクス一覧の取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "チームメトリクス一覧の取得に失敗しました")
 		return
 	}
 
@@ -175,6 +256,7 @@ func (h *AnalyticsHandler) ListTeamMetrics(w http.ResponseWriter, r *http.Reques
 	filters := AppliedFiltersResponse{
 		Period:    string(params.Period),
 		DateRange: DateRangeResponse{Start: params.StartDate, End: params.EndDate},
+		Team:      params.Team,
 	}
 
 	// レスポンス形式に変換
@@ -183,6 +265,7 @@ func (h *AnalyticsHandler) ListTeamMetrics(w http.ResponseWriter, r *http.Reques
 }
 
 // ListDeveloperMetrics は開発者メトリクスの一覧を取得
+// team を指定した場合は集計期間中にそのチームに所属していた開発者のみを返す
 func (h *AnalyticsHandler) ListDeveloperMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	
@@ -192,20 +275,27 @@ func (h *AnalyticsHandler) ListDeveloperMetrics(w http.ResponseWriter, r *http.R
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
 		return
 	}
+	if params.hasLabelFilter() {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
 
 	// 全開発者メトリクスを取得
 	developerMetricsMap, err := h.aggregatedRepo.FindAllDeveloperMetrics(ctx, params.Period, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get developer metrics list: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "開発者メトリクス一覧の取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "開発者メトリクス一覧の取得に失敗しました")
 		return
 	}
 
-	// マップをスライスに変換
+	// マップをスライスに変換（チームを指定した場合は集計期間中にチームに所属していた開発者のみ）
 	metricsList := make([]*analyticsApp.DeveloperMetrics, 0, len(developerMetricsMap))
 	for _, metrics := range developerMetricsMap {
 		metricsList = append(metricsList, metrics)
 	}
+	if params.Team != "" {
+		metricsList = h.filterDeveloperMetricsByTeam(params.Team, metricsList)
+	}
 
 	// ページング処理
 	totalCount := len(metricsList)
@@ -226,6 +316,7 @@ func (h *AnalyticsHandler) ListDeveloperMetrics(w http.ResponseWriter, r *http.R
 }
 
 // ListRepositoryMetrics はリポジトリメトリクスの一覧を取得
+// リポジトリ別の集計データはチームで分けていないため team は指定できない
 func (h *AnalyticsHandler) ListRepositoryMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	
@@ -235,12 +326,21 @@ func (h *AnalyticsHandler) ListRepositoryMetrics(w http.ResponseWriter, r *http.
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
 		return
 	}
+	if params.hasLabelFilter() {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
+
+	if params.Team != "" {
+		h.writeFilterNotSupported(w, "team", params.Team)
+		return
+	}
 
 	// 全リポジトリメトリクスを取得
 	repositoryMetricsMap, err := h.aggregatedRepo.FindAllRepositoryMetrics(ctx, params.Period, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get repository metrics list: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "リポジトリメトリクス一覧の取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "リポジトリメトリクス一覧の取得に失敗しました")
 		return
 	}
 
@@ -268,7 +368,74 @@ func (h *AnalyticsHandler) ListRepositoryMetrics(w http.ResponseWriter, r *http.
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// ListLabelMetrics はラベル別メトリクスの一覧を取得
+// ラベル別の集計データはチームで分けていないため team は指定できない
+func (h *AnalyticsHandler) ListLabelMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	
+	// パラメータ解析
+	params, err := h.parseListParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	if params.Team != "" {
+		h.writeFilterNotSupported(w, "team", params.Team)
+		return
+	}
+
+	// 全ラベルメトリクスを取得
+	labelMetricsMap, err := h.aggregatedRepo.FindAllLabelMetrics(ctx, params.Period, params.StartDate, params.EndDate)
+	if err != nil {
+		log.Printf("Failed to get label metrics list: %v", err)
+		h.writeDatabaseError(w, err, "ラベル別メトリクス一覧の取得に失敗しました")
+		return
+	}
+
+	// ラベルフィルタを適用し、ラベル名順・同じラベルは期間開始の新しい順に並べる
+	metricsList := make([]*analyticsApp.LabelMetrics, 0, len(labelMetricsMap))
+	for key, metrics := range labelMetricsMap {
+		if !h.matchesLabelFilter(key.Label, params.Labels, params.ExcludeLabels) {
+			continue
+		}
+		metricsList = append(metricsList, metrics)
+	}
+	sort.Slice(metricsList, func(i, j int) bool {
+		if metricsList[i].Label != metricsList[j].Label {
+			return metricsList[i].Label < metricsList[j].Label
+		}
+		return metricsList[i].DateRange.Start.After(metricsList[j].DateRange.Start)
+	})
+
+	// ページング処理
+	totalCount := len(metricsList)
+	startIndex := (params.Page - 1) * params.PageSize
+	endIndex := startIndex + params.PageSize
+
+	if startIndex >= totalCount {
+		metricsList = []*analyticsApp.LabelMetrics{}
+	} else if endIndex > totalCount {
+		metricsList = metricsList[startIndex:]
+	} else {
+		metricsList = metricsList[startIndex:endIndex]
+	}
+
+	// フィルタ情報の構築
+	filters := AppliedFiltersResponse{
+		Period:        string(params.Period),
+		DateRange:     DateRangeResponse{Start: params.StartDate, End: params.EndDate},
+		Labels:        params.Labels,
+		ExcludeLabels: params.ExcludeLabels,
+	}
+
+	// レスポンス形式に変換
+	response := h.presenter.ToLabelMetricsListResponse(metricsList, totalCount, params.Page, params.PageSize, filters)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
 // GetTrends はトレンド分析を取得
+// 集計ジョブが保存したスナップショットのうち最新の期間を返し、スナップショットがない場合は集計データから返す
 func (h *AnalyticsHandler) GetTrends(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	
@@ -278,12 +445,35 @@ func (h *AnalyticsHandler) GetTrends(w http.ResponseWriter, r *http.Request) {
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
 		return
 	}
+	if params.hasLabelFilter() {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
+
+	// 保存済みのスナップショットから取得（期間の新しい順）
+	snapshots, err := h.trends.FindTrendSnapshots(ctx, params.Team, params.Period, params.StartDate, params.EndDate)
+	if err != nil {
+		log.Printf("Failed to get trend snapshots: %v", err)
+		h.writeDatabaseError(w, err, "トレンドデータの取得に失敗しました")
+		return
+	}
+	if len(snapshots) > 0 {
+		var latest []*analyticsApp.TrendSnapshot
+		for _, snapshot := range snapshots {
+			if snapshot.DateRange.Start.Equal(snapshots[0].DateRange.Start) {
+				latest = append(latest, snapshot)
+			}
+		}
+		response := h.presenter.toTrendAnalysisResponse(analyticsApp.ToTrendAnalysisResult(latest))
+		h.writeJSONResponse(w, http.StatusOK, response)
+		return
+	}
 
 	// チームメトリクスからトレンドを取得
-	metricsList, err := h.aggregatedRepo.FindTeamMetrics(ctx, params.Period, params.StartDate, params.EndDate)
+	metricsList, err := h.aggregatedRepo.FindTeamMetricsByName(ctx, params.Team, params.Period, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get trends: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "トレンドデータの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "トレンドデータの取得に失敗しました")
 		return
 	}
 
@@ -298,6 +488,201 @@ func (h *AnalyticsHandler) GetTrends(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// ListBottlenecks は検出したボトルネックの履歴を最初の検出日時の新しい順に返す
+// startdate・enddate は最初の検出日時の範囲（enddate は当日を含む）
+// team を指定した場合はそのチームに所属したことのある作者のPRのみを返す
+func (h *AnalyticsHandler) ListBottlenecks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := analyticsApp.BottleneckFilter{
+		Type:       query.Get("type"),
+		Repository: query.Get("repository"),
+		Limit:      defaultBottleneckLimit,
+	}
+
+	team, err := h.parseTeam(query)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+	if team != "" {
+		filter.Authors = h.teamLogins(team)
+	}
+	if len(query["labels[]"]) > 0 || len(query["excludeLabels[]"]) > 0 {
+		h.writeFilterNotSupported(w, "labels[]・excludeLabels[]", nil)
+		return
+	}
+
+	switch status := query.Get("status"); status {
+	case "", analyticsApp.BottleneckStatusActive, analyticsApp.BottleneckStatusResolved, analyticsApp.BottleneckStatusIgnored:
+		filter.Status = status
+	default:
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "status は active・resolved・ignored のいずれかで指定してください", status)
+		return
+	}
+
+	if startDateStr := query.Get("startdate"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "startdate は YYYY-MM-DD 形式で指定してください", startDateStr)
+			return
+		}
+		filter.SeenSince = parsed
+	}
+	if endDateStr := query.Get("enddate"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "enddate は YYYY-MM-DD 形式で指定してください", endDateStr)
+			return
+		}
+		filter.SeenUntil = parsed.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxBottleneckLimit {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", fmt.Sprintf("limit は1から%dの整数で指定してください", maxBottleneckLimit), limitStr)
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	// メンバーのいないチームは、作者の条件が空（絞り込みなし）にならないよう取得せずに空の結果を返す
+	var records []*analyticsApp.BottleneckRecord
+	if team == "" || len(filter.Authors) > 0 {
+		records, err = h.bottlenecks.FindBottlenecks(r.Context(), filter)
+		if err != nil {
+			log.Printf("Failed to list bottlenecks: %v", err)
+			h.writeDatabaseError(w, err, "ボトルネック履歴の取得に失敗しました")
+			return
+		}
+	}
+	h.writeJSONResponse(w, http.StatusOK, h.presenter.ToBottleneckHistoryResponse(records))
+}
+
+// GetKnowledgeDistribution はディレクトリごとの作者・レビュアー数、バスファクター、知識の集中を取得
+// enddate（当日を含む）までの window_days 日間にマージされたPRを、ディレクトリの先頭 depth 階層ごとに集計する
+// team を指定した場合はPR作成時点でそのチームに所属していたメンバーのPRのみ、
+// labels[]・excludeLabels[] を指定した場合はラベル条件に一致するPRのみを集計する
+func (h *AnalyticsHandler) GetKnowledgeDistribution(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	team, err := h.parseTeam(query)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	windowEnd := time.Now()
+	if endDateStr := query.Get("enddate"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "enddate は YYYY-MM-DD 形式で指定してください", endDateStr)
+			return
+		}
+		windowEnd = parsed.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	windowDays := defaultKnowledgeWindowDays
+	if windowDaysStr := query.Get("window_days"); windowDaysStr != "" {
+		parsed, err := strconv.Atoi(windowDaysStr)
+		if err != nil || parsed < 1 || parsed > maxKnowledgeWindowDays {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", fmt.Sprintf("window_days は1から%dの整数で指定してください", maxKnowledgeWindowDays), windowDaysStr)
+			return
+		}
+		windowDays = parsed
+	}
+	windowStart := windowEnd.AddDate(0, 0, -windowDays)
+
+	depth := prDomain.DefaultKnowledgeDirectoryDepth
+	if depthStr := query.Get("depth"); depthStr != "" {
+		parsed, err := strconv.Atoi(depthStr)
+		if err != nil || parsed < 1 || parsed > maxKnowledgeDirectoryDepth {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", fmt.Sprintf("depth は1から%dの整数で指定してください", maxKnowledgeDirectoryDepth), depthStr)
+			return
+		}
+		depth = parsed
+	}
+
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, windowStart.Add(-knowledgeCreationLookback), windowEnd, nil, query["repositories[]"])
+	if err != nil {
+		log.Printf("Failed to get PR metrics for knowledge distribution: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+	if team != "" {
+		selected, _ := h.teams.Get(team)
+		metrics = selected.FilterMetrics(metrics, h.identities)
+	}
+	metrics = prDomain.FilterMetricsByLabels(metrics, query["labels[]"], query["excludeLabels[]"])
+
+	prIDs := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		prIDs = append(prIDs, metric.PRID)
+	}
+	events, err := h.prMetricsRepo.FindReviewEventsByPRIDs(ctx, prIDs)
+	if err != nil {
+		log.Printf("Failed to get review events for knowledge distribution: %v", err)
+		h.writeDatabaseError(w, err, "レビューイベントの取得に失敗しました")
+		return
+	}
+
+	knowledge, err := h.metricsAggregator.AggregateKnowledgeDistribution(ctx, metrics, events, depth, windowStart, windowEnd)
+	if err != nil {
+		log.Printf("Failed to aggregate knowledge distribution: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "知識の分散の集計に失敗しました", nil)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, h.presenter.ToKnowledgeDistributionResponse(knowledge, depth))
+}
+
+// GetWIP は開発者ごと・チームごとの同時にオープンなPR数（WIP）と推奨WIP上限を取得
+// enddate の時点（未来の場合は現在）のWIPを現在のWIPとし、period ごとの平均・最大WIPを返す
+// team を指定した場合はそのチームのメンバーのPRのみ、labels[]・excludeLabels[] を指定した場合はラベル条件に一致するPRのみを集計する
+func (h *AnalyticsHandler) GetWIP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params, err := h.parseAnalyticsParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+	end := params.EndDate
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	var developers []string
+	var teams []teamDomain.Team
+	if params.Team != "" {
+		team, _ := h.teams.Get(params.Team)
+		developers = team.ExpandedLogins(h.identities)
+		teams = []teamDomain.Team{team}
+	} else if h.teams != nil {
+		teams = h.teams.List()
+	}
+
+	// メンバーのいないチームは、開発者の条件が空（絞り込みなし）にならないよう取得せずに空の結果とする
+	var metrics []*prDomain.PRMetrics
+	if params.Team == "" || len(developers) > 0 {
+		metrics, err = h.prMetricsRepo.FindByDateRange(ctx, params.StartDate.Add(-wipCreationLookback), end, developers, r.URL.Query()["repositories[]"])
+		if err != nil {
+			log.Printf("Failed to get PR metrics for WIP: %v", err)
+			h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+			return
+		}
+	}
+	metrics = prDomain.FilterMetricsByLabels(metrics, params.Labels, params.ExcludeLabels)
+
+	wip, err := h.metricsAggregator.AggregateWIP(ctx, metrics, teams, params.Period, params.StartDate, end)
+	if err != nil {
+		log.Printf("Failed to aggregate WIP: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "WIPの集計に失敗しました", nil)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, h.presenter.ToWIPResponse(wip))
+}
+
 // GetAnalyticsHealthCheck は集計データシステムのヘルスチェック
 func (h *AnalyticsHandler) GetAnalyticsHealthCheck(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -325,6 +710,18 @@ func (h *AnalyticsHandler) GetAnalyticsHealthCheck(w http.ResponseWriter, r *htt
 		"statistics": stats,
 	}
 
+	// パーティションの状態は参考情報のため、取得できなくても unhealthy にはしない
+	if h.partitions != nil {
+		partitions, err := h.partitions.ListPartitions(ctx)
+		if err != nil {
+			services["partitions"] = "unavailable"
+			log.Printf("Failed to list partitions: %v", err)
+		} else {
+			services["partitions"] = "available"
+			response["partitions"] = partitions
+		}
+	}
+
 	statusCode := http.StatusOK
 	if status == "unhealthy" {
 		statusCode = http.StatusServiceUnavailable
@@ -336,9 +733,18 @@ func (h *AnalyticsHandler) GetAnalyticsHealthCheck(w http.ResponseWriter, r *htt
 // プライベートメソッド
 
 type AnalyticsParams struct {
-	Period    analyticsApp.AggregationPeriod
-	StartDate time.Time
-	EndDate   time.Time
+	Period        analyticsApp.AggregationPeriod
+	StartDate     time.Time
+	EndDate       time.Time
+	Labels        []string
+	ExcludeLabels []string
+	Team          string // 空の場合は集計対象全員
+}
+
+// hasLabelFilter はラベルの包含・除外条件が指定されているかを判定
+// ラベル別に分けていない集計データのエンドポイントでは指定できない
+func (p *AnalyticsParams) hasLabelFilter() bool {
+	return len(p.Labels) > 0 || len(p.ExcludeLabels) > 0
 }
 
 type ListParams struct {
@@ -388,13 +794,79 @@ func (h *AnalyticsHandler) parseAnalyticsParams(r *http.Request) (*AnalyticsPara
 		endDate = parsed
 	}
 
+	// ラベルフィルタ
+	labels := query["labels[]"]
+	excludeLabels := query["excludeLabels[]"]
+
+	// チームフィルタ
+	team, err := h.parseTeam(query)
+	if err != nil {
+		return nil, err
+	}
+
 	return &AnalyticsParams{
-		Period:    period,
-		StartDate: startDate,
-		EndDate:   endDate,
+		Period:        period,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Labels:        labels,
+		ExcludeLabels: excludeLabels,
+		Team:          team,
 	}, nil
 }
 
+// parseTeam は team パラメータが登録済みのチームかを検証して返す（指定がない場合は空）
+func (h *AnalyticsHandler) parseTeam(query url.Values) (string, error) {
+	team := query.Get("team")
+	if team != "" {
+		if _, exists := h.teams.Get(team); !exists {
+			return "", fmt.Errorf("unknown team: %s", team)
+		}
+	}
+	return team, nil
+}
+
+// teamLogins はチームに所属したことのある全メンバーのログイン名を、別アカウントを含めて返す
+func (h *AnalyticsHandler) teamLogins(name string) []string {
+	team, _ := h.teams.Get(name)
+	return team.ExpandedLogins(h.identities)
+}
+
+// filterDeveloperMetricsByTeam は集計期間中にチームに所属していた開発者のメトリクスのみを返す
+// PRメトリクスをPR作成時点の所属で絞り込むのと同じく、所属期間外のデータは含めない
+func (h *AnalyticsHandler) filterDeveloperMetricsByTeam(name string, metricsList []*analyticsApp.DeveloperMetrics) []*analyticsApp.DeveloperMetrics {
+	team, _ := h.teams.Get(name)
+	filtered := make([]*analyticsApp.DeveloperMetrics, 0, len(metricsList))
+	for _, metrics := range metricsList {
+		if team.IsMemberDuringResolved(metrics.Developer, metrics.DateRange.Start, metrics.DateRange.End, h.identities) {
+			filtered = append(filtered, metrics)
+		}
+	}
+	return filtered
+}
+
+// writeFilterNotSupported は絞り込みに対応していないエンドポイントにその条件が指定された場合のエラーを返す
+func (h *AnalyticsHandler) writeFilterNotSupported(w http.ResponseWriter, filter string, value interface{}) {
+	h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", fmt.Sprintf("このエンドポイントは %s による絞り込みに対応していません", filter), value)
+}
+
+// matchesLabelFilter はラベルが包含・除外条件に一致するかを判定（PRMetrics.HasLabel と同じく大文字・小文字を区別しない）
+func (h *AnalyticsHandler) matchesLabelFilter(label string, include, exclude []string) bool {
+	for _, excluded := range exclude {
+		if strings.EqualFold(label, excluded) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, included := range include {
+		if strings.EqualFold(label, included) {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *AnalyticsHandler) parseListParams(r *http.Request) (*ListParams, error) {
 	analyticsParams, err := h.parseAnalyticsParams(r)
 	if err != nil {
@@ -438,6 +910,23 @@ func (h *AnalyticsHandler) writeJSONResponse(w http.ResponseWriter, statusCode i
 	}
 }
 
+// writeDatabaseError はデータベースエラーをレスポンスに変換する
+// 接続できない場合は一時的な障害として 503 を返す
+func (h *AnalyticsHandler) writeDatabaseError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, database.ErrNotConfigured):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_NOT_CONFIGURED", "データベースが設定されていません", nil)
+	case database.IsTimeout(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_TIMEOUT", "データベースの応答がタイムアウトしました", nil)
+	case database.IsUnavailable(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "データベースに接続できません", nil)
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", message, nil)
+	}
+}
+
 func (h *AnalyticsHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
 	errorResponse := AnalyticsErrorResponse{
 		Error:     http.StatusText(statusCode),
@@ -470,9 +959,21 @@ func (h *AnalyticsHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/api/analytics/repository_metrics/{repository}", h.GetRepositoryMetrics).Methods("GET")
 	router.HandleFunc("/api/analytics/repository_metrics", h.ListRepositoryMetrics).Methods("GET")
 	
+	// ラベル別メトリクス
+	router.HandleFunc("/api/analytics/label_metrics", h.ListLabelMetrics).Methods("GET")
+	
 	// トレンド分析
 	router.HandleFunc("/api/analytics/trends", h.GetTrends).Methods("GET")
 	
+	// ボトルネック履歴
+	router.HandleFunc("/api/analytics/bottlenecks", h.ListBottlenecks).Methods("GET")
+	
+	// 知識の分散（バスファクター）
+	router.HandleFunc("/api/analytics/knowledge_distribution", h.GetKnowledgeDistribution).Methods("GET")
+	
+	// 同時にオープンなPR数（WIP）
+	router.HandleFunc("/api/analytics/wip", h.GetWIP).Methods("GET")
+	
 	// ヘルスチェック
 	router.HandleFunc("/api/analytics/health", h.GetAnalyticsHealthCheck).Methods("GET")
 }
\ No newline at end of file
@@ -0,0 +1,293 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	developerDomain "github-stats-metrics/domain/developer"
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/infrastructure/memory"
+)
+
+// newFilterTestRouter は backend チーム（alice と、離脱済みの carol）と、チーム外の bob のデータを持つハンドラーのルーターを返す
+// PRのラベルは alice が Bug、bob が bug、carol が feature。alice は別アカウント alice-work でも Bug のPRを作成している
+// carol は離脱後の5月の開発者メトリクスも持つ。メンバーのいない empty チームも登録している
+func newFilterTestRouter(t *testing.T) *mux.Router {
+	t.Helper()
+	ctx := context.Background()
+	periodStart := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	leftAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	roster, err := teamDomain.NewRoster([]teamDomain.Team{{
+		Name: "backend",
+		Members: []teamDomain.Membership{
+			{Login: "alice"},
+			{Login: "Carol", LeftAt: &leftAt},
+		},
+	}, {
+		Name: "empty",
+	}})
+	require.NoError(t, err)
+	identities, err := developerDomain.NewIdentityRegistry([]developerDomain.Developer{
+		{Id: "alice", Logins: []string{"alice", "alice-work"}},
+	})
+	require.NoError(t, err)
+
+	aggregated := memory.NewAggregatedMetricsRepository()
+	bottlenecks := memory.NewBottleneckRepository()
+	prMetrics := memory.NewPRMetricsRepository()
+	labels := map[string]string{"alice": "Bug", "bob": "bug", "carol": "feature"}
+	for i, developer := range []string{"alice", "bob", "carol"} {
+		require.NoError(t, aggregated.SaveDeveloperMetrics(ctx, &analyticsApp.DeveloperMetrics{
+			Developer:   developer,
+			Period:      analyticsApp.AggregationPeriodMonthly,
+			TotalPRs:    i + 1,
+			DateRange:   analyticsApp.DateRange{Start: periodStart, End: periodEnd},
+			GeneratedAt: periodEnd,
+		}))
+
+		prID := "pr-" + developer
+		mergedAt := periodStart.Add(time.Duration(i+1) * 24 * time.Hour)
+		require.NoError(t, prMetrics.Save(ctx, &prDomain.PRMetrics{
+			PRID:       prID,
+			Author:     developer,
+			Repository: "org/api",
+			CreatedAt:  mergedAt.Add(-time.Hour),
+			MergedAt:   &mergedAt,
+			Labels:     []string{labels[developer]},
+		}))
+		require.NoError(t, bottlenecks.SaveBottlenecks(ctx, []*analyticsApp.BottleneckRecord{{
+			ID:          analyticsApp.BottleneckRecordID("large_pr", prID),
+			Type:        "large_pr",
+			PRID:        prID,
+			Author:      developer,
+			Repository:  "org/api",
+			Status:      analyticsApp.BottleneckStatusActive,
+			FirstSeenAt: mergedAt,
+			UpdatedAt:   mergedAt,
+		}}))
+	}
+	require.NoError(t, aggregated.SaveDeveloperMetrics(ctx, &analyticsApp.DeveloperMetrics{
+		Developer: "carol",
+		Period:    analyticsApp.AggregationPeriodMonthly,
+		TotalPRs:  1,
+		DateRange: analyticsApp.DateRange{
+			Start: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 5, 31, 23, 59, 59, 0, time.UTC),
+		},
+		GeneratedAt: periodEnd,
+	}))
+
+	aliasMergedAt := periodStart.Add(4 * 24 * time.Hour)
+	require.NoError(t, prMetrics.Save(ctx, &prDomain.PRMetrics{
+		PRID:       "pr-alice-work",
+		Author:     "alice-work",
+		Repository: "org/api",
+		CreatedAt:  aliasMergedAt.Add(-time.Hour),
+		MergedAt:   &aliasMergedAt,
+		Labels:     []string{"Bug"},
+	}))
+	require.NoError(t, bottlenecks.SaveBottlenecks(ctx, []*analyticsApp.BottleneckRecord{{
+		ID:          analyticsApp.BottleneckRecordID("large_pr", "pr-alice-work"),
+		Type:        "large_pr",
+		PRID:        "pr-alice-work",
+		Author:      "alice-work",
+		Repository:  "org/api",
+		Status:      analyticsApp.BottleneckStatusActive,
+		FirstSeenAt: aliasMergedAt,
+		UpdatedAt:   aliasMergedAt,
+	}}))
+
+	require.NoError(t, aggregated.SaveRepositoryMetrics(ctx, &analyticsApp.RepositoryMetrics{
+		Repository:  "api",
+		Period:      analyticsApp.AggregationPeriodMonthly,
+		DateRange:   analyticsApp.DateRange{Start: periodStart, End: periodEnd},
+		GeneratedAt: periodEnd,
+	}))
+
+	for _, label := range []string{"bug", "feature"} {
+		require.NoError(t, aggregated.SaveLabelMetrics(ctx, &analyticsApp.LabelMetrics{
+			Label:       label,
+			Period:      analyticsApp.AggregationPeriodMonthly,
+			DateRange:   analyticsApp.DateRange{Start: periodStart, End: periodEnd},
+			GeneratedAt: periodEnd,
+		}))
+	}
+	require.NoError(t, aggregated.SaveLabelMetrics(ctx, &analyticsApp.LabelMetrics{
+		Label:       "bug",
+		Period:      analyticsApp.AggregationPeriodMonthly,
+		DateRange:   analyticsApp.DateRange{Start: periodStart.AddDate(0, -1, 0), End: periodStart.Add(-time.Second)},
+		GeneratedAt: periodStart,
+	}))
+
+	handler := NewAnalyticsHandler(aggregated, memory.NewTrendSnapshotRepository(), bottlenecks, prMetrics,
+		analyticsApp.NewMetricsAggregator(), roster, identities, nil)
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+	return router
+}
+
+func serveAnalytics(t *testing.T, router *mux.Router, path string, response interface{}) int {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	if response != nil && recorder.Code == http.StatusOK {
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(response))
+	}
+	return recorder.Code
+}
+
+func TestAnalyticsHandler_TeamFilter(t *testing.T) {
+	router := newFilterTestRouter(t)
+	const dateRange = "startdate=2024-03-01&enddate=2024-04-01"
+
+	t.Run("開発者一覧はチームに所属したことのある開発者のみ", func(t *testing.T) {
+		var all, filtered DeveloperMetricsListResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/developer_metrics?"+dateRange, &all))
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/developer_metrics?team=backend&"+dateRange, &filtered))
+
+		assert.Equal(t, 3, all.TotalCount)
+		assert.Equal(t, 2, filtered.TotalCount)
+		developers := make([]string, 0, len(filtered.Metrics))
+		for _, metrics := range filtered.Metrics {
+			developers = append(developers, metrics.Developer)
+		}
+		assert.ElementsMatch(t, []string{"alice", "carol"}, developers)
+	})
+
+	t.Run("開発者一覧は集計期間中にチームに所属していた開発者のみ", func(t *testing.T) {
+		var may DeveloperMetricsListResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/developer_metrics?team=backend&startdate=2024-05-01&enddate=2024-06-01", &may))
+		assert.Zero(t, may.TotalCount, "離脱後の期間は含めない")
+	})
+
+	t.Run("チーム外の開発者はデータなし", func(t *testing.T) {
+		var member DeveloperMetricsResponse
+		assert.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/developer_metrics/alice?team=backend&"+dateRange, &member))
+		assert.Equal(t, "alice", member.Developer)
+
+		assert.Equal(t, http.StatusNotFound, serveAnalytics(t, router, "/api/analytics/developer_metrics/bob?team=backend&"+dateRange, nil))
+		assert.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/developer_metrics/bob?"+dateRange, nil))
+
+		const may = "startdate=2024-05-01&enddate=2024-06-01"
+		assert.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/developer_metrics/carol?team=backend&"+dateRange, nil))
+		assert.Equal(t, http.StatusNotFound, serveAnalytics(t, router, "/api/analytics/developer_metrics/carol?team=backend&"+may, nil), "離脱後の期間はデータなし")
+		assert.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/developer_metrics/carol?"+may, nil))
+	})
+
+	t.Run("メンバーのいないチームは空の結果", func(t *testing.T) {
+		var bottlenecks BottleneckHistoryResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/bottlenecks?team=empty", &bottlenecks))
+		assert.Empty(t, bottlenecks.Bottlenecks)
+
+		var wip WIPResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/wip?team=empty&"+dateRange, &wip))
+		assert.Empty(t, wip.Developers)
+	})
+
+	t.Run("ボトルネックはチームのメンバーが別アカウントを含めて作成したPRのみ", func(t *testing.T) {
+		var response BottleneckHistoryResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/bottlenecks?team=backend", &response))
+
+		prIDs := make([]string, 0, len(response.Bottlenecks))
+		for _, bottleneck := range response.Bottlenecks {
+			prIDs = append(prIDs, bottleneck.PRID)
+		}
+		assert.ElementsMatch(t, []string{"pr-alice", "pr-alice-work", "pr-carol"}, prIDs)
+	})
+
+	t.Run("知識の分散はチームのメンバーが別アカウントを含めて作成したPRのみ", func(t *testing.T) {
+		var all, filtered KnowledgeDistributionResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/knowledge_distribution?enddate=2024-03-31", &all))
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/knowledge_distribution?team=backend&enddate=2024-03-31", &filtered))
+
+		assert.Equal(t, 4, all.TotalPRs)
+		assert.Equal(t, 3, filtered.TotalPRs)
+	})
+
+	t.Run("チームで分けていない集計データに team を指定すると400", func(t *testing.T) {
+		for _, path := range []string{
+			"/api/analytics/repository_metrics/api?team=backend&" + dateRange,
+			"/api/analytics/repository_metrics?team=backend&" + dateRange,
+			"/api/analytics/label_metrics?team=backend&" + dateRange,
+		} {
+			assert.Equal(t, http.StatusBadRequest, serveAnalytics(t, router, path, nil), path)
+		}
+		assert.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/repository_metrics/api?"+dateRange, nil))
+	})
+
+	t.Run("登録されていないチームは400", func(t *testing.T) {
+		for _, path := range []string{
+			"/api/analytics/developer_metrics?team=unknown",
+			"/api/analytics/bottlenecks?team=unknown",
+			"/api/analytics/knowledge_distribution?team=unknown",
+		} {
+			assert.Equal(t, http.StatusBadRequest, serveAnalytics(t, router, path, nil), path)
+		}
+	})
+}
+
+func TestAnalyticsHandler_LabelFilter(t *testing.T) {
+	router := newFilterTestRouter(t)
+	const dateRange = "startdate=2024-03-01&enddate=2024-04-01"
+
+	t.Run("ラベル別メトリクスは大文字・小文字を区別せずに絞り込む", func(t *testing.T) {
+		var included, excluded LabelMetricsListResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/label_metrics?labels[]=BUG&"+dateRange, &included))
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/label_metrics?excludeLabels[]=Bug&"+dateRange, &excluded))
+
+		require.Len(t, included.Metrics, 1)
+		assert.Equal(t, "bug", included.Metrics[0].Label)
+		require.Len(t, excluded.Metrics, 1)
+		assert.Equal(t, "feature", excluded.Metrics[0].Label)
+	})
+
+	t.Run("同じラベルの集計データを期間ごとに返す", func(t *testing.T) {
+		var response LabelMetricsListResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/label_metrics?labels[]=bug&startdate=2024-02-01&enddate=2024-04-01", &response))
+
+		require.Len(t, response.Metrics, 2)
+		assert.Equal(t, "2024-03-01", response.Metrics[0].DateRange.Start.Format("2006-01-02"), "新しい期間から並べる")
+		assert.Equal(t, "2024-02-01", response.Metrics[1].DateRange.Start.Format("2006-01-02"))
+	})
+
+	t.Run("知識の分散はラベル条件に一致するPRのみ", func(t *testing.T) {
+		var included, excluded KnowledgeDistributionResponse
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/knowledge_distribution?labels[]=bug&enddate=2024-03-31", &included))
+		require.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/knowledge_distribution?excludeLabels[]=BUG&enddate=2024-03-31", &excluded))
+
+		assert.Equal(t, 3, included.TotalPRs)
+		assert.Equal(t, 1, excluded.TotalPRs)
+	})
+
+	t.Run("WIPはラベル条件を指定できる", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serveAnalytics(t, router, "/api/analytics/wip?labels[]=bug&"+dateRange, nil))
+	})
+
+	t.Run("ラベル別に分けていない集計データにラベル条件を指定すると400", func(t *testing.T) {
+		for _, path := range []string{
+			"/api/analytics/team_metrics?labels[]=bug&" + dateRange,
+			"/api/analytics/team_metrics/list?excludeLabels[]=bug&" + dateRange,
+			"/api/analytics/developer_metrics/alice?labels[]=bug&" + dateRange,
+			"/api/analytics/developer_metrics?labels[]=bug&" + dateRange,
+			"/api/analytics/repository_metrics/api?labels[]=bug&" + dateRange,
+			"/api/analytics/repository_metrics?excludeLabels[]=bug&" + dateRange,
+			"/api/analytics/trends?labels[]=bug&" + dateRange,
+			"/api/analytics/bottlenecks?labels[]=bug",
+		} {
+			assert.Equal(t, http.StatusBadRequest, serveAnalytics(t, router, path, nil), path)
+		}
+	})
+}
@@ -20,6 +20,7 @@ func NewAnalyticsPresenter() *AnalyticsPresenter {
 // ToTeamMetricsResponse はチームメトリクスをレスポンス形式に変換
 func (presenter *AnalyticsPresenter) ToTeamMetricsResponse(metrics *analyticsApp.TeamMetrics) *TeamMetricsResponse {
 	return &TeamMetricsResponse{
+		Team:        metrics.Team,
 		Period:      string(metrics.Period),
 		TotalPRs:    metrics.TotalPRs,
 		DateRange:   presenter.toDateRangeResponse(metrics.DateRange),
@@ -77,6 +78,25 @@ func (presenter *AnalyticsPresenter) ToRepositoryMetricsResponse(metrics *analyt
 	}
 }
 
+// ToLabelMetricsResponse はラベル別メトリクスをレスポンス形式に変換
+func (presenter *AnalyticsPresenter) ToLabelMetricsResponse(metrics *analyticsApp.LabelMetrics) *LabelMetricsResponse {
+	return &LabelMetricsResponse{
+		Label:       metrics.Label,
+		Period:      string(metrics.Period),
+		TotalPRs:    metrics.TotalPRs,
+		DateRange:   presenter.toDateRangeResponse(metrics.DateRange),
+		GeneratedAt: metrics.GeneratedAt,
+		
+		CycleTimeStats:  presenter.toCycleTimeStatsAggResponse(metrics.CycleTimeStats),
+		ReviewStats:     presenter.toReviewStatsAggResponse(metrics.ReviewStats),
+		SizeStats:       presenter.toSizeStatsAggResponse(metrics.SizeStats),
+		QualityStats:    presenter.toQualityStatsAggResponse(metrics.QualityStats),
+		ComplexityStats: presenter.toComplexityStatsAggResponse(metrics.ComplexityStats),
+		
+		Contributors: metrics.Contributors,
+	}
+}
+
 // ToTeamMetricsListResponse はチームメトリクスリストをレスポンス形式に変換
 func (presenter *AnalyticsPresenter) ToTeamMetricsListResponse(
 	metricsList []*analyticsApp.TeamMetrics,
@@ -154,6 +174,27 @@ func (presenter *AnalyticsPresenter) ToRepositoryMetricsListResponse(
 	}
 }
 
+// ToLabelMetricsListResponse はラベル別メトリクスリストをレスポンス形式に変換
+func (presenter *AnalyticsPresenter) ToLabelMetricsListResponse(
+	metricsList []*analyticsApp.LabelMetrics,
+	totalCount, page, pageSize int,
+	filters AppliedFiltersResponse,
+) *LabelMetricsListResponse {
+	metrics := make([]LabelMetricsResponse, len(metricsList))
+	for i, metric := range metricsList {
+		metrics[i] = *presenter.ToLabelMetricsResponse(metric)
+	}
+
+	return &LabelMetricsListResponse{
+		Metrics:    metrics,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		HasMore:    (page * pageSize) < totalCount,
+		Filters:    filters,
+	}
+}
+
 // プライベートメソッド
 
 func (presenter *AnalyticsPresenter) toDateRangeResponse(dateRange analyticsApp.DateRange) DateRangeResponse {
@@ -321,7 +362,7 @@ func (presenter *AnalyticsPresenter) toBottlenecksResponse(bottlenecks []analyti
 			Severity:    bottleneck.Severity,
 			Description: bottleneck.Description,
 			Value:       bottleneck.Value,
-			Threshold:   presenter.calculateThreshold(bottleneck.Type),
+			Threshold:   presenter.calculateThreshold(bottleneck),
 			
 			// 改善提案
 			Suggestion:    presenter.generateSuggestion(bottleneck),
@@ -329,12 +370,129 @@ func (presenter *AnalyticsPresenter) toBottlenecksResponse(bottlenecks []analyti
 			ActionItems:   presenter.generateActionItems(bottleneck),
 			
 			// 関連情報
-			FrequencyScore: presenter.calculateFrequencyScore(bottleneck),
+			AffectedDevelopers: bottleneck.AffectedDevelopers,
+			FrequencyScore:     presenter.calculateFrequencyScore(bottleneck),
+		}
+	}
+	return response
+}
+
+// ToKnowledgeDistributionResponse は知識の分散をレスポンスに変換
+func (presenter *AnalyticsPresenter) ToKnowledgeDistributionResponse(knowledge *analyticsApp.KnowledgeDistributionMetrics, depth int) *KnowledgeDistributionResponse {
+	return &KnowledgeDistributionResponse{
+		WindowStart: knowledge.WindowStart,
+		WindowEnd:   knowledge.WindowEnd,
+		Depth:       depth,
+		SiloShare:   knowledge.SiloShare,
+		TotalPRs:    knowledge.TotalPRs,
+		Directories: presenter.toDirectoryKnowledgeResponses(knowledge.Directories),
+		Silos:       presenter.toDirectoryKnowledgeResponses(knowledge.Silos),
+		Bottlenecks: presenter.toBottlenecksResponse(knowledge.Bottlenecks),
+		GeneratedAt: knowledge.GeneratedAt,
+	}
+}
+
+func (presenter *AnalyticsPresenter) toDirectoryKnowledgeResponses(directories []prDomain.DirectoryKnowledge) []DirectoryKnowledgeResponse {
+	response := make([]DirectoryKnowledgeResponse, len(directories))
+	for i, directory := range directories {
+		contributors := make([]KnowledgeContributorResponse, len(directory.Contributors))
+		for j, contributor := range directory.Contributors {
+			contributors[j] = KnowledgeContributorResponse{
+				Login:         contributor.Login,
+				AuthoredPRs:   contributor.AuthoredPRs,
+				ReviewedPRs:   contributor.ReviewedPRs,
+				LinesChanged:  contributor.LinesChanged,
+				LinesReviewed: contributor.LinesReviewed,
+				Share:         contributor.Share,
+			}
+		}
+		response[i] = DirectoryKnowledgeResponse{
+			Repository:        directory.Repository,
+			Directory:         directory.Directory,
+			PRs:               directory.PRs,
+			DistinctAuthors:   directory.DistinctAuthors,
+			DistinctReviewers: directory.DistinctReviewers,
+			BusFactor:         directory.BusFactor,
+			IsSilo:            directory.IsSilo,
+			Contributors:      contributors,
+		}
+		if top := directory.TopContributor(); top != nil {
+			response[i].TopContributor = top.Login
+			response[i].TopShare = top.Share
+		}
+	}
+	return response
+}
+
+// ToWIPResponse はWIPの集計をレスポンスに変換
+func (presenter *AnalyticsPresenter) ToWIPResponse(wip *analyticsApp.WIPMetrics) *WIPResponse {
+	return &WIPResponse{
+		Period:      string(wip.Period),
+		StartDate:   wip.DateRange.Start,
+		EndDate:     wip.DateRange.End,
+		StaleDays:   int(wip.StaleAfter / (24 * time.Hour)),
+		Developers:  presenter.toWIPStatsResponses(wip.Developers),
+		Teams:       presenter.toWIPStatsResponses(wip.Teams),
+		Bottlenecks: presenter.toBottlenecksResponse(wip.Bottlenecks),
+		GeneratedAt: wip.GeneratedAt,
+	}
+}
+
+func (presenter *AnalyticsPresenter) toWIPStatsResponses(stats []*analyticsApp.WIPStats) []WIPStatsResponse {
+	response := make([]WIPStatsResponse, len(stats))
+	for i, s := range stats {
+		periods := make([]WIPPeriodResponse, len(s.Periods))
+		for j, period := range s.Periods {
+			periods[j] = WIPPeriodResponse{
+				Start:      period.Start,
+				End:        period.End,
+				AverageWIP: period.AverageWIP,
+				PeakWIP:    period.PeakWIP,
+			}
+		}
+		response[i] = WIPStatsResponse{
+			Name:               s.Name,
+			CurrentWIP:         s.CurrentWIP,
+			AverageWIP:         s.AverageWIP,
+			PeakWIP:            s.PeakWIP,
+			MergedPRs:          s.MergedPRs,
+			ThroughputPerDay:   s.Throughput,
+			MedianLeadTimeDays: s.MedianLeadTime.Hours() / 24,
+			RecommendedLimit:   s.RecommendedLimit,
+			Exceeded:           s.Exceeded,
+			Periods:            periods,
 		}
 	}
 	return response
 }
 
+// ToBottleneckHistoryResponse はボトルネック履歴をレスポンスに変換
+func (presenter *AnalyticsPresenter) ToBottleneckHistoryResponse(records []*analyticsApp.BottleneckRecord) *BottleneckHistoryResponse {
+	response := make([]BottleneckRecordResponse, len(records))
+	for i, record := range records {
+		response[i] = BottleneckRecordResponse{
+			ID:          record.ID,
+			Type:        record.Type,
+			PRID:        record.PRID,
+			Severity:    record.Severity,
+			Description: record.Description,
+			Value:       record.Value,
+			Threshold:   record.Threshold,
+			Author:      record.Author,
+			Repository:  record.Repository,
+			Status:      record.Status,
+			FirstSeenAt: record.FirstSeenAt,
+			ResolvedAt:  record.ResolvedAt,
+			UpdatedAt:   record.UpdatedAt,
+		}
+	}
+
+	return &BottleneckHistoryResponse{
+		Bottlenecks: response,
+		TotalCount:  len(records),
+	}
+}
+
 func (presenter *AnalyticsPresenter) toProductivityMetricsResponse(productivity analyticsApp.ProductivityMetrics) ProductivityMetricsResponse {
 	return ProductivityMetricsResponse{
 		PRsPerDay:   productivity.PRsPerDay,
@@ -451,8 +609,12 @@ func (presenter *AnalyticsPresenter) calculateSignificance(confidence float64) s
 	}
 }
 
-func (presenter *AnalyticsPresenter) calculateThreshold(bottleneckType string) float64 {
-	switch bottleneckType {
+// calculateThreshold は判定に使った閾値を返す（閾値が記録されていない場合は種類ごとの既定値）
+func (presenter *AnalyticsPresenter) calculateThreshold(bottleneck analyticsApp.Bottleneck) float64 {
+	if bottleneck.Threshold > 0 {
+		return bottleneck.Threshold
+	}
+	switch bottleneck.Type {
 	case "long_cycle_time":
 		return 7 * 24 // 7日（時間）
 	case "multiple_review_rounds":
@@ -472,6 +634,10 @@ func (presenter *AnalyticsPresenter) generateSuggestion(bottleneck analyticsApp.
 		return "初回レビューの品質向上とレビューガイドラインの策定をお勧めします"
 	case "large_pr":
 		return "PRを小さく分割することでレビュー効率を向上できます"
+	case string(prDomain.BottleneckTypeKnowledgeSilo):
+		return "他のメンバーによるレビューやペアプログラミングで知識を共有してください"
+	case string(prDomain.BottleneckTypeWIPExceeded):
+		return "新しい作業を始める前に、オープンなPRのレビューとマージを優先してください"
 	default:
 		return "プロセスの見直しを検討してください"
 	}
@@ -510,6 +676,18 @@ func (presenter *AnalyticsPresenter) generateActionItems(bottleneck analyticsApp
 			"PRサイズガイドラインの策定",
 			"WIP機能の活用",
 		}
+	case string(prDomain.BottleneckTypeKnowledgeSilo):
+		return []string{
+			"他のメンバーをレビュアーにアサイン",
+			"ペアプログラミング・モブプログラミングの実施",
+			"設計や運用手順のドキュメント化",
+		}
+	case string(prDomain.BottleneckTypeWIPExceeded):
+		return []string{
+			"オープンなPRのレビュー・マージを優先",
+			"WIP上限を超える新規PRの作成を控える",
+			"長期間オープンなPRの分割またはクローズ",
+		}
 	default:
 		return []string{"詳細な分析が必要です"}
 	}
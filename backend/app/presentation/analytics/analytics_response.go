@@ -6,6 +6,7 @@ import (
 
 // TeamMetricsResponse はチームメトリクスのAPIレスポンス
 type TeamMetricsResponse struct {
+	Team        string                    `json:"team,omitempty"` // 空の場合は集計対象全員
 	Period      string                    `json:"period"`
 	TotalPRs    int                       `json:"totalPRs"`
 	DateRange   DateRangeResponse         `json:"dateRange"`
@@ -70,6 +71,25 @@ type RepositoryMetricsResponse struct {
 	ActivityLevel ActivityLevelResponse `json:"activityLevel"`
 }
 
+// LabelMetricsResponse はラベル別メトリクスのAPIレスポンス
+type LabelMetricsResponse struct {
+	Label       string                    `json:"label"`
+	Period      string                    `json:"period"`
+	TotalPRs    int                       `json:"totalPRs"`
+	DateRange   DateRangeResponse         `json:"dateRange"`
+	GeneratedAt time.Time                 `json:"generatedAt"`
+	
+	// 統計データ
+	CycleTimeStats  CycleTimeStatsAggResponse `json:"cycleTimeStats"`
+	ReviewStats     ReviewStatsAggResponse    `json:"reviewStats"`
+	SizeStats       SizeStatsAggResponse      `json:"sizeStats"`
+	QualityStats    QualityStatsAggResponse   `json:"qualityStats"`
+	ComplexityStats ComplexityStatsAggResponse `json:"complexityStats"`
+	
+	// 貢献者情報
+	Contributors []string `json:"contributors"`
+}
+
 // TrendAnalysisResponse はトレンド分析のレスポンス
 type TrendAnalysisResponse struct {
 	CycleTimeTrend  TrendDataResponse `json:"cycleTimeTrend"`
@@ -323,6 +343,100 @@ type BottleneckResponse struct {
 	FrequencyScore     float64  `json:"frequencyScore"`
 }
 
+// BottleneckRecordResponse はボトルネック履歴の1件分のレスポンス
+type BottleneckRecordResponse struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	PRID        string     `json:"prId"`
+	Severity    string     `json:"severity"`
+	Description string     `json:"description"`
+	Value       float64    `json:"value"`
+	Threshold   float64    `json:"threshold"`
+	Author      string     `json:"author"`
+	Repository  string     `json:"repository"`
+	Status      string     `json:"status"`
+	FirstSeenAt time.Time  `json:"firstSeenAt"`
+	ResolvedAt  *time.Time `json:"resolvedAt,omitempty"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// BottleneckHistoryResponse はボトルネック履歴のレスポンス
+type BottleneckHistoryResponse struct {
+	Bottlenecks []BottleneckRecordResponse `json:"bottlenecks"`
+	TotalCount  int                        `json:"totalCount"`
+}
+
+// KnowledgeDistributionResponse はディレクトリごとの知識の分散のレスポンス
+type KnowledgeDistributionResponse struct {
+	WindowStart time.Time                    `json:"windowStart"`
+	WindowEnd   time.Time                    `json:"windowEnd"`
+	Depth       int                          `json:"depth"`     // 集計したディレクトリの階層の深さ
+	SiloShare   float64                      `json:"siloShare"` // 知識の集中とみなす1人の貢献の割合
+	TotalPRs    int                          `json:"totalPRs"`
+	Directories []DirectoryKnowledgeResponse `json:"directories"` // バスファクターの小さい順
+	Silos       []DirectoryKnowledgeResponse `json:"silos"`
+	Bottlenecks []BottleneckResponse         `json:"bottlenecks"`
+	GeneratedAt time.Time                    `json:"generatedAt"`
+}
+
+// DirectoryKnowledgeResponse はディレクトリ1件分の知識の分散
+type DirectoryKnowledgeResponse struct {
+	Repository        string                         `json:"repository"`
+	Directory         string                         `json:"directory"`
+	PRs               int                            `json:"prs"`
+	DistinctAuthors   int                            `json:"distinctAuthors"`
+	DistinctReviewers int                            `json:"distinctReviewers"`
+	BusFactor         int                            `json:"busFactor"`
+	TopContributor    string                         `json:"topContributor"`
+	TopShare          float64                        `json:"topShare"`
+	IsSilo            bool                           `json:"isSilo"`
+	Contributors      []KnowledgeContributorResponse `json:"contributors"`
+}
+
+// KnowledgeContributorResponse はディレクトリへの1人の貢献
+type KnowledgeContributorResponse struct {
+	Login         string  `json:"login"`
+	AuthoredPRs   int     `json:"authoredPRs"`
+	ReviewedPRs   int     `json:"reviewedPRs"`
+	LinesChanged  int     `json:"linesChanged"`
+	LinesReviewed int     `json:"linesReviewed"`
+	Share         float64 `json:"share"`
+}
+
+// WIPResponse は同時にオープンなPR数（WIP）のレスポンス
+type WIPResponse struct {
+	Period      string               `json:"period"`
+	StartDate   time.Time            `json:"startDate"`
+	EndDate     time.Time            `json:"endDate"`
+	StaleDays   int                  `json:"staleDays"` // これを過ぎた未マージのPRはWIPに数えない
+	Developers  []WIPStatsResponse   `json:"developers"`
+	Teams       []WIPStatsResponse   `json:"teams"`
+	Bottlenecks []BottleneckResponse `json:"bottlenecks"`
+	GeneratedAt time.Time            `json:"generatedAt"`
+}
+
+// WIPStatsResponse は開発者またはチームのWIPのレスポンス
+type WIPStatsResponse struct {
+	Name               string              `json:"name"`
+	CurrentWIP         int                 `json:"currentWIP"`
+	AverageWIP         float64             `json:"averageWIP"`
+	PeakWIP            int                 `json:"peakWIP"`
+	MergedPRs          int                 `json:"mergedPRs"`
+	ThroughputPerDay   float64             `json:"throughputPerDay"`
+	MedianLeadTimeDays float64             `json:"medianLeadTimeDays"`
+	RecommendedLimit   int                 `json:"recommendedLimit"` // 0 はマージが少なく算出しない
+	Exceeded           bool                `json:"exceeded"`
+	Periods            []WIPPeriodResponse `json:"periods"`
+}
+
+// WIPPeriodResponse は集計期間ごとのWIPのレスポンス
+type WIPPeriodResponse struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	AverageWIP float64   `json:"averageWIP"`
+	PeakWIP    int       `json:"peakWIP"`
+}
+
 // リスト・ページネーション関連レスポンス
 type TeamMetricsListResponse struct {
 	Metrics    []TeamMetricsResponse `json:"metrics"`
@@ -358,6 +472,17 @@ type RepositoryMetricsListResponse struct {
 	ActivitySummary RepositoryActivitySummary `json:"activitySummary"`
 }
 
+type LabelMetricsListResponse struct {
+	Metrics    []LabelMetricsResponse `json:"metrics"`
+	TotalCount int                    `json:"totalCount"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"pageSize"`
+	HasMore    bool                   `json:"hasMore"`
+	
+	// メタ情報
+	Filters AppliedFiltersResponse `json:"filters"`
+}
+
 type DeveloperRankingSummary struct {
 	Developer   string  `json:"developer"`
 	Rank        int     `json:"rank"`
@@ -378,6 +503,9 @@ type AppliedFiltersResponse struct {
 	DateRange    DateRangeResponse `json:"dateRange"`
 	Developers   []string `json:"developers,omitempty"`
 	Repositories []string `json:"repositories,omitempty"`
+	Labels        []string `json:"labels,omitempty"`
+	ExcludeLabels []string `json:"excludeLabels,omitempty"`
+	Team          string   `json:"team,omitempty"`
 	Metrics      []string `json:"metrics,omitempty"`
 }
 
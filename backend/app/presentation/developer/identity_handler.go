@@ -0,0 +1,181 @@
+package developer
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	developerDomain "github-stats-metrics/domain/developer"
+)
+
+// IdentityPersister は開発者IDエイリアスの永続化先
+type IdentityPersister interface {
+	Save(developers []developerDomain.Developer) error
+}
+
+// IdentityHandler は開発者IDエイリアス管理APIのハンドラー
+type IdentityHandler struct {
+	registry  *developerDomain.IdentityRegistry
+	persister IdentityPersister
+}
+
+// NewIdentityHandler は新しいIDエイリアスハンドラーを作成
+// persister が nil の場合、変更はメモリ上のみに反映される
+func NewIdentityHandler(registry *developerDomain.IdentityRegistry, persister IdentityPersister) *IdentityHandler {
+	return &IdentityHandler{
+		registry:  registry,
+		persister: persister,
+	}
+}
+
+// ListIdentities は登録済みの開発者一覧を取得
+func (h *IdentityHandler) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	developers := h.registry.List()
+
+	response := IdentityListResponse{
+		Developers: make([]IdentityResponse, 0, len(developers)),
+		TotalCount: len(developers),
+	}
+	for _, developer := range developers {
+		response.Developers = append(response.Developers, toIdentityResponse(developer))
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// PutIdentity は開発者のエイリアスを登録・更新
+func (h *IdentityHandler) PutIdentity(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_DEVELOPER", "開発者IDが指定されていません", nil)
+		return
+	}
+
+	var request IdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", "リクエストボディの形式が不正です", nil)
+		return
+	}
+
+	developer := developerDomain.Developer{
+		Id:         id,
+		ScreenName: request.ScreenName,
+		ImageURL:   request.ImageURL,
+		Logins:     request.Logins,
+		Emails:     request.Emails,
+	}
+
+	if err := h.registry.Register(developer); err != nil {
+		var identityErr *developerDomain.IdentityError
+		if errors.As(err, &identityErr) && identityErr.IsConflict() {
+			h.writeErrorResponse(w, http.StatusConflict, "ALIAS_CONFLICT", "別の開発者に登録済みのエイリアスが含まれています", identityErr.Message)
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_DEVELOPER", err.Error(), nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, toIdentityResponse(developer))
+}
+
+// DeleteIdentity は開発者のエイリアス登録を削除
+func (h *IdentityHandler) DeleteIdentity(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if !h.registry.Remove(id) {
+		h.writeErrorResponse(w, http.StatusNotFound, "DEVELOPER_NOT_FOUND", "指定された開発者が見つかりません", nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResolveIdentity はログイン名・メールアドレスを開発者IDに解決
+func (h *IdentityHandler) ResolveIdentity(w http.ResponseWriter, r *http.Request) {
+	alias := r.URL.Query().Get("alias")
+	if alias == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "aliasパラメータが指定されていません", nil)
+		return
+	}
+
+	response := ResolveIdentityResponse{
+		Alias:       alias,
+		DeveloperId: alias,
+	}
+	if developer, exists := h.registry.Lookup(alias); exists {
+		identity := toIdentityResponse(developer)
+		response.DeveloperId = developer.Id
+		response.Registered = true
+		response.Developer = &identity
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// persist はレジストリの内容を永続化（失敗時はエラーレスポンスを書き込み false を返す）
+func (h *IdentityHandler) persist(w http.ResponseWriter) bool {
+	if h.persister == nil {
+		return true
+	}
+	if err := h.persister.Save(h.registry.List()); err != nil {
+		log.Printf("Failed to save identities: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "PERSISTENCE_ERROR", "エイリアス定義の保存に失敗しました", nil)
+		return false
+	}
+	return true
+}
+
+func toIdentityResponse(developer developerDomain.Developer) IdentityResponse {
+	response := IdentityResponse{
+		Id:         developer.Id,
+		ScreenName: developer.ScreenName,
+		ImageURL:   developer.ImageURL,
+		Logins:     developer.Logins,
+		Emails:     developer.Emails,
+	}
+	if response.Logins == nil {
+		response.Logins = []string{}
+	}
+	if response.Emails == nil {
+		response.Emails = []string{}
+	}
+	return response
+}
+
+func (h *IdentityHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+	}
+}
+
+func (h *IdentityHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
+	errorResponse := ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
+
+// RegisterRoutes はルートを登録
+func (h *IdentityHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/identities", h.ListIdentities).Methods("GET")
+	router.HandleFunc("/api/identities/resolve", h.ResolveIdentity).Methods("GET")
+	router.HandleFunc("/api/identities/{id}", h.PutIdentity).Methods("PUT")
+	router.HandleFunc("/api/identities/{id}", h.DeleteIdentity).Methods("DELETE")
+}
@@ -0,0 +1,40 @@
+package developer
+
+// IdentityResponse は開発者IDエイリアスのレスポンス
+type IdentityResponse struct {
+	Id         string   `json:"id"`
+	ScreenName string   `json:"screenName"`
+	ImageURL   string   `json:"imageUrl,omitempty"`
+	Logins     []string `json:"logins"`
+	Emails     []string `json:"emails"`
+}
+
+// IdentityListResponse は開発者IDエイリアス一覧のレスポンス
+type IdentityListResponse struct {
+	Developers []IdentityResponse `json:"developers"`
+	TotalCount int                `json:"totalCount"`
+}
+
+// ResolveIdentityResponse は別名解決結果のレスポンス
+type ResolveIdentityResponse struct {
+	Alias       string            `json:"alias"`
+	DeveloperId string            `json:"developerId"`
+	Registered  bool              `json:"registered"`
+	Developer   *IdentityResponse `json:"developer,omitempty"`
+}
+
+// IdentityRequest は開発者IDエイリアスの登録リクエスト
+type IdentityRequest struct {
+	ScreenName string   `json:"screenName"`
+	ImageURL   string   `json:"imageUrl"`
+	Logins     []string `json:"logins"`
+	Emails     []string `json:"emails"`
+}
+
+// ErrorResponse はエラーレスポンス
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
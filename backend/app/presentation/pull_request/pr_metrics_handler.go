@@ -3,6 +3,7 @@ package pull_request
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,25 +13,39 @@ import (
 	"github.com/gorilla/mux"
 
 	analyticsApp "github-stats-metrics/application/analytics"
+	developerDomain "github-stats-metrics/domain/developer"
 	prDomain "github-stats-metrics/domain/pull_request"
-	"github-stats-metrics/infrastructure/repository"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/infrastructure/database"
 )
 
 // PRMetricsHandler はPRメトリクスのHTTPハンドラー
 type PRMetricsHandler struct {
-	prMetricsRepo     *repository.PRMetricsRepository
+	prMetricsRepo     prDomain.MetricsRepository
 	metricsAggregator *analyticsApp.MetricsAggregator
+	identities        *developerDomain.IdentityRegistry
+	teams             *teamDomain.Roster
+	codeOwners        *prDomain.CodeOwnersRegistry
 	presenter         *PRMetricsPresenter
 }
 
 // NewPRMetricsHandler は新しいPRメトリクスハンドラーを作成
+// identities が指定されている場合、開発者フィルタは同一人物の全アカウントに展開される
+// teams が指定されている場合、team パラメータでチームに絞り込める
+// codeOwners が nil の場合、CODEOWNERSの集計はすべてのリポジトリをCODEOWNERSなしとして扱う
 func NewPRMetricsHandler(
-	prMetricsRepo *repository.PRMetricsRepository,
+	prMetricsRepo prDomain.MetricsRepository,
 	metricsAggregator *analyticsApp.MetricsAggregator,
+	identities *developerDomain.IdentityRegistry,
+	teams *teamDomain.Roster,
+	codeOwners *prDomain.CodeOwnersRegistry,
 ) *PRMetricsHandler {
 	return &PRMetricsHandler{
 		prMetricsRepo:     prMetricsRepo,
 		metricsAggregator: metricsAggregator,
+		identities:        identities,
+		teams:             teams,
+		codeOwners:        codeOwners,
 		presenter:         NewPRMetricsPresenter(),
 	}
 }
@@ -50,7 +65,7 @@ func (h *PRMetricsHandler) GetPRMetrics(w http.ResponseWriter, r *http.Request)
 	metrics, err := h.prMetricsRepo.FindByPRID(ctx, prID)
 	if err != nil {
 		log.Printf("Failed to get PR metrics: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "メトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
 		return
 	}
 
@@ -64,6 +79,99 @@ func (h *PRMetricsHandler) GetPRMetrics(w http.ResponseWriter, r *http.Request)
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// GetPRTimeline は指定されたPRの作成からマージまでのタイムラインを取得
+func (h *PRMetricsHandler) GetPRTimeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	prID := mux.Vars(r)["id"]
+
+	if prID == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PR_ID", "PR IDが指定されていません", nil)
+		return
+	}
+
+	metrics, err := h.prMetricsRepo.FindByPRID(ctx, prID)
+	if err != nil {
+		log.Printf("Failed to get PR metrics: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+
+	if metrics == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, "PR_NOT_FOUND", "指定されたPRが見つかりません", nil)
+		return
+	}
+
+	events, err := h.prMetricsRepo.FindReviewEvents(ctx, prID)
+	if err != nil {
+		log.Printf("Failed to get review events: %v", err)
+		h.writeDatabaseError(w, err, "レビューイベントの取得に失敗しました")
+		return
+	}
+
+	timeline := prDomain.BuildTimeline(metrics, events)
+	h.writeJSONResponse(w, http.StatusOK, h.presenter.ToTimelineResponse(metrics, timeline))
+}
+
+// reviewerSuggestionLookback はレビュアー推薦の根拠にする履歴の期間
+const reviewerSuggestionLookback = 90 * 24 * time.Hour
+
+// GetReviewerSuggestions は指定されたPRの変更ファイルの履歴からレビュアーを推薦
+// limit で推薦数を指定できる（既定5、最大20）
+func (h *PRMetricsHandler) GetReviewerSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	prID := mux.Vars(r)["id"]
+
+	if prID == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PR_ID", "PR IDが指定されていません", nil)
+		return
+	}
+
+	config := prDomain.DefaultReviewerRecommendationConfig()
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > 20 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", fmt.Sprintf("invalid limit: %s", limitStr), nil)
+			return
+		}
+		config.Limit = parsed
+	}
+
+	metrics, err := h.prMetricsRepo.FindByPRID(ctx, prID)
+	if err != nil {
+		log.Printf("Failed to get PR metrics: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+
+	if metrics == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, "PR_NOT_FOUND", "指定されたPRが見つかりません", nil)
+		return
+	}
+
+	now := time.Now()
+	history, err := h.prMetricsRepo.FindByRepository(ctx, metrics.Repository, now.Add(-reviewerSuggestionLookback), now)
+	if err != nil {
+		log.Printf("Failed to get PR metrics history for reviewer suggestions: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+
+	prIDs := []string{metrics.PRID}
+	for _, metric := range history {
+		prIDs = append(prIDs, metric.PRID)
+	}
+	events, err := h.prMetricsRepo.FindReviewEventsByPRIDs(ctx, prIDs)
+	if err != nil {
+		log.Printf("Failed to get review events for reviewer suggestions: %v", err)
+		h.writeDatabaseError(w, err, "レビューイベントの取得に失敗しました")
+		return
+	}
+
+	recommender := prDomain.NewReviewerRecommender(config, h.identities)
+	suggestions := recommender.RecommendForPR(metrics, history, events, now)
+	h.writeJSONResponse(w, http.StatusOK, h.presenter.ToReviewerSuggestionsResponse(metrics, suggestions))
+}
+
 // GetCycleTimeMetrics はサイクルタイムメトリクスを取得
 func (h *PRMetricsHandler) GetCycleTimeMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -79,9 +187,10 @@ func (h *PRMetricsHandler) GetCycleTimeMetrics(w http.ResponseWriter, r *http.Re
 	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
 	if err != nil {
 		log.Printf("Failed to get PR metrics for cycle time: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "メトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
 		return
 	}
+	metrics = h.filterMetrics(metrics, params)
 
 	// サイクルタイムメトリクスに変換
 	response := h.presenter.ToCycleTimeMetricsResponse(metrics, params.Period, params.StartDate, params.EndDate)
@@ -103,15 +212,300 @@ func (h *PRMetricsHandler) GetReviewTimeMetrics(w http.ResponseWriter, r *http.R
 	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
 	if err != nil {
 		log.Printf("Failed to get PR metrics for review time: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "メトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
 		return
 	}
+	metrics = h.filterMetrics(metrics, params)
 
 	// レビュー時間メトリクスに変換
 	response := h.presenter.ToReviewTimeMetricsResponse(metrics, params.Period, params.StartDate, params.EndDate)
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// GetAutomationMetrics はbotが作成したPRのメトリクスを取得
+func (h *PRMetricsHandler) GetAutomationMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	
+	// クエリパラメータの解析
+	params, err := h.parseDateRangeParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	// PRメトリクスを取得
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get PR metrics for automation: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+	metrics = h.filterMetricsWithBots(metrics, params)
+
+	// botのPRを集計
+	automationMetrics, err := h.metricsAggregator.AggregateAutomationMetrics(ctx, metrics, analyticsApp.AggregationPeriod(params.Period))
+	if err != nil {
+		log.Printf("Failed to aggregate automation metrics: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "メトリクスの集計に失敗しました", nil)
+		return
+	}
+
+	response := h.presenter.ToAutomationMetricsResponse(automationMetrics, params.Period, params.StartDate, params.EndDate)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// changeFailureLookback はリバート・ホットフィックスの元PRを集計期間より前に遡って探す期間
+const changeFailureLookback = 90 * 24 * time.Hour
+
+// GetChangeFailureMetrics はリバート・ホットフィックスのPRとリバート率を取得
+func (h *PRMetricsHandler) GetChangeFailureMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	
+	// クエリパラメータの解析
+	params, err := h.parseDateRangeParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	// PRメトリクスを取得
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get PR metrics for change failure: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+	metrics = h.filterMetrics(metrics, params)
+
+	// 元PRは作者・ラベルによらず、期間より前に作成されたPRからも探す
+	history, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate.Add(-changeFailureLookback), params.StartDate, nil, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get PR metrics history for change failure: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+
+	changeFailureMetrics, err := h.metricsAggregator.AggregateChangeFailureMetrics(ctx, metrics, history, analyticsApp.AggregationPeriod(params.Period))
+	if err != nil {
+		log.Printf("Failed to aggregate change failure metrics: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "メトリクスの集計に失敗しました", nil)
+		return
+	}
+
+	response := h.presenter.ToChangeFailureMetricsResponse(changeFailureMetrics, params.Period, params.StartDate, params.EndDate)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetReworkMetrics はマージ後の手戻り（同じファイルへの後続の変更）を取得
+func (h *PRMetricsHandler) GetReworkMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	
+	// クエリパラメータの解析
+	params, err := h.parseDateRangeParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	// PRメトリクスを取得
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get PR metrics for rework: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+	metrics = h.filterMetrics(metrics, params)
+
+	// 手戻りは作者・ラベルによらず、期間後に作成されたPRからも探す
+	followUps, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate.Add(h.metricsAggregator.ReworkWindow()), nil, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get follow-up PR metrics for rework: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+
+	reworkMetrics, err := h.metricsAggregator.AggregateReworkMetrics(ctx, metrics, followUps, analyticsApp.AggregationPeriod(params.Period))
+	if err != nil {
+		log.Printf("Failed to aggregate rework metrics: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "メトリクスの集計に失敗しました", nil)
+		return
+	}
+
+	response := h.presenter.ToReworkMetricsResponse(reworkMetrics, params.Period, params.StartDate, params.EndDate)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetReviewWorkload はレビュアーごとのレビュー負荷と偏りを取得
+// period が daily・weekly・monthly の場合はその単位で、それ以外は週ごとに推移を集計する
+func (h *PRMetricsHandler) GetReviewWorkload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	
+	// クエリパラメータの解析
+	params, err := h.parseDateRangeParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	// PRメトリクスを取得
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get PR metrics for review workload: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+	metrics = prDomain.FilterMetricsByLabels(metrics, params.Labels, params.ExcludeLabels)
+
+	prIDs := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		prIDs = append(prIDs, metric.PRID)
+	}
+	events, err := h.prMetricsRepo.FindReviewEventsByPRIDs(ctx, prIDs)
+	if err != nil {
+		log.Printf("Failed to get review events for review workload: %v", err)
+		h.writeDatabaseError(w, err, "レビューイベントの取得に失敗しました")
+		return
+	}
+
+	// team パラメータはPRの作者ではなくレビュアーの所属で絞り込む
+	var teams []teamDomain.Team
+	if params.Team != nil {
+		teams = []teamDomain.Team{*params.Team}
+	} else if h.teams != nil {
+		teams = h.teams.List()
+	}
+
+	bucketPeriod := analyticsApp.AggregationPeriod(params.Period)
+	switch bucketPeriod {
+	case analyticsApp.AggregationPeriodDaily, analyticsApp.AggregationPeriodWeekly, analyticsApp.AggregationPeriodMonthly:
+	default:
+		bucketPeriod = analyticsApp.AggregationPeriodWeekly
+	}
+
+	workload, err := h.metricsAggregator.AggregateReviewWorkload(ctx, metrics, events, teams, bucketPeriod)
+	if err != nil {
+		log.Printf("Failed to aggregate review workload: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "メトリクスの集計に失敗しました", nil)
+		return
+	}
+
+	response := h.presenter.ToReviewWorkloadResponse(workload, params.Period, params.StartDate, params.EndDate)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetReviewResponse はレビュー依頼ごとの、依頼されたレビュアーがレビューするまでの時間と未対応の依頼を取得
+func (h *PRMetricsHandler) GetReviewResponse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	
+	// クエリパラメータの解析
+	params, err := h.parseDateRangeParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	// PRメトリクスを取得
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get PR metrics for review response: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+	metrics = prDomain.FilterMetricsByLabels(metrics, params.Labels, params.ExcludeLabels)
+
+	prIDs := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		prIDs = append(prIDs, metric.PRID)
+	}
+	events, err := h.prMetricsRepo.FindReviewEventsByPRIDs(ctx, prIDs)
+	if err != nil {
+		log.Printf("Failed to get review events for review response: %v", err)
+		h.writeDatabaseError(w, err, "レビューイベントの取得に失敗しました")
+		return
+	}
+
+	// team パラメータはPRの作者ではなくレビュアーの所属で絞り込む
+	var teams []teamDomain.Team
+	if params.Team != nil {
+		teams = []teamDomain.Team{*params.Team}
+	} else if h.teams != nil {
+		teams = h.teams.List()
+	}
+
+	responseMetrics, err := h.metricsAggregator.AggregateReviewResponse(ctx, metrics, events, teams, time.Now())
+	if err != nil {
+		log.Printf("Failed to aggregate review response: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "メトリクスの集計に失敗しました", nil)
+		return
+	}
+
+	response := h.presenter.ToReviewResponseResponse(responseMetrics, params.Period, params.StartDate, params.EndDate)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetCodeOwnership はCODEOWNERSの所有者がレビューしたPRの割合と所有者のいない変更を取得
+// CODEOWNERSを取得できなかったリポジトリはCODEOWNERSなしとして集計する
+func (h *PRMetricsHandler) GetCodeOwnership(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	
+	// クエリパラメータの解析
+	params, err := h.parseDateRangeParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	// PRメトリクスを取得
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
+	if err != nil {
+		log.Printf("Failed to get PR metrics for code ownership: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+	metrics = h.filterMetrics(metrics, params)
+
+	prIDs := make([]string, 0, len(metrics))
+	codeOwners := make(map[string]*prDomain.CodeOwners)
+	looked := make(map[string]bool)
+	for _, metric := range metrics {
+		prIDs = append(prIDs, metric.PRID)
+		if looked[metric.Repository] {
+			continue
+		}
+		looked[metric.Repository] = true
+		owners, err := h.codeOwners.Lookup(ctx, metric.Repository)
+		if err != nil {
+			log.Printf("Failed to get CODEOWNERS of %s: %v", metric.Repository, err)
+			continue
+		}
+		if owners != nil {
+			codeOwners[metric.Repository] = owners
+		}
+	}
+
+	events, err := h.prMetricsRepo.FindReviewEventsByPRIDs(ctx, prIDs)
+	if err != nil {
+		log.Printf("Failed to get review events for code ownership: %v", err)
+		h.writeDatabaseError(w, err, "レビューイベントの取得に失敗しました")
+		return
+	}
+
+	var teams []teamDomain.Team
+	if h.teams != nil {
+		teams = h.teams.List()
+	}
+
+	ownership, err := h.metricsAggregator.AggregateCodeOwnership(ctx, metrics, events, codeOwners, teams, analyticsApp.AggregationPeriod(params.Period))
+	if err != nil {
+		log.Printf("Failed to aggregate code ownership: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "メトリクスの集計に失敗しました", nil)
+		return
+	}
+
+	response := h.presenter.ToCodeOwnershipResponse(ownership, params.Period, params.StartDate, params.EndDate)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
 // ListPRMetrics はPRメトリクスの一覧を取得
 func (h *PRMetricsHandler) ListPRMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -127,9 +521,10 @@ func (h *PRMetricsHandler) ListPRMetrics(w http.ResponseWriter, r *http.Request)
 	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, params.Developers, params.Repositories)
 	if err != nil {
 		log.Printf("Failed to get PR metrics list: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "メトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
 		return
 	}
+	metrics = h.filterMetricsWithBots(metrics, &params.DateRangeParams)
 
 	// ページング処理
 	totalCount := len(metrics)
@@ -167,13 +562,14 @@ func (h *PRMetricsHandler) GetDeveloperMetrics(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// 開発者のPRメトリクスを取得
-	metrics, err := h.prMetricsRepo.FindByDeveloper(ctx, developer, params.StartDate, params.EndDate)
+	// 開発者のPRメトリクスを取得（別アカウントも含める）
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, params.StartDate, params.EndDate, h.expandDevelopers([]string{developer}), nil)
 	if err != nil {
 		log.Printf("Failed to get developer metrics: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "メトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
 		return
 	}
+	metrics = h.filterMetricsWithBots(metrics, params)
 
 	// レスポンス形式に変換
 	response := h.presenter.ToPRListResponse(metrics, len(metrics), 1, len(metrics))
@@ -202,9 +598,10 @@ func (h *PRMetricsHandler) GetRepositoryMetrics(w http.ResponseWriter, r *http.R
 	metrics, err := h.prMetricsRepo.FindByRepository(ctx, repository, params.StartDate, params.EndDate)
 	if err != nil {
 		log.Printf("Failed to get repository metrics: %v", err)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "メトリクスの取得に失敗しました", nil)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
 		return
 	}
+	metrics = h.filterMetricsWithBots(metrics, params)
 
 	// レスポンス形式に変換
 	response := h.presenter.ToPRListResponse(metrics, len(metrics), 1, len(metrics))
@@ -259,11 +656,14 @@ func (h *PRMetricsHandler) GetHealthCheck(w http.ResponseWriter, r *http.Request
 // プライベートメソッド
 
 type DateRangeParams struct {
-	StartDate    time.Time
-	EndDate      time.Time
-	Period       string
-	Developers   []string
-	Repositories []string
+	StartDate     time.Time
+	EndDate       time.Time
+	Period        string
+	Developers    []string
+	Repositories  []string
+	Labels        []string
+	ExcludeLabels []string
+	Team          *teamDomain.Team // PR作成時点の所属で絞り込むチーム
 }
 
 type ListParams struct {
@@ -301,21 +701,72 @@ func (h *PRMetricsHandler) parseDateRangeParams(r *http.Request) (*DateRangePara
 		endDate = parsed
 	}
 
-	// 開発者フィルタ
-	developers := query["developers[]"]
+	// 開発者フィルタ（同一人物の別アカウントに展開）
+	developers := h.expandDevelopers(query["developers[]"])
 
 	// リポジトリフィルタ
 	repositories := query["repositories[]"]
 
+	// ラベルフィルタ
+	labels := query["labels[]"]
+	excludeLabels := query["excludeLabels[]"]
+
+	// チームフィルタ
+	var team *teamDomain.Team
+	if teamName := query.Get("team"); teamName != "" {
+		found, exists := h.teams.Get(teamName)
+		if !exists {
+			return nil, fmt.Errorf("unknown team: %s", teamName)
+		}
+		team = &found
+	}
+
 	return &DateRangeParams{
-		StartDate:    startDate,
-		EndDate:      endDate,
-		Period:       period,
-		Developers:   developers,
-		Repositories: repositories,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Period:        period,
+		Developers:    developers,
+		Repositories:  repositories,
+		Labels:        labels,
+		ExcludeLabels: excludeLabels,
+		Team:          team,
 	}, nil
 }
 
+// filterMetrics はラベル・チームの条件でメトリクスを絞り込み、設定に応じてbotのPRを除外する（集計用）
+func (h *PRMetricsHandler) filterMetrics(metrics []*prDomain.PRMetrics, params *DateRangeParams) []*prDomain.PRMetrics {
+	return h.metricsAggregator.ExcludeBots(h.filterMetricsWithBots(metrics, params))
+}
+
+// filterMetricsWithBots はラベル・チームの条件でメトリクスを絞り込む（botのPRも残す）
+// botのPRを集計するオートメーションと、PRごとに isBot を返す一覧で使う
+func (h *PRMetricsHandler) filterMetricsWithBots(metrics []*prDomain.PRMetrics, params *DateRangeParams) []*prDomain.PRMetrics {
+	metrics = prDomain.FilterMetricsByLabels(metrics, params.Labels, params.ExcludeLabels)
+	if params.Team != nil {
+		metrics = params.Team.FilterMetrics(metrics, h.identities)
+	}
+	return metrics
+}
+
+// expandDevelopers は開発者フィルタを登録済みの全ログイン名に展開
+func (h *PRMetricsHandler) expandDevelopers(developers []string) []string {
+	if h.identities == nil || len(developers) == 0 {
+		return developers
+	}
+
+	expanded := make([]string, 0, len(developers))
+	seen := make(map[string]bool)
+	for _, developer := range developers {
+		for _, login := range h.identities.ExpandLogins(developer) {
+			if !seen[login] {
+				seen[login] = true
+				expanded = append(expanded, login)
+			}
+		}
+	}
+	return expanded
+}
+
 func (h *PRMetricsHandler) parseListParams(r *http.Request) (*ListParams, error) {
 	dateParams, err := h.parseDateRangeParams(r)
 	if err != nil {
@@ -375,6 +826,23 @@ func (h *PRMetricsHandler) writeJSONResponse(w http.ResponseWriter, statusCode i
 	}
 }
 
+// writeDatabaseError はデータベースエラーをレスポンスに変換する
+// 接続できない場合は一時的な障害として 503 を返す
+func (h *PRMetricsHandler) writeDatabaseError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, database.ErrNotConfigured):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_NOT_CONFIGURED", "データベースが設定されていません", nil)
+	case database.IsTimeout(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_TIMEOUT", "データベースの応答がタイムアウトしました", nil)
+	case database.IsUnavailable(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "データベースに接続できません", nil)
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", message, nil)
+	}
+}
+
 func (h *PRMetricsHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
 	errorResponse := ErrorResponse{
 		Error:   http.StatusText(statusCode),
@@ -390,10 +858,18 @@ func (h *PRMetricsHandler) writeErrorResponse(w http.ResponseWriter, statusCode
 func (h *PRMetricsHandler) RegisterRoutes(router *mux.Router) {
 	// PRメトリクス個別取得
 	router.HandleFunc("/api/pull_requests/{id}/metrics", h.GetPRMetrics).Methods("GET")
+	router.HandleFunc("/api/pull_requests/{id}/timeline", h.GetPRTimeline).Methods("GET")
+	router.HandleFunc("/api/pull_requests/{id}/reviewer_suggestions", h.GetReviewerSuggestions).Methods("GET")
 	
 	// メトリクス集計API
 	router.HandleFunc("/api/metrics/cycle_time", h.GetCycleTimeMetrics).Methods("GET")
 	router.HandleFunc("/api/metrics/review_time", h.GetReviewTimeMetrics).Methods("GET")
+	router.HandleFunc("/api/metrics/automation", h.GetAutomationMetrics).Methods("GET")
+	router.HandleFunc("/api/metrics/change_failure", h.GetChangeFailureMetrics).Methods("GET")
+	router.HandleFunc("/api/metrics/rework", h.GetReworkMetrics).Methods("GET")
+	router.HandleFunc("/api/metrics/review_workload", h.GetReviewWorkload).Methods("GET")
+	router.HandleFunc("/api/metrics/review_response", h.GetReviewResponse).Methods("GET")
+	router.HandleFunc("/api/metrics/code_ownership", h.GetCodeOwnership).Methods("GET")
 	
 	// PRリスト取得
 	router.HandleFunc("/api/pull_requests", h.ListPRMetrics).Methods("GET")
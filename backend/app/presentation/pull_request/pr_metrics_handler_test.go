@@ -0,0 +1,193 @@
+package pull_request
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/infrastructure/memory"
+	"github-stats-metrics/infrastructure/repository"
+)
+
+func TestPRMetricsHandler_DatabaseUnavailable(t *testing.T) {
+	tests := []struct {
+		name         string
+		cause        error
+		expectedCode string
+		retryAfter   bool
+	}{
+		{"接続先が未設定", database.ErrNotConfigured, "DATABASE_NOT_CONFIGURED", false},
+		{"接続できない", database.ErrUnavailable, "DATABASE_UNAVAILABLE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := database.Disconnected(tt.cause)
+			defer db.Close()
+
+			repo := repository.NewPRMetricsRepositoryWithDialect(db, database.Postgres())
+			handler := NewPRMetricsHandler(repo, analyticsApp.NewMetricsAggregator(), nil, nil, nil)
+			router := mux.NewRouter()
+			handler.RegisterRoutes(router)
+
+			for _, path := range []string{"/api/metrics/cycle_time", "/api/pull_requests/pr-1/metrics"} {
+				recorder := httptest.NewRecorder()
+				router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+				if recorder.Code != http.StatusServiceUnavailable {
+					t.Fatalf("%s: status = %d, want 503", path, recorder.Code)
+				}
+
+				var response ErrorResponse
+				if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+					t.Fatalf("%s: failed to decode response: %v", path, err)
+				}
+				if response.Code != tt.expectedCode {
+					t.Errorf("%s: code = %s, want %s", path, response.Code, tt.expectedCode)
+				}
+				if hasRetryAfter := recorder.Header().Get("Retry-After") != ""; hasRetryAfter != tt.retryAfter {
+					t.Errorf("%s: Retry-After present = %v, want %v", path, hasRetryAfter, tt.retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestPRMetricsHandler_GetPRTimeline(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(8 * time.Hour)
+
+	repo := memory.NewPRMetricsRepository()
+	err := repo.Save(context.Background(), &prDomain.PRMetrics{
+		PRID:       "pr-1",
+		Author:     "alice",
+		Repository: "org/api",
+		CreatedAt:  createdAt,
+		MergedAt:   &mergedAt,
+		ReviewEvents: []prDomain.ReviewEvent{
+			{Type: prDomain.ReviewEventTypeApproved, CreatedAt: createdAt.Add(5 * time.Hour), Actor: "bob", Reviewer: "bob"},
+			{Type: prDomain.ReviewEventTypeRequested, CreatedAt: createdAt.Add(time.Hour), Actor: "alice", Reviewer: "bob"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to save metrics: %v", err)
+	}
+
+	handler := NewPRMetricsHandler(repo, analyticsApp.NewMetricsAggregator(), nil, nil, nil)
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	t.Run("作成からマージまでのステップと経過時間を返す", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/pull_requests/pr-1/timeline", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", recorder.Code)
+		}
+
+		var response PRTimelineResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		expectedTypes := []string{"opened", "requested", "approved", "merged"}
+		expectedSeconds := []int64{0, 3600, 4 * 3600, 3 * 3600}
+		if len(response.Steps) != len(expectedTypes) {
+			t.Fatalf("len(steps) = %d, want %d", len(response.Steps), len(expectedTypes))
+		}
+		for i, step := range response.Steps {
+			if step.Type != expectedTypes[i] {
+				t.Errorf("steps[%d].type = %s, want %s", i, step.Type, expectedTypes[i])
+			}
+			if step.SincePrevious.Seconds != expectedSeconds[i] {
+				t.Errorf("steps[%d].sincePrevious = %d, want %d", i, step.SincePrevious.Seconds, expectedSeconds[i])
+			}
+		}
+		if response.TotalDuration == nil || response.TotalDuration.Seconds != 8*3600 {
+			t.Errorf("totalDuration = %+v, want 8h", response.TotalDuration)
+		}
+	})
+
+	t.Run("存在しないPRは404", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/pull_requests/missing/timeline", nil))
+
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", recorder.Code)
+		}
+	})
+}
+
+func TestPRMetricsHandler_ExcludesBots(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(8 * time.Hour)
+
+	firstReview := time.Hour
+	quality := prDomain.PRQualityMetrics{ReviewRoundCount: 1}
+	timeMetrics := prDomain.PRTimeMetrics{TimeToFirstReview: &firstReview}
+
+	repo := memory.NewPRMetricsRepository()
+	for _, metrics := range []*prDomain.PRMetrics{
+		{PRID: "pr-1", Author: "alice", Repository: "org/api", CreatedAt: createdAt, MergedAt: &mergedAt, TimeMetrics: timeMetrics, QualityMetrics: quality},
+		{PRID: "pr-2", Author: "dependabot[bot]", Repository: "org/api", CreatedAt: createdAt, MergedAt: &mergedAt, TimeMetrics: timeMetrics, QualityMetrics: quality, IsBot: true},
+	} {
+		if err := repo.Save(context.Background(), metrics); err != nil {
+			t.Fatalf("failed to save metrics: %v", err)
+		}
+	}
+
+	serve := func(t *testing.T, aggregator *analyticsApp.MetricsAggregator, path string) int {
+		t.Helper()
+		handler := NewPRMetricsHandler(repo, aggregator, nil, nil, nil)
+		router := mux.NewRouter()
+		handler.RegisterRoutes(router)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path+"?startdate=2024-01-01&enddate=2024-01-31", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, want 200", path, recorder.Code)
+		}
+
+		var response struct {
+			TotalPRs int `json:"totalPRs"`
+		}
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", path, err)
+		}
+		return response.TotalPRs
+	}
+
+	t.Run("サイクルタイム・レビュー時間はデフォルトでbotのPRを除外する", func(t *testing.T) {
+		aggregator := analyticsApp.NewMetricsAggregator()
+		for _, path := range []string{"/api/metrics/cycle_time", "/api/metrics/review_time"} {
+			if total := serve(t, aggregator, path); total != 1 {
+				t.Errorf("%s: totalPRs = %d, want 1", path, total)
+			}
+		}
+	})
+
+	t.Run("IncludeBots の場合はbotのPRも含める", func(t *testing.T) {
+		config := analyticsApp.DefaultAggregatorConfig()
+		config.IncludeBots = true
+		aggregator := analyticsApp.NewMetricsAggregatorWithConfig(config)
+		for _, path := range []string{"/api/metrics/cycle_time", "/api/metrics/review_time"} {
+			if total := serve(t, aggregator, path); total != 2 {
+				t.Errorf("%s: totalPRs = %d, want 2", path, total)
+			}
+		}
+	})
+
+	t.Run("オートメーションはbotのPRを集計する", func(t *testing.T) {
+		if total := serve(t, analyticsApp.NewMetricsAggregator(), "/api/metrics/automation"); total != 1 {
+			t.Errorf("totalPRs = %d, want 1", total)
+		}
+	})
+}
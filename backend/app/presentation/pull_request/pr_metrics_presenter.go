@@ -2,9 +2,12 @@ package pull_request
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
+	analyticsApp "github-stats-metrics/application/analytics"
 	prDomain "github-stats-metrics/domain/pull_request"
+	"github-stats-metrics/shared/utils"
 )
 
 // PRMetricsPresenter はPRメトリクスのプレゼンター
@@ -29,6 +32,10 @@ func (presenter *PRMetricsPresenter) ToPRMetricsResponse(metrics *prDomain.PRMet
 		Repository: metrics.Repository,
 		CreatedAt:  metrics.CreatedAt,
 		MergedAt:   metrics.MergedAt,
+		Labels:     metrics.Labels,
+		IsBot:      metrics.IsBot,
+
+		ChangeFailure: presenter.toChangeFailureSignalsResponse(metrics.ChangeFailure),
 
 		SizeMetrics:    presenter.toSizeMetricsResponse(metrics.SizeMetrics),
 		TimeMetrics:    presenter.toTimeMetricsResponse(metrics.TimeMetrics),
@@ -38,6 +45,8 @@ func (presenter *PRMetricsPresenter) ToPRMetricsResponse(metrics *prDomain.PRMet
 		ComplexityLevel: string(presenter.complexityAnalyzer.GetComplexityLevel(metrics.ComplexityScore)),
 		SizeCategory:    string(metrics.SizeCategory),
 
+		DefinitionVersion: metrics.DefinitionVersion,
+
 		AnalysisResults: presenter.toAnalysisResultsResponse(metrics),
 	}
 }
@@ -52,6 +61,8 @@ func (presenter *PRMetricsPresenter) ToPRSummaryResponse(metrics *prDomain.PRMet
 		Repository:      metrics.Repository,
 		CreatedAt:       metrics.CreatedAt,
 		MergedAt:        metrics.MergedAt,
+		Labels:          metrics.Labels,
+		IsBot:           metrics.IsBot,
 		LinesChanged:    metrics.SizeMetrics.LinesChanged,
 		FilesChanged:    metrics.SizeMetrics.FilesChanged,
 		ComplexityScore: metrics.ComplexityScore,
@@ -59,6 +70,7 @@ func (presenter *PRMetricsPresenter) ToPRSummaryResponse(metrics *prDomain.PRMet
 		CycleTime:       presenter.toDurationResponse(metrics.TimeMetrics.TotalCycleTime),
 		ReviewTime:      presenter.toDurationResponse(metrics.TimeMetrics.TimeToFirstReview),
 		IsHighQuality:   metrics.IsHighQuality(),
+		DefinitionVersion: metrics.DefinitionVersion,
 	}
 }
 
@@ -74,6 +86,7 @@ func (presenter *PRMetricsPresenter) ToCycleTimeMetricsResponse(
 			StartDate: startDate,
 			EndDate:   endDate,
 			TotalPRs:  0,
+			Definitions: []DefinitionUsageResponse{},
 		}
 	}
 
@@ -110,7 +123,428 @@ func (presenter *PRMetricsPresenter) ToCycleTimeMetricsResponse(
 			TimeToApproval:    presenter.toCycleTimeStatsResponse(approvalTimes),
 			TimeToMerge:       presenter.toCycleTimeStatsResponse(mergeTimes),
 		},
-		Trends: presenter.calculateTrendResponse(cycleTimes),
+		Trends:      presenter.calculateTrendResponse(cycleTimes),
+		Definitions: presenter.toDefinitionUsageResponse(metrics),
+	}
+}
+
+// ToAutomationMetricsResponse はbotのPRメトリクスをレスポンス形式に変換
+func (presenter *PRMetricsPresenter) ToAutomationMetricsResponse(
+	metrics *analyticsApp.AutomationMetrics,
+	period string,
+	startDate, endDate time.Time,
+) *AutomationMetricsResponse {
+	response := &AutomationMetricsResponse{
+		Period:        period,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		TotalPRs:      metrics.TotalPRs,
+		MergedPRs:     metrics.MergedPRs,
+		ShareOfAllPRs: metrics.ShareOfAllPRs,
+		MergeLatency:  presenter.toDurationStatisticsResponse(metrics.MergeLatency),
+		Percentiles:   presenter.toDurationPercentilesResponse(metrics.MergeLatency),
+		Bots:          make([]BotAutomationResponse, 0, len(metrics.ByBot)),
+	}
+
+	for _, botStats := range metrics.ByBot {
+		response.Bots = append(response.Bots, BotAutomationResponse{
+			Bot:          botStats.Bot,
+			TotalPRs:     botStats.TotalPRs,
+			MergedPRs:    botStats.MergedPRs,
+			MergeLatency: presenter.toDurationStatisticsResponse(botStats.MergeLatency),
+		})
+	}
+
+	// PR数の多い順
+	sort.Slice(response.Bots, func(i, j int) bool {
+		if response.Bots[i].TotalPRs != response.Bots[j].TotalPRs {
+			return response.Bots[i].TotalPRs > response.Bots[j].TotalPRs
+		}
+		return response.Bots[i].Bot < response.Bots[j].Bot
+	})
+
+	return response
+}
+
+// ToChangeFailureMetricsResponse はリバート・ホットフィックスのメトリクスをレスポンス形式に変換
+func (presenter *PRMetricsPresenter) ToChangeFailureMetricsResponse(
+	metrics *analyticsApp.ChangeFailureMetrics,
+	period string,
+	startDate, endDate time.Time,
+) *ChangeFailureMetricsResponse {
+	response := &ChangeFailureMetricsResponse{
+		Period:       period,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		MergedPRs:    metrics.MergedPRs,
+		RevertedPRs:  metrics.RevertedPRs,
+		RevertRate:   metrics.RevertRate,
+		RevertPRs:    metrics.RevertPRs,
+		HotfixPRs:    metrics.HotfixPRs,
+		UnlinkedPRs:  metrics.UnlinkedPRs,
+		TimeToRevert: presenter.toDurationStatisticsResponse(metrics.TimeToRevert),
+		Authors:      presenter.toChangeFailureStatsResponses(metrics.ByAuthor),
+		Repositories: presenter.toChangeFailureStatsResponses(metrics.ByRepository),
+		Changes:      make([]ChangeFailureLinkResponse, 0, len(metrics.Links)),
+	}
+
+	// 新しい順
+	links := append([]prDomain.ChangeFailureLink(nil), metrics.Links...)
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].Change.CreatedAt.After(links[j].Change.CreatedAt)
+	})
+
+	for _, link := range links {
+		linkResponse := ChangeFailureLinkResponse{
+			Type:         string(link.Change.ChangeFailure.Type),
+			Signals:      link.Change.ChangeFailure.Signals,
+			PR:           presenter.toPRReferenceResponse(link.Change),
+			TimeToRevert: presenter.toDurationResponse(link.TimeToRevert()),
+		}
+		if link.Original != nil {
+			original := presenter.toPRReferenceResponse(link.Original)
+			linkResponse.Original = &original
+		}
+		response.Changes = append(response.Changes, linkResponse)
+	}
+
+	return response
+}
+
+// ToReworkMetricsResponse はマージ後の手戻りのメトリクスをレスポンス形式に変換
+// PRは手戻りのあったもののみを手戻りの行数の多い順に返す
+func (presenter *PRMetricsPresenter) ToReworkMetricsResponse(
+	metrics *analyticsApp.ReworkMetrics,
+	period string,
+	startDate, endDate time.Time,
+) *ReworkMetricsResponse {
+	response := &ReworkMetricsResponse{
+		Period:       period,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		WindowDays:   int(metrics.Window / (24 * time.Hour)),
+		MergedPRs:    metrics.MergedPRs,
+		ReworkedPRs:  metrics.ReworkedPRs,
+		LinesAdded:   metrics.LinesAdded,
+		ReworkLines:  metrics.ReworkLines,
+		ChurnRate:    metrics.ChurnRate,
+		Developers:   presenter.toReworkStatsResponses(metrics.ByDeveloper),
+		Repositories: presenter.toReworkStatsResponses(metrics.ByRepository),
+		Directories:  presenter.toReworkStatsResponses(metrics.ByDirectory),
+		PRs:          make([]PRReworkResponse, 0, metrics.ReworkedPRs),
+	}
+
+	for _, rework := range metrics.PRs {
+		if rework.ReworkLines == 0 {
+			continue
+		}
+		prResponse := PRReworkResponse{
+			PR:          presenter.toPRReferenceResponse(rework.PR),
+			LinesAdded:  rework.LinesAdded,
+			ReworkLines: rework.ReworkLines,
+			ChurnRate:   float64(rework.ReworkLines) / float64(rework.LinesAdded),
+			Files:       []FileReworkResponse{},
+		}
+		for _, file := range rework.Files {
+			if file.ReworkLines == 0 {
+				continue
+			}
+			fileResponse := FileReworkResponse{
+				FileName:    file.FileName,
+				LinesAdded:  file.LinesAdded,
+				ReworkLines: file.ReworkLines,
+				ReworkedBy:  make([]PRReferenceResponse, 0, len(file.ReworkedBy)),
+			}
+			for _, later := range file.ReworkedBy {
+				fileResponse.ReworkedBy = append(fileResponse.ReworkedBy, presenter.toPRReferenceResponse(later))
+			}
+			prResponse.Files = append(prResponse.Files, fileResponse)
+		}
+		response.PRs = append(response.PRs, prResponse)
+	}
+
+	sort.SliceStable(response.PRs, func(i, j int) bool {
+		return response.PRs[i].ReworkLines > response.PRs[j].ReworkLines
+	})
+
+	return response
+}
+
+// ToCodeOwnershipResponse はCODEOWNERSの所有者によるレビューの集計をレスポンス形式に変換
+// 所有者はPR数の多い順、所有者のいないPRは変更ファイルの多い順に返す
+func (presenter *PRMetricsPresenter) ToCodeOwnershipResponse(
+	metrics *analyticsApp.CodeOwnershipMetrics,
+	period string,
+	startDate, endDate time.Time,
+) *CodeOwnershipResponse {
+	response := &CodeOwnershipResponse{
+		Period:                        period,
+		StartDate:                     startDate,
+		EndDate:                       endDate,
+		TotalPRs:                      metrics.TotalPRs,
+		CoveredPRs:                    metrics.CoveredPRs,
+		OwnedPRs:                      metrics.OwnedPRs,
+		OwnerReviewedPRs:              metrics.OwnerReviewedPRs,
+		OwnerReviewRate:               metrics.OwnerReviewRate,
+		TimeToOwnerReview:             presenter.toDurationStatisticsResponse(metrics.TimeToOwnerReview),
+		UnownedPRs:                    metrics.UnownedPRs,
+		UnownedFiles:                  metrics.UnownedFiles,
+		RepositoriesWithoutCodeOwners: metrics.RepositoriesWithoutCodeOwners,
+		Owners:                        make([]OwnershipStatsResponse, 0, len(metrics.ByOwner)),
+		UnownedChanges:                make([]UnownedChangeResponse, 0, len(metrics.Unowned)),
+	}
+
+	for _, stats := range metrics.ByOwner {
+		response.Owners = append(response.Owners, OwnershipStatsResponse{
+			Owner:        stats.Owner,
+			Team:         stats.Team,
+			PRs:          stats.PRs,
+			Files:        stats.Files,
+			ReviewedPRs:  stats.ReviewedPRs,
+			ReviewRate:   stats.ReviewRate,
+			TimeToReview: presenter.toDurationStatisticsResponse(stats.TimeToReview),
+		})
+	}
+	sort.Slice(response.Owners, func(i, j int) bool {
+		if response.Owners[i].PRs != response.Owners[j].PRs {
+			return response.Owners[i].PRs > response.Owners[j].PRs
+		}
+		return response.Owners[i].Owner < response.Owners[j].Owner
+	})
+
+	for _, change := range metrics.Unowned {
+		response.UnownedChanges = append(response.UnownedChanges, UnownedChangeResponse{
+			PR:    presenter.toPRReferenceResponse(change.PR),
+			Files: change.Files,
+		})
+	}
+	sort.SliceStable(response.UnownedChanges, func(i, j int) bool {
+		return len(response.UnownedChanges[i].Files) > len(response.UnownedChanges[j].Files)
+	})
+
+	return response
+}
+
+// ToReviewerSuggestionsResponse はレビュアー推薦をレスポンス形式に変換
+func (presenter *PRMetricsPresenter) ToReviewerSuggestionsResponse(metrics *prDomain.PRMetrics, suggestions []prDomain.ReviewerSuggestion) *ReviewerSuggestionsResponse {
+	response := &ReviewerSuggestionsResponse{
+		PR:          presenter.toPRReferenceResponse(metrics),
+		Suggestions: make([]ReviewerSuggestionResponse, 0, len(suggestions)),
+	}
+	for _, suggestion := range suggestions {
+		response.Suggestions = append(response.Suggestions, ReviewerSuggestionResponse{
+			Reviewer:           suggestion.Reviewer,
+			Score:              suggestion.Score,
+			Expertise:          suggestion.Expertise,
+			AuthoredPRs:        suggestion.AuthoredPRs,
+			ReviewedPRs:        suggestion.ReviewedPRs,
+			MatchedPaths:       suggestion.MatchedPaths,
+			LastActivityAt:     suggestion.LastActivityAt,
+			PendingReviews:     suggestion.PendingReviews,
+			MedianResponseTime: presenter.toDurationResponse(suggestion.MedianResponseTime),
+		})
+	}
+	return response
+}
+
+// ToReviewWorkloadResponse はレビュー負荷の集計をレスポンス形式に変換
+func (presenter *PRMetricsPresenter) ToReviewWorkloadResponse(
+	metrics *analyticsApp.ReviewWorkloadMetrics,
+	period string,
+	startDate, endDate time.Time,
+) *ReviewWorkloadResponse {
+	response := &ReviewWorkloadResponse{
+		Period:             period,
+		BucketPeriod:       string(metrics.Period),
+		StartDate:          startDate,
+		EndDate:            endDate,
+		AlertShare:         metrics.AlertShare,
+		TotalRequests:      metrics.TotalRequests,
+		TotalReviews:       metrics.TotalReviews,
+		TotalLinesReviewed: metrics.TotalLinesReviewed,
+		GiniCoefficient:    metrics.GiniCoefficient,
+		BalanceIndex:       metrics.BalanceIndex,
+		Reviewers:          presenter.toReviewerLoadResponses(metrics.Reviewers),
+		Periods:            make([]ReviewWorkloadPeriodResponse, 0, len(metrics.Periods)),
+		Teams:              make([]TeamReviewBalanceResponse, 0, len(metrics.Teams)),
+		Alerts:             make([]ReviewLoadAlertResponse, 0, len(metrics.Alerts)),
+	}
+
+	for _, bucket := range metrics.Periods {
+		response.Periods = append(response.Periods, ReviewWorkloadPeriodResponse{
+			Start:           bucket.Start,
+			End:             bucket.End,
+			TotalReviews:    bucket.TotalReviews,
+			GiniCoefficient: bucket.GiniCoefficient,
+			BalanceIndex:    bucket.BalanceIndex,
+			Reviewers:       presenter.toReviewerLoadResponses(bucket.Reviewers),
+		})
+	}
+	for _, team := range metrics.Teams {
+		response.Teams = append(response.Teams, TeamReviewBalanceResponse{
+			Team:            team.Team,
+			TotalReviews:    team.TotalReviews,
+			GiniCoefficient: team.GiniCoefficient,
+			BalanceIndex:    team.BalanceIndex,
+			Reviewers:       presenter.toReviewerLoadResponses(team.Reviewers),
+		})
+	}
+	for _, alert := range metrics.Alerts {
+		response.Alerts = append(response.Alerts, ReviewLoadAlertResponse{
+			Reviewer:     alert.Reviewer,
+			Team:         alert.Team,
+			ReviewsGiven: alert.ReviewsGiven,
+			TotalReviews: alert.TotalReviews,
+			Share:        alert.Share,
+			Threshold:    alert.Threshold,
+		})
+	}
+
+	return response
+}
+
+// ToReviewResponseResponse はレビュー依頼への応答時間の集計をレスポンス形式に変換
+// 未対応の依頼は待ち時間の長い順に返す
+func (presenter *PRMetricsPresenter) ToReviewResponseResponse(
+	metrics *analyticsApp.ReviewResponseMetrics,
+	period string,
+	startDate, endDate time.Time,
+) *ReviewResponseResponse {
+	response := &ReviewResponseResponse{
+		Period:             period,
+		StartDate:          startDate,
+		EndDate:            endDate,
+		TotalRequests:      metrics.TotalRequests,
+		Reviewed:           metrics.Reviewed,
+		Removed:            metrics.Removed,
+		Unanswered:         metrics.Unanswered,
+		ResponseRate:       metrics.ResponseRate,
+		ResponseTime:       presenter.toDurationStatisticsResponse(metrics.ResponseTime),
+		Percentiles:        presenter.toDurationPercentilesResponse(metrics.ResponseTime),
+		Reviewers:          presenter.toReviewResponseStatsResponses(metrics.Reviewers),
+		Teams:              presenter.toReviewResponseStatsResponses(metrics.Teams),
+		UnansweredRequests: make([]UnansweredReviewRequestResponse, 0, len(metrics.UnansweredRequests)),
+	}
+
+	for _, unanswered := range metrics.UnansweredRequests {
+		waitingTime := unanswered.WaitingTime
+		response.UnansweredRequests = append(response.UnansweredRequests, UnansweredReviewRequestResponse{
+			PR:          presenter.toPRReferenceResponse(unanswered.Request.PR),
+			Reviewer:    unanswered.Request.Reviewer,
+			RequestedAt: unanswered.Request.RequestedAt,
+			WaitingTime: presenter.toDurationResponse(&waitingTime),
+		})
+	}
+
+	return response
+}
+
+// toReviewResponseStatsResponses はレビュアー・チームごとの応答時間を変換（並び順は集計結果のまま）
+func (presenter *PRMetricsPresenter) toReviewResponseStatsResponses(stats []*analyticsApp.ReviewResponseStats) []ReviewResponseStatsResponse {
+	responses := make([]ReviewResponseStatsResponse, 0, len(stats))
+	for _, s := range stats {
+		responses = append(responses, ReviewResponseStatsResponse{
+			Name:          s.Name,
+			TotalRequests: s.TotalRequests,
+			Reviewed:      s.Reviewed,
+			Removed:       s.Removed,
+			Unanswered:    s.Unanswered,
+			ResponseRate:  s.ResponseRate,
+			ResponseTime:  presenter.toDurationStatisticsResponse(s.ResponseTime),
+			Percentiles:   presenter.toDurationPercentilesResponse(s.ResponseTime),
+		})
+	}
+	return responses
+}
+
+// toReviewerLoadResponses はレビュアーごとの負荷を変換（並び順は集計結果のまま）
+func (presenter *PRMetricsPresenter) toReviewerLoadResponses(loads []*analyticsApp.ReviewerLoad) []ReviewerLoadResponse {
+	responses := make([]ReviewerLoadResponse, 0, len(loads))
+	for _, load := range loads {
+		responses = append(responses, ReviewerLoadResponse{
+			Reviewer:         load.Reviewer,
+			RequestsReceived: load.RequestsReceived,
+			ReviewsGiven:     load.ReviewsGiven,
+			LinesReviewed:    load.LinesReviewed,
+			ReviewShare:      load.ReviewShare,
+		})
+	}
+	return responses
+}
+
+// toReworkStatsResponses は開発者・リポジトリ・ディレクトリ別の手戻りを手戻りの割合の高い順に変換
+func (presenter *PRMetricsPresenter) toReworkStatsResponses(stats map[string]*analyticsApp.ReworkStats) []ReworkStatsResponse {
+	responses := make([]ReworkStatsResponse, 0, len(stats))
+	for _, stat := range stats {
+		responses = append(responses, ReworkStatsResponse{
+			Key:         stat.Key,
+			Repository:  stat.Repository,
+			Directory:   stat.Directory,
+			MergedPRs:   stat.MergedPRs,
+			ReworkedPRs: stat.ReworkedPRs,
+			LinesAdded:  stat.LinesAdded,
+			ReworkLines: stat.ReworkLines,
+			ChurnRate:   stat.ChurnRate,
+		})
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		if responses[i].ChurnRate != responses[j].ChurnRate {
+			return responses[i].ChurnRate > responses[j].ChurnRate
+		}
+		return responses[i].Key < responses[j].Key
+	})
+
+	return responses
+}
+
+// toChangeFailureStatsResponses は作者・リポジトリ別の統計をリバート率の高い順に変換
+func (presenter *PRMetricsPresenter) toChangeFailureStatsResponses(stats map[string]*analyticsApp.ChangeFailureStats) []ChangeFailureStatsResponse {
+	responses := make([]ChangeFailureStatsResponse, 0, len(stats))
+	for _, stat := range stats {
+		responses = append(responses, ChangeFailureStatsResponse{
+			Key:          stat.Key,
+			MergedPRs:    stat.MergedPRs,
+			RevertedPRs:  stat.RevertedPRs,
+			RevertRate:   stat.RevertRate,
+			HotfixPRs:    stat.HotfixPRs,
+			TimeToRevert: presenter.toDurationStatisticsResponse(stat.TimeToRevert),
+		})
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		if responses[i].RevertRate != responses[j].RevertRate {
+			return responses[i].RevertRate > responses[j].RevertRate
+		}
+		return responses[i].Key < responses[j].Key
+	})
+
+	return responses
+}
+
+// toChangeFailureSignalsResponse はリバート・ホットフィックスの判定結果を変換（該当しない場合は nil）
+func (presenter *PRMetricsPresenter) toChangeFailureSignalsResponse(signals prDomain.ChangeFailureSignals) *ChangeFailureSignalsResponse {
+	if signals.Type == "" {
+		return nil
+	}
+	return &ChangeFailureSignalsResponse{
+		Type:              string(signals.Type),
+		Signals:           signals.Signals,
+		RevertedTitle:     signals.RevertedTitle,
+		RevertedPRNumber:  signals.RevertedPRNumber,
+		ReferencedCommits: signals.ReferencedCommits,
+	}
+}
+
+// toPRReferenceResponse はPRメトリクスを参照用の要約に変換
+func (presenter *PRMetricsPresenter) toPRReferenceResponse(metrics *prDomain.PRMetrics) PRReferenceResponse {
+	return PRReferenceResponse{
+		PRID:       metrics.PRID,
+		PRNumber:   metrics.PRNumber,
+		Title:      metrics.Title,
+		Author:     metrics.Author,
+		Repository: metrics.Repository,
+		MergedAt:   metrics.MergedAt,
 	}
 }
 
@@ -126,6 +560,7 @@ func (presenter *PRMetricsPresenter) ToReviewTimeMetricsResponse(
 			StartDate: startDate,
 			EndDate:   endDate,
 			TotalPRs:  0,
+			Definitions: []DefinitionUsageResponse{},
 		}
 	}
 
@@ -174,6 +609,7 @@ func (presenter *PRMetricsPresenter) ToReviewTimeMetricsResponse(
 		EfficiencyMetrics: presenter.calculateReviewEfficiency(metrics),
 		Bottlenecks:       bottlenecks,
 		Trends:           presenter.calculateTrendResponse(reviewTimes),
+		Definitions:      presenter.toDefinitionUsageResponse(metrics),
 	}
 }
 
@@ -234,6 +670,8 @@ func (presenter *PRMetricsPresenter) toTimeMetricsResponse(metrics prDomain.PRTi
 		ReviewWaitTime:     presenter.toDurationResponse(metrics.ReviewWaitTime),
 		ReviewActiveTime:   presenter.toDurationResponse(metrics.ReviewActiveTime),
 		FirstCommitToMerge: presenter.toDurationResponse(metrics.FirstCommitToMerge),
+		TimeInDraft:        presenter.toDurationResponse(metrics.TimeInDraft),
+		ReadyForReviewAt:   metrics.ReadyForReviewAt,
 		CreatedHour:        metrics.CreatedHour,
 		MergedHour:         metrics.MergedHour,
 	}
@@ -245,6 +683,7 @@ func (presenter *PRMetricsPresenter) toQualityMetricsResponse(metrics prDomain.P
 		ReviewRoundCount:      metrics.ReviewRoundCount,
 		ReviewerCount:         metrics.ReviewerCount,
 		ReviewersInvolved:     metrics.ReviewersInvolved,
+		BotReviewersInvolved:  metrics.BotReviewersInvolved,
 		CommitCount:           metrics.CommitCount,
 		FixupCommitCount:      metrics.FixupCommitCount,
 		ForceUpdateCount:      metrics.ForceUpdateCount,
@@ -272,6 +711,56 @@ func (presenter *PRMetricsPresenter) toAnalysisResultsResponse(metrics *prDomain
 	}
 }
 
+// ToTimelineResponse はPRのタイムラインをレスポンス形式に変換
+func (presenter *PRMetricsPresenter) ToTimelineResponse(metrics *prDomain.PRMetrics, timeline *prDomain.PRTimeline) *PRTimelineResponse {
+	steps := make([]TimelineStepResponse, 0, len(timeline.Steps))
+	for _, step := range timeline.Steps {
+		sincePrevious := step.SincePrevious
+		sinceOpened := step.SinceOpened
+		steps = append(steps, TimelineStepResponse{
+			Type:          string(step.Type),
+			At:            step.At,
+			Actor:         step.Actor,
+			Reviewer:      step.Reviewer,
+			IsBot:         step.IsBot,
+			SincePrevious: *presenter.toDurationResponse(&sincePrevious),
+			SinceOpened:   *presenter.toDurationResponse(&sinceOpened),
+		})
+	}
+
+	return &PRTimelineResponse{
+		PRID:          metrics.PRID,
+		PRNumber:      metrics.PRNumber,
+		Title:         metrics.Title,
+		Author:        metrics.Author,
+		Repository:    metrics.Repository,
+		CreatedAt:     metrics.CreatedAt,
+		MergedAt:      metrics.MergedAt,
+		TotalDuration: presenter.toDurationResponse(timeline.TotalDuration),
+		Steps:         steps,
+	}
+}
+
+// toDefinitionUsageResponse は計算定義のバージョンごとのPR数をPR数の多い順で返す
+func (presenter *PRMetricsPresenter) toDefinitionUsageResponse(metrics []*prDomain.PRMetrics) []DefinitionUsageResponse {
+	counts := make(map[string]int)
+	for _, metric := range metrics {
+		counts[metric.DefinitionVersion]++
+	}
+
+	usages := make([]DefinitionUsageResponse, 0, len(counts))
+	for version, count := range counts {
+		usages = append(usages, DefinitionUsageResponse{Version: version, PRCount: count})
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].PRCount != usages[j].PRCount {
+			return usages[i].PRCount > usages[j].PRCount
+		}
+		return usages[i].Version < usages[j].Version
+	})
+	return usages
+}
+
 func (presenter *PRMetricsPresenter) toDurationResponse(duration *time.Duration) *DurationResponse {
 	if duration == nil {
 		return nil
@@ -341,6 +830,35 @@ func (presenter *PRMetricsPresenter) toCycleTimeStatsResponse(durations []time.D
 	}
 }
 
+func (presenter *PRMetricsPresenter) toDurationStatisticsResponse(stats utils.DurationStatistics) CycleTimeStatsResponse {
+	if stats.Count == 0 {
+		return CycleTimeStatsResponse{}
+	}
+
+	return CycleTimeStatsResponse{
+		Mean:   presenter.toDurationResponse(&stats.Mean),
+		Median: presenter.toDurationResponse(&stats.Median),
+		Min:    presenter.toDurationResponse(&stats.Min),
+		Max:    presenter.toDurationResponse(&stats.Max),
+		StdDev: presenter.toDurationResponse(&stats.StdDev),
+	}
+}
+
+func (presenter *PRMetricsPresenter) toDurationPercentilesResponse(stats utils.DurationStatistics) PercentilesResponse {
+	if stats.Count == 0 {
+		return PercentilesResponse{}
+	}
+
+	percentiles := stats.Percentiles
+	return PercentilesResponse{
+		P25: presenter.toDurationResponse(&percentiles.P25),
+		P50: presenter.toDurationResponse(&percentiles.P50),
+		P75: presenter.toDurationResponse(&percentiles.P75),
+		P90: presenter.toDurationResponse(&percentiles.P90),
+		P95: presenter.toDurationResponse(&percentiles.P95),
+	}
+}
+
 func (presenter *PRMetricsPresenter) toPercentilesResponse(durations []time.Duration) PercentilesResponse {
 	if len(durations) == 0 {
 		return PercentilesResponse{}
@@ -14,6 +14,11 @@ type PRMetricsResponse struct {
 	Repository string    `json:"repository"`
 	CreatedAt  time.Time `json:"createdAt"`
 	MergedAt   *time.Time `json:"mergedAt,omitempty"`
+	Labels     []string   `json:"labels"`
+	IsBot      bool       `json:"isBot"`
+
+	// リバート・ホットフィックスの判定結果（該当しない場合は省略）
+	ChangeFailure *ChangeFailureSignalsResponse `json:"changeFailure,omitempty"`
 
 	// サイズメトリクス
 	SizeMetrics PRSizeMetricsResponse `json:"sizeMetrics"`
@@ -29,10 +34,22 @@ type PRMetricsResponse struct {
 	ComplexityLevel  string  `json:"complexityLevel"`
 	SizeCategory     string  `json:"sizeCategory"`
 
+	// 計算に使った定義バージョン（未計算の場合は空）
+	DefinitionVersion string `json:"definitionVersion,omitempty"`
+
 	// 分析結果
 	AnalysisResults PRAnalysisResultsResponse `json:"analysisResults"`
 }
 
+// ChangeFailureSignalsResponse はリバート・ホットフィックスの判定結果のレスポンス
+type ChangeFailureSignalsResponse struct {
+	Type              string   `json:"type"`
+	Signals           []string `json:"signals"`
+	RevertedTitle     string   `json:"revertedTitle,omitempty"`
+	RevertedPRNumber  int      `json:"revertedPrNumber,omitempty"`
+	ReferencedCommits []string `json:"referencedCommits,omitempty"`
+}
+
 // PRSizeMetricsResponse はPRサイズメトリクスのレスポンス
 type PRSizeMetricsResponse struct {
 	LinesAdded        int                       `json:"linesAdded"`
@@ -64,10 +81,36 @@ type PRTimeMetricsResponse struct {
 	ReviewWaitTime     *DurationResponse `json:"reviewWaitTime,omitempty"`
 	ReviewActiveTime   *DurationResponse `json:"reviewActiveTime,omitempty"`
 	FirstCommitToMerge *DurationResponse `json:"firstCommitToMerge,omitempty"`
+	TimeInDraft        *DurationResponse `json:"timeInDraft,omitempty"`
+	ReadyForReviewAt   *time.Time        `json:"readyForReviewAt,omitempty"`
 	CreatedHour        int               `json:"createdHour"`
 	MergedHour         *int              `json:"mergedHour,omitempty"`
 }
 
+// PRTimelineResponse はPRタイムラインのレスポンス
+type PRTimelineResponse struct {
+	PRID          string                 `json:"prId"`
+	PRNumber      int                    `json:"prNumber"`
+	Title         string                 `json:"title"`
+	Author        string                 `json:"author"`
+	Repository    string                 `json:"repository"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	MergedAt      *time.Time             `json:"mergedAt,omitempty"`
+	TotalDuration *DurationResponse      `json:"totalDuration,omitempty"`
+	Steps         []TimelineStepResponse `json:"steps"`
+}
+
+// TimelineStepResponse はタイムラインの各ステップのレスポンス
+type TimelineStepResponse struct {
+	Type          string           `json:"type"`
+	At            time.Time        `json:"at"`
+	Actor         string           `json:"actor,omitempty"`
+	Reviewer      string           `json:"reviewer,omitempty"`
+	IsBot         bool             `json:"isBot"`
+	SincePrevious DurationResponse `json:"sincePrevious"`
+	SinceOpened   DurationResponse `json:"sinceOpened"`
+}
+
 // DurationResponse は時間の人間が読みやすい形式のレスポンス
 type DurationResponse struct {
 	Seconds     int64  `json:"seconds"`
@@ -80,6 +123,7 @@ type PRQualityMetricsResponse struct {
 	ReviewRoundCount      int      `json:"reviewRoundCount"`
 	ReviewerCount         int      `json:"reviewerCount"`
 	ReviewersInvolved     []string `json:"reviewersInvolved"`
+	BotReviewersInvolved  []string `json:"botReviewersInvolved,omitempty"`
 	CommitCount           int      `json:"commitCount"`
 	FixupCommitCount      int      `json:"fixupCommitCount"`
 	ForceUpdateCount      int      `json:"forceUpdateCount"`
@@ -117,6 +161,14 @@ type CycleTimeMetricsResponse struct {
 	Percentiles PercentilesResponse      `json:"percentiles"`
 	Breakdown   CycleTimeBreakdownResponse `json:"breakdown"`
 	Trends      TrendResponse            `json:"trends"`
+	Definitions []DefinitionUsageResponse `json:"definitions"` // 集計したPRの計算に使われた定義
+}
+
+// DefinitionUsageResponse は計算定義ごとのPR数のレスポンス
+// リポジトリごとの設定や再計算前のPRが混在すると複数になる（未計算のPRは空のバージョン）
+type DefinitionUsageResponse struct {
+	Version string `json:"version"`
+	PRCount int    `json:"prCount"`
 }
 
 // CycleTimeStatsResponse はサイクルタイム統計のレスポンス
@@ -155,6 +207,7 @@ type ReviewTimeMetricsResponse struct {
 	EfficiencyMetrics ReviewEfficiencyResponse  `json:"efficiencyMetrics"`
 	Bottlenecks      []BottleneckResponse       `json:"bottlenecks"`
 	Trends           TrendResponse              `json:"trends"`
+	Definitions      []DefinitionUsageResponse  `json:"definitions"` // 集計したPRの計算に使われた定義
 }
 
 // ReviewTimeStatsResponse はレビュー時間統計のレスポンス
@@ -192,6 +245,266 @@ type TrendResponse struct {
 	Description string  `json:"description"`
 }
 
+// AutomationMetricsResponse はbotが作成したPRのメトリクスのレスポンス
+type AutomationMetricsResponse struct {
+	Period        string                    `json:"period"`
+	StartDate     time.Time                 `json:"startDate"`
+	EndDate       time.Time                 `json:"endDate"`
+	TotalPRs      int                       `json:"totalPRs"`
+	MergedPRs     int                       `json:"mergedPRs"`
+	ShareOfAllPRs float64                   `json:"shareOfAllPRs"`
+	MergeLatency  CycleTimeStatsResponse    `json:"mergeLatency"`
+	Percentiles   PercentilesResponse       `json:"percentiles"`
+	Bots          []BotAutomationResponse   `json:"bots"`
+}
+
+// BotAutomationResponse はbotアカウント別の統計のレスポンス
+type BotAutomationResponse struct {
+	Bot          string                 `json:"bot"`
+	TotalPRs     int                    `json:"totalPRs"`
+	MergedPRs    int                    `json:"mergedPRs"`
+	MergeLatency CycleTimeStatsResponse `json:"mergeLatency"`
+}
+
+// ChangeFailureMetricsResponse はリバート・ホットフィックスのメトリクスのレスポンス
+type ChangeFailureMetricsResponse struct {
+	Period       string                       `json:"period"`
+	StartDate    time.Time                    `json:"startDate"`
+	EndDate      time.Time                    `json:"endDate"`
+	MergedPRs    int                          `json:"mergedPRs"`
+	RevertedPRs  int                          `json:"revertedPRs"`
+	RevertRate   float64                      `json:"revertRate"`
+	RevertPRs    int                          `json:"revertPRs"`
+	HotfixPRs    int                          `json:"hotfixPRs"`
+	UnlinkedPRs  int                          `json:"unlinkedPRs"`
+	TimeToRevert CycleTimeStatsResponse       `json:"timeToRevert"`
+	Authors      []ChangeFailureStatsResponse `json:"authors"`
+	Repositories []ChangeFailureStatsResponse `json:"repositories"`
+	Changes      []ChangeFailureLinkResponse  `json:"changes"`
+}
+
+// ChangeFailureStatsResponse は作者・リポジトリ別のリバート率のレスポンス
+type ChangeFailureStatsResponse struct {
+	Key          string                 `json:"key"`
+	MergedPRs    int                    `json:"mergedPRs"`
+	RevertedPRs  int                    `json:"revertedPRs"`
+	RevertRate   float64                `json:"revertRate"`
+	HotfixPRs    int                    `json:"hotfixPRs"`
+	TimeToRevert CycleTimeStatsResponse `json:"timeToRevert"`
+}
+
+// ChangeFailureLinkResponse はリバート・ホットフィックスのPRと元PRのレスポンス
+type ChangeFailureLinkResponse struct {
+	Type         string               `json:"type"`
+	Signals      []string             `json:"signals"`
+	PR           PRReferenceResponse  `json:"pr"`
+	Original     *PRReferenceResponse `json:"original,omitempty"`
+	TimeToRevert *DurationResponse    `json:"timeToRevert,omitempty"`
+}
+
+// PRReferenceResponse は他のPRを参照する際の要約のレスポンス
+type PRReferenceResponse struct {
+	PRID       string     `json:"prId"`
+	PRNumber   int        `json:"prNumber"`
+	Title      string     `json:"title"`
+	Author     string     `json:"author"`
+	Repository string     `json:"repository"`
+	MergedAt   *time.Time `json:"mergedAt,omitempty"`
+}
+
+// ReworkMetricsResponse はマージ後の手戻りのメトリクスのレスポンス
+type ReworkMetricsResponse struct {
+	Period       string                `json:"period"`
+	StartDate    time.Time             `json:"startDate"`
+	EndDate      time.Time             `json:"endDate"`
+	WindowDays   int                   `json:"windowDays"`
+	MergedPRs    int                   `json:"mergedPRs"`
+	ReworkedPRs  int                   `json:"reworkedPRs"`
+	LinesAdded   int                   `json:"linesAdded"`
+	ReworkLines  int                   `json:"reworkLines"`
+	ChurnRate    float64               `json:"churnRate"`
+	Developers   []ReworkStatsResponse `json:"developers"`
+	Repositories []ReworkStatsResponse `json:"repositories"`
+	Directories  []ReworkStatsResponse `json:"directories"`
+	PRs          []PRReworkResponse    `json:"prs"`
+}
+
+// ReworkStatsResponse は開発者・リポジトリ・ディレクトリ別の手戻りのレスポンス
+type ReworkStatsResponse struct {
+	Key         string  `json:"key"`
+	Repository  string  `json:"repository,omitempty"`
+	Directory   string  `json:"directory,omitempty"`
+	MergedPRs   int     `json:"mergedPRs"`
+	ReworkedPRs int     `json:"reworkedPRs"`
+	LinesAdded  int     `json:"linesAdded"`
+	ReworkLines int     `json:"reworkLines"`
+	ChurnRate   float64 `json:"churnRate"`
+}
+
+// PRReworkResponse は手戻りのあったPRのレスポンス
+type PRReworkResponse struct {
+	PR          PRReferenceResponse  `json:"pr"`
+	LinesAdded  int                  `json:"linesAdded"`
+	ReworkLines int                  `json:"reworkLines"`
+	ChurnRate   float64              `json:"churnRate"`
+	Files       []FileReworkResponse `json:"files"`
+}
+
+// FileReworkResponse はファイル単位の手戻りのレスポンス
+type FileReworkResponse struct {
+	FileName    string                `json:"fileName"`
+	LinesAdded  int                   `json:"linesAdded"`
+	ReworkLines int                   `json:"reworkLines"`
+	ReworkedBy  []PRReferenceResponse `json:"reworkedBy"`
+}
+
+// ReviewWorkloadResponse はレビュアーごとのレビュー負荷と偏りのレスポンス
+type ReviewWorkloadResponse struct {
+	Period             string                         `json:"period"`
+	BucketPeriod       string                         `json:"bucketPeriod"` // periods の集計単位
+	StartDate          time.Time                      `json:"startDate"`
+	EndDate            time.Time                      `json:"endDate"`
+	AlertShare         float64                        `json:"alertShare"`
+	TotalRequests      int                            `json:"totalRequests"`
+	TotalReviews       int                            `json:"totalReviews"`
+	TotalLinesReviewed int                            `json:"totalLinesReviewed"`
+	GiniCoefficient    float64                        `json:"giniCoefficient"`
+	BalanceIndex       float64                        `json:"balanceIndex"`
+	Reviewers          []ReviewerLoadResponse         `json:"reviewers"`
+	Periods            []ReviewWorkloadPeriodResponse `json:"periods"`
+	Teams              []TeamReviewBalanceResponse    `json:"teams"`
+	Alerts             []ReviewLoadAlertResponse      `json:"alerts"`
+}
+
+// ReviewerLoadResponse はレビュアー1人のレビュー負荷のレスポンス
+type ReviewerLoadResponse struct {
+	Reviewer         string  `json:"reviewer"`
+	RequestsReceived int     `json:"requestsReceived"`
+	ReviewsGiven     int     `json:"reviewsGiven"`
+	LinesReviewed    int     `json:"linesReviewed"`
+	ReviewShare      float64 `json:"reviewShare"`
+}
+
+// ReviewWorkloadPeriodResponse は集計期間ごとのレビュー負荷のレスポンス
+type ReviewWorkloadPeriodResponse struct {
+	Start           time.Time              `json:"start"`
+	End             time.Time              `json:"end"`
+	TotalReviews    int                    `json:"totalReviews"`
+	GiniCoefficient float64                `json:"giniCoefficient"`
+	BalanceIndex    float64                `json:"balanceIndex"`
+	Reviewers       []ReviewerLoadResponse `json:"reviewers"`
+}
+
+// TeamReviewBalanceResponse はチームのレビュー負荷の偏りのレスポンス
+type TeamReviewBalanceResponse struct {
+	Team            string                 `json:"team"`
+	TotalReviews    int                    `json:"totalReviews"`
+	GiniCoefficient float64                `json:"giniCoefficient"`
+	BalanceIndex    float64                `json:"balanceIndex"`
+	Reviewers       []ReviewerLoadResponse `json:"reviewers"`
+}
+
+// ReviewLoadAlertResponse はレビューの集中の警告のレスポンス
+type ReviewLoadAlertResponse struct {
+	Reviewer     string  `json:"reviewer"`
+	Team         string  `json:"team,omitempty"`
+	ReviewsGiven int     `json:"reviewsGiven"`
+	TotalReviews int     `json:"totalReviews"`
+	Share        float64 `json:"share"`
+	Threshold    float64 `json:"threshold"`
+}
+
+// ReviewResponseResponse はレビュー依頼への応答時間のレスポンス
+type ReviewResponseResponse struct {
+	Period             string                            `json:"period"`
+	StartDate          time.Time                         `json:"startDate"`
+	EndDate            time.Time                         `json:"endDate"`
+	TotalRequests      int                               `json:"totalRequests"`
+	Reviewed           int                               `json:"reviewed"`
+	Removed            int                               `json:"removed"`
+	Unanswered         int                               `json:"unanswered"`
+	ResponseRate       float64                           `json:"responseRate"`
+	ResponseTime       CycleTimeStatsResponse            `json:"responseTime"`
+	Percentiles        PercentilesResponse               `json:"percentiles"`
+	Reviewers          []ReviewResponseStatsResponse     `json:"reviewers"`
+	Teams              []ReviewResponseStatsResponse     `json:"teams"`
+	UnansweredRequests []UnansweredReviewRequestResponse `json:"unansweredRequests"`
+}
+
+// ReviewResponseStatsResponse はレビュアーまたはチームごとの応答時間のレスポンス
+type ReviewResponseStatsResponse struct {
+	Name          string                 `json:"name"`
+	TotalRequests int                    `json:"totalRequests"`
+	Reviewed      int                    `json:"reviewed"`
+	Removed       int                    `json:"removed"`
+	Unanswered    int                    `json:"unanswered"`
+	ResponseRate  float64                `json:"responseRate"`
+	ResponseTime  CycleTimeStatsResponse `json:"responseTime"`
+	Percentiles   PercentilesResponse    `json:"percentiles"`
+}
+
+// UnansweredReviewRequestResponse は未対応のレビュー依頼のレスポンス
+type UnansweredReviewRequestResponse struct {
+	PR          PRReferenceResponse `json:"pr"`
+	Reviewer    string              `json:"reviewer"`
+	RequestedAt time.Time           `json:"requestedAt"`
+	WaitingTime *DurationResponse   `json:"waitingTime"`
+}
+
+// ReviewerSuggestionsResponse はPRのレビュアー推薦のレスポンス
+type ReviewerSuggestionsResponse struct {
+	PR          PRReferenceResponse          `json:"pr"`
+	Suggestions []ReviewerSuggestionResponse `json:"suggestions"`
+}
+
+// ReviewerSuggestionResponse は推薦するレビュアーのレスポンス
+type ReviewerSuggestionResponse struct {
+	Reviewer           string            `json:"reviewer"`
+	Score              float64           `json:"score"`
+	Expertise          float64           `json:"expertise"`
+	AuthoredPRs        int               `json:"authoredPRs"`
+	ReviewedPRs        int               `json:"reviewedPRs"`
+	MatchedPaths       []string          `json:"matchedPaths"`
+	LastActivityAt     time.Time         `json:"lastActivityAt"`
+	PendingReviews     int               `json:"pendingReviews"`
+	MedianResponseTime *DurationResponse `json:"medianResponseTime,omitempty"`
+}
+
+// CodeOwnershipResponse はCODEOWNERSの所有者によるレビューのレスポンス
+type CodeOwnershipResponse struct {
+	Period                        string                   `json:"period"`
+	StartDate                     time.Time                `json:"startDate"`
+	EndDate                       time.Time                `json:"endDate"`
+	TotalPRs                      int                      `json:"totalPRs"`
+	CoveredPRs                    int                      `json:"coveredPRs"`
+	OwnedPRs                      int                      `json:"ownedPRs"`
+	OwnerReviewedPRs              int                      `json:"ownerReviewedPRs"`
+	OwnerReviewRate               float64                  `json:"ownerReviewRate"`
+	TimeToOwnerReview             CycleTimeStatsResponse   `json:"timeToOwnerReview"`
+	UnownedPRs                    int                      `json:"unownedPRs"`
+	UnownedFiles                  int                      `json:"unownedFiles"`
+	RepositoriesWithoutCodeOwners []string                 `json:"repositoriesWithoutCodeOwners"`
+	Owners                        []OwnershipStatsResponse `json:"owners"`
+	UnownedChanges                []UnownedChangeResponse  `json:"unownedChanges"`
+}
+
+// OwnershipStatsResponse は所有者ごとのレビュー負荷のレスポンス
+type OwnershipStatsResponse struct {
+	Owner        string                 `json:"owner"`
+	Team         string                 `json:"team,omitempty"`
+	PRs          int                    `json:"prs"`
+	Files        int                    `json:"files"`
+	ReviewedPRs  int                    `json:"reviewedPRs"`
+	ReviewRate   float64                `json:"reviewRate"`
+	TimeToReview CycleTimeStatsResponse `json:"timeToReview"`
+}
+
+// UnownedChangeResponse は所有者のいないファイルを変更したPRのレスポンス
+type UnownedChangeResponse struct {
+	PR    PRReferenceResponse `json:"pr"`
+	Files []string            `json:"files"`
+}
+
 // PRListResponse はPRリストのレスポンス
 type PRListResponse struct {
 	PRs        []PRSummaryResponse `json:"prs"`
@@ -210,6 +523,8 @@ type PRSummaryResponse struct {
 	Repository      string    `json:"repository"`
 	CreatedAt       time.Time `json:"createdAt"`
 	MergedAt        *time.Time `json:"mergedAt,omitempty"`
+	Labels          []string  `json:"labels"`
+	IsBot           bool      `json:"isBot"`
 	LinesChanged    int       `json:"linesChanged"`
 	FilesChanged    int       `json:"filesChanged"`
 	ComplexityScore float64   `json:"complexityScore"`
@@ -217,6 +532,7 @@ type PRSummaryResponse struct {
 	CycleTime       *DurationResponse `json:"cycleTime,omitempty"`
 	ReviewTime      *DurationResponse `json:"reviewTime,omitempty"`
 	IsHighQuality   bool      `json:"isHighQuality"`
+	DefinitionVersion string  `json:"definitionVersion,omitempty"`
 }
 
 // ErrorResponse はエラーレスポンス
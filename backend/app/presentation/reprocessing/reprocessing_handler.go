@@ -0,0 +1,148 @@
+package reprocessing
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	reprocessingApp "github-stats-metrics/application/reprocessing"
+	"github-stats-metrics/infrastructure/database"
+)
+
+// ReprocessingHandler はメトリクス再計算APIのハンドラー
+type ReprocessingHandler struct {
+	service *reprocessingApp.Service
+}
+
+// NewReprocessingHandler は新しい再計算ハンドラーを作成
+func NewReprocessingHandler(service *reprocessingApp.Service) *ReprocessingHandler {
+	return &ReprocessingHandler{service: service}
+}
+
+// GetDefinition は現在の計算定義とそのバージョンを返す
+func (h *ReprocessingHandler) GetDefinition(w http.ResponseWriter, r *http.Request) {
+	definition := h.service.Definition()
+	response := DefinitionResponse{
+		Version: h.service.DefinitionVersion(),
+		CycleTime: CycleTimeDefinitionResponse{
+			UseBusinessHours: definition.CycleTime.UseBusinessHours,
+			BusinessStart:    definition.CycleTime.BusinessStart,
+			BusinessEnd:      definition.CycleTime.BusinessEnd,
+			ExcludeWeekends:  definition.CycleTime.ExcludeWeekends,
+			ExcludeHolidays:  definition.CycleTime.ExcludeHolidays,
+		},
+	}
+	if calendar, ok := definition.CycleTime.LookupHolidayCalendar(""); ok {
+		response.CycleTime.HolidayCalendar = calendar.Name()
+	}
+	if definition.CycleTime.Timezone != nil {
+		response.CycleTime.Timezone = definition.CycleTime.Timezone.String()
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// Run は指定期間のPRメトリクスを現在の計算定義で再計算する
+// startdate・enddate（YYYY-MM-DD、enddate は当日を含む）は必須
+func (h *ReprocessingHandler) Run(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	startDateStr, endDateStr := query.Get("startdate"), query.Get("enddate")
+	if startDateStr == "" || endDateStr == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "startdate と enddate を指定してください", nil)
+		return
+	}
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "startdate は YYYY-MM-DD 形式で指定してください", startDateStr)
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "enddate は YYYY-MM-DD 形式で指定してください", endDateStr)
+		return
+	}
+	if endDate.Before(startDate) {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "enddate は startdate 以降の日付を指定してください", nil)
+		return
+	}
+
+	req := reprocessingApp.Request{
+		StartDate:    startDate,
+		EndDate:      endDate.AddDate(0, 0, 1).Add(-time.Nanosecond),
+		Repositories: query["repositories[]"],
+	}
+	if req.Force, err = h.parseBool(query.Get("force")); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "force は true または false で指定してください", query.Get("force"))
+		return
+	}
+	if req.DryRun, err = h.parseBool(query.Get("dryRun")); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", "dryRun は true または false で指定してください", query.Get("dryRun"))
+		return
+	}
+
+	report, err := h.service.Run(r.Context(), req)
+	if err != nil {
+		log.Printf("Failed to reprocess pr metrics: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの再計算に失敗しました")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, report)
+}
+
+// parseBool は省略時 false として真偽値のパラメータを解釈する
+func (h *ReprocessingHandler) parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// writeDatabaseError はデータベースエラーをレスポンスに変換する
+// 接続できない場合は一時的な障害として 503 を返す
+func (h *ReprocessingHandler) writeDatabaseError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, database.ErrNotConfigured):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_NOT_CONFIGURED", "データベースが設定されていません", nil)
+	case database.IsTimeout(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_TIMEOUT", "データベースの応答がタイムアウトしました", nil)
+	case database.IsUnavailable(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "データベースに接続できません", nil)
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", message, nil)
+	}
+}
+
+func (h *ReprocessingHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+	}
+}
+
+func (h *ReprocessingHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
+	errorResponse := ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
+
+// RegisterRoutes はルートを登録
+func (h *ReprocessingHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/admin/reprocess/definition", h.GetDefinition).Methods("GET")
+	router.HandleFunc("/api/admin/reprocess", h.Run).Methods("POST")
+}
@@ -0,0 +1,26 @@
+package reprocessing
+
+// CycleTimeDefinitionResponse はサイクルタイムの計算設定のレスポンス
+type CycleTimeDefinitionResponse struct {
+	UseBusinessHours bool   `json:"useBusinessHours"`
+	BusinessStart    int    `json:"businessStart"`
+	BusinessEnd      int    `json:"businessEnd"`
+	ExcludeWeekends  bool   `json:"excludeWeekends"`
+	ExcludeHolidays  bool   `json:"excludeHolidays"`
+	HolidayCalendar  string `json:"holidayCalendar"` // 既定の休日カレンダー名
+	Timezone         string `json:"timezone"`
+}
+
+// DefinitionResponse は現在の計算定義のレスポンス
+type DefinitionResponse struct {
+	Version   string                      `json:"version"`
+	CycleTime CycleTimeDefinitionResponse `json:"cycleTime"`
+}
+
+// ErrorResponse はエラーレスポンス
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,165 @@
+package retention
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	retentionApp "github-stats-metrics/application/retention"
+	"github-stats-metrics/infrastructure/database"
+)
+
+// RetentionHandler はデータ保持・アーカイブ管理APIのハンドラー
+type RetentionHandler struct {
+	service *retentionApp.Service
+}
+
+// NewRetentionHandler は新しいデータ保持ハンドラーを作成
+func NewRetentionHandler(service *retentionApp.Service) *RetentionHandler {
+	return &RetentionHandler{service: service}
+}
+
+// Preview は削除せずに保持期間を過ぎたデータの件数を返す
+func (h *RetentionHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.Preview(r.Context())
+	if err != nil {
+		log.Printf("Failed to preview retention: %v", err)
+		h.writeDatabaseError(w, err, "削除対象の取得に失敗しました")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, h.toReportResponse(report))
+}
+
+// Run は保持ポリシーを即時に適用する
+func (h *RetentionHandler) Run(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.Run(r.Context())
+	if err != nil {
+		log.Printf("Failed to run retention: %v", err)
+		h.writeDatabaseError(w, err, "保持ポリシーの適用に失敗しました")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, h.toReportResponse(report))
+}
+
+// ListArchives は保存済みアーカイブの一覧を返す
+func (h *RetentionHandler) ListArchives(w http.ResponseWriter, r *http.Request) {
+	archives, err := h.service.ListArchives(r.Context())
+	if err != nil {
+		if errors.Is(err, retentionApp.ErrArchiveNotConfigured) {
+			h.writeErrorResponse(w, http.StatusConflict, "ARCHIVE_NOT_CONFIGURED", "アーカイブが有効になっていません", nil)
+			return
+		}
+		log.Printf("Failed to list archives: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "ARCHIVE_ERROR", "アーカイブ一覧の取得に失敗しました", nil)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, ArchiveListResponse{
+		Archives:   archives,
+		TotalCount: len(archives),
+	})
+}
+
+// RestoreArchive はアーカイブのデータを書き戻す
+func (h *RetentionHandler) RestoreArchive(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	result, err := h.service.Restore(r.Context(), name)
+	if err != nil {
+		switch {
+		case errors.Is(err, retentionApp.ErrArchiveNotConfigured):
+			h.writeErrorResponse(w, http.StatusConflict, "ARCHIVE_NOT_CONFIGURED", "アーカイブが有効になっていません", nil)
+		case errors.Is(err, retentionApp.ErrInvalidArchiveName):
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_ARCHIVE_NAME", "アーカイブ名が不正です", name)
+		case errors.Is(err, retentionApp.ErrArchiveNotFound):
+			h.writeErrorResponse(w, http.StatusNotFound, "ARCHIVE_NOT_FOUND", "アーカイブが見つかりません", name)
+		default:
+			log.Printf("Failed to restore archive %s: %v", name, err)
+			h.writeDatabaseError(w, err, "アーカイブの復元に失敗しました")
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, RestoreResponse{
+		Archive:    result.Archive,
+		PRMetrics:  result.PRMetrics,
+		Aggregated: result.Aggregated,
+	})
+}
+
+func (h *RetentionHandler) toReportResponse(report *retentionApp.Report) RetentionReportResponse {
+	policy := h.service.Policy()
+	response := RetentionReportResponse{
+		DryRun:     report.DryRun,
+		ExecutedAt: report.ExecutedAt,
+		Policy: RetentionPolicyResponse{
+			DetailedDataRetentionDays:       policy.DetailedDataRetentionDays,
+			DailyAggregationRetentionDays:   policy.DailyAggregationRetentionDays,
+			WeeklyAggregationRetentionDays:  policy.WeeklyAggregationRetentionDays,
+			MonthlyAggregationRetentionDays: policy.MonthlyAggregationRetentionDays,
+			EnableArchiving:                 policy.EnableArchiving,
+			EnableCompression:               policy.EnableCompression,
+		},
+		Targets: report.Targets,
+	}
+	if policy.EnableArchiving {
+		response.Policy.ArchiveStoragePath = policy.ArchiveStoragePath
+	}
+	if response.Targets == nil {
+		response.Targets = []retentionApp.Target{}
+	}
+	for _, target := range report.Targets {
+		response.TotalCount += target.Count
+	}
+	return response
+}
+
+// writeDatabaseError はデータベースエラーをレスポンスに変換する
+// 接続できない場合は一時的な障害として 503 を返す
+func (h *RetentionHandler) writeDatabaseError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, database.ErrNotConfigured):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_NOT_CONFIGURED", "データベースが設定されていません", nil)
+	case database.IsTimeout(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_TIMEOUT", "データベースの応答がタイムアウトしました", nil)
+	case database.IsUnavailable(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "データベースに接続できません", nil)
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", message, nil)
+	}
+}
+
+func (h *RetentionHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+	}
+}
+
+func (h *RetentionHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
+	errorResponse := ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
+
+// RegisterRoutes はルートを登録
+func (h *RetentionHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/admin/retention/preview", h.Preview).Methods("GET")
+	router.HandleFunc("/api/admin/retention/run", h.Run).Methods("POST")
+	router.HandleFunc("/api/admin/retention/archives", h.ListArchives).Methods("GET")
+	router.HandleFunc("/api/admin/retention/archives/{name}/restore", h.RestoreArchive).Methods("POST")
+}
@@ -0,0 +1,48 @@
+package retention
+
+import (
+	"time"
+
+	retentionApp "github-stats-metrics/application/retention"
+)
+
+// RetentionPolicyResponse は適用中の保持ポリシーのレスポンス
+type RetentionPolicyResponse struct {
+	DetailedDataRetentionDays       int    `json:"detailedDataRetentionDays"`
+	DailyAggregationRetentionDays   int    `json:"dailyAggregationRetentionDays"`
+	WeeklyAggregationRetentionDays  int    `json:"weeklyAggregationRetentionDays"`
+	MonthlyAggregationRetentionDays int    `json:"monthlyAggregationRetentionDays"`
+	EnableArchiving                 bool   `json:"enableArchiving"`
+	ArchiveStoragePath              string `json:"archiveStoragePath,omitempty"`
+	EnableCompression               bool   `json:"enableCompression"`
+}
+
+// RetentionReportResponse は保持ポリシーの適用結果（プレビュー・実行）のレスポンス
+type RetentionReportResponse struct {
+	DryRun     bool                    `json:"dryRun"`
+	ExecutedAt time.Time               `json:"executedAt"`
+	Policy     RetentionPolicyResponse `json:"policy"`
+	Targets    []retentionApp.Target   `json:"targets"`
+	TotalCount int64                   `json:"totalCount"`
+}
+
+// ArchiveListResponse はアーカイブ一覧のレスポンス
+type ArchiveListResponse struct {
+	Archives   []retentionApp.ArchiveInfo `json:"archives"`
+	TotalCount int                        `json:"totalCount"`
+}
+
+// RestoreResponse はアーカイブ復元結果のレスポンス
+type RestoreResponse struct {
+	Archive    string `json:"archive"`
+	PRMetrics  int    `json:"prMetrics"`
+	Aggregated int    `json:"aggregated"`
+}
+
+// ErrorResponse はエラーレスポンス
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
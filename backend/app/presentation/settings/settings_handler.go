@@ -0,0 +1,180 @@
+package settings
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// SettingsPersister は分析設定の永続化先
+type SettingsPersister interface {
+	Save(settings prDomain.AnalysisSettings) error
+}
+
+// SettingsHandler はPR分析設定の管理APIのハンドラー
+type SettingsHandler struct {
+	registry  *prDomain.AnalysisSettingsRegistry
+	persister SettingsPersister
+}
+
+// NewSettingsHandler は新しい分析設定ハンドラーを作成
+// persister が nil の場合、変更はメモリ上のみに反映される
+func NewSettingsHandler(registry *prDomain.AnalysisSettingsRegistry, persister SettingsPersister) *SettingsHandler {
+	return &SettingsHandler{registry: registry, persister: persister}
+}
+
+// GetSettings は全体とリポジトリごとの上書き設定を取得
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	h.writeJSONResponse(w, http.StatusOK, toSettingsResponse(h.registry.Settings()))
+}
+
+// PutDefaults は全リポジトリに適用する上書き設定を置き換える
+func (h *SettingsHandler) PutDefaults(w http.ResponseWriter, r *http.Request) {
+	var override prDomain.DefinitionOverride
+	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", "リクエストボディの形式が不正です", nil)
+		return
+	}
+
+	if err := h.registry.UpdateDefaults(override); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_SETTINGS", err.Error(), nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, toSettingsResponse(h.registry.Settings()))
+}
+
+// PutRepository はリポジトリの上書き設定を登録・更新
+func (h *SettingsHandler) PutRepository(w http.ResponseWriter, r *http.Request) {
+	repository := mux.Vars(r)["repository"]
+
+	var override prDomain.DefinitionOverride
+	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", "リクエストボディの形式が不正です", nil)
+		return
+	}
+
+	if err := h.registry.SetRepository(repository, override); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_SETTINGS", err.Error(), nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, h.toEffectiveSettingsResponse(repository))
+}
+
+// DeleteRepository はリポジトリの上書き設定を削除（全体の設定に戻す）
+func (h *SettingsHandler) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	if !h.registry.RemoveRepository(mux.Vars(r)["repository"]) {
+		h.writeErrorResponse(w, http.StatusNotFound, "SETTINGS_NOT_FOUND", "指定されたリポジトリの設定が見つかりません", nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetEffectiveSettings はリポジトリに適用される計算定義とそのバージョンを取得
+// repository を省略した場合は全体の定義を返す
+func (h *SettingsHandler) GetEffectiveSettings(w http.ResponseWriter, r *http.Request) {
+	h.writeJSONResponse(w, http.StatusOK, h.toEffectiveSettingsResponse(r.URL.Query().Get("repository")))
+}
+
+// persist は変更後の設定を保存する（失敗した場合はエラーレスポンスを書き込み false を返す）
+func (h *SettingsHandler) persist(w http.ResponseWriter) bool {
+	if h.persister == nil {
+		return true
+	}
+	if err := h.persister.Save(h.registry.Settings()); err != nil {
+		log.Printf("Failed to save analysis settings: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "PERSISTENCE_ERROR", "分析設定の保存に失敗しました", nil)
+		return false
+	}
+	return true
+}
+
+func (h *SettingsHandler) toEffectiveSettingsResponse(repository string) EffectiveSettingsResponse {
+	definition := h.registry.DefinitionFor(repository)
+	response := EffectiveSettingsResponse{
+		Repository:        repository,
+		DefinitionVersion: definition.Version(),
+		CycleTime: CycleTimeSettingsResponse{
+			UseBusinessHours: definition.CycleTime.UseBusinessHours,
+			BusinessStart:    definition.CycleTime.BusinessStart,
+			BusinessEnd:      definition.CycleTime.BusinessEnd,
+			ExcludeWeekends:  definition.CycleTime.ExcludeWeekends,
+			ExcludeHolidays:  definition.CycleTime.ExcludeHolidays,
+		},
+		SizeThresholds:  definition.Size,
+		FileTypeWeights: definition.Complexity.FileTypeWeights,
+		Review: ReviewSettingsResponse{
+			ReviewRoundIntervalMinutes:  int(definition.Review.MinTimeBetweenRounds / time.Minute),
+			HighQualityCommentThreshold: definition.Review.HighQualityCommentThreshold,
+			LowQualityCommentThreshold:  definition.Review.LowQualityCommentThreshold,
+		},
+		Bottleneck: BottleneckSettingsResponse{
+			LongCycleTimeHours: definition.Bottleneck.LongCycleTime.Hours(),
+			ReviewRoundLimit:   definition.Bottleneck.ReviewRounds,
+			LargePRLines:       definition.Bottleneck.LargePRLines,
+		},
+	}
+	if calendar, ok := definition.CycleTime.LookupHolidayCalendar(""); ok {
+		response.CycleTime.HolidayCalendar = calendar.Name()
+	}
+	if definition.CycleTime.Timezone != nil {
+		response.CycleTime.Timezone = definition.CycleTime.Timezone.String()
+	}
+	return response
+}
+
+func toSettingsResponse(settings prDomain.AnalysisSettings) SettingsResponse {
+	return SettingsResponse{
+		Defaults:     settings.Defaults,
+		Repositories: settings.Repositories,
+	}
+}
+
+func (h *SettingsHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+	}
+}
+
+func (h *SettingsHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
+	errorResponse := ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
+
+// RegisterRoutes はルートを登録
+// リポジトリ名は owner/name 形式のためスラッシュを含めて受け付ける
+func (h *SettingsHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/admin/settings", h.GetSettings).Methods("GET")
+	router.HandleFunc("/api/admin/settings/effective", h.GetEffectiveSettings).Methods("GET")
+	router.HandleFunc("/api/admin/settings/defaults", h.PutDefaults).Methods("PUT")
+	router.HandleFunc("/api/admin/settings/repositories/{repository:.+}", h.PutRepository).Methods("PUT")
+	router.HandleFunc("/api/admin/settings/repositories/{repository:.+}", h.DeleteRepository).Methods("DELETE")
+}
@@ -0,0 +1,55 @@
+package settings
+
+import (
+	prDomain "github-stats-metrics/domain/pull_request"
+)
+
+// SettingsResponse は管理APIで変更した分析設定のレスポンス
+type SettingsResponse struct {
+	Defaults     prDomain.DefinitionOverride            `json:"defaults"`
+	Repositories map[string]prDomain.DefinitionOverride `json:"repositories"`
+}
+
+// CycleTimeSettingsResponse はサイクルタイムの計算設定のレスポンス
+type CycleTimeSettingsResponse struct {
+	UseBusinessHours bool   `json:"useBusinessHours"`
+	BusinessStart    int    `json:"businessStart"`
+	BusinessEnd      int    `json:"businessEnd"`
+	ExcludeWeekends  bool   `json:"excludeWeekends"`
+	ExcludeHolidays  bool   `json:"excludeHolidays"`
+	HolidayCalendar  string `json:"holidayCalendar"`
+	Timezone         string `json:"timezone"`
+}
+
+// ReviewSettingsResponse はレビューの判定設定のレスポンス
+type ReviewSettingsResponse struct {
+	ReviewRoundIntervalMinutes  int `json:"reviewRoundIntervalMinutes"`
+	HighQualityCommentThreshold int `json:"highQualityCommentThreshold"`
+	LowQualityCommentThreshold  int `json:"lowQualityCommentThreshold"`
+}
+
+// BottleneckSettingsResponse はボトルネックの判定閾値のレスポンス
+type BottleneckSettingsResponse struct {
+	LongCycleTimeHours float64 `json:"longCycleTimeHours"`
+	ReviewRoundLimit   int     `json:"reviewRoundLimit"`
+	LargePRLines       int     `json:"largePRLines"`
+}
+
+// EffectiveSettingsResponse はリポジトリに適用される計算定義のレスポンス
+type EffectiveSettingsResponse struct {
+	Repository        string                     `json:"repository,omitempty"`
+	DefinitionVersion string                     `json:"definitionVersion"`
+	CycleTime         CycleTimeSettingsResponse  `json:"cycleTime"`
+	SizeThresholds    prDomain.SizeThresholds    `json:"sizeThresholds"`
+	FileTypeWeights   map[string]float64         `json:"fileTypeWeights"`
+	Review            ReviewSettingsResponse     `json:"review"`
+	Bottleneck        BottleneckSettingsResponse `json:"bottleneck"`
+}
+
+// ErrorResponse はエラーレスポンス
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
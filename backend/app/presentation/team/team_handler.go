@@ -0,0 +1,370 @@
+package team
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	developerDomain "github-stats-metrics/domain/developer"
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/infrastructure/database"
+)
+
+// TeamPersister はチーム定義の永続化先
+type TeamPersister interface {
+	Save(teams []teamDomain.Team) error
+}
+
+// TeamHandler はチーム管理APIのハンドラー
+type TeamHandler struct {
+	roster            *teamDomain.Roster
+	persister         TeamPersister
+	memberSource      teamDomain.MemberSource
+	prMetricsRepo     prDomain.MetricsRepository
+	metricsAggregator *analyticsApp.MetricsAggregator
+	identities        *developerDomain.IdentityRegistry
+}
+
+// NewTeamHandler は新しいチーム管理ハンドラーを作成
+// persister が nil の場合、変更はメモリ上のみに反映される
+// identities が指定されている場合、チームのメトリクスはメンバーの別アカウントで作成したPRも含める
+func NewTeamHandler(
+	roster *teamDomain.Roster,
+	persister TeamPersister,
+	memberSource teamDomain.MemberSource,
+	prMetricsRepo prDomain.MetricsRepository,
+	metricsAggregator *analyticsApp.MetricsAggregator,
+	identities *developerDomain.IdentityRegistry,
+) *TeamHandler {
+	return &TeamHandler{
+		roster:            roster,
+		persister:         persister,
+		memberSource:      memberSource,
+		prMetricsRepo:     prMetricsRepo,
+		metricsAggregator: metricsAggregator,
+		identities:        identities,
+	}
+}
+
+// ListTeams は登録済みのチーム一覧を取得
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	teams := h.roster.List()
+	now := time.Now()
+
+	response := TeamListResponse{
+		Teams:      make([]TeamResponse, 0, len(teams)),
+		TotalCount: len(teams),
+	}
+	for _, team := range teams {
+		response.Teams = append(response.Teams, toTeamResponse(team, now))
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetTeam はチーム定義を取得
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	team, exists := h.roster.Get(mux.Vars(r)["name"])
+	if !exists {
+		h.writeErrorResponse(w, http.StatusNotFound, "TEAM_NOT_FOUND", "指定されたチームが見つかりません", nil)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, toTeamResponse(team, time.Now()))
+}
+
+// PutTeam はチームを登録・更新
+func (h *TeamHandler) PutTeam(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	if name == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_TEAM", "チーム名が指定されていません", nil)
+		return
+	}
+
+	var request TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", "リクエストボディの形式が不正です", nil)
+		return
+	}
+
+	team := teamDomain.Team{
+		Name:         name,
+		GitHubTeam:   request.GitHubTeam,
+		Members:      make([]teamDomain.Membership, 0, len(request.Members)),
+		WorkingHours: toWorkingHours(request.WorkingHours),
+	}
+	for _, member := range request.Members {
+		team.Members = append(team.Members, teamDomain.Membership{
+			Login:        member.Login,
+			JoinedAt:     member.JoinedAt,
+			LeftAt:       member.LeftAt,
+			WorkingHours: toWorkingHours(member.WorkingHours),
+		})
+	}
+
+	if err := h.roster.Register(team); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_TEAM", err.Error(), nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, toTeamResponse(team, time.Now()))
+}
+
+// DeleteTeam はチームを削除
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	if !h.roster.Remove(mux.Vars(r)["name"]) {
+		h.writeErrorResponse(w, http.StatusNotFound, "TEAM_NOT_FOUND", "指定されたチームが見つかりません", nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SyncTeam はGitHubチームのメンバー一覧で所属情報を更新
+func (h *TeamHandler) SyncTeam(w http.ResponseWriter, r *http.Request) {
+	if h.memberSource == nil {
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "SYNC_UNAVAILABLE", "メンバー同期は設定されていません", nil)
+		return
+	}
+
+	now := time.Now()
+	result, err := h.roster.Sync(r.Context(), mux.Vars(r)["name"], h.memberSource, now)
+	if err != nil {
+		var teamErr *teamDomain.TeamError
+		if errors.As(err, &teamErr) {
+			if teamErr.IsNotFound() {
+				h.writeErrorResponse(w, http.StatusNotFound, "TEAM_NOT_FOUND", "指定されたチームが見つかりません", nil)
+				return
+			}
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_TEAM", teamErr.Message, nil)
+			return
+		}
+		log.Printf("Failed to sync team members: %v", err)
+		h.writeErrorResponse(w, http.StatusBadGateway, "SYNC_FAILED", "GitHubチームのメンバー取得に失敗しました", nil)
+		return
+	}
+
+	if !h.persist(w) {
+		return
+	}
+
+	team, _ := h.roster.Get(result.Team)
+	response := TeamSyncResponse{
+		Team:     toTeamResponse(team, now),
+		Joined:   nonNilStrings(result.Joined),
+		Left:     nonNilStrings(result.Left),
+		SyncedAt: result.SyncedAt,
+	}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetTeamMetrics はチームのメトリクス推移を取得
+// 各PRは作成時点の所属で判定するため、メンバーの入れ替わりがあっても過去の値は変わらない
+func (h *TeamHandler) GetTeamMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	team, exists := h.roster.Get(mux.Vars(r)["name"])
+	if !exists {
+		h.writeErrorResponse(w, http.StatusNotFound, "TEAM_NOT_FOUND", "指定されたチームが見つかりません", nil)
+		return
+	}
+
+	period, startDate, endDate, err := parseMetricsParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error(), nil)
+		return
+	}
+
+	// 過去のメンバーと別アカウントを含めて取得し、所属期間で絞り込む
+	metrics, err := h.prMetricsRepo.FindByDateRange(ctx, startDate, endDate, team.ExpandedLogins(h.identities), nil)
+	if err != nil {
+		log.Printf("Failed to get team PR metrics: %v", err)
+		h.writeDatabaseError(w, err, "メトリクスの取得に失敗しました")
+		return
+	}
+
+	timeline, err := h.metricsAggregator.AggregateTeamMetricsOverTime(ctx, team, metrics, period)
+	if err != nil {
+		log.Printf("Failed to aggregate team metrics: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "AGGREGATION_ERROR", "メトリクスの集計に失敗しました", nil)
+		return
+	}
+
+	response := TeamMetricsTimelineResponse{
+		Team:      team.Name,
+		Period:    string(period),
+		StartDate: startDate,
+		EndDate:   endDate,
+		Points:    make([]TeamMetricsPointResponse, 0, len(timeline)),
+	}
+	for _, point := range timeline {
+		response.Points = append(response.Points, TeamMetricsPointResponse{
+			PeriodStart:                   point.DateRange.Start,
+			PeriodEnd:                     point.DateRange.End,
+			Members:                       nonNilStrings(team.MembersAt(point.DateRange.Start)),
+			TotalPRs:                      point.TotalPRs,
+			AverageCycleTimeHours:         point.CycleTimeStats.TotalCycleTime.Mean.Hours(),
+			MedianCycleTimeHours:          point.CycleTimeStats.TotalCycleTime.Median.Hours(),
+			AverageTimeToFirstReviewHours: point.CycleTimeStats.TimeToFirstReview.Mean.Hours(),
+			AverageLinesChanged:           point.SizeStats.LinesChanged.Mean,
+		})
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// parseMetricsParams は集計期間と日付範囲を解析（デフォルトは直近3ヶ月の週次）
+func parseMetricsParams(r *http.Request) (analyticsApp.AggregationPeriod, time.Time, time.Time, error) {
+	query := r.URL.Query()
+
+	var period analyticsApp.AggregationPeriod
+	switch strings.ToLower(query.Get("period")) {
+	case "daily":
+		period = analyticsApp.AggregationPeriodDaily
+	case "", "weekly":
+		period = analyticsApp.AggregationPeriodWeekly
+	case "monthly":
+		period = analyticsApp.AggregationPeriodMonthly
+	default:
+		return "", time.Time{}, time.Time{}, fmt.Errorf("invalid period: %s", query.Get("period"))
+	}
+
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, -3, 0)
+
+	if startDateStr := query.Get("startdate"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, fmt.Errorf("invalid start date format: %s", startDateStr)
+		}
+		startDate = parsed
+	}
+
+	if endDateStr := query.Get("enddate"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, fmt.Errorf("invalid end date format: %s", endDateStr)
+		}
+		endDate = parsed
+	}
+
+	return period, startDate, endDate, nil
+}
+
+// persist はロスターの内容を永続化（失敗時はエラーレスポンスを書き込み false を返す）
+func (h *TeamHandler) persist(w http.ResponseWriter) bool {
+	if h.persister == nil {
+		return true
+	}
+	if err := h.persister.Save(h.roster.List()); err != nil {
+		log.Printf("Failed to save teams: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "PERSISTENCE_ERROR", "チーム定義の保存に失敗しました", nil)
+		return false
+	}
+	return true
+}
+
+func toTeamResponse(team teamDomain.Team, now time.Time) TeamResponse {
+	response := TeamResponse{
+		Name:           team.Name,
+		GitHubTeam:     team.GitHubTeam,
+		Members:        make([]MembershipResponse, 0, len(team.Members)),
+		CurrentMembers: nonNilStrings(team.MembersAt(now)),
+		WorkingHours:   toWorkingHoursResponse(team.WorkingHours),
+	}
+	for _, member := range team.Members {
+		response.Members = append(response.Members, MembershipResponse{
+			Login:        member.Login,
+			JoinedAt:     member.JoinedAt,
+			LeftAt:       member.LeftAt,
+			WorkingHours: toWorkingHoursResponse(member.WorkingHours),
+		})
+	}
+	return response
+}
+
+func toWorkingHours(request *WorkingHoursResponse) *prDomain.WorkingHours {
+	if request == nil {
+		return nil
+	}
+	hours := prDomain.WorkingHours(*request)
+	return &hours
+}
+
+func toWorkingHoursResponse(hours *prDomain.WorkingHours) *WorkingHoursResponse {
+	if hours == nil {
+		return nil
+	}
+	response := WorkingHoursResponse(*hours)
+	return &response
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func (h *TeamHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+	}
+}
+
+// writeDatabaseError はデータベースエラーをレスポンスに変換する
+// 接続できない場合は一時的な障害として 503 を返す
+func (h *TeamHandler) writeDatabaseError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, database.ErrNotConfigured):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_NOT_CONFIGURED", "データベースが設定されていません", nil)
+	case database.IsTimeout(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_TIMEOUT", "データベースの応答がタイムアウトしました", nil)
+	case database.IsUnavailable(err):
+		w.Header().Set("Retry-After", "30")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "データベースに接続できません", nil)
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", message, nil)
+	}
+}
+
+func (h *TeamHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
+	errorResponse := ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+
+	h.writeJSONResponse(w, statusCode, errorResponse)
+}
+
+// RegisterRoutes はルートを登録
+func (h *TeamHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/teams", h.ListTeams).Methods("GET")
+	router.HandleFunc("/api/teams/{name}", h.GetTeam).Methods("GET")
+	router.HandleFunc("/api/teams/{name}", h.PutTeam).Methods("PUT")
+	router.HandleFunc("/api/teams/{name}", h.DeleteTeam).Methods("DELETE")
+	router.HandleFunc("/api/teams/{name}/sync", h.SyncTeam).Methods("POST")
+	router.HandleFunc("/api/teams/{name}/metrics", h.GetTeamMetrics).Methods("GET")
+}
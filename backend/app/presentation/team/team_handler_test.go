@@ -0,0 +1,61 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analyticsApp "github-stats-metrics/application/analytics"
+	developerDomain "github-stats-metrics/domain/developer"
+	prDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/infrastructure/memory"
+)
+
+func TestTeamHandler_GetTeamMetrics(t *testing.T) {
+	ctx := context.Background()
+	roster, err := teamDomain.NewRoster([]teamDomain.Team{{
+		Name:    "backend",
+		Members: []teamDomain.Membership{{Login: "alice"}},
+	}})
+	require.NoError(t, err)
+	identities, err := developerDomain.NewIdentityRegistry([]developerDomain.Developer{
+		{Id: "alice", Logins: []string{"alice", "alice-work"}},
+	})
+	require.NoError(t, err)
+
+	prMetrics := memory.NewPRMetricsRepository()
+	createdAt := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	for i, author := range []string{"alice", "alice-work", "bob"} {
+		require.NoError(t, prMetrics.Save(ctx, &prDomain.PRMetrics{
+			PRID:       "pr-" + author,
+			Author:     author,
+			Repository: "org/api",
+			CreatedAt:  createdAt.Add(time.Duration(i) * time.Hour),
+		}))
+	}
+
+	config := analyticsApp.DefaultAggregatorConfig()
+	config.IdentityResolver = identities
+	handler := NewTeamHandler(roster, nil, nil, prMetrics, analyticsApp.NewMetricsAggregatorWithConfig(config), identities)
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	t.Run("メンバーの別アカウントで作成したPRも含める", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/teams/backend/metrics?period=monthly&startdate=2024-03-01&enddate=2024-03-31", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response TeamMetricsTimelineResponse
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+		require.Len(t, response.Points, 1)
+		assert.Equal(t, 2, response.Points[0].TotalPRs)
+	})
+}
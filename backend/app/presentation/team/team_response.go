@@ -0,0 +1,81 @@
+package team
+
+import (
+	"time"
+)
+
+// TeamResponse はチーム定義のレスポンス
+type TeamResponse struct {
+	Name           string                `json:"name"`
+	GitHubTeam     string                `json:"githubTeam,omitempty"`
+	Members        []MembershipResponse  `json:"members"`
+	CurrentMembers []string              `json:"currentMembers"`
+	WorkingHours   *WorkingHoursResponse `json:"workingHours,omitempty"`
+}
+
+// MembershipResponse はチームへの所属期間のレスポンス
+type MembershipResponse struct {
+	Login        string                `json:"login"`
+	JoinedAt     time.Time             `json:"joinedAt"`
+	LeftAt       *time.Time            `json:"leftAt,omitempty"`
+	WorkingHours *WorkingHoursResponse `json:"workingHours,omitempty"`
+}
+
+// WorkingHoursResponse は勤務時間の設定のレスポンス（未指定の項目は全体の設定を使う）
+type WorkingHoursResponse struct {
+	Timezone        string `json:"timezone,omitempty"`
+	BusinessStart   *int   `json:"businessStart,omitempty"`
+	BusinessEnd     *int   `json:"businessEnd,omitempty"`
+	ExcludeWeekends *bool  `json:"excludeWeekends,omitempty"`
+	HolidayCalendar string `json:"holidayCalendar,omitempty"`
+}
+
+// TeamListResponse はチーム一覧のレスポンス
+type TeamListResponse struct {
+	Teams      []TeamResponse `json:"teams"`
+	TotalCount int            `json:"totalCount"`
+}
+
+// TeamSyncResponse はメンバー同期結果のレスポンス
+type TeamSyncResponse struct {
+	Team     TeamResponse `json:"team"`
+	Joined   []string     `json:"joined"`
+	Left     []string     `json:"left"`
+	SyncedAt time.Time    `json:"syncedAt"`
+}
+
+// TeamMetricsPointResponse は期間ごとのチームメトリクスのレスポンス
+type TeamMetricsPointResponse struct {
+	PeriodStart                   time.Time `json:"periodStart"`
+	PeriodEnd                     time.Time `json:"periodEnd"`
+	Members                       []string  `json:"members"` // 期間開始時点の所属メンバー
+	TotalPRs                      int       `json:"totalPRs"`
+	AverageCycleTimeHours         float64   `json:"averageCycleTimeHours"`
+	MedianCycleTimeHours          float64   `json:"medianCycleTimeHours"`
+	AverageTimeToFirstReviewHours float64   `json:"averageTimeToFirstReviewHours"`
+	AverageLinesChanged           float64   `json:"averageLinesChanged"`
+}
+
+// TeamMetricsTimelineResponse はチームメトリクス推移のレスポンス
+type TeamMetricsTimelineResponse struct {
+	Team      string                     `json:"team"`
+	Period    string                     `json:"period"`
+	StartDate time.Time                  `json:"startDate"`
+	EndDate   time.Time                  `json:"endDate"`
+	Points    []TeamMetricsPointResponse `json:"points"`
+}
+
+// TeamRequest はチーム定義の登録リクエスト
+type TeamRequest struct {
+	GitHubTeam   string                `json:"githubTeam"`
+	Members      []MembershipResponse  `json:"members"`
+	WorkingHours *WorkingHoursResponse `json:"workingHours"`
+}
+
+// ErrorResponse はエラーレスポンス
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	aggregationApp "github-stats-metrics/application/aggregation"
+	analyticsApp "github-stats-metrics/application/analytics"
+	"github-stats-metrics/shared/logging"
+)
+
+// startAggregationJob は事前集計を起動直後と interval ごとに実行する
+// 前回以降に収集されたPRが属する期間だけを再集計する。ctx がキャンセルされると停止する
+func startAggregationJob(ctx context.Context, service *aggregationApp.Service, interval time.Duration, logger *logging.StructuredLogger) {
+	logger.Info(ctx, "Aggregation job scheduled", map[string]interface{}{
+		"interval": interval.String(),
+	})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			runAggregation(ctx, service, logger)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runAggregation は事前集計を1回実行して結果をログに出力する
+func runAggregation(ctx context.Context, service *aggregationApp.Service, logger *logging.StructuredLogger) {
+	run, err := service.Run(ctx, false)
+	if err != nil {
+		logger.Error(ctx, "Aggregation job failed", err)
+	}
+	if run == nil || run.Status != analyticsApp.AggregationRunSucceeded {
+		return
+	}
+
+	logger.Info(ctx, "Aggregation applied", map[string]interface{}{
+		"run_id":      run.ID,
+		"changed_prs": run.ChangedPRs,
+		"periods":     run.Periods,
+		"records":     run.Records,
+	})
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/infrastructure/database/partition"
+	"github-stats-metrics/shared/config"
+	"github-stats-metrics/shared/logging"
+)
+
+// newPartitionManager は PostgreSQL の接続先が設定されている場合にパーティション管理を作成（それ以外は nil）
+// DATABASE_URL が未設定またはメモリ内の保存先の場合、db は接続されていないデータベースのため作成しない
+func newPartitionManager(cfg *config.Config, db *sql.DB, dialect database.Dialect) *partition.Manager {
+	if cfg.Database.URL == "" || cfg.Database.UsesMemoryStorage() {
+		return nil
+	}
+	if db == nil || dialect == nil || dialect.Name() != "postgres" {
+		return nil
+	}
+	return partition.NewManager(db, cfg.Partition.Strategy, cfg.Partition.PremakeMonths)
+}
+
+// startPartitionJob はパーティション保守を起動直後と interval ごとに実行する
+// retentionDays が 0 の場合はパーティションを切り離さない
+func startPartitionJob(ctx context.Context, manager *partition.Manager, interval time.Duration, retentionDays int, logger *logging.StructuredLogger) {
+	logger.Info(ctx, "Partition maintenance scheduled", map[string]interface{}{
+		"interval":       interval.String(),
+		"retention_days": retentionDays,
+	})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			result, err := manager.Maintain(ctx, retentionDays)
+			if err != nil {
+				logger.Error(ctx, "Partition maintenance failed", err)
+			}
+			if result != nil && (len(result.Created) > 0 || len(result.Detached) > 0) {
+				logger.Info(ctx, "Partition maintenance applied", map[string]interface{}{
+					"created":  result.Created,
+					"detached": result.Detached,
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
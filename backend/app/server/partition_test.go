@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/shared/config"
+)
+
+func TestNewPartitionManager(t *testing.T) {
+	db := database.Disconnected(database.ErrNotConfigured)
+	defer db.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		dialect database.Dialect
+		want    bool
+	}{
+		{name: "DATABASE_URL が未設定の場合は作成しない", url: "", dialect: database.Postgres(), want: false},
+		{name: "メモリ内の保存先の場合は作成しない", url: "memory:", dialect: database.Postgres(), want: false},
+		{name: "SQLite の場合は作成しない", url: "sqlite://metrics.db", dialect: database.SQLite(), want: false},
+		{name: "PostgreSQL の場合は作成する", url: "postgres://localhost/metrics", dialect: database.Postgres(), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Database.URL = tt.url
+
+			if got := newPartitionManager(cfg, db, tt.dialect) != nil; got != tt.want {
+				t.Errorf("newPartitionManager() != nil = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
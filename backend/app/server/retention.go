@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	retentionApp "github-stats-metrics/application/retention"
+	"github-stats-metrics/infrastructure/filestore"
+	"github-stats-metrics/shared/config"
+	"github-stats-metrics/shared/logging"
+)
+
+// newRetentionArchive は設定に応じてアーカイブの保存先を作成（無効の場合は nil）
+func newRetentionArchive(cfg *config.Config) retentionApp.Archive {
+	policy := cfg.Retention.Policy
+	if !policy.EnableArchiving || policy.ArchiveStoragePath == "" {
+		return nil
+	}
+	return filestore.NewArchiveFileStore(policy.ArchiveStoragePath, policy.EnableCompression)
+}
+
+// startRetentionJob は保持ポリシーを起動直後と interval ごとに適用する
+// ctx がキャンセルされると停止する
+func startRetentionJob(ctx context.Context, service *retentionApp.Service, interval time.Duration, logger *logging.StructuredLogger) {
+	logger.Info(ctx, "Retention job scheduled", map[string]interface{}{
+		"interval": interval.String(),
+	})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			runRetention(ctx, service, logger)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runRetention は保持ポリシーを1回適用して結果をログに出力する
+func runRetention(ctx context.Context, service *retentionApp.Service, logger *logging.StructuredLogger) {
+	report, err := service.Run(ctx)
+	if err != nil {
+		logger.Error(ctx, "Retention job failed", err)
+	}
+	if report == nil {
+		return
+	}
+
+	for _, target := range report.Targets {
+		logger.Info(ctx, "Retention applied", map[string]interface{}{
+			"target":         target.Name,
+			"period":         target.Period,
+			"retention_days": target.RetentionDays,
+			"cutoff":         target.Cutoff,
+			"deleted":        target.Count,
+			"archive":        target.Archive,
+		})
+	}
+}
@@ -3,14 +3,29 @@ package server
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
+	aggregationApp "github-stats-metrics/application/aggregation"
 	analyticsApp "github-stats-metrics/application/analytics"
 	pullRequestUseCase "github-stats-metrics/application/pull_request"
+	retentionApp "github-stats-metrics/application/retention"
+	reprocessingApp "github-stats-metrics/application/reprocessing"
 	pullRequestHandler "github-stats-metrics/presentation/pull_request"
 	analyticsHandler "github-stats-metrics/presentation/analytics"
+	developerHandler "github-stats-metrics/presentation/developer"
+	teamHandler "github-stats-metrics/presentation/team"
+	retentionHandler "github-stats-metrics/presentation/retention"
+	aggregationHandler "github-stats-metrics/presentation/aggregation"
+	reprocessingHandler "github-stats-metrics/presentation/reprocessing"
+	settingsHandler "github-stats-metrics/presentation/settings"
+	developerDomain "github-stats-metrics/domain/developer"
+	pullRequestDomain "github-stats-metrics/domain/pull_request"
+	teamDomain "github-stats-metrics/domain/team"
+	"github-stats-metrics/infrastructure/database"
+	"github-stats-metrics/infrastructure/filestore"
 	githubRepository "github-stats-metrics/infrastructure/github_api"
 	"github-stats-metrics/infrastructure/repository"
 	todoUseCase "github-stats-metrics/application/todo"
@@ -23,7 +38,7 @@ import (
 	"github-stats-metrics/shared/monitoring"
 )
 
-func StartWebServer(cfg *config.Config, logger *logging.StructuredLogger, metricsCollector *metrics.MetricsCollector, healthChecker *monitoring.HealthChecker, db *sql.DB) error {
+func StartWebServer(cfg *config.Config, logger *logging.StructuredLogger, metricsCollector *metrics.MetricsCollector, healthChecker *monitoring.HealthChecker, db *sql.DB, dialect database.Dialect) error {
 	ctx := context.Background()
 	
 	logger.Info(ctx, "Initializing web server", map[string]interface{}{
@@ -33,19 +48,108 @@ func StartWebServer(cfg *config.Config, logger *logging.StructuredLogger, metric
 	// 依存関係の注入（Clean Architecture パターン）
 	// Infrastructure層 → Application層 → Presentation層の順で組み立て
 	
-	// Pull Request関連の依存関係
-	prRepository := githubRepository.NewRepository(cfg)
+	// 開発者IDエイリアス関連の依存関係
+	identityRegistry, identityPersister, err := loadIdentityRegistry(cfg)
+	if err != nil {
+		return err
+	}
+	identityHandlerInstance := developerHandler.NewIdentityHandler(identityRegistry, identityPersister)
+	
+	// チーム関連の依存関係
+	teamRoster, teamPersister, err := loadTeamRoster(cfg)
+	if err != nil {
+		return err
+	}
+	
+	// メトリクスの計算定義（休日カレンダーとチーム・開発者ごとの勤務時間を含める）
+	metricDefinition, err := loadMetricDefinition(cfg, teamRoster)
+	if err != nil {
+		return err
+	}
+	
+	// リポジトリごとの分析設定（計算定義に管理APIで変更した設定を重ねる）
+	analysisSettings, settingsPersister, err := loadAnalysisSettings(cfg, metricDefinition)
+	if err != nil {
+		return err
+	}
+	settingsHandlerInstance := settingsHandler.NewSettingsHandler(analysisSettings, settingsPersister)
+	
+	// Pull Request関連の依存関係（収集時もリポジトリごとの分析設定で計算する）
+	prRepository := githubRepository.NewRepositoryWithDefinitions(cfg, analysisSettings)
 	prUseCase := pullRequestUseCase.NewUseCase(prRepository)
 	prHandler := pullRequestHandler.NewHandler(prUseCase)
 	
+	// リポジトリごとのCODEOWNERS（ローカルファイルを優先し、それ以外はGitHubから取得）
+	codeOwners, err := loadCodeOwners(cfg)
+	if err != nil {
+		return err
+	}
+	
+	// PRメトリクスのパーティション管理（PostgreSQL のみ、切り離しはデータ保持ジョブ有効時のみ）
+	var partitionReporter analyticsHandler.PartitionReporter
+	if partitionManager := newPartitionManager(cfg, db, dialect); partitionManager != nil {
+		partitionReporter = partitionManager
+		retentionDays := 0
+		if cfg.Retention.Enabled {
+			retentionDays = cfg.Retention.Policy.DetailedDataRetentionDays
+		}
+		startPartitionJob(ctx, partitionManager, cfg.Partition.Interval, retentionDays, logger)
+	}
+	
+	// メトリクスの保存先（データベースなしの場合は各APIが 503 を返す）
+	// DATABASE_URL が未設定の場合、db は接続されていないデータベースになる
+	storageConfigured := cfg.Database.URL != "" || cfg.Database.UsesMemoryStorage()
+	var prMetricsRepo pullRequestDomain.MetricsRepository
+	var aggregatedRepo analyticsApp.AggregatedMetricsRepository
+	var aggregationRuns analyticsApp.AggregationJobRunRepository
+	var trendSnapshots analyticsApp.TrendSnapshotRepository
+	var bottlenecks analyticsApp.BottleneckRepository
+	if cfg.Database.UsesMemoryStorage() {
+		prMetricsRepo = memoryRepository.NewPRMetricsRepository()
+		aggregatedRepo = memoryRepository.NewAggregatedMetricsRepository()
+		aggregationRuns = memoryRepository.NewAggregationJobRunRepository()
+		trendSnapshots = memoryRepository.NewTrendSnapshotRepository()
+		bottlenecks = memoryRepository.NewBottleneckRepository()
+	} else {
+		prMetricsRepo = repository.NewPRMetricsRepositoryWithDialect(db, dialect)
+		aggregatedRepo = repository.NewAggregatedMetricsRepositoryWithDialect(db, dialect)
+		aggregationRuns = repository.NewAggregationJobRunRepositoryWithDialect(db, dialect)
+		trendSnapshots = repository.NewTrendSnapshotRepositoryWithDialect(db, dialect)
+		bottlenecks = repository.NewBottleneckRepositoryWithDialect(db, dialect)
+	}
+
 	// PRメトリクス関連の依存関係
-	prMetricsRepo := repository.NewPRMetricsRepository(db)
-	metricsAggregator := analyticsApp.NewMetricsAggregator()
-	prMetricsHandler := pullRequestHandler.NewPRMetricsHandler(prMetricsRepo, metricsAggregator)
+	aggregatorConfig := analyticsApp.DefaultAggregatorConfig()
+	aggregatorConfig.IdentityResolver = identityRegistry
+	aggregatorConfig.Definitions = analysisSettings
+	aggregatorConfig.ReworkWindow = cfg.Metrics.ReworkWindow
+	aggregatorConfig.ReviewLoadAlertShare = cfg.Metrics.ReviewLoadAlertShare
+	aggregatorConfig.KnowledgeSiloShare = cfg.Metrics.KnowledgeSiloShare
+	aggregatorConfig.WIPStaleAfter = cfg.Metrics.WIPStaleAfter
+	metricsAggregator := analyticsApp.NewMetricsAggregatorWithConfig(aggregatorConfig)
+	prMetricsHandler := pullRequestHandler.NewPRMetricsHandler(prMetricsRepo, metricsAggregator, identityRegistry, teamRoster, codeOwners)
+	teamHandlerInstance := teamHandler.NewTeamHandler(teamRoster, teamPersister, githubRepository.NewTeamMemberSource(cfg), prMetricsRepo, metricsAggregator, identityRegistry)
 	
 	// 集計データ関連の依存関係
-	aggregatedRepo := repository.NewAggregatedMetricsRepository(db)
-	analyticsHandlerInstance := analyticsHandler.NewAnalyticsHandler(aggregatedRepo, metricsAggregator)
+	analyticsHandlerInstance := analyticsHandler.NewAnalyticsHandler(aggregatedRepo, trendSnapshots, bottlenecks, prMetricsRepo, metricsAggregator, teamRoster, identityRegistry, partitionReporter)
+	
+	// データ保持関連の依存関係（定期実行は RETENTION_ENABLED の場合のみ）
+	retentionService := retentionApp.NewService(prMetricsRepo, aggregatedRepo, newRetentionArchive(cfg), cfg.Retention.Policy)
+	retentionHandlerInstance := retentionHandler.NewRetentionHandler(retentionService)
+	if cfg.Retention.Enabled {
+		startRetentionJob(ctx, retentionService, cfg.Retention.Interval, logger)
+	}
+	
+	// 事前集計関連の依存関係（定期実行はメトリクスの保存先がある場合のみ）
+	aggregationService := aggregationApp.NewService(prMetricsRepo, aggregatedRepo, aggregationRuns, trendSnapshots, bottlenecks, metricsAggregator, teamRoster)
+	aggregationHandlerInstance := aggregationHandler.NewAggregationHandler(aggregationService)
+	if cfg.Aggregation.Enabled && storageConfigured {
+		startAggregationJob(ctx, aggregationService, cfg.Aggregation.Interval, logger)
+	}
+	
+	// メトリクス再計算関連の依存関係（再計算したPRの期間は次回の事前集計で作り直される）
+	reprocessingService := reprocessingApp.NewService(prMetricsRepo, analysisSettings)
+	reprocessingHandlerInstance := reprocessingHandler.NewReprocessingHandler(reprocessingService)
 	
 	// Todo関連の依存関係
 	todoRepository := memoryRepository.NewTodoRepository()
@@ -67,9 +171,28 @@ func StartWebServer(cfg *config.Config, logger *logging.StructuredLogger, metric
 	
 	// 集計データ API ルートの登録
 	analyticsHandlerInstance.RegisterRoutes(r)
+	
+	// 開発者IDエイリアス API ルートの登録
+	identityHandlerInstance.RegisterRoutes(r)
+	
+	// チーム API ルートの登録
+	teamHandlerInstance.RegisterRoutes(r)
+	
+	// データ保持管理 API ルートの登録
+	retentionHandlerInstance.RegisterRoutes(r)
+	
+	// 事前集計管理 API ルートの登録
+	aggregationHandlerInstance.RegisterRoutes(r)
+	
+	// メトリクス再計算 API ルートの登録
+	reprocessingHandlerInstance.RegisterRoutes(r)
+	
+	// 分析設定管理 API ルートの登録
+	settingsHandlerInstance.RegisterRoutes(r)
 
-	// ミドルウェアの適用
-	handler := corsMiddleware(r, cfg)
+	// ミドルウェアの適用（管理APIは ADMIN_API_TOKEN で認証し、未設定の場合は無効）
+	handler := middleware.AdminAuthMiddleware(cfg.Security.AdminToken)(r)
+	handler = corsMiddleware(handler, cfg)
 	handler = middleware.MetricsMiddleware(metricsCollector)(handler)
 
 	logger.Info(ctx, "Web server starting", map[string]interface{}{
@@ -77,14 +200,42 @@ func StartWebServer(cfg *config.Config, logger *logging.StructuredLogger, metric
 			"/api/todos",
 			"/api/pull_requests", 
 			"/api/pull_requests/{id}/metrics",
+			"/api/pull_requests/{id}/timeline",
+			"/api/pull_requests/{id}/reviewer_suggestions",
 			"/api/metrics/cycle_time",
 			"/api/metrics/review_time",
+			"/api/metrics/automation",
+			"/api/metrics/change_failure",
+			"/api/metrics/rework",
+			"/api/metrics/review_workload",
+			"/api/metrics/review_response",
+			"/api/metrics/code_ownership",
 			"/api/developers/{developer}/metrics",
 			"/api/repositories/{repository}/metrics",
 			"/api/analytics/team_metrics",
 			"/api/analytics/developer_metrics",
 			"/api/analytics/repository_metrics",
+			"/api/analytics/label_metrics",
 			"/api/analytics/trends",
+			"/api/analytics/bottlenecks",
+			"/api/analytics/knowledge_distribution",
+			"/api/analytics/wip",
+			"/api/identities",
+			"/api/teams",
+			"/api/teams/{name}/sync",
+			"/api/teams/{name}/metrics",
+			"/api/admin/retention/preview",
+			"/api/admin/retention/run",
+			"/api/admin/retention/archives",
+			"/api/admin/retention/archives/{name}/restore",
+			"/api/admin/aggregation/runs",
+			"/api/admin/aggregation/run",
+			"/api/admin/reprocess/definition",
+			"/api/admin/reprocess",
+			"/api/admin/settings",
+			"/api/admin/settings/effective",
+			"/api/admin/settings/defaults",
+			"/api/admin/settings/repositories/{repository}",
 			"/health",
 			"/metrics",
 		},
@@ -93,3 +244,90 @@ func StartWebServer(cfg *config.Config, logger *logging.StructuredLogger, metric
 	// 設定からポートを取得してサーバーを起動
 	return http.ListenAndServe(cfg.GetListenAddress(), handler)
 }
+
+// loadIdentityRegistry は設定ファイルから開発者IDエイリアスを読み込み
+// ファイルが未設定の場合は空のレジストリを返し、変更はメモリ上のみに保持する
+func loadIdentityRegistry(cfg *config.Config) (*developerDomain.IdentityRegistry, developerHandler.IdentityPersister, error) {
+	if cfg.Developer.IdentityFile == "" {
+		registry, err := developerDomain.NewIdentityRegistry(nil)
+		return registry, nil, err
+	}
+	
+	store := filestore.NewIdentityFileStore(cfg.Developer.IdentityFile)
+	registry, err := store.LoadIdentityRegistry()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load developer identities: %w", err)
+	}
+	return registry, store, nil
+}
+
+// loadMetricDefinition は休日カレンダーのファイルを読み込み、ロスターの勤務時間を使う計算定義を作成
+func loadMetricDefinition(cfg *config.Config, roster *teamDomain.Roster) (pullRequestDomain.MetricDefinition, error) {
+	definition := cfg.Metrics.Definition
+	if len(cfg.Metrics.HolidayCalendarFiles) > 0 {
+		definition.CycleTime.HolidayCalendars = make(map[string]*pullRequestDomain.HolidayCalendar)
+		for name, path := range cfg.Metrics.HolidayCalendarFiles {
+			calendar, err := filestore.LoadHolidayCalendar(name, path)
+			if err != nil {
+				return definition, fmt.Errorf("failed to load holiday calendar %s: %w", name, err)
+			}
+			definition.CycleTime.HolidayCalendars[name] = calendar
+		}
+	}
+	if _, ok := definition.CycleTime.LookupHolidayCalendar(""); !ok {
+		return definition, fmt.Errorf("holiday calendar %q is not configured", definition.CycleTime.HolidayCalendar)
+	}
+	
+	definition.CycleTime.WorkingHours = roster
+	return definition, nil
+}
+
+// loadAnalysisSettings は設定ファイルからリポジトリごとの分析設定を読み込み
+// ファイルが未設定の場合は計算定義のみのレジストリを返し、変更はメモリ上のみに保持する
+func loadAnalysisSettings(cfg *config.Config, definition pullRequestDomain.MetricDefinition) (*pullRequestDomain.AnalysisSettingsRegistry, settingsHandler.SettingsPersister, error) {
+	if cfg.Metrics.SettingsFile == "" {
+		registry, err := pullRequestDomain.NewAnalysisSettingsRegistry(definition, pullRequestDomain.AnalysisSettings{})
+		return registry, nil, err
+	}
+	
+	store := filestore.NewAnalysisSettingsFileStore(cfg.Metrics.SettingsFile)
+	registry, err := store.LoadRegistry(definition)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load analysis settings: %w", err)
+	}
+	return registry, store, nil
+}
+
+// loadCodeOwners はローカルのCODEOWNERSファイルを読み込み、GitHubから取得する設定の場合は取得元を設定する
+func loadCodeOwners(cfg *config.Config) (*pullRequestDomain.CodeOwnersRegistry, error) {
+	files := make(map[string]*pullRequestDomain.CodeOwners)
+	for repository, path := range cfg.GitHub.CodeOwnersFiles {
+		codeOwners, err := filestore.LoadCodeOwners(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CODEOWNERS of %s: %w", repository, err)
+		}
+		files[repository] = codeOwners
+	}
+	
+	var source pullRequestDomain.CodeOwnersSource
+	if cfg.GitHub.CodeOwnersFetch {
+		source = githubRepository.NewCodeOwnersSource(cfg)
+	}
+	return pullRequestDomain.NewCodeOwnersRegistry(files, source, cfg.GitHub.CodeOwnersCacheTTL), nil
+}
+
+// loadTeamRoster は設定ファイルからチーム定義を読み込み
+// ファイルが未設定の場合は空のロスターを返し、変更はメモリ上のみに保持する
+func loadTeamRoster(cfg *config.Config) (*teamDomain.Roster, teamHandler.TeamPersister, error) {
+	if cfg.Developer.TeamRosterFile == "" {
+		roster, err := teamDomain.NewRoster(nil)
+		return roster, nil, err
+	}
+	
+	store := filestore.NewTeamFileStore(cfg.Developer.TeamRosterFile)
+	roster, err := store.LoadRoster()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load team roster: %w", err)
+	}
+	return roster, store, nil
+}
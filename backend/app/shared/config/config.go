@@ -6,21 +6,37 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github-stats-metrics/domain/analytics"
+	prDomain "github-stats-metrics/domain/pull_request"
 )
 
 // Config はアプリケーション設定を管理
 type Config struct {
 	GitHub   GitHubConfig
 	Server   ServerConfig
-	Security SecurityConfig
-	Logging  LoggingConfig
+	Security  SecurityConfig
+	Logging   LoggingConfig
+	Developer DeveloperConfig
+	Database  DatabaseConfig
+	Retention RetentionConfig
+	Partition PartitionConfig
+	Aggregation AggregationConfig
+	Metrics     MetricsConfig
 }
 
 // GitHubConfig はGitHub関連の設定
 type GitHubConfig struct {
-	Token        string
-	Repositories []string
-	Timeout      time.Duration
+	Token           string
+	Repositories    []string
+	Timeout         time.Duration
+	BotAccounts     []string // 既定リストに加えてbotとして扱うアカウント
+	ReleaseBranches []string // リリースブランチとして扱うブランチ名のパターン（空の場合は release/* など）
+
+	// CODEOWNERS（ローカルファイルのリポジトリ以外はGitHubから取得し、一定時間キャッシュする）
+	CodeOwnersFiles    map[string]string // リポジトリ（owner/name） → ローカルのCODEOWNERSファイル
+	CodeOwnersFetch    bool              // ローカルファイルのないリポジトリのCODEOWNERSをGitHubから取得するか
+	CodeOwnersCacheTTL time.Duration     // GitHubから取得したCODEOWNERSのキャッシュ期間
 }
 
 // ServerConfig はサーバー関連の設定
@@ -34,6 +50,7 @@ type ServerConfig struct {
 // SecurityConfig はセキュリティ関連の設定
 type SecurityConfig struct {
 	AllowedOrigins []string
+	AdminToken     string // 管理API（/api/admin/）の認証トークン（未設定の場合は管理APIを無効にする）
 }
 
 // LoggingConfig はログ関連の設定
@@ -42,6 +59,75 @@ type LoggingConfig struct {
 	Format string
 }
 
+// DeveloperConfig は開発者情報関連の設定
+type DeveloperConfig struct {
+	IdentityFile   string // 同一人物の複数アカウントを定義するJSONファイル
+	TeamRosterFile string // チームと所属期間を定義するJSONファイル
+}
+
+// DatabaseConfig はデータベース関連の設定
+type DatabaseConfig struct {
+	URL                string        // postgres://...、sqlite:///path/to/metrics.db、または memory:（デモ用）
+	AutoMigrate        bool          // 起動時に未適用のマイグレーションを適用するか
+	MaxConnections     int           // 最大接続数
+	MaxIdleConnections int           // アイドル状態で保持する最大接続数
+	ConnMaxLifetime    time.Duration // 接続の最大再利用時間
+	ConnectTimeout     time.Duration // 接続確立（起動時のping）のタイムアウト
+	QueryTimeout       time.Duration // クエリ1件あたりのタイムアウト
+}
+
+// RetentionConfig はデータ保持ジョブの設定
+type RetentionConfig struct {
+	Enabled  bool                          // 保持ジョブを定期実行するか
+	Interval time.Duration                 // 実行間隔
+	Policy   analytics.DataRetentionPolicy // 保持期間とアーカイブ設定
+}
+
+// PartitionConfig は pr_metrics のパーティション管理の設定（PostgreSQL のみ）
+type PartitionConfig struct {
+	Strategy      analytics.PartitionStrategy
+	PremakeMonths int           // 当月に加えて事前に作成する月数
+	Interval      time.Duration // 保守の実行間隔
+}
+
+// AggregationConfig は事前集計ジョブの設定
+type AggregationConfig struct {
+	Enabled  bool          // 集計ジョブを定期実行するか
+	Interval time.Duration // 実行間隔
+}
+
+// MetricsConfig はPRメトリクスの計算設定
+type MetricsConfig struct {
+	Definition prDomain.MetricDefinition // 変更すると定義バージョンが変わり再計算の対象になる
+	
+	// 休日カレンダーとして読み込むiCalendarファイル（カレンダー名 → パス）
+	HolidayCalendarFiles map[string]string
+	
+	// 管理APIで変更したリポジトリごとの分析設定を保存するJSONファイル（未設定の場合はメモリ上のみ）
+	SettingsFile string
+	
+	// マージ後に同じファイルを変更したPRを手戻りとして数える期間
+	ReworkWindow time.Duration
+	
+	// 1人のレビュアーに集中しているとみなすレビュー数の割合
+	ReviewLoadAlertShare float64
+	
+	// 1人にディレクトリの知識が集中しているとみなす作成・レビューの貢献の割合
+	KnowledgeSiloShare float64
+	
+	// マージされていないPRを放置されたとみなしWIPに数えなくなるまでの期間
+	WIPStaleAfter time.Duration
+}
+
+// memoryStorageURL はメトリクスをメモリ内に保存する DATABASE_URL
+const memoryStorageURL = "memory:"
+
+// UsesMemoryStorage はメトリクスをデータベースではなくメモリ内に保存するかを返す
+// デモ用で、保存したデータはプロセス終了時に失われる
+func (d DatabaseConfig) UsesMemoryStorage() bool {
+	return d.URL == memoryStorageURL
+}
+
 // NewConfig は環境変数から設定を読み込み
 func NewConfig() (*Config, error) {
 	config := &Config{}
@@ -66,6 +152,36 @@ func NewConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to load logging config: %w", err)
 	}
 	
+	// 開発者情報設定
+	if err := config.loadDeveloperConfig(); err != nil {
+		return nil, fmt.Errorf("failed to load developer config: %w", err)
+	}
+	
+	// データベース設定
+	if err := config.loadDatabaseConfig(); err != nil {
+		return nil, fmt.Errorf("failed to load database config: %w", err)
+	}
+	
+	// データ保持設定
+	if err := config.loadRetentionConfig(); err != nil {
+		return nil, fmt.Errorf("failed to load retention config: %w", err)
+	}
+	
+	// パーティション設定
+	if err := config.loadPartitionConfig(); err != nil {
+		return nil, fmt.Errorf("failed to load partition config: %w", err)
+	}
+	
+	// 事前集計設定
+	if err := config.loadAggregationConfig(); err != nil {
+		return nil, fmt.Errorf("failed to load aggregation config: %w", err)
+	}
+	
+	// メトリクス計算設定
+	if err := config.loadMetricsConfig(); err != nil {
+		return nil, fmt.Errorf("failed to load metrics config: %w", err)
+	}
+	
 	// 設定の検証
 	if err := config.validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -102,6 +218,48 @@ func (c *Config) loadGitHubConfig() error {
 		c.GitHub.Timeout = timeout
 	}
 	
+	// オプション: 追加のbotアカウント（カンマ区切り）
+	if botStr := os.Getenv("GITHUB_BOT_ACCOUNTS"); botStr != "" {
+		for _, account := range strings.Split(botStr, ",") {
+			if account = strings.TrimSpace(account); account != "" {
+				c.GitHub.BotAccounts = append(c.GitHub.BotAccounts, account)
+			}
+		}
+	}
+	
+	// オプション: ホットフィックスの判定に使うリリースブランチのパターン（カンマ区切り、例: release/*）
+	if branchesStr := os.Getenv("GITHUB_RELEASE_BRANCHES"); branchesStr != "" {
+		for _, pattern := range strings.Split(branchesStr, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				c.GitHub.ReleaseBranches = append(c.GitHub.ReleaseBranches, pattern)
+			}
+		}
+	}
+	
+	// オプション: ローカルのCODEOWNERSファイル（"owner/name=パス" のカンマ区切り）
+	if filesStr := os.Getenv("CODEOWNERS_FILES"); filesStr != "" {
+		c.GitHub.CodeOwnersFiles = make(map[string]string)
+		for _, entry := range strings.Split(filesStr, ",") {
+			repository, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || strings.TrimSpace(repository) == "" || strings.TrimSpace(path) == "" {
+				return fmt.Errorf("invalid CODEOWNERS_FILES entry %q: must be owner/name=path", entry)
+			}
+			c.GitHub.CodeOwnersFiles[strings.TrimSpace(repository)] = strings.TrimSpace(path)
+		}
+	}
+	
+	// オプション: CODEOWNERSをGitHubから取得するか（デフォルトtrue）とキャッシュ期間（デフォルト1時間）
+	fetch, err := getEnvBool("CODEOWNERS_FETCH", true)
+	if err != nil {
+		return err
+	}
+	c.GitHub.CodeOwnersFetch = fetch
+	cacheTTL, err := getEnvDuration("CODEOWNERS_CACHE_TTL", time.Hour)
+	if err != nil {
+		return err
+	}
+	c.GitHub.CodeOwnersCacheTTL = cacheTTL
+	
 	return nil
 }
 
@@ -171,9 +329,358 @@ func (c *Config) loadSecurityConfig() error {
 		c.Security.AllowedOrigins = strings.Split(originsStr, ",")
 	}
 	
+	// オプション: 管理APIの認証トークン（未設定の場合は管理APIを無効にする）
+	c.Security.AdminToken = os.Getenv("ADMIN_API_TOKEN")
+	
+	return nil
+}
+
+// loadDeveloperConfig は開発者情報関連の設定を読み込み
+func (c *Config) loadDeveloperConfig() error {
+	// オプション: IDエイリアス定義ファイル（未設定の場合はエイリアスなし）
+	c.Developer.IdentityFile = os.Getenv("DEVELOPER_IDENTITY_FILE")
+	
+	// オプション: チーム定義ファイル（未設定の場合はAPIで登録したチームのみ、再起動で消える）
+	c.Developer.TeamRosterFile = os.Getenv("TEAM_ROSTER_FILE")
+	
+	return nil
+}
+
+// loadDatabaseConfig はデータベース関連の設定を読み込み
+func (c *Config) loadDatabaseConfig() error {
+	// オプション: 接続先（未設定の場合はデータベースなしで起動）
+	c.Database.URL = strings.TrimSpace(os.Getenv("DATABASE_URL"))
+	if c.Database.URL == "" {
+		return nil
+	}
+	
+	if c.Database.UsesMemoryStorage() {
+		return nil
+	}
+
+	if !strings.HasPrefix(c.Database.URL, "postgres://") &&
+		!strings.HasPrefix(c.Database.URL, "postgresql://") &&
+		!strings.HasPrefix(c.Database.URL, "sqlite:") &&
+		!strings.HasPrefix(c.Database.URL, "file:") {
+		return fmt.Errorf("invalid DATABASE_URL: scheme must be postgres://, sqlite:, file: or memory:")
+	}
+	
+	// 接続プールの既定値はストレージ設定の推奨値に合わせる
+	defaults := analytics.GetDefaultStorageConfiguration()
+	
+	// オプション: 最大接続数（デフォルト25）
+	maxConnections, err := getEnvInt("DATABASE_MAX_CONNECTIONS", defaults.MaxConnections)
+	if err != nil {
+		return err
+	}
+	if maxConnections < 1 {
+		return fmt.Errorf("DATABASE_MAX_CONNECTIONS must be positive")
+	}
+	c.Database.MaxConnections = maxConnections
+	
+	// オプション: アイドル接続数（デフォルトは最大接続数の半分）
+	maxIdleConnections, err := getEnvInt("DATABASE_MAX_IDLE_CONNECTIONS", (maxConnections+1)/2)
+	if err != nil {
+		return err
+	}
+	if maxIdleConnections < 0 || maxIdleConnections > maxConnections {
+		return fmt.Errorf("DATABASE_MAX_IDLE_CONNECTIONS must be between 0 and DATABASE_MAX_CONNECTIONS")
+	}
+	c.Database.MaxIdleConnections = maxIdleConnections
+	
+	// オプション: 接続の最大再利用時間（デフォルト30分）
+	if c.Database.ConnMaxLifetime, err = getEnvDuration("DATABASE_CONN_MAX_LIFETIME", 30*time.Minute); err != nil {
+		return err
+	}
+	
+	// オプション: 接続タイムアウト（デフォルト5秒）
+	if c.Database.ConnectTimeout, err = getEnvDuration("DATABASE_CONNECT_TIMEOUT", 5*time.Second); err != nil {
+		return err
+	}
+	
+	// オプション: クエリタイムアウト（デフォルト30秒）
+	if c.Database.QueryTimeout, err = getEnvDuration("DATABASE_QUERY_TIMEOUT", time.Duration(defaults.QueryTimeoutSeconds)*time.Second); err != nil {
+		return err
+	}
+	
+	// オプション: 起動時の自動マイグレーション（デフォルト無効）
+	if autoMigrateStr := os.Getenv("DATABASE_AUTO_MIGRATE"); autoMigrateStr != "" {
+		autoMigrate, err := strconv.ParseBool(autoMigrateStr)
+		if err != nil {
+			return fmt.Errorf("invalid DATABASE_AUTO_MIGRATE: %w", err)
+		}
+		c.Database.AutoMigrate = autoMigrate
+	}
+	
+	return nil
+}
+
+// loadRetentionConfig はデータ保持関連の設定を読み込み
+func (c *Config) loadRetentionConfig() error {
+	policy := analytics.GetDefaultRetentionPolicy()
+	var err error
+	
+	// オプション: 定期実行（デフォルト無効）
+	if c.Retention.Enabled, err = getEnvBool("RETENTION_ENABLED", false); err != nil {
+		return err
+	}
+	
+	// オプション: 実行間隔（デフォルト24時間）
+	if c.Retention.Interval, err = getEnvDuration("RETENTION_INTERVAL", 24*time.Hour); err != nil {
+		return err
+	}
+	if c.Retention.Enabled && c.Retention.Interval == 0 {
+		return fmt.Errorf("RETENTION_INTERVAL must be positive")
+	}
+	
+	// オプション: 保持日数（0 の場合はその対象を削除しない）
+	retentionDays := []struct {
+		key   string
+		value *int
+	}{
+		{"RETENTION_DETAILED_DAYS", &policy.DetailedDataRetentionDays},
+		{"RETENTION_DAILY_DAYS", &policy.DailyAggregationRetentionDays},
+		{"RETENTION_WEEKLY_DAYS", &policy.WeeklyAggregationRetentionDays},
+		{"RETENTION_MONTHLY_DAYS", &policy.MonthlyAggregationRetentionDays},
+	}
+	for _, days := range retentionDays {
+		if *days.value, err = getEnvInt(days.key, *days.value); err != nil {
+			return err
+		}
+		if *days.value < 0 {
+			return fmt.Errorf("%s must not be negative", days.key)
+		}
+	}
+	
+	// オプション: 削除前のアーカイブ（デフォルト無効）
+	if policy.EnableArchiving, err = getEnvBool("RETENTION_ARCHIVE_ENABLED", policy.EnableArchiving); err != nil {
+		return err
+	}
+	if path := os.Getenv("RETENTION_ARCHIVE_PATH"); path != "" {
+		policy.ArchiveStoragePath = path
+	}
+	if policy.EnableCompression, err = getEnvBool("RETENTION_ARCHIVE_COMPRESSION", policy.EnableCompression); err != nil {
+		return err
+	}
+	
+	c.Retention.Policy = policy
+	return nil
+}
+
+// loadPartitionConfig はパーティション管理関連の設定を読み込み
+func (c *Config) loadPartitionConfig() error {
+	strategy := analytics.GetRecommendedPartitionStrategy()
+	var err error
+	
+	// オプション: 月次パーティションの自動作成（デフォルト有効）
+	if strategy.AutoCreatePartitions, err = getEnvBool("PARTITION_AUTO_CREATE", strategy.AutoCreatePartitions); err != nil {
+		return err
+	}
+	
+	// オプション: 保持期間を過ぎたパーティションの切り離し（デフォルト有効、RETENTION_ENABLED の場合のみ実行）
+	if strategy.PartitionMaintenanceEnabled, err = getEnvBool("PARTITION_MAINTENANCE_ENABLED", strategy.PartitionMaintenanceEnabled); err != nil {
+		return err
+	}
+	c.Partition.Strategy = strategy
+	
+	// オプション: 事前に作成する月数（デフォルト3）
+	if c.Partition.PremakeMonths, err = getEnvInt("PARTITION_PREMAKE_MONTHS", 3); err != nil {
+		return err
+	}
+	if c.Partition.PremakeMonths < 0 {
+		return fmt.Errorf("PARTITION_PREMAKE_MONTHS must not be negative")
+	}
+	
+	// オプション: 保守の実行間隔（デフォルト24時間）
+	if c.Partition.Interval, err = getEnvDuration("PARTITION_MAINTENANCE_INTERVAL", 24*time.Hour); err != nil {
+		return err
+	}
+	if c.Partition.Interval == 0 {
+		return fmt.Errorf("PARTITION_MAINTENANCE_INTERVAL must be positive")
+	}
+	
+	return nil
+}
+
+// loadAggregationConfig は事前集計関連の設定を読み込み
+func (c *Config) loadAggregationConfig() error {
+	var err error
+	
+	// オプション: 定期実行（デフォルト有効）
+	if c.Aggregation.Enabled, err = getEnvBool("AGGREGATION_ENABLED", true); err != nil {
+		return err
+	}
+	
+	// オプション: 実行間隔（デフォルト1時間）
+	if c.Aggregation.Interval, err = getEnvDuration("AGGREGATION_INTERVAL", time.Hour); err != nil {
+		return err
+	}
+	if c.Aggregation.Enabled && c.Aggregation.Interval == 0 {
+		return fmt.Errorf("AGGREGATION_INTERVAL must be positive")
+	}
+	
+	return nil
+}
+
+// loadMetricsConfig はメトリクス計算関連の設定を読み込み
+func (c *Config) loadMetricsConfig() error {
+	definition := prDomain.DefaultMetricDefinition()
+	cycleTime := &definition.CycleTime
+	var err error
+	
+	// オプション: 営業時間のみでサイクルタイムを計算（デフォルト無効）
+	if cycleTime.UseBusinessHours, err = getEnvBool("CYCLE_TIME_USE_BUSINESS_HOURS", cycleTime.UseBusinessHours); err != nil {
+		return err
+	}
+	if cycleTime.BusinessStart, err = getEnvInt("CYCLE_TIME_BUSINESS_START", cycleTime.BusinessStart); err != nil {
+		return err
+	}
+	if cycleTime.BusinessEnd, err = getEnvInt("CYCLE_TIME_BUSINESS_END", cycleTime.BusinessEnd); err != nil {
+		return err
+	}
+	if cycleTime.BusinessStart < 0 || cycleTime.BusinessEnd > 24 || cycleTime.BusinessStart >= cycleTime.BusinessEnd {
+		return fmt.Errorf("CYCLE_TIME_BUSINESS_START must be before CYCLE_TIME_BUSINESS_END within 0-24")
+	}
+	if cycleTime.ExcludeWeekends, err = getEnvBool("CYCLE_TIME_EXCLUDE_WEEKENDS", cycleTime.ExcludeWeekends); err != nil {
+		return err
+	}
+	
+	// オプション: 休日カレンダーの祝日を営業日から除外（デフォルト無効）
+	if cycleTime.ExcludeHolidays, err = getEnvBool("CYCLE_TIME_EXCLUDE_HOLIDAYS", cycleTime.ExcludeHolidays); err != nil {
+		return err
+	}
+	
+	// オプション: レビュー待ちの起点をPR作成時刻とする旧定義を使う（デフォルトは最後に ready_for_review になった時刻）
+	if cycleTime.UseLegacyReviewStart, err = getEnvBool("CYCLE_TIME_LEGACY_REVIEW_START", cycleTime.UseLegacyReviewStart); err != nil {
+		return err
+	}
+	
+	// オプション: 既定の休日カレンダー名（デフォルトは組み込みの日本の祝日）
+	cycleTime.HolidayCalendar = os.Getenv("CYCLE_TIME_HOLIDAY_CALENDAR")
+	
+	// オプション: 休日カレンダーのiCalendarファイル（"名前=パス" のカンマ区切り）
+	if filesStr := os.Getenv("CYCLE_TIME_HOLIDAY_CALENDAR_FILES"); filesStr != "" {
+		c.Metrics.HolidayCalendarFiles = make(map[string]string)
+		for _, entry := range strings.Split(filesStr, ",") {
+			name, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(path) == "" {
+				return fmt.Errorf("invalid CYCLE_TIME_HOLIDAY_CALENDAR_FILES entry %q: must be name=path", entry)
+			}
+			c.Metrics.HolidayCalendarFiles[strings.TrimSpace(name)] = strings.TrimSpace(path)
+		}
+	}
+	
+	// オプション: 営業時間のタイムゾーン（IANA 名）
+	if name := os.Getenv("CYCLE_TIME_TIMEZONE"); name != "" {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("invalid CYCLE_TIME_TIMEZONE: %w", err)
+		}
+		cycleTime.Timezone = location
+	}
+	
+	// オプション: リポジトリごとの分析設定ファイル
+	c.Metrics.SettingsFile = os.Getenv("ANALYSIS_SETTINGS_FILE")
+	
+	// オプション: マージ後の手戻りとして数える日数（デフォルト21日）
+	reworkDays, err := getEnvInt("REWORK_WINDOW_DAYS", int(prDomain.DefaultReworkWindow/(24*time.Hour)))
+	if err != nil {
+		return err
+	}
+	if reworkDays < 1 {
+		return fmt.Errorf("REWORK_WINDOW_DAYS must be positive")
+	}
+	c.Metrics.ReworkWindow = time.Duration(reworkDays) * 24 * time.Hour
+	
+	// オプション: レビューの集中を警告する1人あたりの割合（デフォルト0.4）
+	alertShare, err := getEnvFloat("REVIEW_LOAD_ALERT_SHARE", prDomain.DefaultReviewLoadAlertShare)
+	if err != nil {
+		return err
+	}
+	if alertShare <= 0 || alertShare > 1 {
+		return fmt.Errorf("REVIEW_LOAD_ALERT_SHARE must be greater than 0 and at most 1")
+	}
+	c.Metrics.ReviewLoadAlertShare = alertShare
+	
+	// オプション: 知識の集中とみなす1人あたりの貢献の割合（デフォルト0.8）
+	siloShare, err := getEnvFloat("KNOWLEDGE_SILO_SHARE", prDomain.DefaultKnowledgeSiloShare)
+	if err != nil {
+		return err
+	}
+	if siloShare <= 0 || siloShare > 1 {
+		return fmt.Errorf("KNOWLEDGE_SILO_SHARE must be greater than 0 and at most 1")
+	}
+	c.Metrics.KnowledgeSiloShare = siloShare
+	
+	// オプション: 未マージのPRをWIPに数える日数（デフォルト30日）
+	wipStaleDays, err := getEnvInt("WIP_STALE_DAYS", int(prDomain.DefaultWIPStaleAfter/(24*time.Hour)))
+	if err != nil {
+		return err
+	}
+	if wipStaleDays < 1 {
+		return fmt.Errorf("WIP_STALE_DAYS must be positive")
+	}
+	c.Metrics.WIPStaleAfter = time.Duration(wipStaleDays) * 24 * time.Hour
+	
+	c.Metrics.Definition = definition
 	return nil
 }
 
+// getEnvBool は真偽値の環境変数を読み込み、未設定の場合は defaultValue を返す
+func getEnvBool(key string, defaultValue bool) (bool, error) {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// getEnvInt は整数の環境変数を読み込み、未設定の場合は defaultValue を返す
+func getEnvInt(key string, defaultValue int) (int, error) {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// getEnvFloat は小数の環境変数を読み込み、未設定の場合は defaultValue を返す
+func getEnvFloat(key string, defaultValue float64) (float64, error) {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// getEnvDuration は時間の環境変数を読み込み、未設定の場合は defaultValue を返す
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue, nil
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("%s must not be negative", key)
+	}
+	return value, nil
+}
+
 // loadLoggingConfig はログ関連の設定を読み込み
 func (c *Config) loadLoggingConfig() error {
 	// オプション: ログレベル（デフォルトINFO）
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+)
+
+// setRequiredEnv は NewConfig に必須の環境変数を設定
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_GRAPHQL_SEARCH_QUERY_TARGET_REPOSITORIES", "org/repo")
+}
+
+func TestNewConfig_LegacyReviewStart(t *testing.T) {
+	t.Run("未設定の場合は最後の ready_for_review を起点とする", func(t *testing.T) {
+		setRequiredEnv(t)
+
+		cfg, err := NewConfig()
+		if err != nil {
+			t.Fatalf("NewConfig() error = %v", err)
+		}
+		if cfg.Metrics.Definition.CycleTime.UseLegacyReviewStart {
+			t.Error("UseLegacyReviewStart = true, want false")
+		}
+	})
+
+	t.Run("CYCLE_TIME_LEGACY_REVIEW_START で旧定義に戻せる", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("CYCLE_TIME_LEGACY_REVIEW_START", "true")
+
+		cfg, err := NewConfig()
+		if err != nil {
+			t.Fatalf("NewConfig() error = %v", err)
+		}
+		if !cfg.Metrics.Definition.CycleTime.UseLegacyReviewStart {
+			t.Error("UseLegacyReviewStart = false, want true")
+		}
+	})
+
+	t.Run("真偽値でない場合はエラー", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("CYCLE_TIME_LEGACY_REVIEW_START", "sometimes")
+
+		if _, err := NewConfig(); err == nil {
+			t.Error("NewConfig() error = nil, want error")
+		}
+	})
+}
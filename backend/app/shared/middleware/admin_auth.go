@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AdminPathPrefix は管理APIのパスの接頭辞
+const AdminPathPrefix = "/api/admin/"
+
+// AdminAuthMiddleware は管理APIへのリクエストを管理トークンで認証するミドルウェア
+// トークンが未設定の場合は管理APIを無効とし、すべて 403 を返す
+// 認証は Authorization: Bearer <token> ヘッダーで行う
+func AdminAuthMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, AdminPathPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if token == "" {
+				writeAdminAuthError(w, http.StatusForbidden, "ADMIN_API_DISABLED", "管理APIが有効になっていません")
+				return
+			}
+			if !validAdminToken(r.Header.Get("Authorization"), token) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				writeAdminAuthError(w, http.StatusUnauthorized, "UNAUTHORIZED", "管理トークンが正しくありません")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// validAdminToken は Authorization ヘッダーのトークンが管理トークンと一致するかを判定
+// 比較時間からトークンを推測されないよう、固定時間で比較する
+func validAdminToken(header, token string) bool {
+	const scheme = "Bearer "
+	if len(header) < len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) {
+		return false
+	}
+	given := strings.TrimSpace(header[len(scheme):])
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// writeAdminAuthError は各ハンドラーと同じ形式でエラーレスポンスを書き込む
+func writeAdminAuthError(w http.ResponseWriter, statusCode int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   http.StatusText(statusCode),
+		"code":    code,
+		"message": message,
+	})
+}
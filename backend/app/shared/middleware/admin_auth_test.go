@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name          string
+		token         string
+		path          string
+		authorization string
+		want          int
+	}{
+		{name: "管理API以外は認証しない", token: "", path: "/api/analytics/wip", want: http.StatusNoContent},
+		{name: "トークン未設定の場合は管理APIを無効にする", token: "", path: "/api/admin/retention/run", authorization: "Bearer ", want: http.StatusForbidden},
+		{name: "トークンなし", token: "secret", path: "/api/admin/retention/run", want: http.StatusUnauthorized},
+		{name: "トークン不一致", token: "secret", path: "/api/admin/aggregation/run", authorization: "Bearer other", want: http.StatusUnauthorized},
+		{name: "Bearer 以外の方式", token: "secret", path: "/api/admin/reprocess", authorization: "Basic secret", want: http.StatusUnauthorized},
+		{name: "トークン一致", token: "secret", path: "/api/admin/settings/defaults", authorization: "Bearer secret", want: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			AdminAuthMiddleware(tt.token)(next).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
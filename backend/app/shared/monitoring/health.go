@@ -2,6 +2,7 @@ package monitoring
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -211,4 +212,55 @@ func (s *SystemHealthCheck) Check(ctx context.Context) HealthStatus {
 			"go_version":     runtime.Version(),
 		},
 	}
-}
\ No newline at end of file
+}
+// DatabaseHealthCheck はデータベースへの疎通と接続プールの状態をチェック
+type DatabaseHealthCheck struct {
+	db      *sql.DB
+	dialect string
+	timeout time.Duration
+}
+
+// NewDatabaseHealthCheck は新しいデータベースヘルスチェックを作成
+func NewDatabaseHealthCheck(db *sql.DB, dialect string, timeout time.Duration) *DatabaseHealthCheck {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	return &DatabaseHealthCheck{db: db, dialect: dialect, timeout: timeout}
+}
+
+func (d *DatabaseHealthCheck) Name() string {
+	return "database"
+}
+
+func (d *DatabaseHealthCheck) Check(ctx context.Context) HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := d.db.PingContext(ctx)
+	latency := time.Since(start)
+
+	stats := d.db.Stats()
+	details := map[string]interface{}{
+		"dialect":          d.dialect,
+		"ping_ms":          latency.Milliseconds(),
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+		"max_open":         stats.MaxOpenConnections,
+		"wait_count":       stats.WaitCount,
+	}
+
+	if err != nil {
+		return HealthStatus{
+			Status:  "unhealthy",
+			Message: fmt.Sprintf("Database ping failed: %v", err),
+			Details: details,
+		}
+	}
+
+	return HealthStatus{
+		Status:  "healthy",
+		Details: details,
+	}
+}
@@ -484,6 +484,34 @@ func (calc *StatisticsCalculator) DetectOutliersZScore(values []float64, thresho
 	}
 }
 
+// CalculateGiniCoefficient は値の偏りをジニ係数で返す（0 は均等、1 に近いほど一部に集中）
+// 負の値を含む場合や合計が 0 の場合は 0 を返す
+func (calc *StatisticsCalculator) CalculateGiniCoefficient(values []float64) float64 {
+	n := len(values)
+	if n < 2 {
+		return 0
+	}
+	
+	sorted := make([]float64, n)
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	if sorted[0] < 0 {
+		return 0
+	}
+	
+	// G = Σ(2i - n - 1)x_i / (n Σx_i)（i は昇順の1始まりの順位）
+	var weighted, total float64
+	for i, v := range sorted {
+		weighted += float64(2*(i+1)-n-1) * v
+		total += v
+	}
+	if total == 0 {
+		return 0
+	}
+	
+	return weighted / (float64(n) * total)
+}
+
 // TrendAnalysis はトレンド分析の結果
 type TrendAnalysis struct {
 	Slope            float64 `json:"slope"`
@@ -265,6 +265,32 @@ func TestStatisticsCalculator_DetectOutliersIQR(t *testing.T) {
 	}
 }
 
+func TestStatisticsCalculator_CalculateGiniCoefficient(t *testing.T) {
+	calc := NewStatisticsCalculator()
+	
+	tests := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{"均等", []float64{5, 5, 5, 5}, 0},
+		{"1人に集中", []float64{0, 0, 0, 12}, 0.75},
+		{"偏りあり", []float64{1, 2, 3, 4}, 0.25},
+		{"合計が0", []float64{0, 0, 0}, 0},
+		{"データが少ない", []float64{10}, 0},
+		{"負の値を含む", []float64{-1, 2, 3}, 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calc.CalculateGiniCoefficient(tt.values)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("CalculateGiniCoefficient() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestStatisticsCalculator_DetectOutliersZScore(t *testing.T) {
 	calc := NewStatisticsCalculator()
 	